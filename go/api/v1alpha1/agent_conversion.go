@@ -0,0 +1,330 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"encoding/json"
+	"fmt"
+
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	apivalidation "k8s.io/apimachinery/pkg/api/validation"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	"github.com/kagent-dev/kagent/go/api/v1alpha2"
+)
+
+// ConversionDataAnnotation holds the fields of an Agent that cannot be
+// represented in the version it is currently served as, so that a
+// round trip through the other version is lossless.
+const ConversionDataAnnotation = "kagent.dev/conversion-data"
+
+// maxConversionDataSize bounds the conversion data annotation, leaving room for the
+// other annotations within the 256KiB the API server allows for all of them.
+const maxConversionDataSize = 128 * 1024
+
+const (
+	// v1alpha1 ToolServers are served by RemoteMCPServers in v1alpha2
+	toolServerKind = "RemoteMCPServer"
+	agentKind      = "Agent"
+)
+
+var _ conversion.Convertible = &Agent{}
+
+// ConvertTo converts this Agent to the Hub version (v1alpha2).
+func (src *Agent) ConvertTo(dstRaw conversion.Hub) error {
+	dst, ok := dstRaw.(*v1alpha2.Agent)
+	if !ok {
+		return fmt.Errorf("unsupported conversion target %T", dstRaw)
+	}
+	in := src.DeepCopy()
+
+	dst.ObjectMeta = in.ObjectMeta
+	dst.Spec = convertAgentSpecToHub(&in.Spec)
+	dst.Status = v1alpha2.AgentStatus{
+		ObservedGeneration: in.Status.ObservedGeneration,
+		Conditions:         in.Status.Conditions,
+	}
+
+	// Restore the v1alpha2 fields saved when this object was converted down
+	restored := &v1alpha2.Agent{}
+	ok, err := unmarshalConversionData(dst, restored)
+	if err != nil {
+		return err
+	}
+	if ok {
+		if apiequality.Semantic.DeepEqual(convertAgentSpecFromHub(&restored.Spec), in.Spec) {
+			// Not modified as v1alpha1, the saved spec is authoritative
+			dst.Spec = restored.Spec
+		} else {
+			mergeHubAgentSpec(&dst.Spec, &restored.Spec)
+		}
+	}
+
+	if len(in.Status.ConfigHash) == 0 {
+		return nil
+	}
+	return marshalConversionData(&Agent{Status: AgentStatus{ConfigHash: in.Status.ConfigHash}}, dst)
+}
+
+// ConvertFrom converts from the Hub version (v1alpha2) to this version.
+func (dst *Agent) ConvertFrom(srcRaw conversion.Hub) error {
+	src, ok := srcRaw.(*v1alpha2.Agent)
+	if !ok {
+		return fmt.Errorf("unsupported conversion source %T", srcRaw)
+	}
+	in := src.DeepCopy()
+
+	dst.ObjectMeta = *in.ObjectMeta.DeepCopy()
+	dst.Spec = convertAgentSpecFromHub(&in.Spec)
+	dst.Status = AgentStatus{
+		ObservedGeneration: in.Status.ObservedGeneration,
+		Conditions:         in.Status.Conditions,
+	}
+
+	// Restore the v1alpha1 fields saved when this object was converted up
+	restored := &Agent{}
+	ok, err := unmarshalConversionData(dst, restored)
+	if err != nil {
+		return err
+	}
+	if ok {
+		dst.Status.ConfigHash = restored.Status.ConfigHash
+	}
+
+	return marshalConversionData(&v1alpha2.Agent{Spec: in.Spec}, dst)
+}
+
+func convertAgentSpecToHub(in *AgentSpec) v1alpha2.AgentSpec {
+	out := v1alpha2.AgentSpec{
+		Type:        v1alpha2.AgentType_Declarative,
+		Description: in.Description,
+		Declarative: &v1alpha2.DeclarativeAgentSpec{
+			SystemMessage: in.SystemMessage,
+			ModelConfig:   in.ModelConfig,
+			Stream:        in.Stream,
+			Memory:        in.Memory,
+		},
+	}
+
+	if in.Tools != nil {
+		out.Declarative.Tools = make([]*v1alpha2.Tool, len(in.Tools))
+		for i, tool := range in.Tools {
+			if tool == nil {
+				continue
+			}
+			converted := &v1alpha2.Tool{
+				Type: v1alpha2.ToolProviderType(tool.Type),
+			}
+			if tool.McpServer != nil {
+				converted.McpServer = &v1alpha2.McpServerTool{
+					TypedLocalReference: v1alpha2.TypedLocalReference{
+						Kind:     toolServerKind,
+						ApiGroup: GroupVersion.Group,
+						Name:     tool.McpServer.ToolServer,
+					},
+					ToolNames: tool.McpServer.ToolNames,
+				}
+			}
+			if tool.Agent != nil {
+				converted.Agent = &v1alpha2.TypedLocalReference{
+					Kind:     agentKind,
+					ApiGroup: GroupVersion.Group,
+					Name:     tool.Agent.Ref,
+				}
+			}
+			out.Declarative.Tools[i] = converted
+		}
+	}
+
+	if in.A2AConfig != nil {
+		out.Declarative.A2AConfig = &v1alpha2.A2AConfig{}
+		if in.A2AConfig.Skills != nil {
+			out.Declarative.A2AConfig.Skills = make([]v1alpha2.AgentSkill, len(in.A2AConfig.Skills))
+			for i, skill := range in.A2AConfig.Skills {
+				out.Declarative.A2AConfig.Skills[i] = v1alpha2.AgentSkill(skill)
+			}
+		}
+	}
+
+	if in.Deployment != nil {
+		out.Declarative.Deployment = &v1alpha2.DeclarativeDeploymentSpec{
+			SharedDeploymentSpec: v1alpha2.SharedDeploymentSpec{
+				Replicas:         in.Deployment.Replicas,
+				ImagePullSecrets: in.Deployment.ImagePullSecrets,
+				Volumes:          in.Deployment.Volumes,
+				Labels:           in.Deployment.Labels,
+				Annotations:      in.Deployment.Annotations,
+				Env:              in.Deployment.Env,
+			},
+		}
+	}
+
+	return out
+}
+
+func convertAgentSpecFromHub(in *v1alpha2.AgentSpec) AgentSpec {
+	out := AgentSpec{
+		Description: in.Description,
+	}
+
+	declarative := in.Declarative
+	if declarative == nil {
+		return out
+	}
+
+	out.SystemMessage = declarative.SystemMessage
+	out.ModelConfig = declarative.ModelConfig
+	out.Stream = declarative.Stream
+	out.Memory = declarative.Memory
+
+	if declarative.Tools != nil {
+		out.Tools = make([]*Tool, len(declarative.Tools))
+		for i, tool := range declarative.Tools {
+			if tool == nil {
+				continue
+			}
+			converted := &Tool{
+				Type: ToolProviderType(tool.Type),
+			}
+			if tool.McpServer != nil {
+				converted.McpServer = &McpServerTool{
+					ToolServer: tool.McpServer.Name,
+					ToolNames:  tool.McpServer.ToolNames,
+				}
+			}
+			if tool.Agent != nil {
+				converted.Agent = &AgentTool{
+					Ref: tool.Agent.Name,
+				}
+			}
+			out.Tools[i] = converted
+		}
+	}
+
+	if declarative.A2AConfig != nil {
+		out.A2AConfig = &A2AConfig{}
+		if declarative.A2AConfig.Skills != nil {
+			out.A2AConfig.Skills = make([]AgentSkill, len(declarative.A2AConfig.Skills))
+			for i, skill := range declarative.A2AConfig.Skills {
+				out.A2AConfig.Skills[i] = AgentSkill(skill)
+			}
+		}
+	}
+
+	if declarative.Deployment != nil {
+		out.Deployment = &DeploymentSpec{
+			Replicas:         declarative.Deployment.Replicas,
+			ImagePullSecrets: declarative.Deployment.ImagePullSecrets,
+			Volumes:          declarative.Deployment.Volumes,
+			Labels:           declarative.Deployment.Labels,
+			Annotations:      declarative.Deployment.Annotations,
+			Env:              declarative.Deployment.Env,
+		}
+	}
+
+	return out
+}
+
+// mergeHubAgentSpec carries the fields that have no v1alpha1 equivalent over
+// from a previously saved spec, for agents that were modified as v1alpha1.
+func mergeHubAgentSpec(dst, restored *v1alpha2.AgentSpec) {
+	dst.Skills = restored.Skills
+	if restored.Type == v1alpha2.AgentType_BYO {
+		dst.Type = restored.Type
+		dst.BYO = restored.BYO
+		dst.Declarative = nil
+		return
+	}
+
+	if dst.Declarative == nil || restored.Declarative == nil {
+		return
+	}
+
+	if dst.Declarative.SystemMessage == "" {
+		dst.Declarative.SystemMessageFrom = restored.Declarative.SystemMessageFrom
+	}
+	dst.Declarative.ExecuteCodeBlocks = restored.Declarative.ExecuteCodeBlocks
+
+	for i, tool := range dst.Declarative.Tools {
+		if tool == nil || i >= len(restored.Declarative.Tools) || restored.Declarative.Tools[i] == nil {
+			continue
+		}
+		restoredTool := restored.Declarative.Tools[i]
+		tool.HeadersFrom = restoredTool.HeadersFrom
+		if tool.McpServer != nil && restoredTool.McpServer != nil && tool.McpServer.Name == restoredTool.McpServer.Name {
+			tool.McpServer.Kind = restoredTool.McpServer.Kind
+			tool.McpServer.ApiGroup = restoredTool.McpServer.ApiGroup
+		}
+		if tool.Agent != nil && restoredTool.Agent != nil && tool.Agent.Name == restoredTool.Agent.Name {
+			tool.Agent.Kind = restoredTool.Agent.Kind
+			tool.Agent.ApiGroup = restoredTool.Agent.ApiGroup
+		}
+	}
+
+	if dst.Declarative.Deployment != nil && restored.Declarative.Deployment != nil {
+		deployment := dst.Declarative.Deployment
+		restoredDeployment := restored.Declarative.Deployment
+		deployment.ImageRegistry = restoredDeployment.ImageRegistry
+		deployment.VolumeMounts = restoredDeployment.VolumeMounts
+		deployment.ImagePullPolicy = restoredDeployment.ImagePullPolicy
+		deployment.Resources = restoredDeployment.Resources
+		deployment.Tolerations = restoredDeployment.Tolerations
+		deployment.Affinity = restoredDeployment.Affinity
+		deployment.NodeSelector = restoredDeployment.NodeSelector
+	}
+}
+
+func marshalConversionData(src any, dst metav1.Object) error {
+	data, err := json.Marshal(src)
+	if err != nil {
+		return fmt.Errorf("failed to marshal conversion data: %w", err)
+	}
+	if len(data) > maxConversionDataSize {
+		return fmt.Errorf("conversion data of %d bytes exceeds the limit of %d bytes, use the v1alpha2 API for this agent",
+			len(data), maxConversionDataSize)
+	}
+
+	annotations := dst.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[ConversionDataAnnotation] = string(data)
+	if err := apivalidation.ValidateAnnotationsSize(annotations); err != nil {
+		return fmt.Errorf("failed to add conversion data: %w", err)
+	}
+	dst.SetAnnotations(annotations)
+	return nil
+}
+
+// unmarshalConversionData reads the conversion data annotation of from into to
+// and removes the annotation. It returns false if there was no annotation.
+func unmarshalConversionData(from metav1.Object, to any) (bool, error) {
+	annotations := from.GetAnnotations()
+	data, ok := annotations[ConversionDataAnnotation]
+	if !ok {
+		return false, nil
+	}
+
+	if err := json.Unmarshal([]byte(data), to); err != nil {
+		return false, fmt.Errorf("failed to unmarshal conversion data: %w", err)
+	}
+
+	delete(annotations, ConversionDataAnnotation)
+	from.SetAnnotations(annotations)
+	return true, nil
+}
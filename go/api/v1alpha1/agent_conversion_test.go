@@ -0,0 +1,173 @@
+package v1alpha1
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/apitesting/fuzzer"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	metafuzzer "k8s.io/apimachinery/pkg/apis/meta/fuzzer"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	"k8s.io/apimachinery/pkg/util/diff"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/randfill"
+
+	"github.com/kagent-dev/kagent/go/api/v1alpha2"
+)
+
+const fuzzIterations = 500
+
+func newAgentFuzzer(t *testing.T, seed int64) *randfill.Filler {
+	scheme := runtime.NewScheme()
+	require.NoError(t, AddToScheme(scheme))
+	require.NoError(t, v1alpha2.AddToScheme(scheme))
+
+	return fuzzer.FuzzerFor(metafuzzer.Funcs, rand.NewSource(seed), serializer.NewCodecFactory(scheme))
+}
+
+func TestAgentConversionFuzzSpokeHubSpoke(t *testing.T) {
+	f := newAgentFuzzer(t, 1)
+
+	for i := 0; i < fuzzIterations; i++ {
+		original := &Agent{}
+		f.Fill(original)
+		delete(original.Annotations, ConversionDataAnnotation)
+
+		hub := &v1alpha2.Agent{}
+		require.NoError(t, original.DeepCopy().ConvertTo(hub))
+
+		converted := &Agent{}
+		require.NoError(t, converted.ConvertFrom(hub))
+		delete(converted.Annotations, ConversionDataAnnotation)
+
+		if !apiequality.Semantic.DeepEqual(original, converted) {
+			t.Fatalf("v1alpha1 -> v1alpha2 -> v1alpha1 round trip is lossy:\n%s", diff.Diff(original, converted))
+		}
+	}
+}
+
+func TestAgentConversionFuzzHubSpokeHub(t *testing.T) {
+	f := newAgentFuzzer(t, 2)
+
+	for i := 0; i < fuzzIterations; i++ {
+		original := &v1alpha2.Agent{}
+		f.Fill(original)
+		delete(original.Annotations, ConversionDataAnnotation)
+
+		spoke := &Agent{}
+		require.NoError(t, spoke.ConvertFrom(original.DeepCopy()))
+
+		converted := &v1alpha2.Agent{}
+		require.NoError(t, spoke.ConvertTo(converted))
+
+		if !apiequality.Semantic.DeepEqual(original, converted) {
+			t.Fatalf("v1alpha2 -> v1alpha1 -> v1alpha2 round trip is lossy:\n%s", diff.Diff(original, converted))
+		}
+	}
+}
+
+func TestAgentConversionToolServerRefs(t *testing.T) {
+	src := &Agent{
+		Spec: AgentSpec{
+			SystemMessage: "You are a helpful agent.",
+			ModelConfig:   "default-model-config",
+			Tools: []*Tool{
+				{
+					Type: ToolProviderType_McpServer,
+					McpServer: &McpServerTool{
+						ToolServer: "tools/kagent-tool-server",
+						ToolNames:  []string{"k8s_get_resources"},
+					},
+				},
+				{
+					Type:  ToolProviderType_Agent,
+					Agent: &AgentTool{Ref: "helper-agent"},
+				},
+			},
+			Memory: []string{"kb-memory"},
+		},
+	}
+
+	dst := &v1alpha2.Agent{}
+	require.NoError(t, src.ConvertTo(dst))
+
+	assert.Equal(t, v1alpha2.AgentType_Declarative, dst.Spec.Type)
+	require.NotNil(t, dst.Spec.Declarative)
+	assert.Equal(t, []string{"kb-memory"}, dst.Spec.Declarative.Memory)
+	require.Len(t, dst.Spec.Declarative.Tools, 2)
+	assert.Equal(t, &v1alpha2.McpServerTool{
+		TypedLocalReference: v1alpha2.TypedLocalReference{
+			Kind:     "RemoteMCPServer",
+			ApiGroup: "kagent.dev",
+			Name:     "tools/kagent-tool-server",
+		},
+		ToolNames: []string{"k8s_get_resources"},
+	}, dst.Spec.Declarative.Tools[0].McpServer)
+	assert.Equal(t, &v1alpha2.TypedLocalReference{
+		Kind:     "Agent",
+		ApiGroup: "kagent.dev",
+		Name:     "helper-agent",
+	}, dst.Spec.Declarative.Tools[1].Agent)
+	assert.NotContains(t, dst.Annotations, ConversionDataAnnotation)
+}
+
+func TestAgentConversionKeepsHubFieldsWhenModified(t *testing.T) {
+	hub := &v1alpha2.Agent{
+		Spec: v1alpha2.AgentSpec{
+			Type: v1alpha2.AgentType_Declarative,
+			Declarative: &v1alpha2.DeclarativeAgentSpec{
+				SystemMessage:     "You are a helpful agent.",
+				ExecuteCodeBlocks: ptr.To(true),
+				Tools: []*v1alpha2.Tool{
+					{
+						Type: v1alpha2.ToolProviderType_McpServer,
+						McpServer: &v1alpha2.McpServerTool{
+							TypedLocalReference: v1alpha2.TypedLocalReference{
+								Kind: "Service",
+								Name: "mcp-service",
+							},
+						},
+						HeadersFrom: []v1alpha2.ValueRef{{Name: "X-Token", Value: "token"}},
+					},
+				},
+			},
+		},
+	}
+
+	spoke := &Agent{}
+	require.NoError(t, spoke.ConvertFrom(hub))
+	assert.Contains(t, spoke.Annotations, ConversionDataAnnotation)
+
+	spoke.Spec.SystemMessage = "You are a very helpful agent."
+
+	converted := &v1alpha2.Agent{}
+	require.NoError(t, spoke.ConvertTo(converted))
+
+	require.NotNil(t, converted.Spec.Declarative)
+	assert.Equal(t, "You are a very helpful agent.", converted.Spec.Declarative.SystemMessage)
+	assert.Equal(t, ptr.To(true), converted.Spec.Declarative.ExecuteCodeBlocks)
+	require.Len(t, converted.Spec.Declarative.Tools, 1)
+	assert.Equal(t, "Service", converted.Spec.Declarative.Tools[0].McpServer.Kind)
+	assert.Equal(t, hub.Spec.Declarative.Tools[0].HeadersFrom, converted.Spec.Declarative.Tools[0].HeadersFrom)
+}
+
+func TestAgentConversionBoundsConversionData(t *testing.T) {
+	hub := &v1alpha2.Agent{
+		Spec: v1alpha2.AgentSpec{
+			Type: v1alpha2.AgentType_Declarative,
+			Declarative: &v1alpha2.DeclarativeAgentSpec{
+				SystemMessage: strings.Repeat("a", maxConversionDataSize),
+			},
+		},
+	}
+	assert.ErrorContains(t, (&Agent{}).ConvertFrom(hub), "exceeds the limit")
+
+	// the conversion data and the other annotations must fit the annotations size limit
+	hub.Spec.Declarative.SystemMessage = "You are a helpful agent."
+	hub.Annotations = map[string]string{"large": strings.Repeat("a", 256*1024-100)}
+	assert.ErrorContains(t, (&Agent{}).ConvertFrom(hub), "annotations size")
+}
@@ -58,8 +58,68 @@ type AgentSpec struct {
 	// and made available to the agent under the `/skills` folder.
 	// +optional
 	Skills *SkillForAgent `json:"skills,omitempty"`
+
+	// ReadinessMode determines when the agent is reported Ready. Deployment, the default,
+	// only waits for the replicas of the agent to be available. Runtime additionally requires
+	// the agent card to be served, by the agent and through the controller, and the credentials
+	// of the model to be valid.
+	// +optional
+	// +kubebuilder:validation:Enum=Deployment;Runtime
+	ReadinessMode AgentReadinessMode `json:"readinessMode,omitempty"`
+
+	// RetentionPolicy determines what happens to the sessions, tasks, events and push notifications
+	// of the agent, and to the tools of the MCP services it owns, when the agent is deleted.
+	// Delete, the default, removes them. Archive soft deletes them so they can still be restored
+	// from the database. Keep leaves them, and the stored agent, untouched so that the sessions
+	// can still be browsed.
+	// +optional
+	// +kubebuilder:validation:Enum=Delete;Archive;Keep
+	RetentionPolicy AgentRetentionPolicy `json:"retentionPolicy,omitempty"`
+
+	// RateLimits limits how much each user can use the agent. They are enforced on the
+	// messages sent to the agent through the kagent A2A endpoint, and the daily token budget
+	// also on the tasks it creates through the tasks API.
+	// +optional
+	RateLimits *RateLimits `json:"rateLimits,omitempty"`
+}
+
+// RateLimits are the limits of the use of an agent, applied to each user separately.
+// Unset limits are not enforced.
+type RateLimits struct {
+	// RequestsPerMinute is the number of messages a user can send to the agent per minute.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	RequestsPerMinute *int32 `json:"requestsPerMinute,omitempty"`
+
+	// ConcurrentTasks is the number of tasks of a user the agent works on at the same time.
+	// A task counts until it completes, fails, is canceled or waits for input, or for at most
+	// 15 minutes for the agents that do not report the states of their tasks.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	ConcurrentTasks *int32 `json:"concurrentTasks,omitempty"`
+
+	// DailyTokenBudget is the number of model tokens, input and output, a user can use with
+	// the agent per day. Days start at midnight UTC.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	DailyTokenBudget *int64 `json:"dailyTokenBudget,omitempty"`
 }
 
+type AgentReadinessMode string
+
+const (
+	AgentReadinessMode_Deployment AgentReadinessMode = "Deployment"
+	AgentReadinessMode_Runtime    AgentReadinessMode = "Runtime"
+)
+
+type AgentRetentionPolicy string
+
+const (
+	AgentRetentionPolicy_Delete  AgentRetentionPolicy = "Delete"
+	AgentRetentionPolicy_Archive AgentRetentionPolicy = "Archive"
+	AgentRetentionPolicy_Keep    AgentRetentionPolicy = "Keep"
+)
+
 type SkillForAgent struct {
 	// Fetch images insecurely from registries (allowing HTTP and skipping TLS verification).
 	// Meant for development and testing purposes only.
@@ -85,12 +145,24 @@ type DeclarativeAgentSpec struct {
 	// Must be in the same namespace as the Agent.
 	// +optional
 	ModelConfig string `json:"modelConfig,omitempty"`
+	// ModelOverrides overrides parameters of the model config for this agent only.
+	// Parameters must be supported by the typed config of the provider of the model config.
+	// Overrides are not supported for the GeminiVertexAI and AnthropicVertexAI providers.
+	// +optional
+	ModelOverrides *ModelOverrides `json:"modelOverrides,omitempty"`
 	// Whether to stream the response from the model.
 	// If not specified, the default value is true.
 	// +optional
 	Stream *bool `json:"stream,omitempty"`
 	// +kubebuilder:validation:MaxItems=20
 	Tools []*Tool `json:"tools,omitempty"`
+	// The names of the v1alpha1 Memory resources to use for this agent.
+	// Can either be a reference to the name of a Memory in the same namespace as the Agent,
+	// or a reference to the name of a Memory in a different namespace in the form <namespace>/<name>.
+	// The API key Secret of each Memory must be in the same namespace as the Agent.
+	// +kubebuilder:validation:MaxItems=20
+	// +optional
+	Memory []string `json:"memory,omitempty"`
 	// A2AConfig instantiates an A2A server for this agent,
 	// served on the HTTP port of the kagent kubernetes
 	// controller (default 8083).
@@ -110,6 +182,32 @@ type DeclarativeAgentSpec struct {
 	ExecuteCodeBlocks *bool `json:"executeCodeBlocks,omitempty"`
 }
 
+// ModelOverrides are generation parameters set on top of those of a shared model config.
+type ModelOverrides struct {
+	// Temperature for sampling, between 0 and 2 for the OpenAI, AzureOpenAI and OpenAICompatible
+	// providers, and between 0 and 1 for the Anthropic and Bedrock providers
+	// +optional
+	Temperature string `json:"temperature,omitempty"`
+
+	// Maximum tokens to generate
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxTokens *int `json:"maxTokens,omitempty"`
+
+	// Top-p sampling parameter, between 0 and 1
+	// +optional
+	TopP string `json:"topP,omitempty"`
+
+	// Top-k sampling parameter
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	TopK *int `json:"topK,omitempty"`
+
+	// Reasoning effort
+	// +optional
+	ReasoningEffort *OpenAIReasoningEffort `json:"reasoningEffort,omitempty"`
+}
+
 type DeclarativeDeploymentSpec struct {
 	// +optional
 	ImageRegistry string `json:"imageRegistry,omitempty"`
@@ -179,6 +277,10 @@ type Tool struct {
 	Type ToolProviderType `json:"type,omitempty"`
 	// +optional
 	McpServer *McpServerTool `json:"mcpServer,omitempty"`
+	// The reference to the Agent to use as a tool.
+	// Can either be the name of an Agent in the same namespace, or <namespace>/<name> for an Agent
+	// in a different namespace that allows it through the kagent.dev/allowed-namespaces annotation.
+	// Set kind to RemoteAgent to use an agent imported with a RemoteAgent instead.
 	// +optional
 	Agent *TypedLocalReference `json:"agent,omitempty"`
 
@@ -209,6 +311,7 @@ func (s *Tool) ResolveHeaders(ctx context.Context, client client.Client, namespa
 type McpServerTool struct {
 	// The reference to the ToolServer that provides the tool.
 	// Can either be a reference to the name of a ToolServer in the same namespace as the referencing Agent, or a reference to the name of an ToolServer in a different namespace in the form <namespace>/<name>
+	// A ToolServer in a different namespace must list the Agent's namespace, or "*", in its kagent.dev/allowed-namespaces annotation.
 	// +optional
 	TypedLocalReference `json:",inline"`
 
@@ -245,6 +348,25 @@ const (
 	AgentConditionTypeReady    = "Ready"
 )
 
+// AgentCleanupFinalizer keeps an agent around until its sessions, tasks and
+// tools have been cleaned up according to its retention policy.
+const AgentCleanupFinalizer = "kagent.dev/agent-cleanup"
+
+// Reasons of the Ready condition of an agent
+const (
+	AgentReadyReasonDeploymentReady = "DeploymentReady"
+	// AgentReadyReasonAgentReady is used in the Runtime readiness mode
+	AgentReadyReasonAgentReady = "AgentReady"
+
+	AgentReadyReasonDeploymentNotFound    = "DeploymentNotFound"
+	AgentReadyReasonDeploymentNotReady    = "DeploymentNotReady"
+	AgentReadyReasonCrashLooping          = "CrashLooping"
+	AgentReadyReasonImagePullBackOff      = "ImagePullBackOff"
+	AgentReadyReasonToolServerUnavailable = "ToolServerUnavailable"
+	AgentReadyReasonModelAuthFailed       = "ModelAuthFailed"
+	AgentReadyReasonAgentCardUnavailable  = "AgentCardUnavailable"
+)
+
 // AgentStatus defines the observed state of Agent.
 type AgentStatus struct {
 	ObservedGeneration int64              `json:"observedGeneration"`
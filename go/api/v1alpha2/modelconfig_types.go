@@ -18,14 +18,21 @@ package v1alpha2
 
 import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 )
 
 const (
 	ModelConfigConditionTypeAccepted = "Accepted"
+	// ModelConfigConditionTypeCredentialsValid reports whether the provider accepted the credentials of the model config.
+	ModelConfigConditionTypeCredentialsValid = "CredentialsValid"
 )
 
+// ModelConfigSkipCredentialsCheckAnnotation disables probing the provider with the
+// credentials of the model config when set to "true".
+const ModelConfigSkipCredentialsCheckAnnotation = "kagent.dev/skip-credentials-check"
+
 // ModelProvider represents the model provider type
-// +kubebuilder:validation:Enum=Anthropic;OpenAI;AzureOpenAI;Ollama;Gemini;GeminiVertexAI;AnthropicVertexAI
+// +kubebuilder:validation:Enum=Anthropic;OpenAI;AzureOpenAI;Ollama;Gemini;GeminiVertexAI;AnthropicVertexAI;Bedrock;OpenAICompatible;Fallback
 type ModelProvider string
 
 const (
@@ -36,6 +43,12 @@ const (
 	ModelProviderGemini            ModelProvider = "Gemini"
 	ModelProviderGeminiVertexAI    ModelProvider = "GeminiVertexAI"
 	ModelProviderAnthropicVertexAI ModelProvider = "AnthropicVertexAI"
+	ModelProviderBedrock           ModelProvider = "Bedrock"
+	// ModelProviderOpenAICompatible is any server implementing the OpenAI chat completions API,
+	// e.g. vLLM, a LiteLLM proxy, Together or Groq
+	ModelProviderOpenAICompatible ModelProvider = "OpenAICompatible"
+	// ModelProviderFallback fails over between other ModelConfigs
+	ModelProviderFallback ModelProvider = "Fallback"
 )
 
 type BaseVertexAIConfig struct {
@@ -62,6 +75,20 @@ type BaseVertexAIConfig struct {
 	// Stop sequences
 	// +optional
 	StopSequences []string `json:"stopSequences,omitempty"`
+
+	// Authenticate with GKE Workload Identity instead of a service account JSON key
+	// +optional
+	WorkloadIdentity *GKEWorkloadIdentityConfig `json:"workloadIdentity,omitempty"`
+}
+
+// GKEWorkloadIdentityConfig lets the agent obtain Google credentials for its Kubernetes
+// service account from the GKE metadata server.
+type GKEWorkloadIdentityConfig struct {
+	// Email of the Google service account to impersonate. The Kubernetes service account
+	// of the agent must be granted roles/iam.workloadIdentityUser on it. Leave empty when
+	// the Kubernetes service account is granted access to Vertex AI directly as a principal.
+	// +optional
+	ServiceAccount string `json:"serviceAccount,omitempty"`
 }
 
 // GeminiVertexAIConfig contains Gemini Vertex AI-specific configuration options
@@ -179,10 +206,9 @@ type AzureOpenAIConfig struct {
 	// +optional
 	AzureADToken string `json:"azureAdToken,omitempty"`
 
-	// Azure AD token provider
+	// Authenticate with Microsoft Entra tokens obtained by the agent instead of an API key
 	// +optional
-	// TODO (peterj): We need to figure out how to implement this
-	// AzureADTokenProvider interface{} `json:"azureAdTokenProvider,omitempty"`
+	Identity *AzureIdentityConfig `json:"identity,omitempty"`
 
 	// Temperature for sampling
 	// +optional
@@ -197,6 +223,40 @@ type AzureOpenAIConfig struct {
 	TopP string `json:"topP,omitempty"`
 }
 
+// AzureIdentityMode selects how the agent obtains Microsoft Entra tokens
+// +kubebuilder:validation:Enum=WorkloadIdentity;ManagedIdentity
+type AzureIdentityMode string
+
+const (
+	// AzureIdentityModeWorkloadIdentity exchanges a token of the agent's service account
+	// federated with an application or a user-assigned managed identity.
+	AzureIdentityModeWorkloadIdentity AzureIdentityMode = "WorkloadIdentity"
+	// AzureIdentityModeManagedIdentity uses the managed identity of the node through the
+	// instance metadata service.
+	AzureIdentityModeManagedIdentity AzureIdentityMode = "ManagedIdentity"
+)
+
+// AzureIdentityConfig configures a token credential for Azure OpenAI
+// +kubebuilder:validation:XValidation:message="clientId and tenantId must be set for WorkloadIdentity",rule="(has(self.mode) && self.mode == 'ManagedIdentity') || (has(self.clientId) && has(self.tenantId))"
+type AzureIdentityConfig struct {
+	// +kubebuilder:default=WorkloadIdentity
+	// +optional
+	Mode AzureIdentityMode `json:"mode,omitempty"`
+
+	// Client ID of the application or user-assigned managed identity. With ManagedIdentity
+	// it selects a user-assigned identity instead of the system-assigned one.
+	// +optional
+	ClientID string `json:"clientId,omitempty"`
+
+	// Tenant ID of the application, required with WorkloadIdentity
+	// +optional
+	TenantID string `json:"tenantId,omitempty"`
+
+	// Microsoft Entra authority host, defaults to https://login.microsoftonline.com/
+	// +optional
+	AuthorityHost string `json:"authorityHost,omitempty"`
+}
+
 // OllamaConfig contains Ollama-specific configuration options
 type OllamaConfig struct {
 	// Host for the Ollama API
@@ -210,9 +270,195 @@ type OllamaConfig struct {
 
 type GeminiConfig struct{}
 
-// TLSConfig contains TLS/SSL configuration options for model provider connections.
+// BedrockConfig contains AWS Bedrock-specific configuration options.
+// The model ID, e.g. anthropic.claude-3-5-sonnet-20240620-v1:0, is set in the model field.
+//
+// Without an API key secret, the agent uses the default AWS credential chain, for example
+// IRSA or EKS Pod Identity set up for its service account, or the role in RoleARN.
+// An API key secret holds a Bedrock API key.
+type BedrockConfig struct {
+	// AWS region of the Bedrock runtime, e.g. us-east-1
+	// +required
+	Region string `json:"region"`
+
+	// ID or ARN of an inference profile to invoke instead of the model ID,
+	// e.g. for cross-region inference
+	// +optional
+	InferenceProfile string `json:"inferenceProfile,omitempty"`
+
+	// ID or ARN of a guardrail applied to every request
+	// +optional
+	GuardrailID string `json:"guardrailId,omitempty"`
+
+	// Version of the guardrail, required when GuardrailID is set
+	// +optional
+	GuardrailVersion string `json:"guardrailVersion,omitempty"`
+
+	// ARN of an IAM role the agent assumes with STS, using a token of its service account
+	// with the sts.amazonaws.com audience. This is the same web identity federation IRSA
+	// uses, without needing the EKS pod identity webhook.
+	// +optional
+	RoleARN string `json:"roleArn,omitempty"`
+
+	// Maximum tokens to generate
+	// +optional
+	MaxTokens int `json:"maxTokens,omitempty"`
+
+	// Temperature for sampling
+	// +optional
+	Temperature string `json:"temperature,omitempty"`
+
+	// Top-p sampling parameter
+	// +optional
+	TopP string `json:"topP,omitempty"`
+}
+
+// OpenAICompatibleProfile selects the defaults of a well known OpenAI-compatible server
+// +kubebuilder:validation:Enum=Custom;vLLM;LiteLLM;Together;Groq
+type OpenAICompatibleProfile string
+
+const (
+	OpenAICompatibleProfileCustom   OpenAICompatibleProfile = "Custom"
+	OpenAICompatibleProfileVLLM     OpenAICompatibleProfile = "vLLM"
+	OpenAICompatibleProfileLiteLLM  OpenAICompatibleProfile = "LiteLLM"
+	OpenAICompatibleProfileTogether OpenAICompatibleProfile = "Together"
+	OpenAICompatibleProfileGroq     OpenAICompatibleProfile = "Groq"
+)
+
+// ModelCapabilities describes which features of the OpenAI API the model supports.
+// Capabilities that are not set default to the ones of the profile.
+type ModelCapabilities struct {
+	// Whether the model can call tools
+	// +optional
+	FunctionCalling *bool `json:"functionCalling,omitempty"`
+
+	// Whether responses can be streamed
+	// +optional
+	Streaming *bool `json:"streaming,omitempty"`
+
+	// Whether the model accepts images
+	// +optional
+	Vision *bool `json:"vision,omitempty"`
+
+	// Whether the model supports the json_object response format
+	// +optional
+	JSONMode *bool `json:"jsonMode,omitempty"`
+}
+
+// OpenAICompatibleConfig contains the configuration of a server implementing the OpenAI API.
+// The API key secret is optional, many self-hosted servers do not require one.
+type OpenAICompatibleConfig struct {
+	// Profile of the server, used for the default base URL and capabilities
+	// +optional
+	// +kubebuilder:default=Custom
+	Profile OpenAICompatibleProfile `json:"profile,omitempty"`
+
+	// Base URL of the API, including the version, e.g. http://vllm.models:8000/v1.
+	// Required unless the profile has a default one.
+	// +optional
+	BaseURL string `json:"baseUrl,omitempty"`
+
+	// Capabilities of the model, overriding the defaults of the profile
+	// +optional
+	Capabilities *ModelCapabilities `json:"capabilities,omitempty"`
+
+	// Temperature for sampling
+	// +optional
+	Temperature string `json:"temperature,omitempty"`
+
+	// Maximum tokens to generate
+	// +optional
+	MaxTokens int `json:"maxTokens,omitempty"`
+
+	// Top-p sampling parameter
+	// +optional
+	TopP string `json:"topP,omitempty"`
+
+	// Timeout of a request in seconds
+	// +optional
+	Timeout *int `json:"timeout,omitempty"`
+}
+
+var openAICompatibleProfileBaseURLs = map[OpenAICompatibleProfile]string{
+	OpenAICompatibleProfileTogether: "https://api.together.xyz/v1",
+	OpenAICompatibleProfileGroq:     "https://api.groq.com/openai/v1",
+}
+
+// GetBaseURL returns the configured base URL, or the default one of the profile.
+func (c *OpenAICompatibleConfig) GetBaseURL() string {
+	if c.BaseURL != "" {
+		return c.BaseURL
+	}
+	return openAICompatibleProfileBaseURLs[c.Profile]
+}
+
+// GetCapabilities returns the capabilities of the model with every field set,
+// falling back to the defaults of the profile.
+func (c *OpenAICompatibleConfig) GetCapabilities() ModelCapabilities {
+	capabilities := ModelCapabilities{
+		FunctionCalling: ptr.To(true),
+		Streaming:       ptr.To(true),
+		Vision:          ptr.To(false),
+		// vLLM, LiteLLM and the hosted providers all implement the json_object response format
+		JSONMode: ptr.To(c.Profile != "" && c.Profile != OpenAICompatibleProfileCustom),
+	}
+	if c.Profile == OpenAICompatibleProfileLiteLLM {
+		// LiteLLM translates images for the providers behind it
+		capabilities.Vision = ptr.To(true)
+	}
+
+	if c.Capabilities != nil {
+		if c.Capabilities.FunctionCalling != nil {
+			capabilities.FunctionCalling = c.Capabilities.FunctionCalling
+		}
+		if c.Capabilities.Streaming != nil {
+			capabilities.Streaming = c.Capabilities.Streaming
+		}
+		if c.Capabilities.Vision != nil {
+			capabilities.Vision = c.Capabilities.Vision
+		}
+		if c.Capabilities.JSONMode != nil {
+			capabilities.JSONMode = c.Capabilities.JSONMode
+		}
+	}
+	return capabilities
+}
+
+// FallbackConfig contains the ModelConfigs an agent fails over to
+type FallbackConfig struct {
+	// Names of ModelConfigs in the same namespace, in the order they are tried.
+	// The next model is tried when a model is rate limited, returns a server error or times out.
+	// A model may itself be a Fallback ModelConfig, as long as the chain has no cycles.
+	// +kubebuilder:validation:MinItems=1
+	Models []string `json:"models"`
+
+	// Retry policy applied to each model before failing over to the next one
+	// +optional
+	Retry *ModelRetryPolicy `json:"retry,omitempty"`
+}
+
+// ModelRetryPolicy contains how often and how fast a failed model call is retried
+type ModelRetryPolicy struct {
+	// Maximum number of attempts per model, including the first one
+	// +optional
+	// +kubebuilder:default=1
+	// +kubebuilder:validation:Minimum=1
+	MaxAttempts int `json:"maxAttempts,omitempty"`
+
+	// Backoff before the first retry, doubled on every following retry
+	// +optional
+	// +kubebuilder:default="1s"
+	InitialBackoff *metav1.Duration `json:"initialBackoff,omitempty"`
+
+	// Maximum backoff between retries
+	// +optional
+	// +kubebuilder:default="30s"
+	MaxBackoff *metav1.Duration `json:"maxBackoff,omitempty"`
+}
+
+// TLSConfig contains TLS/SSL configuration options for model provider and MCP server connections.
 // This enables agents to connect to internal LiteLLM gateways or other providers
-// that use self-signed certificates or custom certificate authorities.
+// that use self-signed certificates, custom certificate authorities or mutual TLS.
 type TLSConfig struct {
 	// DisableVerify disables SSL certificate verification entirely.
 	// When false (default), SSL certificates are verified.
@@ -244,6 +490,30 @@ type TLSConfig struct {
 	// +optional
 	// +kubebuilder:default=false
 	DisableSystemCAs bool `json:"disableSystemCAs,omitempty"`
+
+	// ClientCertSecretRef is a reference to a Kubernetes Secret of type kubernetes.io/tls
+	// whose tls.crt and tls.key are presented as client certificate for mutual TLS.
+	// The Secret must be in the same namespace as the referencing resource.
+	// +optional
+	ClientCertSecretRef string `json:"clientCertSecretRef,omitempty"`
+}
+
+// ProxyConfig routes connections through an HTTP(S) proxy.
+type ProxyConfig struct {
+	// URL of the proxy, for example http://proxy.example.com:3128
+	// +kubebuilder:validation:Pattern=`^https?://`
+	URL string `json:"url"`
+
+	// Hosts that are connected to directly, in the format of the NO_PROXY environment
+	// variable: host names, domain suffixes, IP addresses and CIDR ranges.
+	// +optional
+	NoProxy []string `json:"noProxy,omitempty"`
+
+	// CredentialsSecretRef is a reference to a Kubernetes Secret of type kubernetes.io/basic-auth
+	// whose username and password authenticate with the proxy.
+	// The Secret must be in the same namespace as the referencing resource.
+	// +optional
+	CredentialsSecretRef string `json:"credentialsSecretRef,omitempty"`
 }
 
 // ModelConfigSpec defines the desired state of ModelConfig.
@@ -255,12 +525,24 @@ type TLSConfig struct {
 // +kubebuilder:validation:XValidation:message="provider.gemini must be nil if the provider is not Gemini",rule="!(has(self.gemini) && self.provider != 'Gemini')"
 // +kubebuilder:validation:XValidation:message="provider.geminiVertexAI must be nil if the provider is not GeminiVertexAI",rule="!(has(self.geminiVertexAI) && self.provider != 'GeminiVertexAI')"
 // +kubebuilder:validation:XValidation:message="provider.anthropicVertexAI must be nil if the provider is not AnthropicVertexAI",rule="!(has(self.anthropicVertexAI) && self.provider != 'AnthropicVertexAI')"
+// +kubebuilder:validation:XValidation:message="provider.bedrock must be nil if the provider is not Bedrock",rule="!(has(self.bedrock) && self.provider != 'Bedrock')"
+// +kubebuilder:validation:XValidation:message="bedrock.guardrailVersion must be set if bedrock.guardrailId is set",rule="!(has(self.bedrock) && has(self.bedrock.guardrailId) && !has(self.bedrock.guardrailVersion))"
+// +kubebuilder:validation:XValidation:message="bedrock.roleArn cannot be used together with apiKeySecret",rule="!(has(self.bedrock) && has(self.bedrock.roleArn) && has(self.apiKeySecret) && size(self.apiKeySecret) > 0)"
+// +kubebuilder:validation:XValidation:message="azureOpenAI.identity cannot be used together with apiKeySecret or azureAdToken",rule="!(has(self.azureOpenAI) && has(self.azureOpenAI.identity) && ((has(self.apiKeySecret) && size(self.apiKeySecret) > 0) || has(self.azureOpenAI.azureAdToken)))"
+// +kubebuilder:validation:XValidation:message="geminiVertexAI.workloadIdentity cannot be used together with apiKeySecret",rule="!(has(self.geminiVertexAI) && has(self.geminiVertexAI.workloadIdentity) && has(self.apiKeySecret) && size(self.apiKeySecret) > 0)"
+// +kubebuilder:validation:XValidation:message="anthropicVertexAI.workloadIdentity cannot be used together with apiKeySecret",rule="!(has(self.anthropicVertexAI) && has(self.anthropicVertexAI.workloadIdentity) && has(self.apiKeySecret) && size(self.apiKeySecret) > 0)"
+// +kubebuilder:validation:XValidation:message="provider.openAICompatible must be nil if the provider is not OpenAICompatible",rule="!(has(self.openAICompatible) && self.provider != 'OpenAICompatible')"
+// +kubebuilder:validation:XValidation:message="openAICompatible.baseUrl must be set unless the profile is Together or Groq",rule="self.provider != 'OpenAICompatible' || (has(self.openAICompatible) && (has(self.openAICompatible.baseUrl) || (has(self.openAICompatible.profile) && self.openAICompatible.profile in ['Together', 'Groq'])))"
+// +kubebuilder:validation:XValidation:message="provider.fallback must be set if and only if the provider is Fallback",rule="has(self.fallback) == (self.provider == 'Fallback')"
+// +kubebuilder:validation:XValidation:message="model must be set unless the provider is Fallback",rule="self.provider == 'Fallback' || has(self.model)"
 // +kubebuilder:validation:XValidation:message="apiKeySecret must be set if apiKeySecretKey is set",rule="!(has(self.apiKeySecretKey) && !has(self.apiKeySecret))"
 // +kubebuilder:validation:XValidation:message="apiKeySecretKey must be set if apiKeySecret is set",rule="!(has(self.apiKeySecret) && !has(self.apiKeySecretKey))"
 // +kubebuilder:validation:XValidation:message="caCertSecretKey requires caCertSecretRef",rule="!(has(self.tls) && has(self.tls.caCertSecretKey) && size(self.tls.caCertSecretKey) > 0 && (!has(self.tls.caCertSecretRef) || size(self.tls.caCertSecretRef) == 0))"
 // +kubebuilder:validation:XValidation:message="caCertSecretKey requires caCertSecretRef (unless disableVerify is true)",rule="!(has(self.tls) && (!has(self.tls.disableVerify) || !self.tls.disableVerify) && has(self.tls.caCertSecretKey) && size(self.tls.caCertSecretKey) > 0 && (!has(self.tls.caCertSecretRef) || size(self.tls.caCertSecretRef) == 0))"
 // +kubebuilder:validation:XValidation:message="caCertSecretRef requires caCertSecretKey (unless disableVerify is true)",rule="!(has(self.tls) && (!has(self.tls.disableVerify) || !self.tls.disableVerify) && has(self.tls.caCertSecretRef) && size(self.tls.caCertSecretRef) > 0 && (!has(self.tls.caCertSecretKey) || size(self.tls.caCertSecretKey) == 0))"
 type ModelConfigSpec struct {
+	// The name of the model. Not used by the Fallback provider.
+	// +optional
 	Model string `json:"model"`
 
 	// The name of the secret that contains the API key. Must be a reference to the name of a secret in the same namespace as the referencing ModelConfig
@@ -306,11 +588,29 @@ type ModelConfigSpec struct {
 	// +optional
 	AnthropicVertexAI *AnthropicVertexAIConfig `json:"anthropicVertexAI,omitempty"`
 
+	// AWS Bedrock-specific configuration
+	// +optional
+	Bedrock *BedrockConfig `json:"bedrock,omitempty"`
+
+	// OpenAI-compatible server configuration
+	// +optional
+	OpenAICompatible *OpenAICompatibleConfig `json:"openAICompatible,omitempty"`
+
+	// Fallback-specific configuration
+	// +optional
+	Fallback *FallbackConfig `json:"fallback,omitempty"`
+
 	// TLS configuration for provider connections.
 	// Enables agents to connect to internal LiteLLM gateways or other providers
 	// that use self-signed certificates or custom certificate authorities.
+	// A client certificate is only supported by the OpenAI, AzureOpenAI and OpenAICompatible providers.
 	// +optional
 	TLS *TLSConfig `json:"tls,omitempty"`
+
+	// Proxy the connections to the provider are made through.
+	// Only supported by the OpenAI, AzureOpenAI and OpenAICompatible providers.
+	// +optional
+	Proxy *ProxyConfig `json:"proxy,omitempty"`
 }
 
 // ModelConfigStatus defines the observed state of ModelConfig.
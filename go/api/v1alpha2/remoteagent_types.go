@@ -0,0 +1,168 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha2
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// RemoteAgentSpec defines the desired state of RemoteAgent.
+type RemoteAgentSpec struct {
+	// Description of the agent, the description of its agent card is used when empty.
+	// +optional
+	Description string `json:"description,omitempty"`
+	// The base URL of the agent, its agent card is fetched from AgentCardPath under it.
+	// The URL of the agent card must have the same origin, the requests to the agent and
+	// its credentials are not sent to any other origin.
+	// +kubebuilder:validation:Pattern=`^https?://`
+	URL string `json:"url"`
+	// The path of the agent card under the URL. Defaults to /.well-known/agent-card.json.
+	// +optional
+	AgentCardPath string `json:"agentCardPath,omitempty"`
+	// Headers sent with every request to the agent, e.g. an API key. The referenced Secrets
+	// and ConfigMaps are read for every request, so rotated values are used right away.
+	// +optional
+	HeadersFrom []ValueRef `json:"headersFrom,omitempty"`
+	// OAuth 2.0 client credentials the requests to the agent are authenticated with.
+	// +optional
+	OAuth *OAuthClientCredentials `json:"oauth,omitempty"`
+	// TLS configuration for connections to the agent.
+	// +optional
+	TLS *TLSConfig `json:"tls,omitempty"`
+	// Proxy the connections to the agent are made through.
+	// +optional
+	Proxy *ProxyConfig `json:"proxy,omitempty"`
+	// How often the agent card is fetched again. Defaults to 5m, 0 disables periodic fetches.
+	// +optional
+	ResyncInterval *metav1.Duration `json:"resyncInterval,omitempty"`
+}
+
+// OAuthClientCredentials configures the OAuth 2.0 client credentials flow. The tokens are
+// requested by the controller, and renewed when they expire.
+type OAuthClientCredentials struct {
+	// +kubebuilder:validation:Pattern=`^https?://`
+	TokenURL string `json:"tokenURL"`
+	// +kubebuilder:validation:MinLength=1
+	ClientID string `json:"clientID"`
+	// The secret of the client, from a Secret or ConfigMap in the namespace of the RemoteAgent.
+	ClientSecretFrom ValueSource `json:"clientSecretFrom"`
+	// +optional
+	Scopes []string `json:"scopes,omitempty"`
+	// Additional parameters of the token requests, e.g. an audience.
+	// +optional
+	EndpointParams map[string]string `json:"endpointParams,omitempty"`
+}
+
+// DefaultRemoteAgentResyncInterval is used when ResyncInterval is not set.
+const DefaultRemoteAgentResyncInterval = 5 * time.Minute
+
+// GetResyncInterval returns the configured resync interval, or the default one.
+func (s *RemoteAgentSpec) GetResyncInterval() time.Duration {
+	if s.ResyncInterval == nil {
+		return DefaultRemoteAgentResyncInterval
+	}
+	return s.ResyncInterval.Duration
+}
+
+func (s *RemoteAgentSpec) ResolveHeaders(ctx context.Context, client client.Client, namespace string) (map[string]string, error) {
+	result := map[string]string{}
+
+	for _, h := range s.HeadersFrom {
+		k, v, err := h.Resolve(ctx, client, namespace)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve header: %v", err)
+		}
+
+		result[k] = v
+	}
+
+	return result, nil
+}
+
+// RemoteAgentStatus defines the observed state of RemoteAgent.
+type RemoteAgentStatus struct {
+	ObservedGeneration int64              `json:"observedGeneration"`
+	Conditions         []metav1.Condition `json:"conditions"`
+	// The agent card fetched from the agent, without its skills
+	// +optional
+	AgentCard *RemoteAgentCard `json:"agentCard,omitempty"`
+	// The skills advertised in the agent card
+	// +optional
+	DiscoveredSkills []AgentSkill `json:"discoveredSkills,omitempty"`
+	// The last time the agent card was fetched
+	// +optional
+	LastConnectedTime *metav1.Time `json:"lastConnectedTime,omitempty"`
+}
+
+// RemoteAgentCard is the part of the agent card of a remote agent kept in its status.
+type RemoteAgentCard struct {
+	Name string `json:"name"`
+	// +optional
+	Description string `json:"description,omitempty"`
+	// The URL the messages to the agent are sent to
+	URL string `json:"url"`
+	// +optional
+	Version string `json:"version,omitempty"`
+	// +optional
+	ProtocolVersion string `json:"protocolVersion,omitempty"`
+	// Whether the agent streams its responses
+	// +optional
+	Streaming bool `json:"streaming,omitempty"`
+}
+
+const (
+	// RemoteAgentConditionTypeAccepted reflects whether the configuration of the agent is valid.
+	RemoteAgentConditionTypeAccepted = "Accepted"
+	// RemoteAgentConditionTypeReady reflects whether a valid agent card was fetched last time.
+	RemoteAgentConditionTypeReady = "Ready"
+)
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:shortName=ragent,categories=kagent
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="URL",type="string",JSONPath=".spec.url"
+// +kubebuilder:printcolumn:name="Agent",type="string",JSONPath=".status.agentCard.name"
+// +kubebuilder:printcolumn:name="Accepted",type="string",JSONPath=".status.conditions[?(@.type=='Accepted')].status"
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+
+// RemoteAgent is the Schema for the RemoteAgents API. It imports an A2A agent running outside
+// of kagent, which is then served by the controller like the other agents and can be used as a
+// tool by declarative agents.
+type RemoteAgent struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RemoteAgentSpec   `json:"spec,omitempty"`
+	Status RemoteAgentStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// RemoteAgentList contains a list of RemoteAgent.
+type RemoteAgentList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []RemoteAgent `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&RemoteAgent{}, &RemoteAgentList{})
+}
@@ -22,8 +22,10 @@ import (
 	"database/sql/driver"
 	"encoding/json"
 	"fmt"
+	"time"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -36,6 +38,9 @@ const (
 )
 
 // RemoteMCPServerSpec defines the desired state of RemoteMCPServer.
+//
+// +kubebuilder:validation:XValidation:message="caCertSecretKey requires caCertSecretRef",rule="!(has(self.tls) && has(self.tls.caCertSecretKey) && size(self.tls.caCertSecretKey) > 0 && (!has(self.tls.caCertSecretRef) || size(self.tls.caCertSecretRef) == 0))"
+// +kubebuilder:validation:XValidation:message="caCertSecretRef requires caCertSecretKey (unless disableVerify is true)",rule="!(has(self.tls) && (!has(self.tls.disableVerify) || !self.tls.disableVerify) && has(self.tls.caCertSecretRef) && size(self.tls.caCertSecretRef) > 0 && (!has(self.tls.caCertSecretKey) || size(self.tls.caCertSecretKey) == 0))"
 type RemoteMCPServerSpec struct {
 	Description string `json:"description"`
 	// +kubebuilder:default=STREAMABLE_HTTP
@@ -52,6 +57,45 @@ type RemoteMCPServerSpec struct {
 	// +optional
 	// +kubebuilder:default=true
 	TerminateOnClose *bool `json:"terminateOnClose,omitempty"`
+	// How often the tools of the server are rediscovered. Defaults to 60s, 0 disables periodic rediscovery.
+	// Servers that send notifications/tools/list_changed are also rediscovered when their tools change.
+	// +optional
+	ResyncInterval *metav1.Duration `json:"resyncInterval,omitempty"`
+	// How often the server is pinged to update its Ready condition. Defaults to 30s, 0 disables periodic pings.
+	// +optional
+	HealthCheckInterval *metav1.Duration `json:"healthCheckInterval,omitempty"`
+	// TLS configuration for connections to the server, used by the controller and the agents.
+	// +optional
+	TLS *TLSConfig `json:"tls,omitempty"`
+	// Proxy the connections to the server are made through, by the controller and the agents.
+	// +optional
+	Proxy *ProxyConfig `json:"proxy,omitempty"`
+}
+
+// RemoteMCPServerRefreshAnnotation is set to the time a refresh of the tools was requested,
+// changing it triggers an immediate rediscovery of the tools of the server.
+const RemoteMCPServerRefreshAnnotation = "kagent.dev/refresh-requested-at"
+
+// DefaultRemoteMCPServerResyncInterval is used when ResyncInterval is not set.
+const DefaultRemoteMCPServerResyncInterval = 60 * time.Second
+
+// GetResyncInterval returns the configured resync interval, or the default one.
+func (s *RemoteMCPServerSpec) GetResyncInterval() time.Duration {
+	if s.ResyncInterval == nil {
+		return DefaultRemoteMCPServerResyncInterval
+	}
+	return s.ResyncInterval.Duration
+}
+
+// DefaultRemoteMCPServerHealthCheckInterval is used when HealthCheckInterval is not set.
+const DefaultRemoteMCPServerHealthCheckInterval = 30 * time.Second
+
+// GetHealthCheckInterval returns the configured health check interval, or the default one.
+func (s *RemoteMCPServerSpec) GetHealthCheckInterval() time.Duration {
+	if s.HealthCheckInterval == nil {
+		return DefaultRemoteMCPServerHealthCheckInterval
+	}
+	return s.HealthCheckInterval.Duration
 }
 
 var _ sql.Scanner = (*RemoteMCPServerSpec)(nil)
@@ -91,13 +135,117 @@ type RemoteMCPServerStatus struct {
 	// Important: Run "make" to regenerate code after modifying this file
 	ObservedGeneration int64              `json:"observedGeneration"`
 	Conditions         []metav1.Condition `json:"conditions"`
+	// The tools of the server, up to MaxStatusToolsBytes
 	// +kubebuilder:validation:Optional
 	DiscoveredTools []*MCPTool `json:"discoveredTools"`
+	// The first MaxStatusResources resources of the server
+	// +kubebuilder:validation:Optional
+	DiscoveredResources []*MCPResource `json:"discoveredResources,omitempty"`
+	// The first MaxStatusPrompts prompts of the server
+	// +kubebuilder:validation:Optional
+	DiscoveredPrompts []*MCPPrompt `json:"discoveredPrompts,omitempty"`
+	// The last time the server answered a ping
+	// +optional
+	LastConnectedTime *metav1.Time `json:"lastConnectedTime,omitempty"`
+	// The round trip time of the last successful ping
+	// +optional
+	PingLatency *metav1.Duration `json:"pingLatency,omitempty"`
 }
 
+const (
+	RemoteMCPServerConditionTypeAccepted = "Accepted"
+	// RemoteMCPServerConditionTypeReady reflects whether the server answered the last ping.
+	RemoteMCPServerConditionTypeReady = "Ready"
+	// RemoteMCPServerConditionTypeDiscoveryTruncated is True when the server returned more
+	// pages of tools, resources or prompts than are followed during discovery.
+	RemoteMCPServerConditionTypeDiscoveryTruncated = "DiscoveryTruncated"
+	// RemoteMCPServerConditionTypeStatusTruncated is True when the status omits some of the
+	// discovered items to stay small. They are all listed by the /api/toolservers endpoint.
+	RemoteMCPServerConditionTypeStatusTruncated = "StatusTruncated"
+)
+
+// MaxStatusResources and MaxStatusPrompts bound the number of resources and prompts listed in
+// the status of a RemoteMCPServer. The others are only kept in the database.
+const (
+	MaxStatusResources = 100
+	MaxStatusPrompts   = 100
+)
+
+// MaxStatusToolSchemaBytes bounds the size of the schemas of a single tool stored in
+// the status of a RemoteMCPServer. Larger schemas are only kept in the database.
+const MaxStatusToolSchemaBytes = 4 * 1024
+
+// MaxStatusToolsBytes bounds the total size of the names, descriptions and schemas of the tools
+// stored in the status of a RemoteMCPServer. Past it, the schemas of the remaining tools are
+// omitted, then the tools themselves.
+const MaxStatusToolsBytes = 256 * 1024
+
 type MCPTool struct {
 	Name        string `json:"name"`
 	Description string `json:"description"`
+	// The JSON schema of the arguments of the tool
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:validation:Type=object
+	// +optional
+	InputSchema *runtime.RawExtension `json:"inputSchema,omitempty"`
+	// The JSON schema of the structured output of the tool
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:validation:Type=object
+	// +optional
+	OutputSchema *runtime.RawExtension `json:"outputSchema,omitempty"`
+	// +optional
+	Annotations *MCPToolAnnotations `json:"annotations,omitempty"`
+	// SchemaOmitted is set in the status when the schemas of the tool are larger than
+	// MaxStatusToolSchemaBytes or do not fit in MaxStatusToolsBytes. The full schemas can be fetched from the /api/tools endpoint.
+	// +optional
+	SchemaOmitted bool `json:"schemaOmitted,omitempty"`
+}
+
+// MCPResource is a resource that a MCP server offers to read.
+type MCPResource struct {
+	URI  string `json:"uri"`
+	Name string `json:"name"`
+	// +optional
+	Description string `json:"description,omitempty"`
+	// +optional
+	MimeType string `json:"mimeType,omitempty"`
+}
+
+// MCPPrompt is a prompt or prompt template offered by a MCP server.
+type MCPPrompt struct {
+	Name string `json:"name"`
+	// +optional
+	Description string `json:"description,omitempty"`
+	// +optional
+	Arguments []MCPPromptArgument `json:"arguments,omitempty"`
+}
+
+type MCPPromptArgument struct {
+	Name string `json:"name"`
+	// +optional
+	Description string `json:"description,omitempty"`
+	// +optional
+	Required bool `json:"required,omitempty"`
+}
+
+// MCPToolAnnotations are the hints a MCP server gives about the behavior of a tool.
+type MCPToolAnnotations struct {
+	// +optional
+	Title string `json:"title,omitempty"`
+	// If true, the tool does not modify its environment
+	// +optional
+	ReadOnlyHint *bool `json:"readOnlyHint,omitempty"`
+	// If true, the tool may perform destructive updates
+	// +optional
+	DestructiveHint *bool `json:"destructiveHint,omitempty"`
+	// If true, repeated calls with the same arguments have no additional effect
+	// +optional
+	IdempotentHint *bool `json:"idempotentHint,omitempty"`
+	// If true, the tool interacts with external entities
+	// +optional
+	OpenWorldHint *bool `json:"openWorldHint,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -106,6 +254,7 @@ type MCPTool struct {
 // +kubebuilder:printcolumn:name="Protocol",type="string",JSONPath=".spec.protocol"
 // +kubebuilder:printcolumn:name="URL",type="string",JSONPath=".spec.url"
 // +kubebuilder:printcolumn:name="Accepted",type="string",JSONPath=".status.conditions[?(@.type=='Accepted')].status"
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
 
 // RemoteMCPServer is the Schema for the RemoteMCPServers API.
 type RemoteMCPServer struct {
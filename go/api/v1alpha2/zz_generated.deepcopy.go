@@ -23,7 +23,7 @@ package v1alpha2
 import (
 	"k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	runtime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
@@ -165,6 +165,11 @@ func (in *AgentSpec) DeepCopyInto(out *AgentSpec) {
 		*out = new(SkillForAgent)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.RateLimits != nil {
+		in, out := &in.RateLimits, &out.RateLimits
+		*out = new(RateLimits)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentSpec.
@@ -230,9 +235,29 @@ func (in *AnthropicVertexAIConfig) DeepCopy() *AnthropicVertexAIConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AzureIdentityConfig) DeepCopyInto(out *AzureIdentityConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureIdentityConfig.
+func (in *AzureIdentityConfig) DeepCopy() *AzureIdentityConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(AzureIdentityConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AzureOpenAIConfig) DeepCopyInto(out *AzureOpenAIConfig) {
 	*out = *in
+	if in.Identity != nil {
+		in, out := &in.Identity, &out.Identity
+		*out = new(AzureIdentityConfig)
+		**out = **in
+	}
 	if in.MaxTokens != nil {
 		in, out := &in.MaxTokens, &out.MaxTokens
 		*out = new(int)
@@ -278,6 +303,11 @@ func (in *BaseVertexAIConfig) DeepCopyInto(out *BaseVertexAIConfig) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.WorkloadIdentity != nil {
+		in, out := &in.WorkloadIdentity, &out.WorkloadIdentity
+		*out = new(GKEWorkloadIdentityConfig)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BaseVertexAIConfig.
@@ -290,6 +320,21 @@ func (in *BaseVertexAIConfig) DeepCopy() *BaseVertexAIConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BedrockConfig) DeepCopyInto(out *BedrockConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BedrockConfig.
+func (in *BedrockConfig) DeepCopy() *BedrockConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(BedrockConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ByoDeploymentSpec) DeepCopyInto(out *ByoDeploymentSpec) {
 	*out = *in
@@ -324,6 +369,11 @@ func (in *DeclarativeAgentSpec) DeepCopyInto(out *DeclarativeAgentSpec) {
 		*out = new(ValueSource)
 		**out = **in
 	}
+	if in.ModelOverrides != nil {
+		in, out := &in.ModelOverrides, &out.ModelOverrides
+		*out = new(ModelOverrides)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Stream != nil {
 		in, out := &in.Stream, &out.Stream
 		*out = new(bool)
@@ -340,6 +390,11 @@ func (in *DeclarativeAgentSpec) DeepCopyInto(out *DeclarativeAgentSpec) {
 			}
 		}
 	}
+	if in.Memory != nil {
+		in, out := &in.Memory, &out.Memory
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.A2AConfig != nil {
 		in, out := &in.A2AConfig, &out.A2AConfig
 		*out = new(A2AConfig)
@@ -383,6 +438,46 @@ func (in *DeclarativeDeploymentSpec) DeepCopy() *DeclarativeDeploymentSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FallbackConfig) DeepCopyInto(out *FallbackConfig) {
+	*out = *in
+	if in.Models != nil {
+		in, out := &in.Models, &out.Models
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Retry != nil {
+		in, out := &in.Retry, &out.Retry
+		*out = new(ModelRetryPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FallbackConfig.
+func (in *FallbackConfig) DeepCopy() *FallbackConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(FallbackConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GKEWorkloadIdentityConfig) DeepCopyInto(out *GKEWorkloadIdentityConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GKEWorkloadIdentityConfig.
+func (in *GKEWorkloadIdentityConfig) DeepCopy() *GKEWorkloadIdentityConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(GKEWorkloadIdentityConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GeminiConfig) DeepCopyInto(out *GeminiConfig) {
 	*out = *in
@@ -414,9 +509,74 @@ func (in *GeminiVertexAIConfig) DeepCopy() *GeminiVertexAIConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MCPPrompt) DeepCopyInto(out *MCPPrompt) {
+	*out = *in
+	if in.Arguments != nil {
+		in, out := &in.Arguments, &out.Arguments
+		*out = make([]MCPPromptArgument, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MCPPrompt.
+func (in *MCPPrompt) DeepCopy() *MCPPrompt {
+	if in == nil {
+		return nil
+	}
+	out := new(MCPPrompt)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MCPPromptArgument) DeepCopyInto(out *MCPPromptArgument) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MCPPromptArgument.
+func (in *MCPPromptArgument) DeepCopy() *MCPPromptArgument {
+	if in == nil {
+		return nil
+	}
+	out := new(MCPPromptArgument)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MCPResource) DeepCopyInto(out *MCPResource) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MCPResource.
+func (in *MCPResource) DeepCopy() *MCPResource {
+	if in == nil {
+		return nil
+	}
+	out := new(MCPResource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MCPTool) DeepCopyInto(out *MCPTool) {
 	*out = *in
+	if in.InputSchema != nil {
+		in, out := &in.InputSchema, &out.InputSchema
+		*out = new(runtime.RawExtension)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OutputSchema != nil {
+		in, out := &in.OutputSchema, &out.OutputSchema
+		*out = new(runtime.RawExtension)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = new(MCPToolAnnotations)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MCPTool.
@@ -429,6 +589,41 @@ func (in *MCPTool) DeepCopy() *MCPTool {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MCPToolAnnotations) DeepCopyInto(out *MCPToolAnnotations) {
+	*out = *in
+	if in.ReadOnlyHint != nil {
+		in, out := &in.ReadOnlyHint, &out.ReadOnlyHint
+		*out = new(bool)
+		**out = **in
+	}
+	if in.DestructiveHint != nil {
+		in, out := &in.DestructiveHint, &out.DestructiveHint
+		*out = new(bool)
+		**out = **in
+	}
+	if in.IdempotentHint != nil {
+		in, out := &in.IdempotentHint, &out.IdempotentHint
+		*out = new(bool)
+		**out = **in
+	}
+	if in.OpenWorldHint != nil {
+		in, out := &in.OpenWorldHint, &out.OpenWorldHint
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MCPToolAnnotations.
+func (in *MCPToolAnnotations) DeepCopy() *MCPToolAnnotations {
+	if in == nil {
+		return nil
+	}
+	out := new(MCPToolAnnotations)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *McpServerTool) DeepCopyInto(out *McpServerTool) {
 	*out = *in
@@ -450,6 +645,41 @@ func (in *McpServerTool) DeepCopy() *McpServerTool {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ModelCapabilities) DeepCopyInto(out *ModelCapabilities) {
+	*out = *in
+	if in.FunctionCalling != nil {
+		in, out := &in.FunctionCalling, &out.FunctionCalling
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Streaming != nil {
+		in, out := &in.Streaming, &out.Streaming
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Vision != nil {
+		in, out := &in.Vision, &out.Vision
+		*out = new(bool)
+		**out = **in
+	}
+	if in.JSONMode != nil {
+		in, out := &in.JSONMode, &out.JSONMode
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelCapabilities.
+func (in *ModelCapabilities) DeepCopy() *ModelCapabilities {
+	if in == nil {
+		return nil
+	}
+	out := new(ModelCapabilities)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ModelConfig) DeepCopyInto(out *ModelConfig) {
 	*out = *in
@@ -554,11 +784,31 @@ func (in *ModelConfigSpec) DeepCopyInto(out *ModelConfigSpec) {
 		*out = new(AnthropicVertexAIConfig)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Bedrock != nil {
+		in, out := &in.Bedrock, &out.Bedrock
+		*out = new(BedrockConfig)
+		**out = **in
+	}
+	if in.OpenAICompatible != nil {
+		in, out := &in.OpenAICompatible, &out.OpenAICompatible
+		*out = new(OpenAICompatibleConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Fallback != nil {
+		in, out := &in.Fallback, &out.Fallback
+		*out = new(FallbackConfig)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.TLS != nil {
 		in, out := &in.TLS, &out.TLS
 		*out = new(TLSConfig)
 		**out = **in
 	}
+	if in.Proxy != nil {
+		in, out := &in.Proxy, &out.Proxy
+		*out = new(ProxyConfig)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelConfigSpec.
@@ -593,6 +843,89 @@ func (in *ModelConfigStatus) DeepCopy() *ModelConfigStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ModelOverrides) DeepCopyInto(out *ModelOverrides) {
+	*out = *in
+	if in.MaxTokens != nil {
+		in, out := &in.MaxTokens, &out.MaxTokens
+		*out = new(int)
+		**out = **in
+	}
+	if in.TopK != nil {
+		in, out := &in.TopK, &out.TopK
+		*out = new(int)
+		**out = **in
+	}
+	if in.ReasoningEffort != nil {
+		in, out := &in.ReasoningEffort, &out.ReasoningEffort
+		*out = new(OpenAIReasoningEffort)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelOverrides.
+func (in *ModelOverrides) DeepCopy() *ModelOverrides {
+	if in == nil {
+		return nil
+	}
+	out := new(ModelOverrides)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ModelRetryPolicy) DeepCopyInto(out *ModelRetryPolicy) {
+	*out = *in
+	if in.InitialBackoff != nil {
+		in, out := &in.InitialBackoff, &out.InitialBackoff
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.MaxBackoff != nil {
+		in, out := &in.MaxBackoff, &out.MaxBackoff
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelRetryPolicy.
+func (in *ModelRetryPolicy) DeepCopy() *ModelRetryPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(ModelRetryPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OAuthClientCredentials) DeepCopyInto(out *OAuthClientCredentials) {
+	*out = *in
+	out.ClientSecretFrom = in.ClientSecretFrom
+	if in.Scopes != nil {
+		in, out := &in.Scopes, &out.Scopes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.EndpointParams != nil {
+		in, out := &in.EndpointParams, &out.EndpointParams
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OAuthClientCredentials.
+func (in *OAuthClientCredentials) DeepCopy() *OAuthClientCredentials {
+	if in == nil {
+		return nil
+	}
+	out := new(OAuthClientCredentials)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OllamaConfig) DeepCopyInto(out *OllamaConfig) {
 	*out = *in
@@ -615,6 +948,31 @@ func (in *OllamaConfig) DeepCopy() *OllamaConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenAICompatibleConfig) DeepCopyInto(out *OpenAICompatibleConfig) {
+	*out = *in
+	if in.Capabilities != nil {
+		in, out := &in.Capabilities, &out.Capabilities
+		*out = new(ModelCapabilities)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(int)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenAICompatibleConfig.
+func (in *OpenAICompatibleConfig) DeepCopy() *OpenAICompatibleConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenAICompatibleConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OpenAIConfig) DeepCopyInto(out *OpenAIConfig) {
 	*out = *in
@@ -650,6 +1008,210 @@ func (in *OpenAIConfig) DeepCopy() *OpenAIConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProxyConfig) DeepCopyInto(out *ProxyConfig) {
+	*out = *in
+	if in.NoProxy != nil {
+		in, out := &in.NoProxy, &out.NoProxy
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProxyConfig.
+func (in *ProxyConfig) DeepCopy() *ProxyConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ProxyConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RateLimits) DeepCopyInto(out *RateLimits) {
+	*out = *in
+	if in.RequestsPerMinute != nil {
+		in, out := &in.RequestsPerMinute, &out.RequestsPerMinute
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ConcurrentTasks != nil {
+		in, out := &in.ConcurrentTasks, &out.ConcurrentTasks
+		*out = new(int32)
+		**out = **in
+	}
+	if in.DailyTokenBudget != nil {
+		in, out := &in.DailyTokenBudget, &out.DailyTokenBudget
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RateLimits.
+func (in *RateLimits) DeepCopy() *RateLimits {
+	if in == nil {
+		return nil
+	}
+	out := new(RateLimits)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemoteAgent) DeepCopyInto(out *RemoteAgent) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoteAgent.
+func (in *RemoteAgent) DeepCopy() *RemoteAgent {
+	if in == nil {
+		return nil
+	}
+	out := new(RemoteAgent)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RemoteAgent) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemoteAgentCard) DeepCopyInto(out *RemoteAgentCard) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoteAgentCard.
+func (in *RemoteAgentCard) DeepCopy() *RemoteAgentCard {
+	if in == nil {
+		return nil
+	}
+	out := new(RemoteAgentCard)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemoteAgentList) DeepCopyInto(out *RemoteAgentList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]RemoteAgent, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoteAgentList.
+func (in *RemoteAgentList) DeepCopy() *RemoteAgentList {
+	if in == nil {
+		return nil
+	}
+	out := new(RemoteAgentList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RemoteAgentList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemoteAgentSpec) DeepCopyInto(out *RemoteAgentSpec) {
+	*out = *in
+	if in.HeadersFrom != nil {
+		in, out := &in.HeadersFrom, &out.HeadersFrom
+		*out = make([]ValueRef, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.OAuth != nil {
+		in, out := &in.OAuth, &out.OAuth
+		*out = new(OAuthClientCredentials)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(TLSConfig)
+		**out = **in
+	}
+	if in.Proxy != nil {
+		in, out := &in.Proxy, &out.Proxy
+		*out = new(ProxyConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ResyncInterval != nil {
+		in, out := &in.ResyncInterval, &out.ResyncInterval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoteAgentSpec.
+func (in *RemoteAgentSpec) DeepCopy() *RemoteAgentSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RemoteAgentSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemoteAgentStatus) DeepCopyInto(out *RemoteAgentStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.AgentCard != nil {
+		in, out := &in.AgentCard, &out.AgentCard
+		*out = new(RemoteAgentCard)
+		**out = **in
+	}
+	if in.DiscoveredSkills != nil {
+		in, out := &in.DiscoveredSkills, &out.DiscoveredSkills
+		*out = make([]AgentSkill, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LastConnectedTime != nil {
+		in, out := &in.LastConnectedTime, &out.LastConnectedTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoteAgentStatus.
+func (in *RemoteAgentStatus) DeepCopy() *RemoteAgentStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RemoteAgentStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RemoteMCPServer) DeepCopyInto(out *RemoteMCPServer) {
 	*out = *in
@@ -734,6 +1296,26 @@ func (in *RemoteMCPServerSpec) DeepCopyInto(out *RemoteMCPServerSpec) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.ResyncInterval != nil {
+		in, out := &in.ResyncInterval, &out.ResyncInterval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.HealthCheckInterval != nil {
+		in, out := &in.HealthCheckInterval, &out.HealthCheckInterval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(TLSConfig)
+		**out = **in
+	}
+	if in.Proxy != nil {
+		in, out := &in.Proxy, &out.Proxy
+		*out = new(ProxyConfig)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoteMCPServerSpec.
@@ -763,10 +1345,41 @@ func (in *RemoteMCPServerStatus) DeepCopyInto(out *RemoteMCPServerStatus) {
 			if (*in)[i] != nil {
 				in, out := &(*in)[i], &(*out)[i]
 				*out = new(MCPTool)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+	if in.DiscoveredResources != nil {
+		in, out := &in.DiscoveredResources, &out.DiscoveredResources
+		*out = make([]*MCPResource, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(MCPResource)
 				**out = **in
 			}
 		}
 	}
+	if in.DiscoveredPrompts != nil {
+		in, out := &in.DiscoveredPrompts, &out.DiscoveredPrompts
+		*out = make([]*MCPPrompt, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(MCPPrompt)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+	if in.LastConnectedTime != nil {
+		in, out := &in.LastConnectedTime, &out.LastConnectedTime
+		*out = (*in).DeepCopy()
+	}
+	if in.PingLatency != nil {
+		in, out := &in.PingLatency, &out.PingLatency
+		*out = new(metav1.Duration)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoteMCPServerStatus.
@@ -208,6 +208,36 @@ func main() {
 
 	getCmd.AddCommand(getSessionCmd, getAgentCmd, getToolCmd)
 
+	taskCmd := &cobra.Command{
+		Use:   "task",
+		Short: "Manage the tasks of agents",
+		Long:  `Manage the tasks of agents`,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmd.Help() //nolint:errcheck
+			os.Exit(1)
+		},
+	}
+
+	cancelTaskCmd := &cobra.Command{
+		Use:   "cancel [task_id]",
+		Short: "Cancel a task",
+		Long:  `Cancel a task of one of your sessions. Agents that do not support cancellation stop streaming the task.`,
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := cli.CheckServerConnection(cmd.Context(), cfg.Client()); err != nil {
+				pf, err := cli.NewPortForward(cmd.Context(), cfg)
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "Error starting port-forward: %v\n", err)
+					return
+				}
+				defer pf.Stop()
+			}
+			cli.CancelTaskCmd(cmd.Context(), cfg, args[0])
+		},
+	}
+
+	taskCmd.AddCommand(cancelTaskCmd)
+
 	initCfg := &cli.InitCfg{
 		Config: cfg,
 	}
@@ -413,7 +443,7 @@ Examples:
 	runCmd.Flags().StringVar(&runCfg.ProjectDir, "project-dir", "", "Project directory (default: current directory)")
 	runCmd.Flags().BoolVar(&runCfg.Build, "build", false, "Rebuild the Docker image before running")
 
-	rootCmd.AddCommand(installCmd, uninstallCmd, invokeCmd, bugReportCmd, versionCmd, dashboardCmd, getCmd, initCmd, buildCmd, deployCmd, addMcpCmd, runCmd, mcp.NewMCPCmd())
+	rootCmd.AddCommand(installCmd, uninstallCmd, invokeCmd, bugReportCmd, versionCmd, dashboardCmd, getCmd, taskCmd, initCmd, buildCmd, deployCmd, addMcpCmd, runCmd, mcp.NewMCPCmd())
 
 	// Initialize config
 	if err := config.Init(); err != nil {
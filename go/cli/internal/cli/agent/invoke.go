@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/kagent-dev/kagent/go/cli/internal/config"
+	"github.com/kagent-dev/kagent/go/pkg/client"
 	a2aclient "trpc.group/trpc-go/trpc-a2a-go/client"
 	"trpc.group/trpc-go/trpc-a2a-go/protocol"
 )
@@ -27,8 +28,9 @@ func InvokeCmd(ctx context.Context, cfg *InvokeCfg) {
 	clientSet := cfg.Config.Client()
 
 	if err := CheckServerConnection(ctx, clientSet); err != nil {
-		// If a connection does not exist, start a short-lived port-forward.
-		pf, err := NewPortForward(ctx, cfg.Config)
+		// If a connection does not exist, start a short-lived port-forward. It outlives an
+		// interruption of the command, to cancel the task of an interrupted stream.
+		pf, err := NewPortForward(context.WithoutCancel(ctx), cfg.Config)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error starting port-forward: %v\n", err)
 			return
@@ -99,10 +101,10 @@ func InvokeCmd(ctx context.Context, cfg *InvokeCfg) {
 
 	// Use A2A client to send message
 	if cfg.Stream {
-		ctx, cancel := context.WithTimeout(ctx, 300*time.Second)
+		streamCtx, cancel := context.WithTimeout(ctx, 300*time.Second)
 		defer cancel()
 
-		result, err := a2aClient.StreamMessage(ctx, protocol.SendMessageParams{
+		result, err := client.StreamMessageResumable(streamCtx, a2aClient, protocol.SendMessageParams{
 			Message: protocol.Message{
 				Kind:      protocol.KindMessage,
 				Role:      protocol.MessageRoleUser,
@@ -114,7 +116,12 @@ func InvokeCmd(ctx context.Context, cfg *InvokeCfg) {
 			fmt.Fprintf(os.Stderr, "Error invoking session: %v\n", err)
 			return
 		}
-		StreamA2AEvents(result, cfg.Config.Verbose)
+		var taskID string
+		StreamA2AEvents(trackTaskID(resumedEvents(result), &taskID), cfg.Config.Verbose)
+		// ctrl-C cancels the task the agent is working on
+		if ctx.Err() != nil && taskID != "" {
+			cancelInterruptedTask(ctx, a2aClient, taskID)
+		}
 	} else {
 		ctx, cancel := context.WithTimeout(ctx, 300*time.Second)
 		defer cancel()
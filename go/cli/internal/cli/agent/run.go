@@ -12,6 +12,7 @@ import (
 	commonexec "github.com/kagent-dev/kagent/go/cli/internal/common/exec"
 	"github.com/kagent-dev/kagent/go/cli/internal/config"
 	"github.com/kagent-dev/kagent/go/cli/internal/tui"
+	"github.com/kagent-dev/kagent/go/pkg/client"
 	a2aclient "trpc.group/trpc-go/trpc-a2a-go/client"
 	"trpc.group/trpc-go/trpc-a2a-go/protocol"
 )
@@ -114,16 +115,17 @@ func RunCmd(ctx context.Context, cfg *RunCfg) error {
 		return fmt.Errorf("failed to create A2A client: %v", err)
 	}
 
-	sendFn := func(ctx context.Context, params protocol.SendMessageParams) (<-chan protocol.StreamingMessageEvent, error) {
-		ch, err := a2aClient.StreamMessage(ctx, params)
-		if err != nil {
-			return nil, err
-		}
-		return ch, err
+	sendFn := func(ctx context.Context, params protocol.SendMessageParams) (<-chan client.StreamUpdate, error) {
+		return client.StreamMessageResumable(ctx, a2aClient, params)
+	}
+
+	cancelFn := func(ctx context.Context, taskID string) error {
+		_, err := a2aClient.CancelTasks(ctx, protocol.TaskIDParams{ID: taskID})
+		return err
 	}
 
 	// Launch TUI chat directly
-	if err := tui.RunChat(manifest.Name, sessionID, sendFn, verbose); err != nil {
+	if err := tui.RunChat(manifest.Name, sessionID, sendFn, cancelFn, verbose); err != nil {
 		return fmt.Errorf("chat session failed: %v", err)
 	}
 
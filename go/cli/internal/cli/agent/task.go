@@ -0,0 +1,57 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/kagent-dev/kagent/go/cli/internal/config"
+	"github.com/kagent-dev/kagent/go/pkg/client/api"
+	a2aclient "trpc.group/trpc-go/trpc-a2a-go/client"
+	"trpc.group/trpc-go/trpc-a2a-go/protocol"
+)
+
+// CancelTaskCmd cancels a task of a session of the user through the controller
+func CancelTaskCmd(ctx context.Context, cfg *config.Config, taskID string) {
+	client := cfg.Client()
+
+	resp, err := client.Task.CancelTask(ctx, taskID)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to cancel task %s: %v\n", taskID, err)
+		return
+	}
+	byt, _ := json.MarshalIndent(resp.Data, "", "  ")
+	fmt.Fprintln(os.Stdout, string(byt))
+}
+
+// cancelInterruptedTask cancels the task of a stream interrupted by the user. The context of the
+// command is already canceled, so the cancellation gets its own.
+func cancelInterruptedTask(ctx context.Context, a2aClient *a2aclient.A2AClient, taskID string) {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 10*time.Second)
+	defer cancel()
+
+	task, err := a2aClient.CancelTasks(ctx, protocol.TaskIDParams{ID: taskID})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to cancel task %s: %v\n", taskID, err)
+		return
+	}
+	fmt.Fprintf(os.Stderr, "Task %s %s.\n", task.ID, task.Status.State)
+}
+
+// trackTaskID forwards the events of a stream, recording the ID of their task. The ID is set
+// once the returned channel is closed.
+func trackTaskID(events <-chan protocol.StreamingMessageEvent, taskID *string) <-chan protocol.StreamingMessageEvent {
+	tracked := make(chan protocol.StreamingMessageEvent)
+	go func() {
+		defer close(tracked)
+		for event := range events {
+			if id := api.StreamingEventTaskID(event.Result); id != "" {
+				*taskID = id
+			}
+			tracked <- event
+		}
+	}()
+	return tracked
+}
@@ -110,6 +110,26 @@ func StreamA2AEvents(ch <-chan protocol.StreamingMessageEvent, verbose bool) {
 	fmt.Fprintln(os.Stdout)
 }
 
+// resumedEvents forwards the events of a resumable stream, reporting on stderr when the stream
+// dropped and is being resumed
+func resumedEvents(updates <-chan client.StreamUpdate) <-chan protocol.StreamingMessageEvent {
+	events := make(chan protocol.StreamingMessageEvent)
+	go func() {
+		defer close(events)
+		for update := range updates {
+			switch {
+			case update.Reconnecting:
+				fmt.Fprintln(os.Stderr, "Connection lost, reconnecting…")
+			case update.Err != nil:
+				fmt.Fprintf(os.Stderr, "Error streaming task: %v\n", update.Err)
+			default:
+				events <- update.Event
+			}
+		}
+	}()
+	return events
+}
+
 // ResolveProjectDir resolves the project directory to an absolute path
 func ResolveProjectDir(projectDir string) (string, error) {
 	if projectDir == "" {
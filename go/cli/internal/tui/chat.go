@@ -13,16 +13,20 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/kagent-dev/kagent/go/cli/internal/tui/theme"
+	"github.com/kagent-dev/kagent/go/pkg/client"
 	"github.com/muesli/reflow/wordwrap"
 	"trpc.group/trpc-go/trpc-a2a-go/protocol"
 )
 
-// SendMessageFn abstracts the A2A client's StreamMessage method for easier testing.
-type SendMessageFn func(ctx context.Context, params protocol.SendMessageParams) (<-chan protocol.StreamingMessageEvent, error)
+// SendMessageFn abstracts the resumable streaming of the A2A client for easier testing.
+type SendMessageFn func(ctx context.Context, params protocol.SendMessageParams) (<-chan client.StreamUpdate, error)
+
+// CancelTaskFn abstracts the A2A client's CancelTasks method for easier testing.
+type CancelTaskFn func(ctx context.Context, taskID string) error
 
 // RunChat starts the TUI chat, blocking until the user exits.
-func RunChat(agentRef string, sessionID string, sendFn SendMessageFn, verbose bool) error {
-	model := newChatModel(agentRef, sessionID, sendFn, verbose)
+func RunChat(agentRef string, sessionID string, sendFn SendMessageFn, cancelFn CancelTaskFn, verbose bool) error {
+	model := newChatModel(agentRef, sessionID, sendFn, cancelFn, verbose)
 	p := tea.NewProgram(model, tea.WithAltScreen())
 	_, err := p.Run()
 	return err
@@ -34,6 +38,16 @@ type a2aEventMsg struct {
 
 type streamDoneMsg struct{}
 
+type streamReconnectingMsg struct{}
+
+type streamErrMsg struct {
+	err error
+}
+
+type taskCanceledMsg struct {
+	err error
+}
+
 type toolCall struct {
 	Name string `json:"name"`
 	ID   string `json:"id"`
@@ -62,14 +76,21 @@ type chatModel struct {
 	spin spinner.Model
 
 	send      SendMessageFn
-	streamCh  <-chan protocol.StreamingMessageEvent
+	streamCh  <-chan client.StreamUpdate
 	cancel    context.CancelFunc
 	streaming bool
+	// reconnecting is set while the dropped stream is being resumed
+	reconnecting bool
+
+	cancelTask CancelTaskFn
+	// taskID is the task of the current stream, once known
+	taskID    string
+	canceling bool
 
 	showInput bool
 }
 
-func newChatModel(agentRef string, sessionID string, send SendMessageFn, verbose bool) *chatModel {
+func newChatModel(agentRef string, sessionID string, send SendMessageFn, cancelTask CancelTaskFn, verbose bool) *chatModel {
 	input := textarea.New()
 	input.Placeholder = "Type a message (Enter to send)"
 	input.FocusedStyle.CursorLine = lipgloss.NewStyle()
@@ -88,15 +109,16 @@ func newChatModel(agentRef string, sessionID string, send SendMessageFn, verbose
 	sp.Style = lipgloss.NewStyle().Foreground(theme.ColorPrimary)
 
 	return &chatModel{
-		agentRef:  agentRef,
-		sessionID: sessionID,
-		verbose:   verbose,
-		vp:        vp,
-		input:     input,
-		send:      send,
-		history:   initial,
-		spin:      sp,
-		showInput: true,
+		agentRef:   agentRef,
+		sessionID:  sessionID,
+		verbose:    verbose,
+		vp:         vp,
+		input:      input,
+		send:       send,
+		cancelTask: cancelTask,
+		history:    initial,
+		spin:       sp,
+		showInput:  true,
 	}
 }
 
@@ -151,6 +173,10 @@ func (m *chatModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case tea.KeyMsg:
 		switch msg.String() {
 		case "ctrl+c", "esc":
+			// the first ctrl+c cancels the task in progress, the next one quits
+			if msg.String() == "ctrl+c" && m.streaming && m.taskID != "" && m.cancelTask != nil && !m.canceling {
+				return m, m.cancelCurrentTask()
+			}
 			if m.cancel != nil {
 				m.cancel()
 			}
@@ -171,13 +197,33 @@ func (m *chatModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, m.submit(text)
 		}
 	case a2aEventMsg:
+		m.reconnecting = false
 		m.appendEvent(msg.Event)
+		m.updateStatus()
+		return m, m.waitNext()
+	case streamReconnectingMsg:
+		m.reconnecting = true
+		m.updateStatus()
+		return m, m.waitNext()
+	case streamErrMsg:
+		m.appendError(msg.err)
 		return m, m.waitNext()
 	case streamDoneMsg:
 		m.streaming = false
+		m.reconnecting = false
 		m.working = false
+		m.canceling = false
 		m.updateStatus()
 		return m, nil
+	case taskCanceledMsg:
+		if msg.err != nil {
+			m.appendError(fmt.Errorf("failed to cancel task: %w", msg.err))
+			// stop waiting for the task at least
+			if m.cancel != nil {
+				m.cancel()
+			}
+		}
+		return m, nil
 	}
 
 	m.input, cmd = m.input.Update(msg)
@@ -216,6 +262,9 @@ func (m *chatModel) View() string {
 
 func (m *chatModel) submit(text string) tea.Cmd {
 	m.streaming = true
+	m.reconnecting = false
+	m.taskID = ""
+	m.canceling = false
 	m.working = true
 	m.workStart = time.Now()
 	m.updateStatus()
@@ -242,17 +291,36 @@ func (m *chatModel) submit(text string) tea.Cmd {
 	return tea.Batch(m.waitNext(), m.tick())
 }
 
+// cancelCurrentTask cancels the task of the current stream, which ends with the cancellation
+func (m *chatModel) cancelCurrentTask() tea.Cmd {
+	m.canceling = true
+	m.appendLine(theme.DimStyle().Render(fmt.Sprintf("Canceling task %s... (ctrl+c again to quit)", m.taskID)))
+	taskID := m.taskID
+	cancelTask := m.cancelTask
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		return taskCanceledMsg{err: cancelTask(ctx, taskID)}
+	}
+}
+
 func (m *chatModel) waitNext() tea.Cmd {
 	ch := m.streamCh
 	if ch == nil {
 		return nil
 	}
 	return func() tea.Msg {
-		ev, ok := <-ch
+		update, ok := <-ch
 		if !ok {
 			return streamDoneMsg{}
 		}
-		return a2aEventMsg{Event: ev}
+		switch {
+		case update.Reconnecting:
+			return streamReconnectingMsg{}
+		case update.Err != nil:
+			return streamErrMsg{err: update.Err}
+		}
+		return a2aEventMsg{Event: update.Event}
 	}
 }
 
@@ -263,6 +331,10 @@ func (m *chatModel) appendUser(text string) {
 func (m *chatModel) appendEvent(ev protocol.StreamingMessageEvent) {
 	switch res := ev.Result.(type) {
 	case *protocol.TaskStatusUpdateEvent:
+		m.taskID = res.TaskID
+		if res.Status.State == protocol.TaskStateCanceled {
+			m.appendLine(theme.DimStyle().Render("Task canceled"))
+		}
 		if res.Final {
 			m.working = false
 			m.updateStatus()
@@ -279,6 +351,7 @@ func (m *chatModel) appendEvent(ev protocol.StreamingMessageEvent) {
 			m.handleMessageParts(*res.Status.Message, res.Final)
 		}
 	case *protocol.TaskArtifactUpdateEvent:
+		m.taskID = res.TaskID
 		// Render artifact content when the last chunk arrives
 		if res.LastChunk != nil && *res.LastChunk {
 			text := extractTextFromParts(res.Artifact.Parts)
@@ -290,6 +363,7 @@ func (m *chatModel) appendEvent(ev protocol.StreamingMessageEvent) {
 		m.handleMessageParts(*res, true)
 
 	case *protocol.Task:
+		m.taskID = res.ID
 		// Show the last message in the task history
 		if len(res.History) > 0 {
 			last := res.History[len(res.History)-1]
@@ -489,7 +563,9 @@ func (m *chatModel) setWorkingTime(ts time.Time) {
 }
 
 func (m *chatModel) updateStatus() {
-	if m.working {
+	if m.reconnecting {
+		m.statusText = "Reconnecting…"
+	} else if m.working {
 		dur := time.Since(m.workStart).Round(time.Second)
 		m.statusText = fmt.Sprintf("Working… %s", dur.String())
 	} else {
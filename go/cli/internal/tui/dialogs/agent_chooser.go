@@ -11,9 +11,9 @@ import (
 
 type AgentItem struct{ api.AgentResponse }
 
-func (i AgentItem) Title() string       { return i.Agent.Name }
-func (i AgentItem) Namespace() string   { return i.Agent.Namespace }
-func (i AgentItem) Description() string { return i.Agent.Spec.Description }
+func (i AgentItem) Title() string       { return i.Object().GetName() }
+func (i AgentItem) Namespace() string   { return i.Object().GetNamespace() }
+func (i AgentItem) Description() string { return i.AgentResponse.Description() }
 func (i AgentItem) FilterValue() string { return i.ID }
 
 type AgentChooser struct {
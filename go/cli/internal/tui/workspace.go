@@ -223,7 +223,7 @@ func (m *workspaceModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		// Sort and store agents for later; do not auto-open chooser or auto-select.
 		slices.SortStableFunc(msg.agents, func(a, b api.AgentResponse) int {
-			return strings.Compare(utils.GetObjectRef(a.Agent), utils.GetObjectRef(a.Agent))
+			return strings.Compare(utils.GetObjectRef(a.Object()), utils.GetObjectRef(b.Object()))
 		})
 		m.agents = msg.agents
 		// Keep welcome screen visible until user presses Ctrl+A
@@ -318,6 +318,11 @@ func (m *workspaceModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		s := msg.String()
 		// Global keys
 		if key.Matches(msg, m.keys.Quit) {
+			// the chat cancels the task in progress first
+			if m.chat != nil && m.chat.streaming && !m.chat.canceling {
+				_, cmd := m.chat.Update(msg)
+				return m, cmd
+			}
 			return m, tea.Quit
 		}
 		if key.Matches(msg, m.keys.Sessions) {
@@ -461,21 +466,25 @@ func (m *workspaceModel) startChat(loadHistory bool) tea.Cmd {
 		return nil
 	}
 	a2aURL := fmt.Sprintf("%s/api/a2a/%s", m.cfg.KAgentURL, m.agentRef)
-	client, err := a2aclient.NewA2AClient(a2aURL,
+	a2aClient, err := a2aclient.NewA2AClient(a2aURL,
 		a2aclient.WithTimeout(m.cfg.Timeout),
 	)
 	if err != nil {
 		m.details.WriteString("\nA2A error\n")
 		return nil
 	}
-	sendFn := func(ctx context.Context, params protocol.SendMessageParams) (<-chan protocol.StreamingMessageEvent, error) {
-		return client.StreamMessage(ctx, params)
+	sendFn := func(ctx context.Context, params protocol.SendMessageParams) (<-chan client.StreamUpdate, error) {
+		return client.StreamMessageResumable(ctx, a2aClient, params)
+	}
+	cancelFn := func(ctx context.Context, taskID string) error {
+		_, err := a2aClient.CancelTasks(ctx, protocol.TaskIDParams{ID: taskID})
+		return err
 	}
 	// Reset chat for new session
 	if m.chat == nil {
-		m.chat = newChatModel(m.agentRef, m.current.ID, sendFn, m.verbose)
+		m.chat = newChatModel(m.agentRef, m.current.ID, sendFn, cancelFn, m.verbose)
 	} else {
-		*m.chat = *newChatModel(m.agentRef, m.current.ID, sendFn, m.verbose)
+		*m.chat = *newChatModel(m.agentRef, m.current.ID, sendFn, cancelFn, m.verbose)
 	}
 	// Set header and clear transcript
 	title := theme.HeadingStyle().Render(fmt.Sprintf("Chat with %s (session %s)", m.agentRef, m.current.ID))
@@ -512,8 +521,8 @@ func (m *workspaceModel) renderDetails() {
 	}
 	m.details.Reset()
 	fmt.Fprintf(&m.details, "Agent: %s\n", utils.ConvertToKubernetesIdentifier(m.agent.ID))
-	if m.agent.Agent.Spec.Description != "" {
-		fmt.Fprintf(&m.details, "\n%s\n", m.agent.Agent.Spec.Description)
+	if description := m.agent.Description(); description != "" {
+		fmt.Fprintf(&m.details, "\n%s\n", description)
 	}
 	// Tools information (if declarative tools are present)
 	if m.agent.Agent != nil && m.agent.Agent.Spec.Declarative != nil && len(m.agent.Agent.Spec.Declarative.Tools) > 0 {
@@ -1,54 +1,127 @@
 package a2a
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
+	"strconv"
 	"sync"
 
 	"github.com/gorilla/mux"
+	"github.com/kagent-dev/kagent/go/api/v1alpha2"
+	"github.com/kagent-dev/kagent/go/internal/database"
 	authimpl "github.com/kagent-dev/kagent/go/internal/httpserver/auth"
+	"github.com/kagent-dev/kagent/go/internal/ratelimit"
 	common "github.com/kagent-dev/kagent/go/internal/utils"
 	"github.com/kagent-dev/kagent/go/pkg/auth"
+	"k8s.io/utils/ptr"
 	"trpc.group/trpc-go/trpc-a2a-go/client"
+	"trpc.group/trpc-go/trpc-a2a-go/protocol"
 	"trpc.group/trpc-go/trpc-a2a-go/server"
+	"trpc.group/trpc-go/trpc-a2a-go/taskmanager"
 )
 
+// RateLimitExceededErrorCode is the JSON-RPC error code of the requests rejected by the rate
+// limits of an agent, in the range reserved for implementation defined server errors
+const RateLimitExceededErrorCode = -32050
+
 // A2AHandlerMux is an interface that defines methods for adding, getting, and removing agentic task handlers.
 type A2AHandlerMux interface {
+	// SetAgentHandler serves an agent through its client. record is set for the agents that do not
+	// store their session history themselves, whose requests are recorded when recording is enabled.
 	SetAgentHandler(
 		agentRef string,
 		client *client.A2AClient,
 		card server.AgentCard,
+		limits *v1alpha2.RateLimits,
+		record bool,
 	) error
 	RemoveAgentHandler(
 		agentRef string,
 	)
+	// CancelTask cancels a task of an agent on behalf of the user of the context
+	CancelTask(ctx context.Context, agentRef string, taskID string) (*protocol.Task, error)
 	http.Handler
 }
 
+// ErrAgentNotFound is returned for the requests to agents without a handler
+var ErrAgentNotFound = errors.New("agent not found")
+
+type agentHandler struct {
+	handler http.Handler
+	manager taskmanager.TaskManager
+	limits  *v1alpha2.RateLimits
+}
+
+// HttpMuxConfig configures the handling of the requests to the agents by the A2A mux
+type HttpMuxConfig struct {
+	// DbClient keeps the rate limit counters, the push notification configs and the streamed
+	// events of the agents. Rate limits, push notifications, recording and resumable streams are
+	// disabled when it is nil.
+	DbClient database.Client
+	// Recording records the messages and tasks of the agents that do not store them themselves
+	// in the database
+	Recording bool
+	// ResumableStreams keeps the agents streaming the tasks whose client disconnected, and stores
+	// the streamed events for the clients resubscribing to the tasks
+	ResumableStreams bool
+	// PushNotificationJWKSURL is the URL of the keys verifying the push notifications, empty if
+	// they are not signed
+	PushNotificationJWKSURL string
+}
+
 type handlerMux struct {
-	handlers       map[string]http.Handler
+	handlers       map[string]agentHandler
 	lock           sync.RWMutex
 	basePathPrefix string
 	authenticator  auth.AuthProvider
+	config         HttpMuxConfig
+	limiter        *ratelimit.Limiter
 }
 
 var _ A2AHandlerMux = &handlerMux{}
 
-func NewA2AHttpMux(pathPrefix string, authenticator auth.AuthProvider) *handlerMux {
-	return &handlerMux{
-		handlers:       make(map[string]http.Handler),
+func NewA2AHttpMux(pathPrefix string, authenticator auth.AuthProvider, config HttpMuxConfig) *handlerMux {
+	mux := &handlerMux{
+		handlers:       make(map[string]agentHandler),
 		basePathPrefix: pathPrefix,
 		authenticator:  authenticator,
+		config:         config,
 	}
+	if config.DbClient != nil {
+		mux.limiter = ratelimit.NewLimiter(config.DbClient)
+	}
+	return mux
 }
 
 func (a *handlerMux) SetAgentHandler(
 	agentRef string,
 	client *client.A2AClient,
 	card server.AgentCard,
+	limits *v1alpha2.RateLimits,
+	record bool,
 ) error {
-	srv, err := server.NewA2AServer(card, NewPassthroughManager(client), server.WithMiddleWare(authimpl.NewA2AAuthenticator(a.authenticator)))
+	manager := NewPassthroughManager(client)
+	if dbClient := a.config.DbClient; dbClient != nil {
+		// the controller delivers the push notifications of all agents
+		manager = NewPushNotificationManager(manager, dbClient, a.config.PushNotificationJWKSURL)
+		card.Capabilities.PushNotifications = ptr.To(true)
+		if a.config.Recording && record {
+			manager = NewRecordingManager(manager, dbClient, common.ConvertToPythonIdentifier(agentRef))
+		}
+	}
+	manager = NewCancellationManager(manager, a.config.DbClient, common.ConvertToPythonIdentifier(agentRef))
+	manager = NewConcurrencyManager(manager)
+	if a.config.DbClient != nil && a.config.ResumableStreams {
+		// streams are resumed from the events stored by the controller
+		manager = NewResumableStreamManager(manager, a.config.DbClient)
+	}
+
+	srv, err := server.NewA2AServer(card, manager, server.WithMiddleWare(authimpl.NewA2AAuthenticator(a.authenticator)))
 	if err != nil {
 		return fmt.Errorf("failed to create A2A server: %w", err)
 	}
@@ -56,7 +129,7 @@ func (a *handlerMux) SetAgentHandler(
 	a.lock.Lock()
 	defer a.lock.Unlock()
 
-	a.handlers[agentRef] = srv.Handler()
+	a.handlers[agentRef] = agentHandler{handler: srv.Handler(), manager: manager, limits: limits}
 
 	return nil
 }
@@ -69,7 +142,15 @@ func (a *handlerMux) RemoveAgentHandler(
 	delete(a.handlers, agentRef)
 }
 
-func (a *handlerMux) getHandler(name string) (http.Handler, bool) {
+func (a *handlerMux) CancelTask(ctx context.Context, agentRef string, taskID string) (*protocol.Task, error) {
+	handler, ok := a.getHandler(agentRef)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrAgentNotFound, agentRef)
+	}
+	return handler.manager.OnCancelTask(ctx, protocol.TaskIDParams{ID: taskID})
+}
+
+func (a *handlerMux) getHandler(name string) (agentHandler, bool) {
 	a.lock.RLock()
 	defer a.lock.RUnlock()
 	handler, ok := a.handlers[name]
@@ -103,5 +184,74 @@ func (a *handlerMux) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	handlerHandler.ServeHTTP(w, r)
+	if handlerHandler.limits != nil && a.limiter != nil && r.Method == http.MethodPost {
+		lease, ok := a.admit(w, r, handlerName, handlerHandler.limits)
+		if !ok {
+			return
+		}
+		defer lease.Release()
+		// the task started by the request may hold the slot beyond the request (see ConcurrencyManager)
+		r = r.WithContext(ratelimit.WithLease(r.Context(), lease))
+	}
+
+	handlerHandler.handler.ServeHTTP(w, r)
+}
+
+// admit applies the rate limits of the agent to the messages sent to it. Other requests, e.g.
+// to get a task, are not limited. It responds with a JSON-RPC error if the request is rejected,
+// and returns the lease of the concurrent slot taken by the request otherwise, if any.
+func (a *handlerMux) admit(w http.ResponseWriter, r *http.Request, agentRef string, limits *v1alpha2.RateLimits) (*ratelimit.Lease, bool) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, "Failed to read request body", http.StatusBadRequest)
+		return nil, false
+	}
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
+	var request struct {
+		ID     json.RawMessage `json:"id"`
+		Method string          `json:"method"`
+	}
+	// malformed requests are rejected by the A2A server
+	if err := json.Unmarshal(body, &request); err != nil ||
+		(request.Method != protocol.MethodMessageSend && request.Method != protocol.MethodMessageStream) {
+		return nil, true
+	}
+
+	var userID string
+	if session, ok := auth.AuthSessionFrom(r.Context()); ok {
+		userID = session.Principal().User.ID
+	}
+
+	lease, err := a.limiter.Admit(common.ConvertToPythonIdentifier(agentRef), userID, limits)
+	var limitErr *ratelimit.LimitExceededError
+	switch {
+	case errors.As(err, &limitErr):
+		writeRateLimitExceeded(w, request.ID, limitErr)
+		return nil, false
+	case err != nil:
+		http.Error(w, fmt.Sprintf("Failed to check rate limits: %v", err), http.StatusInternalServerError)
+		return nil, false
+	}
+	return lease, true
+}
+
+func writeRateLimitExceeded(w http.ResponseWriter, id json.RawMessage, limitErr *ratelimit.LimitExceededError) {
+	retryAfter := limitErr.RetryAfterSeconds()
+	response := map[string]any{
+		"jsonrpc": "2.0",
+		"id":      id,
+		"error": map[string]any{
+			"code":    RateLimitExceededErrorCode,
+			"message": limitErr.Error(),
+			"data": map[string]any{
+				"limit":      limitErr.Limit,
+				"retryAfter": retryAfter,
+			},
+		},
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Retry-After", strconv.FormatInt(retryAfter, 10))
+	w.WriteHeader(http.StatusTooManyRequests)
+	_ = json.NewEncoder(w).Encode(response)
 }
@@ -0,0 +1,101 @@
+package a2a
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/utils/ptr"
+	"trpc.group/trpc-go/trpc-a2a-go/client"
+	"trpc.group/trpc-go/trpc-a2a-go/protocol"
+	"trpc.group/trpc-go/trpc-a2a-go/server"
+
+	"github.com/kagent-dev/kagent/go/api/v1alpha2"
+	database_fake "github.com/kagent-dev/kagent/go/internal/database/fake"
+	authimpl "github.com/kagent-dev/kagent/go/internal/httpserver/auth"
+	"github.com/kagent-dev/kagent/go/internal/ratelimit"
+	"github.com/kagent-dev/kagent/go/pkg/auth"
+)
+
+func TestHandlerMuxRateLimits(t *testing.T) {
+	handlerMux := NewA2AHttpMux("/api/a2a", nil, HttpMuxConfig{DbClient: database_fake.NewClient()})
+	var forwarded []string
+	handlerMux.handlers["default/test-agent"] = agentHandler{
+		handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// the body is still readable by the A2A server
+			body, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			forwarded = append(forwarded, string(body))
+			w.WriteHeader(http.StatusOK)
+		}),
+		limits: &v1alpha2.RateLimits{RequestsPerMinute: ptr.To[int32](1)},
+	}
+
+	send := func(userID, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/api/a2a/default/test-agent/", strings.NewReader(body))
+		req = mux.SetURLVars(req, map[string]string{"namespace": "default", "name": "test-agent"})
+		req = req.WithContext(auth.AuthSessionTo(req.Context(), &authimpl.SimpleSession{
+			P: auth.Principal{User: auth.User{ID: userID}},
+		}))
+		recorder := httptest.NewRecorder()
+		handlerMux.ServeHTTP(recorder, req)
+		return recorder
+	}
+
+	message := `{"jsonrpc":"2.0","id":"1","method":"message/send","params":{}}`
+	recorder := send("alice", message)
+	require.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, []string{message}, forwarded)
+
+	recorder = send("alice", `{"jsonrpc":"2.0","id":"2","method":"message/stream","params":{}}`)
+	require.Equal(t, http.StatusTooManyRequests, recorder.Code)
+	assert.NotEmpty(t, recorder.Header().Get("Retry-After"))
+	var response struct {
+		ID    string `json:"id"`
+		Error struct {
+			Code int `json:"code"`
+			Data struct {
+				Limit      string `json:"limit"`
+				RetryAfter int64  `json:"retryAfter"`
+			} `json:"data"`
+		} `json:"error"`
+	}
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+	assert.Equal(t, "2", response.ID)
+	assert.Equal(t, RateLimitExceededErrorCode, response.Error.Code)
+	assert.Equal(t, ratelimit.LimitRequestsPerMinute, response.Error.Data.Limit)
+	assert.Positive(t, response.Error.Data.RetryAfter)
+
+	// other methods and other users are not limited
+	assert.Equal(t, http.StatusOK, send("alice", `{"jsonrpc":"2.0","id":"3","method":"tasks/get","params":{}}`).Code)
+	assert.Equal(t, http.StatusOK, send("bob", message).Code)
+	assert.Len(t, forwarded, 3)
+}
+
+func TestHandlerMuxServesAgentCard(t *testing.T) {
+	handlerMux := NewA2AHttpMux("/api/a2a", nil, HttpMuxConfig{})
+	a2aClient, err := client.NewA2AClient("http://remote.example.com")
+	require.NoError(t, err)
+	require.NoError(t, handlerMux.SetAgentHandler("default/test-agent", a2aClient, server.AgentCard{
+		Name: "test_agent",
+		URL:  "http://127.0.0.1:8083/api/a2a/default/test-agent/",
+	}, nil, false))
+
+	for _, cardPath := range []string{protocol.AgentCardPath, protocol.OldAgentCardPath} {
+		req := httptest.NewRequest(http.MethodGet, "/api/a2a/default/test-agent"+cardPath, nil)
+		req = mux.SetURLVars(req, map[string]string{"namespace": "default", "name": "test-agent"})
+		recorder := httptest.NewRecorder()
+		handlerMux.ServeHTTP(recorder, req)
+
+		require.Equal(t, http.StatusOK, recorder.Code, cardPath)
+		var card server.AgentCard
+		require.NoError(t, json.NewDecoder(recorder.Body).Decode(&card))
+		assert.Equal(t, "test_agent", card.Name)
+	}
+}
@@ -13,18 +13,24 @@ import (
 	"github.com/kagent-dev/kagent/go/api/v1alpha2"
 	agent_translator "github.com/kagent-dev/kagent/go/internal/controller/translator/agent"
 	authimpl "github.com/kagent-dev/kagent/go/internal/httpserver/auth"
+	"github.com/kagent-dev/kagent/go/internal/remoteagent"
 	common "github.com/kagent-dev/kagent/go/internal/utils"
 	"github.com/kagent-dev/kagent/go/pkg/auth"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/cache"
 	crcache "sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	ctrllog "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	a2aclient "trpc.group/trpc-go/trpc-a2a-go/client"
 )
 
 type A2ARegistrar struct {
-	cache          crcache.Cache
+	cache crcache.Cache
+	// kube resolves the credentials of the remote agents
+	kube           client.Client
 	translator     agent_translator.AdkApiTranslator
 	handlerMux     A2AHandlerMux
 	a2aBaseUrl     string
@@ -36,6 +42,7 @@ var _ manager.Runnable = (*A2ARegistrar)(nil)
 
 func NewA2ARegistrar(
 	cache crcache.Cache,
+	kube client.Client,
 	translator agent_translator.AdkApiTranslator,
 	mux A2AHandlerMux,
 	a2aBaseUrl string,
@@ -46,6 +53,7 @@ func NewA2ARegistrar(
 ) *A2ARegistrar {
 	reg := &A2ARegistrar{
 		cache:         cache,
+		kube:          kube,
 		translator:    translator,
 		handlerMux:    mux,
 		a2aBaseUrl:    a2aBaseUrl,
@@ -112,6 +120,10 @@ func (a *A2ARegistrar) Start(ctx context.Context) error {
 		return fmt.Errorf("failed to add informer event handler: %w", err)
 	}
 
+	if err := a.watchRemoteAgents(ctx, log); err != nil {
+		return err
+	}
+
 	if ok := a.cache.WaitForCacheSync(ctx); !ok {
 		return fmt.Errorf("cache sync failed")
 	}
@@ -143,7 +155,9 @@ func (a *A2ARegistrar) upsertAgentHandler(ctx context.Context, agent *v1alpha2.A
 	cardCopy := *card
 	cardCopy.URL = fmt.Sprintf("%s/%s/", a.a2aBaseUrl, agentRef)
 
-	if err := a.handlerMux.SetAgentHandler(agentRef.String(), client, cardCopy); err != nil {
+	// declarative agents store their session history themselves
+	record := agent.Spec.Type == v1alpha2.AgentType_BYO
+	if err := a.handlerMux.SetAgentHandler(agentRef.String(), client, cardCopy, agent.Spec.RateLimits, record); err != nil {
 		return fmt.Errorf("set handler for %s: %w", agentRef, err)
 	}
 
@@ -151,6 +165,114 @@ func (a *A2ARegistrar) upsertAgentHandler(ctx context.Context, agent *v1alpha2.A
 	return nil
 }
 
+// watchRemoteAgents serves the remote agents once their agent card was fetched
+func (a *A2ARegistrar) watchRemoteAgents(ctx context.Context, log logr.Logger) error {
+	informer, err := a.cache.GetInformer(ctx, &v1alpha2.RemoteAgent{})
+	if err != nil {
+		return fmt.Errorf("failed to get cache informer: %w", err)
+	}
+
+	if _, err := informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj any) {
+			if remoteAgent, ok := obj.(*v1alpha2.RemoteAgent); ok {
+				if err := a.upsertRemoteAgentHandler(ctx, remoteAgent, log); err != nil {
+					log.Error(err, "failed to upsert A2A handler", "remoteAgent", common.GetObjectRef(remoteAgent))
+				}
+			}
+		},
+		UpdateFunc: func(oldObj, newObj any) {
+			oldAgent, ok1 := oldObj.(*v1alpha2.RemoteAgent)
+			newAgent, ok2 := newObj.(*v1alpha2.RemoteAgent)
+			if !ok1 || !ok2 {
+				return
+			}
+			if remoteAgentChanged(oldAgent, newAgent) {
+				if err := a.upsertRemoteAgentHandler(ctx, newAgent, log); err != nil {
+					log.Error(err, "failed to upsert A2A handler", "remoteAgent", common.GetObjectRef(newAgent))
+				}
+			}
+		},
+		DeleteFunc: func(obj any) {
+			remoteAgent, ok := obj.(*v1alpha2.RemoteAgent)
+			if !ok {
+				if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+					if ra, ok := tombstone.Obj.(*v1alpha2.RemoteAgent); ok {
+						remoteAgent = ra
+					}
+				}
+			}
+			if remoteAgent == nil || a.agentExists(ctx, remoteAgent) {
+				return
+			}
+			ref := common.GetObjectRef(remoteAgent)
+			a.handlerMux.RemoveAgentHandler(ref)
+			log.V(1).Info("removed A2A handler", "remoteAgent", ref)
+		},
+	}); err != nil {
+		return fmt.Errorf("failed to add informer event handler: %w", err)
+	}
+	return nil
+}
+
+// upsertRemoteAgentHandler serves a remote agent through a client authenticated with its
+// credentials. Agents whose agent card was never fetched, which are not accepted, whose agent
+// card has an endpoint outside of the origin of their URL, or which have the name of an Agent,
+// are not served.
+func (a *A2ARegistrar) upsertRemoteAgentHandler(ctx context.Context, remoteAgent *v1alpha2.RemoteAgent, log logr.Logger) error {
+	agentRef := types.NamespacedName{Namespace: remoteAgent.GetNamespace(), Name: remoteAgent.GetName()}
+	if a.agentExists(ctx, remoteAgent) {
+		return nil
+	}
+	card := agent_translator.GetRemoteA2AAgentCard(remoteAgent)
+	if card == nil || !meta.IsStatusConditionTrue(remoteAgent.Status.Conditions, v1alpha2.RemoteAgentConditionTypeAccepted) {
+		a.handlerMux.RemoveAgentHandler(agentRef.String())
+		return nil
+	}
+	// the agent card in the status may predate a change of the URL
+	if err := remoteagent.CheckEndpoint(&remoteAgent.Spec, card.URL); err != nil {
+		a.handlerMux.RemoveAgentHandler(agentRef.String())
+		return fmt.Errorf("invalid agent card of %s: %w", agentRef, err)
+	}
+
+	httpClient, err := remoteagent.HTTPClient(ctx, a.kube, remoteAgent)
+	if err != nil {
+		return fmt.Errorf("create HTTP client for %s: %w", agentRef, err)
+	}
+	client, err := a2aclient.NewA2AClient(
+		card.URL,
+		append(
+			[]a2aclient.Option{a2aclient.WithHTTPClient(httpClient)},
+			a.a2aBaseOptions...,
+		)...,
+	)
+	if err != nil {
+		return fmt.Errorf("create A2A client for %s: %w", agentRef, err)
+	}
+
+	card.URL = fmt.Sprintf("%s/%s/", a.a2aBaseUrl, agentRef)
+	if err := a.handlerMux.SetAgentHandler(agentRef.String(), client, *card, nil, true); err != nil {
+		return fmt.Errorf("set handler for %s: %w", agentRef, err)
+	}
+
+	log.V(1).Info("registered/updated A2A handler", "remoteAgent", agentRef)
+	return nil
+}
+
+// agentExists returns whether an Agent has the name of a remote agent, the Agent is served then
+func (a *A2ARegistrar) agentExists(ctx context.Context, remoteAgent *v1alpha2.RemoteAgent) bool {
+	err := a.cache.Get(ctx, client.ObjectKeyFromObject(remoteAgent), &v1alpha2.Agent{})
+	return !apierrors.IsNotFound(err)
+}
+
+func remoteAgentChanged(oldAgent, newAgent *v1alpha2.RemoteAgent) bool {
+	return oldAgent.Generation != newAgent.Generation ||
+		!reflect.DeepEqual(oldAgent.Spec, newAgent.Spec) ||
+		!reflect.DeepEqual(oldAgent.Status.AgentCard, newAgent.Status.AgentCard) ||
+		!reflect.DeepEqual(oldAgent.Status.DiscoveredSkills, newAgent.Status.DiscoveredSkills) ||
+		meta.IsStatusConditionTrue(oldAgent.Status.Conditions, v1alpha2.RemoteAgentConditionTypeAccepted) !=
+			meta.IsStatusConditionTrue(newAgent.Status.Conditions, v1alpha2.RemoteAgentConditionTypeAccepted)
+}
+
 func debugOpt() a2aclient.Option {
 	debugAddr := os.Getenv("KAGENT_A2A_DEBUG_ADDR")
 	if debugAddr != "" {
@@ -0,0 +1,311 @@
+package a2a
+
+import (
+	"context"
+	"maps"
+	"sync"
+	"time"
+
+	"github.com/kagent-dev/kagent/go/internal/database"
+	"github.com/kagent-dev/kagent/go/pkg/client/api"
+	ctrllog "sigs.k8s.io/controller-runtime/pkg/log"
+	"trpc.group/trpc-go/trpc-a2a-go/protocol"
+	"trpc.group/trpc-go/trpc-a2a-go/taskmanager"
+)
+
+const (
+	// CanceledByMetadataKey is the key of the metadata of a canceled task holding the ID of the
+	// user who canceled it
+	CanceledByMetadataKey = "kagent_canceled_by"
+	// CanceledAtMetadataKey is the key of the metadata of a canceled task holding the time it
+	// was canceled at
+	CanceledAtMetadataKey = "kagent_canceled_at"
+
+	// cancellationPollInterval is the interval the streams check the database for the
+	// cancellation of their task at
+	cancellationPollInterval = 2 * time.Second
+)
+
+// CancellationManager cancels the tasks of an agent and records their cancellation in the
+// database. When the agent fails to cancel a task, e.g. because it does not support cancellation,
+// the streams of the task forwarded to the agent are closed instead, as a best effort to stop the
+// agent working on it. The streams forwarded by the other replicas of the controller are closed
+// once they find the cancellation recorded in the database, which they check periodically. Only
+// the streams and tasks of the user canceling the task are closed or canceled this way.
+type CancellationManager struct {
+	taskmanager.TaskManager
+	// agentID is the ID of the agent in the database, whose sessions hold the tasks it cancels
+	agentID string
+	// dbClient records the cancellations, they are not recorded when it is nil and only the
+	// streams of this replica are closed
+	dbClient     database.Client
+	pollInterval time.Duration
+
+	lock    sync.Mutex
+	streams map[string]map[*activeStream]struct{}
+}
+
+// activeStream is a stream of events of a task forwarded from the agent
+type activeStream struct {
+	// userID is the ID of the user who opened the stream
+	userID    string
+	contextID string
+	// canceled is closed when the stream is closed by a cancellation of its task
+	canceled chan struct{}
+	once     sync.Once
+}
+
+func NewCancellationManager(manager taskmanager.TaskManager, dbClient database.Client, agentID string) taskmanager.TaskManager {
+	return &CancellationManager{
+		TaskManager:  manager,
+		agentID:      agentID,
+		dbClient:     dbClient,
+		pollInterval: cancellationPollInterval,
+		streams:      make(map[string]map[*activeStream]struct{}),
+	}
+}
+
+func (m *CancellationManager) OnSendMessageStream(ctx context.Context, request protocol.SendMessageParams) (<-chan protocol.StreamingMessageEvent, error) {
+	taskID := ""
+	if request.Message.TaskID != nil {
+		taskID = *request.Message.TaskID
+	}
+	return m.trackStream(ctx, taskID, func(ctx context.Context) (<-chan protocol.StreamingMessageEvent, error) {
+		return m.TaskManager.OnSendMessageStream(ctx, request)
+	})
+}
+
+func (m *CancellationManager) OnResubscribe(ctx context.Context, params protocol.TaskIDParams) (<-chan protocol.StreamingMessageEvent, error) {
+	return m.trackStream(ctx, params.ID, func(ctx context.Context) (<-chan protocol.StreamingMessageEvent, error) {
+		return m.TaskManager.OnResubscribe(ctx, params)
+	})
+}
+
+// OnCancelTask asks the agent to cancel the task, closing the streams of the task opened by the
+// user if it fails to. The streams of a task in progress forwarded by another replica are closed
+// by recording the cancellation, if the task is in a session of the user with the agent.
+func (m *CancellationManager) OnCancelTask(ctx context.Context, params protocol.TaskIDParams) (*protocol.Task, error) {
+	log := ctrllog.FromContext(ctx).WithName("a2a-cancellation").WithValues("taskID", params.ID)
+	userID := requestUserID(ctx)
+
+	task, err := m.TaskManager.OnCancelTask(ctx, params)
+	if err != nil {
+		contextID, closed := m.closeStreams(params.ID, userID)
+		switch {
+		case closed:
+			log.Info("Agent failed to cancel the task, closed its streams instead", "error", err.Error())
+			task = m.lastKnownTask(params.ID, contextID)
+		case m.userTaskInProgress(params.ID, userID):
+			log.Info("Agent failed to cancel the task, recorded its cancellation for the replicas streaming it to close their streams", "error", err.Error())
+			task = m.lastKnownTask(params.ID, contextID)
+		default:
+			return nil, err
+		}
+	}
+
+	m.recordCancellation(ctx, task, userID)
+	return task, nil
+}
+
+// trackStream opens a stream with a context canceled by the cancellation of its task. The task
+// of a stream starting a new task is known once the agent responds.
+func (m *CancellationManager) trackStream(
+	ctx context.Context,
+	taskID string,
+	open func(ctx context.Context) (<-chan protocol.StreamingMessageEvent, error),
+) (<-chan protocol.StreamingMessageEvent, error) {
+	streamCtx, cancel := context.WithCancel(ctx)
+	events, err := open(streamCtx)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	stream := &activeStream{userID: requestUserID(ctx), canceled: make(chan struct{})}
+	if taskID != "" {
+		m.register(taskID, stream)
+	}
+	forwarded := make(chan protocol.StreamingMessageEvent)
+	go func() {
+		defer close(forwarded)
+		defer cancel()
+		defer func() {
+			if taskID != "" {
+				m.unregister(taskID, stream)
+			}
+		}()
+
+		var poll <-chan time.Time
+		if m.dbClient != nil {
+			ticker := time.NewTicker(m.pollInterval)
+			defer ticker.Stop()
+			poll = ticker.C
+		}
+
+		for {
+			select {
+			case <-poll:
+				// the task may have been canceled through another replica
+				if taskID != "" && m.canceledInDatabase(taskID) {
+					stream.once.Do(func() { close(stream.canceled) })
+				}
+			case event, ok := <-events:
+				if !ok {
+					return
+				}
+				if taskID == "" {
+					if taskID = api.StreamingEventTaskID(event.Result); taskID != "" {
+						m.register(taskID, stream)
+					}
+				}
+				if contextID := streamingEventContextID(event.Result); contextID != "" {
+					m.setContextID(stream, contextID)
+				}
+				select {
+				case forwarded <- event:
+				case <-streamCtx.Done():
+				}
+			case <-stream.canceled:
+				// let the client know the task is over as the agent will not
+				event := protocol.StreamingMessageEvent{Result: &protocol.TaskStatusUpdateEvent{
+					Kind:      protocol.KindTaskStatusUpdate,
+					TaskID:    taskID,
+					ContextID: m.contextID(stream),
+					Status:    canceledStatus(),
+					Final:     true,
+				}}
+				select {
+				case forwarded <- event:
+				case <-ctx.Done():
+				}
+				return
+			case <-streamCtx.Done():
+				return
+			}
+		}
+	}()
+	return forwarded, nil
+}
+
+func (m *CancellationManager) register(taskID string, stream *activeStream) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if m.streams[taskID] == nil {
+		m.streams[taskID] = make(map[*activeStream]struct{})
+	}
+	m.streams[taskID][stream] = struct{}{}
+}
+
+func (m *CancellationManager) unregister(taskID string, stream *activeStream) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	delete(m.streams[taskID], stream)
+	if len(m.streams[taskID]) == 0 {
+		delete(m.streams, taskID)
+	}
+}
+
+func (m *CancellationManager) setContextID(stream *activeStream, contextID string) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	stream.contextID = contextID
+}
+
+func (m *CancellationManager) contextID(stream *activeStream) string {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return stream.contextID
+}
+
+// closeStreams closes the streams of a task opened by a user, returning the context of the task
+// and whether there were any
+func (m *CancellationManager) closeStreams(taskID, userID string) (string, bool) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	contextID := ""
+	closed := false
+	for stream := range m.streams[taskID] {
+		if stream.userID != userID {
+			continue
+		}
+		stream.once.Do(func() { close(stream.canceled) })
+		contextID = stream.contextID
+		closed = true
+	}
+	return contextID, closed
+}
+
+// userTaskInProgress returns whether the recorded task is in progress in a session of the user
+// with the agent, its streams may be forwarded by another replica
+func (m *CancellationManager) userTaskInProgress(taskID, userID string) bool {
+	if m.dbClient == nil {
+		return false
+	}
+	task, err := m.dbClient.GetTask(taskID)
+	if err != nil || api.IsFinalTaskState(task.Status.State) {
+		return false
+	}
+	session, err := m.dbClient.GetSession(task.ContextID, userID)
+	return err == nil && session.AgentID != nil && *session.AgentID == m.agentID
+}
+
+// canceledInDatabase returns whether the cancellation of the task is recorded
+func (m *CancellationManager) canceledInDatabase(taskID string) bool {
+	task, err := m.dbClient.GetTask(taskID)
+	return err == nil && task.Status.State == protocol.TaskStateCanceled
+}
+
+// lastKnownTask returns the recorded task, if any, to mark as canceled when the agent did not
+// cancel it
+func (m *CancellationManager) lastKnownTask(taskID, contextID string) *protocol.Task {
+	if m.dbClient != nil {
+		if task, err := m.dbClient.GetTask(taskID); err == nil {
+			return task
+		}
+	}
+	return &protocol.Task{ID: taskID, ContextID: contextID, Kind: protocol.KindTask}
+}
+
+// recordCancellation marks a task as canceled by the user of the request, and stores it
+func (m *CancellationManager) recordCancellation(ctx context.Context, task *protocol.Task, userID string) {
+	if task.Status.State != protocol.TaskStateCanceled {
+		task.Status = canceledStatus()
+	}
+	metadata := maps.Clone(task.Metadata)
+	if metadata == nil {
+		metadata = make(map[string]any)
+	}
+	metadata[CanceledByMetadataKey] = userID
+	metadata[CanceledAtMetadataKey] = time.Now().UTC().Format(time.RFC3339)
+	task.Metadata = metadata
+
+	if m.dbClient == nil {
+		return
+	}
+	if err := m.dbClient.CancelTask(task, userID); err != nil {
+		ctrllog.FromContext(ctx).Error(err, "Failed to record task cancellation", "taskID", task.ID)
+	}
+}
+
+func canceledStatus() protocol.TaskStatus {
+	return protocol.TaskStatus{
+		State:     protocol.TaskStateCanceled,
+		Timestamp: time.Now().UTC().Format(time.RFC3339),
+	}
+}
+
+func streamingEventContextID(result protocol.StreamingMessageResult) string {
+	switch result := result.(type) {
+	case *protocol.Task:
+		return result.ContextID
+	case *protocol.TaskStatusUpdateEvent:
+		return result.ContextID
+	case *protocol.TaskArtifactUpdateEvent:
+		return result.ContextID
+	case *protocol.Message:
+		if result.ContextID != nil {
+			return *result.ContextID
+		}
+	}
+	return ""
+}
@@ -0,0 +1,152 @@
+package a2a
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/utils/ptr"
+	"trpc.group/trpc-go/trpc-a2a-go/protocol"
+	"trpc.group/trpc-go/trpc-a2a-go/taskmanager"
+
+	"github.com/kagent-dev/kagent/go/internal/database"
+	database_fake "github.com/kagent-dev/kagent/go/internal/database/fake"
+	"github.com/kagent-dev/kagent/go/pkg/client/api"
+)
+
+// workingAgentManager streams a working task until its stream is closed, and cancels tasks if it
+// supports cancellation
+type workingAgentManager struct {
+	taskmanager.TaskManager
+	supportsCancellation bool
+}
+
+func (m *workingAgentManager) OnSendMessageStream(ctx context.Context, request protocol.SendMessageParams) (<-chan protocol.StreamingMessageEvent, error) {
+	events := make(chan protocol.StreamingMessageEvent, 1)
+	events <- protocol.StreamingMessageEvent{Result: &protocol.TaskStatusUpdateEvent{
+		Kind:      protocol.KindTaskStatusUpdate,
+		TaskID:    "task-1",
+		ContextID: "context-1",
+		Status:    protocol.TaskStatus{State: protocol.TaskStateWorking},
+	}}
+	go func() {
+		<-ctx.Done()
+		close(events)
+	}()
+	return events, nil
+}
+
+func (m *workingAgentManager) OnCancelTask(ctx context.Context, params protocol.TaskIDParams) (*protocol.Task, error) {
+	if !m.supportsCancellation {
+		return nil, errors.New("task cancellation is not supported")
+	}
+	return &protocol.Task{
+		ID:        params.ID,
+		ContextID: "context-1",
+		Status:    protocol.TaskStatus{State: protocol.TaskStateCanceled},
+	}, nil
+}
+
+func TestCancellationManagerCancelsThroughAgent(t *testing.T) {
+	dbClient := database_fake.NewClient()
+	manager := NewCancellationManager(&workingAgentManager{supportsCancellation: true}, dbClient, recordedAgentID)
+
+	task, err := manager.OnCancelTask(userContext("alice"), protocol.TaskIDParams{ID: "task-1"})
+	require.NoError(t, err)
+	assert.Equal(t, protocol.TaskStateCanceled, task.Status.State)
+	assert.Equal(t, "alice", task.Metadata[CanceledByMetadataKey])
+
+	recorded, err := dbClient.GetTask("task-1")
+	require.NoError(t, err)
+	assert.Equal(t, protocol.TaskStateCanceled, recorded.Status.State)
+
+	// the cancellation is final
+	require.NoError(t, dbClient.StoreTask(&protocol.Task{ID: "task-1", ContextID: "context-1", Status: protocol.TaskStatus{State: protocol.TaskStateCompleted}}))
+	recorded, err = dbClient.GetTask("task-1")
+	require.NoError(t, err)
+	assert.Equal(t, protocol.TaskStateCanceled, recorded.Status.State)
+}
+
+func TestCancellationManagerClosesStreams(t *testing.T) {
+	dbClient := database_fake.NewClient()
+	manager := NewCancellationManager(&workingAgentManager{}, dbClient, recordedAgentID)
+
+	events, err := manager.OnSendMessageStream(userContext("alice"), newUserMessage(nil, "hi"))
+	require.NoError(t, err)
+	event := <-events
+	require.Equal(t, "task-1", api.StreamingEventTaskID(event.Result))
+
+	// the streams of other users are left open
+	_, err = manager.OnCancelTask(userContext("bob"), protocol.TaskIDParams{ID: "task-1"})
+	assert.ErrorContains(t, err, "not supported")
+
+	task, err := manager.OnCancelTask(userContext("alice"), protocol.TaskIDParams{ID: "task-1"})
+	require.NoError(t, err)
+	assert.Equal(t, protocol.TaskStateCanceled, task.Status.State)
+	assert.Equal(t, "context-1", task.ContextID)
+
+	// the client is told the task is over before the stream is closed
+	event = <-events
+	status, ok := event.Result.(*protocol.TaskStatusUpdateEvent)
+	require.True(t, ok)
+	assert.Equal(t, protocol.TaskStateCanceled, status.Status.State)
+	assert.True(t, status.Final)
+	_, open := <-events
+	assert.False(t, open)
+
+	recorded, err := dbClient.GetTask("task-1")
+	require.NoError(t, err)
+	assert.Equal(t, protocol.TaskStateCanceled, recorded.Status.State)
+
+	// without a stream to close, the task cannot be canceled
+	_, err = manager.OnCancelTask(userContext("alice"), protocol.TaskIDParams{ID: "task-1"})
+	assert.ErrorContains(t, err, "not supported")
+}
+
+func TestCancellationManagerClosesStreamsOfOtherReplicas(t *testing.T) {
+	dbClient := database_fake.NewClient()
+	streaming := NewCancellationManager(&workingAgentManager{}, dbClient, recordedAgentID).(*CancellationManager)
+	streaming.pollInterval = 10 * time.Millisecond
+	canceling := NewCancellationManager(&workingAgentManager{}, dbClient, recordedAgentID)
+	otherAgent := NewCancellationManager(&workingAgentManager{}, dbClient, "default__NS__other_agent")
+
+	// the task is unknown to the database
+	_, err := canceling.OnCancelTask(userContext("alice"), protocol.TaskIDParams{ID: "task-1"})
+	assert.ErrorContains(t, err, "not supported")
+
+	events, err := streaming.OnSendMessageStream(userContext("alice"), newUserMessage(nil, "hi"))
+	require.NoError(t, err)
+	<-events
+	require.NoError(t, dbClient.StoreSession(&database.Session{ID: "context-1", UserID: "alice", AgentID: ptr.To(recordedAgentID)}))
+	require.NoError(t, dbClient.StoreTask(&protocol.Task{ID: "task-1", ContextID: "context-1", Status: protocol.TaskStatus{State: protocol.TaskStateWorking}}))
+
+	// only the tasks of the user with the agent are canceled
+	_, err = canceling.OnCancelTask(userContext("bob"), protocol.TaskIDParams{ID: "task-1"})
+	assert.ErrorContains(t, err, "not supported")
+	_, err = otherAgent.OnCancelTask(userContext("alice"), protocol.TaskIDParams{ID: "task-1"})
+	assert.ErrorContains(t, err, "not supported")
+	recorded, err := dbClient.GetTask("task-1")
+	require.NoError(t, err)
+	assert.Equal(t, protocol.TaskStateWorking, recorded.Status.State)
+
+	task, err := canceling.OnCancelTask(userContext("alice"), protocol.TaskIDParams{ID: "task-1"})
+	require.NoError(t, err)
+	assert.Equal(t, protocol.TaskStateCanceled, task.Status.State)
+	assert.Equal(t, "alice", task.Metadata[CanceledByMetadataKey])
+
+	// the replica streaming the task closes its stream once it finds the cancellation
+	select {
+	case event := <-events:
+		status, ok := event.Result.(*protocol.TaskStatusUpdateEvent)
+		require.True(t, ok)
+		assert.Equal(t, protocol.TaskStateCanceled, status.Status.State)
+		assert.True(t, status.Final)
+	case <-time.After(5 * time.Second):
+		t.Fatal("the stream of the other replica was not closed")
+	}
+	_, open := <-events
+	assert.False(t, open)
+}
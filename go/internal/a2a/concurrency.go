@@ -0,0 +1,71 @@
+package a2a
+
+import (
+	"context"
+
+	"github.com/kagent-dev/kagent/go/internal/ratelimit"
+	"github.com/kagent-dev/kagent/go/pkg/client/api"
+	ctrllog "sigs.k8s.io/controller-runtime/pkg/log"
+	"trpc.group/trpc-go/trpc-a2a-go/protocol"
+	"trpc.group/trpc-go/trpc-a2a-go/taskmanager"
+)
+
+// ConcurrencyManager makes the tasks started by the messages sent to an agent hold the concurrent
+// slot taken by their message (see ratelimit.Lease) until the agent stops working on them. The
+// requests returning before their task does, e.g. the non-blocking messages and the streams whose
+// client went away, would otherwise free the slot of a task still in progress.
+type ConcurrencyManager struct {
+	taskmanager.TaskManager
+}
+
+func NewConcurrencyManager(manager taskmanager.TaskManager) taskmanager.TaskManager {
+	return &ConcurrencyManager{TaskManager: manager}
+}
+
+func (m *ConcurrencyManager) OnSendMessage(ctx context.Context, request protocol.SendMessageParams) (*protocol.MessageResult, error) {
+	result, err := m.TaskManager.OnSendMessage(ctx, request)
+	if err != nil {
+		return result, err
+	}
+
+	// a task returned in a final state releases the slot with the request
+	if task, ok := result.Result.(*protocol.Task); ok && !api.IsFinalTaskState(task.Status.State) {
+		holdLease(ctx, task.ID)
+	}
+	return result, nil
+}
+
+func (m *ConcurrencyManager) OnSendMessageStream(ctx context.Context, request protocol.SendMessageParams) (<-chan protocol.StreamingMessageEvent, error) {
+	lease := ratelimit.LeaseFrom(ctx)
+	events, err := m.TaskManager.OnSendMessageStream(ctx, request)
+	if err != nil || lease == nil {
+		return events, err
+	}
+
+	forwarded := make(chan protocol.StreamingMessageEvent)
+	go func() {
+		defer close(forwarded)
+		held := false
+		for event := range events {
+			if taskID := api.StreamingEventTaskID(event.Result); !held && taskID != "" && !api.IsFinalStreamingEvent(event) {
+				holdLease(ctx, taskID)
+				held = true
+			}
+			if held && api.IsFinalStreamingEvent(event) {
+				lease.Complete()
+			}
+			select {
+			case forwarded <- event:
+			case <-ctx.Done():
+			}
+		}
+	}()
+	return forwarded, nil
+}
+
+// holdLease makes the task hold the concurrent slot of the request, if any
+func holdLease(ctx context.Context, taskID string) {
+	if err := ratelimit.LeaseFrom(ctx).HoldForTask(taskID); err != nil {
+		ctrllog.FromContext(ctx).Error(err, "Failed to hold the concurrent slot for the task", "taskID", taskID)
+	}
+}
@@ -0,0 +1,81 @@
+package a2a
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/utils/ptr"
+	"trpc.group/trpc-go/trpc-a2a-go/protocol"
+
+	"github.com/kagent-dev/kagent/go/api/v1alpha2"
+	database_fake "github.com/kagent-dev/kagent/go/internal/database/fake"
+	"github.com/kagent-dev/kagent/go/internal/ratelimit"
+)
+
+func TestConcurrencyManagerHoldsSlotsForTasksInProgress(t *testing.T) {
+	limiter := ratelimit.NewLimiter(database_fake.NewClient())
+	limits := &v1alpha2.RateLimits{ConcurrentTasks: ptr.To[int32](1)}
+
+	// admit admits a request, failing the test if it is rejected when it should not be
+	admit := func(admitted bool) context.Context {
+		lease, err := limiter.Admit(recordedAgentID, "alice", limits)
+		if !admitted {
+			require.Error(t, err)
+			return nil
+		}
+		require.NoError(t, err)
+		t.Cleanup(lease.Release)
+		return ratelimit.WithLease(context.Background(), lease)
+	}
+	release := func(ctx context.Context) {
+		ratelimit.LeaseFrom(ctx).Release()
+	}
+
+	t.Run("non-blocking message", func(t *testing.T) {
+		manager := NewConcurrencyManager(&fakeAgentManager{result: &protocol.MessageResult{
+			Result: &protocol.Task{ID: "task-1", Status: protocol.TaskStatus{State: protocol.TaskStateWorking}},
+		}})
+
+		ctx := admit(true)
+		_, err := manager.OnSendMessage(ctx, newUserMessage(nil, "hello"))
+		require.NoError(t, err)
+		release(ctx)
+		admit(false)
+	})
+
+	t.Run("completed message", func(t *testing.T) {
+		limiter = ratelimit.NewLimiter(database_fake.NewClient())
+		manager := NewConcurrencyManager(&fakeAgentManager{result: &protocol.MessageResult{
+			Result: &protocol.Task{ID: "task-1", Status: protocol.TaskStatus{State: protocol.TaskStateCompleted}},
+		}})
+
+		ctx := admit(true)
+		_, err := manager.OnSendMessage(ctx, newUserMessage(nil, "hello"))
+		require.NoError(t, err)
+		release(ctx)
+		admit(true)
+	})
+
+	t.Run("stream", func(t *testing.T) {
+		limiter = ratelimit.NewLimiter(database_fake.NewClient())
+		stream := make(chan protocol.StreamingMessageEvent)
+		manager := NewConcurrencyManager(&streamingAgentManager{stream: stream})
+
+		ctx := admit(true)
+		events, err := manager.OnSendMessageStream(ctx, newUserMessage(nil, "hello"))
+		require.NoError(t, err)
+
+		// the client went away while the task is in progress
+		stream <- statusEvent(protocol.TaskStateWorking, false)
+		<-events
+		release(ctx)
+		admit(false)
+
+		// the slot is released once the task completes
+		stream <- statusEvent(protocol.TaskStateCompleted, true)
+		<-events
+		close(stream)
+		admit(true)
+	})
+}
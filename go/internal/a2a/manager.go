@@ -19,23 +19,23 @@ func NewPassthroughManager(client *client.A2AClient) taskmanager.TaskManager {
 }
 
 func (m *PassthroughManager) OnSendMessage(ctx context.Context, request protocol.SendMessageParams) (*protocol.MessageResult, error) {
-	if request.Message.MessageID == "" {
-		request.Message.MessageID = protocol.GenerateMessageID()
-	}
-	if request.Message.Kind == "" {
-		request.Message.Kind = protocol.KindMessage
-	}
+	prepareMessage(&request.Message)
 	return m.client.SendMessage(ctx, request)
 }
 
 func (m *PassthroughManager) OnSendMessageStream(ctx context.Context, request protocol.SendMessageParams) (<-chan protocol.StreamingMessageEvent, error) {
-	if request.Message.MessageID == "" {
-		request.Message.MessageID = protocol.GenerateMessageID()
+	prepareMessage(&request.Message)
+	return m.client.StreamMessage(ctx, request)
+}
+
+// prepareMessage sets the defaults of the fields of a message sent to an agent
+func prepareMessage(message *protocol.Message) {
+	if message.MessageID == "" {
+		message.MessageID = protocol.GenerateMessageID()
 	}
-	if request.Message.Kind == "" {
-		request.Message.Kind = protocol.KindMessage
+	if message.Kind == "" {
+		message.Kind = protocol.KindMessage
 	}
-	return m.client.StreamMessage(ctx, request)
 }
 
 func (m *PassthroughManager) OnGetTask(ctx context.Context, params protocol.TaskQueryParams) (*protocol.Task, error) {
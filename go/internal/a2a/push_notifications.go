@@ -0,0 +1,141 @@
+package a2a
+
+import (
+	"context"
+	"fmt"
+	"maps"
+
+	"github.com/kagent-dev/kagent/go/internal/database"
+	"github.com/kagent-dev/kagent/go/pkg/client/api"
+	ctrllog "sigs.k8s.io/controller-runtime/pkg/log"
+	"trpc.group/trpc-go/trpc-a2a-go/protocol"
+	"trpc.group/trpc-go/trpc-a2a-go/taskmanager"
+)
+
+// PushNotificationManager keeps the push notification configs of the tasks of an agent in the
+// database instead of forwarding them to the agent, for the controller to deliver the push
+// notifications of all agents. A new config is notified of the current state of its task, then
+// of the states the task reaches.
+//
+// Notifications are enqueued when a task is stored in the database, so they are only delivered
+// for the tasks of agents storing them: declarative agents, and the agents whose tasks are
+// recorded by the controller (see RecordingManager).
+type PushNotificationManager struct {
+	taskmanager.TaskManager
+	dbClient database.Client
+	// jwksURL is the URL of the keys verifying the signed notifications, empty if they are not signed
+	jwksURL string
+}
+
+func NewPushNotificationManager(manager taskmanager.TaskManager, dbClient database.Client, jwksURL string) taskmanager.TaskManager {
+	return &PushNotificationManager{
+		TaskManager: manager,
+		dbClient:    dbClient,
+		jwksURL:     jwksURL,
+	}
+}
+
+func (m *PushNotificationManager) OnSendMessage(ctx context.Context, request protocol.SendMessageParams) (*protocol.MessageResult, error) {
+	pushConfig := takePushNotificationConfig(&request)
+	// the config of a known task is stored before the agent works on it, so that no state is missed
+	if pushConfig != nil && request.Message.TaskID != nil {
+		m.storeConfig(ctx, *request.Message.TaskID, *pushConfig)
+		pushConfig = nil
+	}
+	result, err := m.TaskManager.OnSendMessage(ctx, request)
+	if err != nil || pushConfig == nil {
+		return result, err
+	}
+
+	if task, ok := result.Result.(*protocol.Task); ok {
+		m.storeConfig(ctx, task.ID, *pushConfig)
+	}
+	return result, nil
+}
+
+func (m *PushNotificationManager) OnSendMessageStream(ctx context.Context, request protocol.SendMessageParams) (<-chan protocol.StreamingMessageEvent, error) {
+	pushConfig := takePushNotificationConfig(&request)
+	if pushConfig != nil && request.Message.TaskID != nil {
+		m.storeConfig(ctx, *request.Message.TaskID, *pushConfig)
+		pushConfig = nil
+	}
+	events, err := m.TaskManager.OnSendMessageStream(ctx, request)
+	if err != nil || pushConfig == nil {
+		return events, err
+	}
+
+	forwarded := make(chan protocol.StreamingMessageEvent)
+	go func() {
+		defer close(forwarded)
+		stored := false
+		for event := range events {
+			if taskID := api.StreamingEventTaskID(event.Result); !stored && taskID != "" {
+				m.storeConfig(ctx, taskID, *pushConfig)
+				stored = true
+			}
+			select {
+			case forwarded <- event:
+			case <-ctx.Done():
+			}
+		}
+	}()
+	return forwarded, nil
+}
+
+func (m *PushNotificationManager) OnPushNotificationSet(ctx context.Context, params protocol.TaskPushNotificationConfig) (*protocol.TaskPushNotificationConfig, error) {
+	config := m.prepareConfig(params.TaskID, params.PushNotificationConfig)
+	config.Metadata = params.Metadata
+	if err := m.dbClient.StorePushNotification(&config); err != nil {
+		return nil, err
+	}
+	return &config, nil
+}
+
+// OnPushNotificationGet returns the last push notification config set for the task
+func (m *PushNotificationManager) OnPushNotificationGet(ctx context.Context, params protocol.TaskIDParams) (*protocol.TaskPushNotificationConfig, error) {
+	configs, err := m.dbClient.ListPushNotifications(params.ID)
+	if err != nil {
+		return nil, err
+	}
+	if len(configs) == 0 {
+		return nil, fmt.Errorf("no push notification config found for task %s", params.ID)
+	}
+	return configs[len(configs)-1], nil
+}
+
+func (m *PushNotificationManager) storeConfig(ctx context.Context, taskID string, pushConfig protocol.PushNotificationConfig) {
+	config := m.prepareConfig(taskID, pushConfig)
+	if err := m.dbClient.StorePushNotification(&config); err != nil {
+		ctrllog.FromContext(ctx).Error(err, "Failed to store push notification config", "taskID", taskID)
+	}
+}
+
+// prepareConfig identifies a push notification config and adds the URL of the keys verifying
+// the signed notifications to its metadata
+func (m *PushNotificationManager) prepareConfig(taskID string, pushConfig protocol.PushNotificationConfig) protocol.TaskPushNotificationConfig {
+	if pushConfig.ID == "" {
+		pushConfig.ID = protocol.GenerateRPCID()
+	}
+	if m.jwksURL != "" {
+		metadata := maps.Clone(pushConfig.Metadata)
+		if metadata == nil {
+			metadata = make(map[string]any)
+		}
+		metadata["jwksUrl"] = m.jwksURL
+		pushConfig.Metadata = metadata
+	}
+	return protocol.TaskPushNotificationConfig{TaskID: taskID, PushNotificationConfig: pushConfig}
+}
+
+// takePushNotificationConfig removes the push notification config from a request forwarded to an
+// agent and returns it
+func takePushNotificationConfig(request *protocol.SendMessageParams) *protocol.PushNotificationConfig {
+	if request.Configuration == nil || request.Configuration.PushNotificationConfig == nil {
+		return nil
+	}
+	configuration := *request.Configuration
+	pushConfig := configuration.PushNotificationConfig
+	configuration.PushNotificationConfig = nil
+	request.Configuration = &configuration
+	return pushConfig
+}
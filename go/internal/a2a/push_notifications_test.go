@@ -0,0 +1,123 @@
+package a2a
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/utils/ptr"
+	"trpc.group/trpc-go/trpc-a2a-go/protocol"
+	"trpc.group/trpc-go/trpc-a2a-go/taskmanager"
+
+	"github.com/kagent-dev/kagent/go/internal/database"
+	database_fake "github.com/kagent-dev/kagent/go/internal/database/fake"
+)
+
+// configCapturingManager records the configuration of the requests forwarded to the agent
+type configCapturingManager struct {
+	taskmanager.TaskManager
+	configurations []*protocol.SendMessageConfiguration
+}
+
+func (m *configCapturingManager) OnSendMessage(ctx context.Context, request protocol.SendMessageParams) (*protocol.MessageResult, error) {
+	m.configurations = append(m.configurations, request.Configuration)
+	return &protocol.MessageResult{Result: &protocol.Task{ID: "task-1", ContextID: "context-1"}}, nil
+}
+
+// completingManager stores the task as completed before responding, like an agent storing its
+// tasks through the controller during a blocking request
+type completingManager struct {
+	taskmanager.TaskManager
+	dbClient database.Client
+	// configsSeen is the number of push notification configs of the task when the agent was called
+	configsSeen int
+}
+
+func (m *completingManager) OnSendMessage(ctx context.Context, request protocol.SendMessageParams) (*protocol.MessageResult, error) {
+	taskID := "task-1"
+	if request.Message.TaskID != nil {
+		taskID = *request.Message.TaskID
+	}
+	configs, err := m.dbClient.ListPushNotifications(taskID)
+	if err != nil {
+		return nil, err
+	}
+	m.configsSeen = len(configs)
+	task := &protocol.Task{ID: taskID, ContextID: "context-1", Status: protocol.TaskStatus{State: protocol.TaskStateCompleted}}
+	if err := m.dbClient.StoreTask(task); err != nil {
+		return nil, err
+	}
+	return &protocol.MessageResult{Result: task}, nil
+}
+
+func TestPushNotificationManagerNotifiesStatesBeforeConfig(t *testing.T) {
+	for _, taskID := range []*string{nil, ptr.To("task-2")} {
+		dbClient := database_fake.NewClient()
+		agent := &completingManager{dbClient: dbClient}
+		manager := NewPushNotificationManager(agent, dbClient, "")
+
+		request := newUserMessage(nil, "hi")
+		request.Message.TaskID = taskID
+		request.Configuration = &protocol.SendMessageConfiguration{
+			PushNotificationConfig: &protocol.PushNotificationConfig{URL: "http://client/notifications"},
+		}
+		result, err := manager.OnSendMessage(context.Background(), request)
+		require.NoError(t, err)
+
+		// the config of a known task is stored before the agent is called
+		if taskID != nil {
+			assert.Equal(t, 1, agent.configsSeen)
+		}
+
+		// the completed state is notified once
+		deliveries, err := dbClient.ListPushNotificationDeliveries(result.Result.(*protocol.Task).ID)
+		require.NoError(t, err)
+		require.Len(t, deliveries, 1)
+		assert.Equal(t, string(protocol.TaskStateCompleted), deliveries[0].TaskState)
+	}
+}
+
+func TestPushNotificationManager(t *testing.T) {
+	dbClient := database_fake.NewClient()
+	agent := &configCapturingManager{}
+	manager := NewPushNotificationManager(agent, dbClient, "http://kagent:8083/api/push-notifications/jwks.json")
+
+	t.Run("SetAndGet", func(t *testing.T) {
+		config, err := manager.OnPushNotificationSet(context.Background(), protocol.TaskPushNotificationConfig{
+			TaskID:                 "task-0",
+			PushNotificationConfig: protocol.PushNotificationConfig{URL: "http://client/notifications"},
+		})
+		require.NoError(t, err)
+		assert.NotEmpty(t, config.PushNotificationConfig.ID)
+		assert.Equal(t, "http://kagent:8083/api/push-notifications/jwks.json", config.PushNotificationConfig.Metadata["jwksUrl"])
+
+		stored, err := manager.OnPushNotificationGet(context.Background(), protocol.TaskIDParams{ID: "task-0"})
+		require.NoError(t, err)
+		assert.Equal(t, "http://client/notifications", stored.PushNotificationConfig.URL)
+
+		_, err = manager.OnPushNotificationGet(context.Background(), protocol.TaskIDParams{ID: "task-2"})
+		assert.ErrorContains(t, err, "no push notification config found for task task-2")
+	})
+
+	t.Run("ConfigOfMessage", func(t *testing.T) {
+		request := newUserMessage(nil, "hi")
+		request.Configuration = &protocol.SendMessageConfiguration{
+			AcceptedOutputModes:    []string{"text"},
+			PushNotificationConfig: &protocol.PushNotificationConfig{URL: "http://client/notifications"},
+		}
+		_, err := manager.OnSendMessage(context.Background(), request)
+		require.NoError(t, err)
+
+		// the agent does not push notifications itself
+		require.Len(t, agent.configurations, 1)
+		assert.Nil(t, agent.configurations[0].PushNotificationConfig)
+		assert.Equal(t, []string{"text"}, agent.configurations[0].AcceptedOutputModes)
+		assert.NotNil(t, request.Configuration.PushNotificationConfig)
+
+		configs, err := dbClient.ListPushNotifications("task-1")
+		require.NoError(t, err)
+		require.Len(t, configs, 1)
+		assert.Equal(t, "http://client/notifications", configs[0].PushNotificationConfig.URL)
+	})
+}
@@ -0,0 +1,268 @@
+package a2a
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+
+	"github.com/go-logr/logr"
+	"github.com/kagent-dev/kagent/go/internal/database"
+	"github.com/kagent-dev/kagent/go/pkg/auth"
+	"k8s.io/utils/ptr"
+	ctrllog "sigs.k8s.io/controller-runtime/pkg/log"
+	"trpc.group/trpc-go/trpc-a2a-go/protocol"
+	"trpc.group/trpc-go/trpc-a2a-go/taskmanager"
+)
+
+// RecordingManager forwards the requests to an agent like the manager it wraps, and records the
+// messages exchanged with the agent and the tasks of the agent in the database. This keeps the
+// session history of agents that do not store it themselves, and answers for the tasks of agents
+// that lost them, e.g. after a restart.
+//
+// Failures to record are logged and do not fail the requests. The requests without an
+// authenticated user are not recorded, their sessions would have no owner.
+type RecordingManager struct {
+	taskmanager.TaskManager
+	dbClient database.Client
+	agentID  string
+}
+
+// NewRecordingManager records the requests forwarded by manager to the agent with the given ID
+// in the database
+func NewRecordingManager(manager taskmanager.TaskManager, dbClient database.Client, agentID string) taskmanager.TaskManager {
+	return &RecordingManager{
+		TaskManager: manager,
+		dbClient:    dbClient,
+		agentID:     agentID,
+	}
+}
+
+func (m *RecordingManager) OnSendMessage(ctx context.Context, request protocol.SendMessageParams) (*protocol.MessageResult, error) {
+	prepareMessage(&request.Message)
+	result, err := m.TaskManager.OnSendMessage(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	if recorder := m.newRecorder(ctx, &request.Message); recorder != nil {
+		recorder.record(result.Result)
+		recorder.finish()
+	}
+	return result, nil
+}
+
+func (m *RecordingManager) OnSendMessageStream(ctx context.Context, request protocol.SendMessageParams) (<-chan protocol.StreamingMessageEvent, error) {
+	prepareMessage(&request.Message)
+	events, err := m.TaskManager.OnSendMessageStream(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	return m.recordStream(ctx, m.newRecorder(ctx, &request.Message), events), nil
+}
+
+func (m *RecordingManager) OnResubscribe(ctx context.Context, params protocol.TaskIDParams) (<-chan protocol.StreamingMessageEvent, error) {
+	events, err := m.TaskManager.OnResubscribe(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	return m.recordStream(ctx, m.newRecorder(ctx, nil), events), nil
+}
+
+// OnGetTask gets the task from the agent, or the recorded task if the agent fails to return it
+func (m *RecordingManager) OnGetTask(ctx context.Context, params protocol.TaskQueryParams) (*protocol.Task, error) {
+	task, err := m.TaskManager.OnGetTask(ctx, params)
+	if err == nil {
+		return task, nil
+	}
+	recorded, dbErr := m.dbClient.GetTask(params.ID)
+	if dbErr != nil {
+		return nil, err
+	}
+	ctrllog.FromContext(ctx).V(1).Info("Returning recorded task", "taskID", params.ID, "error", err.Error())
+	return recorded, nil
+}
+
+// recordStream records the events of a stream while forwarding them
+func (m *RecordingManager) recordStream(ctx context.Context, recorder *recorder, events <-chan protocol.StreamingMessageEvent) <-chan protocol.StreamingMessageEvent {
+	if recorder == nil {
+		return events
+	}
+	recorded := make(chan protocol.StreamingMessageEvent)
+	go func() {
+		defer close(recorded)
+		defer recorder.finish()
+
+		forward := true
+		for event := range events {
+			recorder.record(event.Result)
+			if !forward {
+				continue
+			}
+			select {
+			case recorded <- event:
+			case <-ctx.Done():
+				// keep recording the events still sent by the agent
+				forward = false
+			}
+		}
+	}()
+	return recorded
+}
+
+// newRecorder returns the recorder of a request, nil if the request has no user to record it for
+func (m *RecordingManager) newRecorder(ctx context.Context, request *protocol.Message) *recorder {
+	log := ctrllog.FromContext(ctx).WithName("a2a-recorder").WithValues("agent", m.agentID)
+	var userID string
+	if session, ok := auth.AuthSessionFrom(ctx); ok {
+		userID = session.Principal().User.ID
+	}
+	if userID == "" {
+		log.V(1).Info("Not recording a request without user")
+		return nil
+	}
+	return &recorder{
+		log:      log,
+		dbClient: m.dbClient,
+		agentID:  m.agentID,
+		userID:   userID,
+		request:  request,
+	}
+}
+
+// recorder records the messages and the task of a request. The context of the request is only
+// known once the agent responds when the request starts a new one.
+type recorder struct {
+	log      logr.Logger
+	dbClient database.Client
+	agentID  string
+	userID   string
+	request  *protocol.Message
+
+	contextID string
+	task      *protocol.Task
+	// taskChanged is set when the task changed since it was last stored
+	taskChanged bool
+}
+
+// record records a result of a request, either a UnaryMessageResult or a StreamingMessageResult
+func (r *recorder) record(result any) {
+	switch result := result.(type) {
+	case *protocol.Message:
+		r.setContext(ptr.Deref(result.ContextID, ""))
+		r.storeMessage(*result)
+	case *protocol.Task:
+		r.setContext(result.ContextID)
+		task := *result
+		if len(task.History) == 0 && r.request != nil {
+			request := *r.request
+			request.ContextID = &task.ContextID
+			task.History = []protocol.Message{request}
+		}
+		r.task = &task
+		r.taskChanged = true
+	case *protocol.TaskStatusUpdateEvent:
+		r.setContext(result.ContextID)
+		task := r.ensureTask(result.TaskID, result.ContextID)
+		task.Status = result.Status
+		if message := result.Status.Message; message != nil {
+			r.storeMessage(*message)
+			task.History = append(task.History, *message)
+		}
+		r.taskChanged = true
+		if result.Final {
+			r.storeTask()
+		}
+	case *protocol.TaskArtifactUpdateEvent:
+		r.setContext(result.ContextID)
+		task := r.ensureTask(result.TaskID, result.ContextID)
+		mergeArtifact(task, result.Artifact, result.Append != nil && *result.Append)
+		r.taskChanged = true
+	}
+}
+
+// finish stores the last state of the task
+func (r *recorder) finish() {
+	r.storeTask()
+}
+
+// setContext creates the session of the context and records the request in it the first time
+// the context is known
+func (r *recorder) setContext(contextID string) {
+	if r.contextID != "" || contextID == "" {
+		return
+	}
+	r.contextID = contextID
+
+	if _, err := r.dbClient.GetSession(contextID, r.userID); err != nil {
+		agentID := r.agentID
+		if err := r.dbClient.StoreSession(&database.Session{ID: contextID, UserID: r.userID, AgentID: &agentID}); err != nil {
+			r.log.Error(err, "Failed to record session", "sessionID", contextID)
+		}
+	}
+
+	if r.request != nil {
+		request := *r.request
+		request.ContextID = &contextID
+		r.storeMessage(request)
+	}
+}
+
+func (r *recorder) storeMessage(message protocol.Message) {
+	if r.contextID == "" {
+		return
+	}
+	data, err := json.Marshal(message)
+	if err != nil {
+		r.log.Error(err, "Failed to serialize message", "messageID", message.MessageID)
+		return
+	}
+	if err := r.dbClient.StoreEvents(&database.Event{
+		ID:        message.MessageID,
+		SessionID: r.contextID,
+		UserID:    r.userID,
+		Data:      string(data),
+	}); err != nil {
+		r.log.Error(err, "Failed to record message", "messageID", message.MessageID)
+	}
+}
+
+func (r *recorder) ensureTask(taskID, contextID string) *protocol.Task {
+	if r.task == nil || r.task.ID != taskID {
+		r.storeTask()
+		r.task = &protocol.Task{ID: taskID, ContextID: contextID, Kind: protocol.KindTask}
+		if r.request != nil {
+			request := *r.request
+			request.ContextID = &contextID
+			r.task.History = []protocol.Message{request}
+		} else if recorded, err := r.dbClient.GetTask(taskID); err == nil {
+			// resubscribing continues a recorded task
+			r.task = recorded
+		}
+	}
+	return r.task
+}
+
+func (r *recorder) storeTask() {
+	if r.task == nil || !r.taskChanged {
+		return
+	}
+	if err := r.dbClient.StoreTask(r.task); err != nil {
+		r.log.Error(err, "Failed to record task", "taskID", r.task.ID)
+		return
+	}
+	r.taskChanged = false
+}
+
+// mergeArtifact adds an artifact to a task, or appends its parts to the artifact of the task with
+// the same ID when streamed in chunks
+func mergeArtifact(task *protocol.Task, artifact protocol.Artifact, appendParts bool) {
+	i := slices.IndexFunc(task.Artifacts, func(a protocol.Artifact) bool { return a.ArtifactID == artifact.ArtifactID })
+	switch {
+	case i < 0:
+		task.Artifacts = append(task.Artifacts, artifact)
+	case appendParts:
+		task.Artifacts[i].Parts = append(task.Artifacts[i].Parts, artifact.Parts...)
+	default:
+		task.Artifacts[i] = artifact
+	}
+}
@@ -0,0 +1,143 @@
+package a2a
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/utils/ptr"
+	"trpc.group/trpc-go/trpc-a2a-go/protocol"
+	"trpc.group/trpc-go/trpc-a2a-go/taskmanager"
+
+	"github.com/kagent-dev/kagent/go/internal/database"
+	database_fake "github.com/kagent-dev/kagent/go/internal/database/fake"
+	authimpl "github.com/kagent-dev/kagent/go/internal/httpserver/auth"
+	"github.com/kagent-dev/kagent/go/pkg/auth"
+)
+
+const recordedAgentID = "default__NS__byo_agent"
+
+// fakeAgentManager answers the requests like an agent
+type fakeAgentManager struct {
+	taskmanager.TaskManager
+	result *protocol.MessageResult
+	events []protocol.StreamingMessageEvent
+}
+
+func (m *fakeAgentManager) OnSendMessage(ctx context.Context, request protocol.SendMessageParams) (*protocol.MessageResult, error) {
+	return m.result, nil
+}
+
+func (m *fakeAgentManager) OnSendMessageStream(ctx context.Context, request protocol.SendMessageParams) (<-chan protocol.StreamingMessageEvent, error) {
+	events := make(chan protocol.StreamingMessageEvent, len(m.events))
+	for _, event := range m.events {
+		events <- event
+	}
+	close(events)
+	return events, nil
+}
+
+func (m *fakeAgentManager) OnGetTask(ctx context.Context, params protocol.TaskQueryParams) (*protocol.Task, error) {
+	return nil, errors.New("task not found")
+}
+
+func userContext(userID string) context.Context {
+	return auth.AuthSessionTo(context.Background(), &authimpl.SimpleSession{
+		P: auth.Principal{User: auth.User{ID: userID}},
+	})
+}
+
+func newUserMessage(contextID *string, text string) protocol.SendMessageParams {
+	message := protocol.NewMessage(protocol.MessageRoleUser, []protocol.Part{protocol.NewTextPart(text)})
+	message.ContextID = contextID
+	return protocol.SendMessageParams{Message: message}
+}
+
+func recordedMessages(t *testing.T, dbClient database.Client, sessionID, userID string) []string {
+	events, err := dbClient.ListEventsForSession(sessionID, userID, database.QueryOptions{})
+	require.NoError(t, err)
+	texts := make([]string, 0, len(events))
+	for _, event := range events {
+		message, err := event.Parse()
+		require.NoError(t, err)
+		texts = append(texts, ExtractText(message))
+	}
+	return texts
+}
+
+func TestRecordingManagerSendMessage(t *testing.T) {
+	dbClient := database_fake.NewClient()
+	reply := protocol.NewMessageWithContext(protocol.MessageRoleAgent, []protocol.Part{protocol.NewTextPart("hello")}, nil, ptr.To("context-1"))
+	manager := NewRecordingManager(&fakeAgentManager{
+		result: &protocol.MessageResult{Result: &reply},
+	}, dbClient, recordedAgentID)
+
+	result, err := manager.OnSendMessage(userContext("alice"), newUserMessage(nil, "hi"))
+	require.NoError(t, err)
+	assert.Equal(t, &reply, result.Result)
+
+	session, err := dbClient.GetSession("context-1", "alice")
+	require.NoError(t, err)
+	assert.Equal(t, recordedAgentID, *session.AgentID)
+	assert.Equal(t, []string{"hi", "hello"}, recordedMessages(t, dbClient, "context-1", "alice"))
+}
+
+func TestRecordingManagerSendMessageStream(t *testing.T) {
+	dbClient := database_fake.NewClient()
+	existing := "named session"
+	require.NoError(t, dbClient.StoreSession(&database.Session{ID: "context-1", UserID: "alice", Name: &existing}))
+
+	working := protocol.NewMessageWithContext(protocol.MessageRoleAgent, []protocol.Part{protocol.NewTextPart("working")}, ptr.To("task-1"), ptr.To("context-1"))
+	done := protocol.NewMessageWithContext(protocol.MessageRoleAgent, []protocol.Part{protocol.NewTextPart("done")}, ptr.To("task-1"), ptr.To("context-1"))
+	events := []protocol.StreamingMessageEvent{
+		{Result: &protocol.TaskStatusUpdateEvent{TaskID: "task-1", ContextID: "context-1", Status: protocol.TaskStatus{State: protocol.TaskStateWorking, Message: &working}}},
+		{Result: &protocol.TaskArtifactUpdateEvent{TaskID: "task-1", ContextID: "context-1", Artifact: protocol.Artifact{ArtifactID: "a1", Parts: []protocol.Part{protocol.NewTextPart("part 1")}}}},
+		{Result: &protocol.TaskArtifactUpdateEvent{TaskID: "task-1", ContextID: "context-1", Append: ptr.To(true), Artifact: protocol.Artifact{ArtifactID: "a1", Parts: []protocol.Part{protocol.NewTextPart("part 2")}}}},
+		{Result: &protocol.TaskStatusUpdateEvent{TaskID: "task-1", ContextID: "context-1", Final: true, Status: protocol.TaskStatus{State: protocol.TaskStateCompleted, Message: &done}}},
+	}
+	manager := NewRecordingManager(&fakeAgentManager{events: events}, dbClient, recordedAgentID)
+
+	stream, err := manager.OnSendMessageStream(userContext("alice"), newUserMessage(ptr.To("context-1"), "hi"))
+	require.NoError(t, err)
+	var forwarded int
+	for range stream {
+		forwarded++
+	}
+	assert.Equal(t, len(events), forwarded)
+
+	// the existing session is kept
+	session, err := dbClient.GetSession("context-1", "alice")
+	require.NoError(t, err)
+	assert.Equal(t, &existing, session.Name)
+	assert.Equal(t, []string{"hi", "working", "done"}, recordedMessages(t, dbClient, "context-1", "alice"))
+
+	task, err := manager.OnGetTask(context.Background(), protocol.TaskQueryParams{ID: "task-1"})
+	require.NoError(t, err)
+	assert.Equal(t, protocol.TaskStateCompleted, task.Status.State)
+	assert.Equal(t, "context-1", task.ContextID)
+	require.Len(t, task.History, 3)
+	require.Len(t, task.Artifacts, 1)
+	assert.Len(t, task.Artifacts[0].Parts, 2)
+
+	_, err = manager.OnGetTask(context.Background(), protocol.TaskQueryParams{ID: "task-2"})
+	assert.ErrorContains(t, err, "task not found")
+}
+
+func TestRecordingManagerSkipsRequestsWithoutUser(t *testing.T) {
+	dbClient := database_fake.NewClient()
+	reply := protocol.NewMessageWithContext(protocol.MessageRoleAgent, []protocol.Part{protocol.NewTextPart("hello")}, nil, ptr.To("context-1"))
+	manager := NewRecordingManager(&fakeAgentManager{
+		result: &protocol.MessageResult{Result: &reply},
+	}, dbClient, recordedAgentID)
+
+	for _, ctx := range []context.Context{context.Background(), userContext("")} {
+		result, err := manager.OnSendMessage(ctx, newUserMessage(nil, "hi"))
+		require.NoError(t, err)
+		assert.Equal(t, &reply, result.Result)
+
+		_, err = dbClient.GetSession("context-1", "")
+		assert.Error(t, err)
+	}
+}
@@ -0,0 +1,489 @@
+package a2a
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"maps"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/kagent-dev/kagent/go/internal/database"
+	"github.com/kagent-dev/kagent/go/internal/ratelimit"
+	"github.com/kagent-dev/kagent/go/pkg/auth"
+	"github.com/kagent-dev/kagent/go/pkg/client/api"
+	"k8s.io/utils/ptr"
+	ctrllog "sigs.k8s.io/controller-runtime/pkg/log"
+	"trpc.group/trpc-go/trpc-a2a-go/protocol"
+	"trpc.group/trpc-go/trpc-a2a-go/taskmanager"
+)
+
+const (
+	// streamEventRetention is the time the events streamed by agents are kept for resubscriptions
+	streamEventRetention = 24 * time.Hour
+	// detachedStreamTimeout is the time an agent keeps streaming a task without subscribers before
+	// its stream is closed
+	detachedStreamTimeout = 10 * time.Minute
+	// streamEventBatchSize is the maximum number of events stored at once
+	streamEventBatchSize = 100
+)
+
+// errStreamNotFound is returned for the resubscriptions to tasks the user did not stream
+var errStreamNotFound = errors.New("task not found")
+
+// ResumableStreamManager keeps streaming the tasks of an agent when their clients disconnect, and
+// stores the streamed events in the database. The events are sent with their ID in their metadata,
+// and a client resubscribing to a task with the ID of the last event it received gets the events
+// it missed, followed by the events the agent still streams.
+//
+// The stream of a task is closed once it has had no subscribers for detachedStreamTimeout. Only
+// the user who streamed a task, or the user of its session, may resubscribe to it.
+type ResumableStreamManager struct {
+	taskmanager.TaskManager
+	dbClient database.Client
+	now      func() time.Time
+	// detachedTimeout is the time a stream without subscribers is kept open
+	detachedTimeout time.Duration
+
+	lock sync.Mutex
+	// streams are the streams of tasks in progress
+	streams   map[string]*liveStream
+	lastPrune time.Time
+}
+
+func NewResumableStreamManager(manager taskmanager.TaskManager, dbClient database.Client) taskmanager.TaskManager {
+	return &ResumableStreamManager{
+		TaskManager:     manager,
+		dbClient:        dbClient,
+		now:             time.Now,
+		detachedTimeout: detachedStreamTimeout,
+		streams:         make(map[string]*liveStream),
+	}
+}
+
+func (m *ResumableStreamManager) OnSendMessageStream(ctx context.Context, request protocol.SendMessageParams) (<-chan protocol.StreamingMessageEvent, error) {
+	// the agent keeps streaming when the client disconnects
+	streamCtx, cancel := detach(ctx)
+	events, err := m.TaskManager.OnSendMessageStream(streamCtx, request)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	stream := m.startStream(ctx, ptr.Deref(request.Message.TaskID, ""), nil, events, cancel)
+	return stream.subscribe(ctx, 0), nil
+}
+
+// OnResubscribe follows the stream of the task if it is in progress, replaying the events after
+// the last event received by the client. Otherwise the stored events are replayed, followed by
+// the events of a new stream of the agent if the task is not over.
+func (m *ResumableStreamManager) OnResubscribe(ctx context.Context, params protocol.TaskIDParams) (<-chan protocol.StreamingMessageEvent, error) {
+	log := ctrllog.FromContext(ctx).WithName("a2a-streams").WithValues("taskID", params.ID)
+	userID := requestUserID(ctx)
+	afterID := lastStreamEventID(params.Metadata)
+
+	if stream := m.liveStream(params.ID); stream != nil {
+		if stream.userID != userID {
+			return nil, errStreamNotFound
+		}
+		return stream.subscribe(ctx, afterID), nil
+	}
+
+	stored, err := m.dbClient.ListStreamEvents(params.ID, userID, afterID)
+	if err != nil {
+		return nil, err
+	}
+	if len(stored) == 0 && !m.sessionOwnsTask(params.ID, userID) {
+		return nil, errStreamNotFound
+	}
+	replayed := make([]streamedEvent, 0, len(stored))
+	for _, storedEvent := range stored {
+		var event protocol.StreamingMessageEvent
+		if err := json.Unmarshal([]byte(storedEvent.Data), &event); err != nil {
+			log.Error(err, "Failed to parse stream event", "eventID", storedEvent.ID)
+			continue
+		}
+		replayed = append(replayed, streamedEvent{id: storedEvent.ID, event: event})
+	}
+
+	if m.taskOver(params.ID, replayed) {
+		return finishedStream(userID, replayed).subscribe(ctx, afterID), nil
+	}
+	streamCtx, cancel := detach(ctx)
+	events, err := m.TaskManager.OnResubscribe(streamCtx, params)
+	if err != nil {
+		cancel()
+		if len(replayed) > 0 {
+			log.Info("Agent failed to resubscribe to the task, replaying the stored events only", "error", err.Error())
+			return finishedStream(userID, replayed).subscribe(ctx, afterID), nil
+		}
+		return nil, err
+	}
+	return m.startStream(ctx, params.ID, replayed, events, cancel).subscribe(ctx, afterID), nil
+}
+
+// startStream stores and buffers the events streamed by the agent for the subscribers of the
+// stream. The events are received from the agent while the previous ones are stored, and stored
+// in batches. The events streamed before the task is known are stored once it is. cancel closes
+// the stream of the agent.
+func (m *ResumableStreamManager) startStream(
+	ctx context.Context,
+	taskID string,
+	replayed []streamedEvent,
+	events <-chan protocol.StreamingMessageEvent,
+	cancel context.CancelFunc,
+) *liveStream {
+	log := ctrllog.FromContext(ctx).WithName("a2a-streams")
+	stream := &liveStream{
+		userID:          requestUserID(ctx),
+		events:          replayed,
+		updated:         make(chan struct{}),
+		cancel:          cancel,
+		detachedTimeout: m.detachedTimeout,
+	}
+	if taskID != "" {
+		m.register(taskID, stream)
+	}
+
+	go func() {
+		defer cancel()
+		var pending []protocol.StreamingMessageEvent
+		for batch := range receiveBatches(events) {
+			for _, event := range batch {
+				if taskID == "" {
+					if taskID = api.StreamingEventTaskID(event.Result); taskID != "" {
+						m.register(taskID, stream)
+					}
+				}
+			}
+			pending = append(pending, batch...)
+			if taskID == "" {
+				continue
+			}
+			for _, event := range m.store(log, taskID, stream.userID, pending) {
+				stream.add(event)
+			}
+			pending = nil
+		}
+		// the stream never had a task to resubscribe to
+		for _, event := range pending {
+			stream.add(streamedEvent{event: event})
+		}
+
+		stream.finish()
+		if taskID != "" {
+			m.unregister(taskID, stream)
+		}
+		m.prune(log)
+	}()
+	return stream
+}
+
+// receiveBatches keeps receiving the events of a stream while the previous batch is handled, and
+// groups them in batches
+func receiveBatches(events <-chan protocol.StreamingMessageEvent) <-chan []protocol.StreamingMessageEvent {
+	received := make(chan protocol.StreamingMessageEvent, streamEventBatchSize)
+	go func() {
+		defer close(received)
+		for event := range events {
+			received <- event
+		}
+	}()
+
+	batches := make(chan []protocol.StreamingMessageEvent)
+	go func() {
+		defer close(batches)
+		for event := range received {
+			batch := []protocol.StreamingMessageEvent{event}
+		drain:
+			for len(batch) < streamEventBatchSize {
+				select {
+				case event, ok := <-received:
+					if !ok {
+						break drain
+					}
+					batch = append(batch, event)
+				default:
+					break drain
+				}
+			}
+			batches <- batch
+		}
+	}()
+	return batches
+}
+
+// store stores events, returning them without ID if they cannot be stored
+func (m *ResumableStreamManager) store(log logr.Logger, taskID, userID string, events []protocol.StreamingMessageEvent) []streamedEvent {
+	streamed := make([]streamedEvent, len(events))
+	stored := make([]*database.StreamEvent, 0, len(events))
+	// storedIndexes are the indexes of the stored events in events
+	storedIndexes := make([]int, 0, len(events))
+	for i, event := range events {
+		streamed[i] = streamedEvent{event: event}
+		data, err := json.Marshal(&event)
+		if err != nil {
+			log.Error(err, "Failed to serialize stream event", "taskID", taskID)
+			continue
+		}
+		stored = append(stored, &database.StreamEvent{TaskID: taskID, UserID: userID, Data: string(data)})
+		storedIndexes = append(storedIndexes, i)
+	}
+	if err := m.dbClient.StoreStreamEvents(stored...); err != nil {
+		log.Error(err, "Failed to store stream events", "taskID", taskID)
+		return streamed
+	}
+	for j, i := range storedIndexes {
+		streamed[i].id = stored[j].ID
+	}
+	return streamed
+}
+
+// sessionOwnsTask returns whether the task is stored in a session of the user
+func (m *ResumableStreamManager) sessionOwnsTask(taskID, userID string) bool {
+	task, err := m.dbClient.GetTask(taskID)
+	if err != nil {
+		return false
+	}
+	_, err = m.dbClient.GetSession(task.ContextID, userID)
+	return err == nil
+}
+
+// taskOver returns whether the agent has nothing more to stream for the task
+func (m *ResumableStreamManager) taskOver(taskID string, replayed []streamedEvent) bool {
+	if len(replayed) > 0 && api.IsFinalStreamingEvent(replayed[len(replayed)-1].event) {
+		return true
+	}
+	task, err := m.dbClient.GetTask(taskID)
+	return err == nil && api.IsFinalTaskState(task.Status.State)
+}
+
+// prune deletes the expired stream events, at most once an hour
+func (m *ResumableStreamManager) prune(log logr.Logger) {
+	m.lock.Lock()
+	now := m.now()
+	if now.Sub(m.lastPrune) < time.Hour {
+		m.lock.Unlock()
+		return
+	}
+	m.lastPrune = now
+	m.lock.Unlock()
+
+	if err := m.dbClient.DeleteStreamEvents(now.Add(-streamEventRetention)); err != nil {
+		log.Error(err, "Failed to delete expired stream events")
+	}
+}
+
+func (m *ResumableStreamManager) register(taskID string, stream *liveStream) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.streams[taskID] = stream
+}
+
+func (m *ResumableStreamManager) unregister(taskID string, stream *liveStream) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if m.streams[taskID] == stream {
+		delete(m.streams, taskID)
+	}
+}
+
+func (m *ResumableStreamManager) liveStream(taskID string) *liveStream {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return m.streams[taskID]
+}
+
+// detach returns a context carrying the logger, the user and the concurrent slot of a request,
+// for the stream of the agent to outlive the request. The stream is closed with the returned
+// function.
+func detach(ctx context.Context) (context.Context, context.CancelFunc) {
+	detached := ctrllog.IntoContext(context.Background(), ctrllog.FromContext(ctx))
+	if session, ok := auth.AuthSessionFrom(ctx); ok {
+		detached = auth.AuthSessionTo(detached, session)
+	}
+	if lease := ratelimit.LeaseFrom(ctx); lease != nil {
+		detached = ratelimit.WithLease(detached, lease)
+	}
+	return context.WithCancel(detached)
+}
+
+// requestUserID returns the ID of the user of a request, empty if it has none
+func requestUserID(ctx context.Context) string {
+	if session, ok := auth.AuthSessionFrom(ctx); ok {
+		return session.Principal().User.ID
+	}
+	return ""
+}
+
+// streamedEvent is an event streamed by an agent with its ID, 0 if it was not stored
+type streamedEvent struct {
+	id    uint
+	event protocol.StreamingMessageEvent
+}
+
+// liveStream buffers the events of a stream for its subscribers
+type liveStream struct {
+	// userID is the ID of the user who streamed the task
+	userID string
+	// cancel closes the stream of the agent, once it has had no subscribers for detachedTimeout
+	cancel          context.CancelFunc
+	detachedTimeout time.Duration
+
+	lock   sync.Mutex
+	events []streamedEvent
+	// updated is closed when events are added or the stream finishes
+	updated     chan struct{}
+	done        bool
+	subscribers int
+	detached    *time.Timer
+}
+
+func finishedStream(userID string, events []streamedEvent) *liveStream {
+	return &liveStream{userID: userID, events: events, updated: make(chan struct{}), done: true}
+}
+
+func (s *liveStream) add(event streamedEvent) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.events = append(s.events, event)
+	close(s.updated)
+	s.updated = make(chan struct{})
+}
+
+func (s *liveStream) finish() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.done = true
+	close(s.updated)
+	s.updated = make(chan struct{})
+	if s.detached != nil {
+		s.detached.Stop()
+	}
+}
+
+// join counts a subscriber of the stream
+func (s *liveStream) join() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.subscribers++
+	if s.detached != nil {
+		s.detached.Stop()
+		s.detached = nil
+	}
+}
+
+// leave counts a subscriber leaving the stream, closing the stream of the agent if no subscriber
+// joins in time
+func (s *liveStream) leave() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.subscribers--
+	if s.subscribers == 0 && !s.done && s.cancel != nil {
+		s.detached = time.AfterFunc(s.detachedTimeout, s.cancel)
+	}
+}
+
+// next returns the events from the given index, and a channel closed once there are more if
+// the stream is not done
+func (s *liveStream) next(i int) ([]streamedEvent, <-chan struct{}, bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.events[i:], s.updated, s.done
+}
+
+// startIndex returns the index of the first event following the event with the given ID
+func (s *liveStream) startIndex(afterID uint) int {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	start := 0
+	for i, event := range s.events {
+		if event.id != 0 && event.id <= afterID {
+			start = i + 1
+		}
+	}
+	return start
+}
+
+// subscribe sends the events of the stream following the event with the given ID, until the
+// stream finishes or the client disconnects
+func (s *liveStream) subscribe(ctx context.Context, afterID uint) <-chan protocol.StreamingMessageEvent {
+	subscribed := make(chan protocol.StreamingMessageEvent)
+	s.join()
+	go func() {
+		defer close(subscribed)
+		defer s.leave()
+		for i := s.startIndex(afterID); ; {
+			events, updated, done := s.next(i)
+			for _, event := range events {
+				select {
+				case subscribed <- withStreamEventID(event):
+				case <-ctx.Done():
+					return
+				}
+			}
+			i += len(events)
+			if len(events) > 0 {
+				continue
+			}
+			if done {
+				return
+			}
+			select {
+			case <-updated:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return subscribed
+}
+
+// withStreamEventID adds the ID of a stored event to its metadata
+func withStreamEventID(event streamedEvent) protocol.StreamingMessageEvent {
+	if event.id == 0 {
+		return event.event
+	}
+	id := strconv.FormatUint(uint64(event.id), 10)
+	withID := func(metadata map[string]any) map[string]any {
+		metadata = maps.Clone(metadata)
+		if metadata == nil {
+			metadata = make(map[string]any)
+		}
+		metadata[api.StreamEventIDMetadataKey] = id
+		return metadata
+	}
+
+	switch result := event.event.Result.(type) {
+	case *protocol.Message:
+		message := *result
+		message.Metadata = withID(message.Metadata)
+		return protocol.StreamingMessageEvent{Result: &message}
+	case *protocol.Task:
+		task := *result
+		task.Metadata = withID(task.Metadata)
+		return protocol.StreamingMessageEvent{Result: &task}
+	case *protocol.TaskStatusUpdateEvent:
+		update := *result
+		update.Metadata = withID(update.Metadata)
+		return protocol.StreamingMessageEvent{Result: &update}
+	case *protocol.TaskArtifactUpdateEvent:
+		update := *result
+		update.Metadata = withID(update.Metadata)
+		return protocol.StreamingMessageEvent{Result: &update}
+	}
+	return event.event
+}
+
+// lastStreamEventID returns the ID of the last event received by a client resubscribing to a task
+func lastStreamEventID(metadata map[string]any) uint {
+	switch id := metadata[api.LastStreamEventIDMetadataKey].(type) {
+	case string:
+		parsed, _ := strconv.ParseUint(id, 10, 64)
+		return uint(parsed)
+	case float64:
+		return uint(id)
+	}
+	return 0
+}
@@ -0,0 +1,177 @@
+package a2a
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"trpc.group/trpc-go/trpc-a2a-go/protocol"
+	"trpc.group/trpc-go/trpc-a2a-go/taskmanager"
+
+	"github.com/kagent-dev/kagent/go/internal/database"
+	database_fake "github.com/kagent-dev/kagent/go/internal/database/fake"
+	"github.com/kagent-dev/kagent/go/pkg/client/api"
+)
+
+// streamingAgentManager streams the events sent on its channels
+type streamingAgentManager struct {
+	taskmanager.TaskManager
+	stream      chan protocol.StreamingMessageEvent
+	resubscribe chan protocol.StreamingMessageEvent
+	// contexts receives the contexts of the streams, if set
+	contexts chan context.Context
+}
+
+func (m *streamingAgentManager) OnSendMessageStream(ctx context.Context, request protocol.SendMessageParams) (<-chan protocol.StreamingMessageEvent, error) {
+	if m.contexts != nil {
+		m.contexts <- ctx
+	}
+	return m.stream, nil
+}
+
+func (m *streamingAgentManager) OnResubscribe(ctx context.Context, params protocol.TaskIDParams) (<-chan protocol.StreamingMessageEvent, error) {
+	return m.resubscribe, nil
+}
+
+func statusEvent(state protocol.TaskState, final bool) protocol.StreamingMessageEvent {
+	return protocol.StreamingMessageEvent{Result: &protocol.TaskStatusUpdateEvent{
+		Kind:      protocol.KindTaskStatusUpdate,
+		TaskID:    "task-1",
+		ContextID: "context-1",
+		Status:    protocol.TaskStatus{State: state},
+		Final:     final,
+	}}
+}
+
+func streamEventID(t *testing.T, event protocol.StreamingMessageEvent) string {
+	status, ok := event.Result.(*protocol.TaskStatusUpdateEvent)
+	require.True(t, ok)
+	id, _ := status.Metadata[api.StreamEventIDMetadataKey].(string)
+	return id
+}
+
+func collectStates(events <-chan protocol.StreamingMessageEvent) []protocol.TaskState {
+	var states []protocol.TaskState
+	for event := range events {
+		states = append(states, event.Result.(*protocol.TaskStatusUpdateEvent).Status.State)
+	}
+	return states
+}
+
+func TestResumableStreamManagerResumesStreams(t *testing.T) {
+	dbClient := database_fake.NewClient()
+	agent := &streamingAgentManager{stream: make(chan protocol.StreamingMessageEvent)}
+	manager := NewResumableStreamManager(agent, dbClient)
+
+	ctx, disconnect := context.WithCancel(userContext("alice"))
+	events, err := manager.OnSendMessageStream(ctx, newUserMessage(nil, "hi"))
+	require.NoError(t, err)
+
+	agent.stream <- statusEvent(protocol.TaskStateSubmitted, false)
+	lastEventID := streamEventID(t, <-events)
+	require.NotEmpty(t, lastEventID)
+
+	// the agent keeps streaming while the client is disconnected
+	disconnect()
+	agent.stream <- statusEvent(protocol.TaskStateWorking, false)
+
+	resumed, err := manager.OnResubscribe(userContext("alice"), protocol.TaskIDParams{
+		ID:       "task-1",
+		Metadata: map[string]any{api.LastStreamEventIDMetadataKey: lastEventID},
+	})
+	require.NoError(t, err)
+	agent.stream <- statusEvent(protocol.TaskStateCompleted, true)
+	close(agent.stream)
+	assert.Equal(t, []protocol.TaskState{protocol.TaskStateWorking, protocol.TaskStateCompleted}, collectStates(resumed))
+
+	// the events of a finished stream are replayed from the database
+	replayed, err := manager.OnResubscribe(userContext("alice"), protocol.TaskIDParams{ID: "task-1"})
+	require.NoError(t, err)
+	assert.Equal(t, []protocol.TaskState{protocol.TaskStateSubmitted, protocol.TaskStateWorking, protocol.TaskStateCompleted}, collectStates(replayed))
+}
+
+func TestResumableStreamManagerResubscribesToAgent(t *testing.T) {
+	dbClient := database_fake.NewClient()
+	// the controller restarted while the agent was working on the task
+	for _, state := range []protocol.TaskState{protocol.TaskStateSubmitted, protocol.TaskStateWorking} {
+		event := statusEvent(state, false)
+		data, err := event.MarshalJSON()
+		require.NoError(t, err)
+		require.NoError(t, dbClient.StoreStreamEvents(&database.StreamEvent{TaskID: "task-1", UserID: "alice", Data: string(data)}))
+	}
+
+	agent := &streamingAgentManager{resubscribe: make(chan protocol.StreamingMessageEvent, 1)}
+	agent.resubscribe <- statusEvent(protocol.TaskStateCompleted, true)
+	close(agent.resubscribe)
+	manager := NewResumableStreamManager(agent, dbClient)
+
+	events, err := manager.OnResubscribe(userContext("alice"), protocol.TaskIDParams{
+		ID:       "task-1",
+		Metadata: map[string]any{api.LastStreamEventIDMetadataKey: "1"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []protocol.TaskState{protocol.TaskStateWorking, protocol.TaskStateCompleted}, collectStates(events))
+
+	stored, err := dbClient.ListStreamEvents("task-1", "alice", 0)
+	require.NoError(t, err)
+	assert.Len(t, stored, 3)
+}
+
+func TestResumableStreamManagerChecksOwner(t *testing.T) {
+	dbClient := database_fake.NewClient()
+	agent := &streamingAgentManager{stream: make(chan protocol.StreamingMessageEvent)}
+	manager := NewResumableStreamManager(agent, dbClient)
+
+	events, err := manager.OnSendMessageStream(userContext("alice"), newUserMessage(nil, "hi"))
+	require.NoError(t, err)
+	agent.stream <- statusEvent(protocol.TaskStateWorking, false)
+	<-events
+
+	// other users cannot follow the stream in progress
+	_, err = manager.OnResubscribe(userContext("bob"), protocol.TaskIDParams{ID: "task-1"})
+	assert.ErrorIs(t, err, errStreamNotFound)
+
+	agent.stream <- statusEvent(protocol.TaskStateCompleted, true)
+	close(agent.stream)
+	for range events {
+	}
+
+	// nor replay the stored events
+	_, err = manager.OnResubscribe(userContext("bob"), protocol.TaskIDParams{ID: "task-1"})
+	assert.ErrorIs(t, err, errStreamNotFound)
+
+	// the user of the session of the task can resubscribe to it
+	require.NoError(t, dbClient.StoreSession(&database.Session{ID: "context-1", UserID: "bob"}))
+	require.NoError(t, dbClient.StoreTask(&protocol.Task{ID: "task-1", ContextID: "context-1", Status: protocol.TaskStatus{State: protocol.TaskStateCompleted}}))
+	replayed, err := manager.OnResubscribe(userContext("bob"), protocol.TaskIDParams{ID: "task-1"})
+	require.NoError(t, err)
+	assert.Empty(t, collectStates(replayed))
+}
+
+func TestResumableStreamManagerClosesDetachedStreams(t *testing.T) {
+	agent := &streamingAgentManager{
+		stream:   make(chan protocol.StreamingMessageEvent),
+		contexts: make(chan context.Context, 1),
+	}
+	manager := NewResumableStreamManager(agent, database_fake.NewClient()).(*ResumableStreamManager)
+	manager.detachedTimeout = 10 * time.Millisecond
+
+	ctx, disconnect := context.WithCancel(userContext("alice"))
+	events, err := manager.OnSendMessageStream(ctx, newUserMessage(nil, "hi"))
+	require.NoError(t, err)
+	agentCtx := <-agent.contexts
+	assert.Equal(t, "alice", requestUserID(agentCtx))
+
+	agent.stream <- statusEvent(protocol.TaskStateWorking, false)
+	<-events
+
+	// the stream of the agent outlives the request, until no client resubscribes in time
+	disconnect()
+	select {
+	case <-agentCtx.Done():
+	case <-time.After(5 * time.Second):
+		t.Fatal("the stream of the agent was not closed")
+	}
+}
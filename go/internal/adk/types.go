@@ -16,8 +16,9 @@ type StreamableHTTPConnectionParams struct {
 }
 
 type HttpMcpServerConfig struct {
-	Params StreamableHTTPConnectionParams `json:"params"`
-	Tools  []string                       `json:"tools"`
+	Params    StreamableHTTPConnectionParams `json:"params"`
+	Tools     []string                       `json:"tools"`
+	Transport *McpTransportConfig            `json:"transport,omitempty"`
 }
 
 type SseConnectionParams struct {
@@ -28,8 +29,22 @@ type SseConnectionParams struct {
 }
 
 type SseMcpServerConfig struct {
-	Params SseConnectionParams `json:"params"`
-	Tools  []string            `json:"tools"`
+	Params    SseConnectionParams `json:"params"`
+	Tools     []string            `json:"tools"`
+	Transport *McpTransportConfig `json:"transport,omitempty"`
+}
+
+// McpTransportConfig is the TLS and proxy configuration of the connections to a MCP server.
+// The certificates and proxy credentials are inlined, as the agent config is stored in a Secret.
+type McpTransportConfig struct {
+	TLSDisableVerify    bool   `json:"tls_disable_verify,omitempty"`
+	TLSDisableSystemCAs bool   `json:"tls_disable_system_cas,omitempty"`
+	TLSCACert           string `json:"tls_ca_cert,omitempty"`
+	TLSClientCert       string `json:"tls_client_cert,omitempty"`
+	TLSClientKey        string `json:"tls_client_key,omitempty"`
+	// ProxyURL includes the credentials of the proxy
+	ProxyURL string   `json:"proxy_url,omitempty"`
+	NoProxy  []string `json:"no_proxy,omitempty"`
 }
 
 type Model interface {
@@ -45,6 +60,26 @@ type BaseModel struct {
 	TLSDisableVerify    *bool   `json:"tls_disable_verify,omitempty"`
 	TLSCACertPath       *string `json:"tls_ca_cert_path,omitempty"`
 	TLSDisableSystemCAs *bool   `json:"tls_disable_system_cas,omitempty"`
+	TLSClientCertPath   *string `json:"tls_client_cert_path,omitempty"`
+	TLSClientKeyPath    *string `json:"tls_client_key_path,omitempty"`
+
+	// Proxy configuration, the credentials are read from the environment
+	ProxyURL *string  `json:"proxy_url,omitempty"`
+	NoProxy  []string `json:"no_proxy,omitempty"`
+
+	// Capabilities of the model, unset when the runtime should assume it supports everything
+	Capabilities *ModelCapabilities `json:"capabilities,omitempty"`
+
+	// Env maps the variables of the provider the model reads to the variables holding their value
+	// for this model, e.g. when the models of a fallback chain use different API keys
+	Env map[string]string `json:"env,omitempty"`
+}
+
+type ModelCapabilities struct {
+	FunctionCalling bool `json:"function_calling"`
+	Streaming       bool `json:"streaming"`
+	Vision          bool `json:"vision"`
+	JSONMode        bool `json:"json_mode"`
 }
 
 type OpenAI struct {
@@ -62,13 +97,15 @@ type OpenAI struct {
 }
 
 const (
-	ModelTypeOpenAI          = "openai"
-	ModelTypeAzureOpenAI     = "azure_openai"
-	ModelTypeAnthropic       = "anthropic"
-	ModelTypeGeminiVertexAI  = "gemini_vertex_ai"
-	ModelTypeGeminiAnthropic = "gemini_anthropic"
-	ModelTypeOllama          = "ollama"
-	ModelTypeGemini          = "gemini"
+	ModelTypeOpenAI           = "openai"
+	ModelTypeAzureOpenAI      = "azure_openai"
+	ModelTypeAnthropic        = "anthropic"
+	ModelTypeGeminiVertexAI   = "gemini_vertex_ai"
+	ModelTypeGeminiAnthropic  = "gemini_anthropic"
+	ModelTypeOllama           = "ollama"
+	ModelTypeGemini           = "gemini"
+	ModelTypeBedrock          = "bedrock"
+	ModelTypeOpenAICompatible = "openai_compatible"
 )
 
 func (o *OpenAI) MarshalJSON() ([]byte, error) {
@@ -87,8 +124,19 @@ func (o *OpenAI) GetType() string {
 	return ModelTypeOpenAI
 }
 
+// Token credentials an AzureOpenAI model authenticates with instead of an API key
+const (
+	AzureTokenCredentialWorkloadIdentity = "workload_identity"
+	AzureTokenCredentialManagedIdentity  = "managed_identity"
+)
+
 type AzureOpenAI struct {
 	BaseModel
+	// TokenCredential is empty when authenticating with an API key
+	TokenCredential string   `json:"token_credential,omitempty"`
+	MaxTokens       *int     `json:"max_tokens,omitempty"`
+	Temperature     *float64 `json:"temperature,omitempty"`
+	TopP            *float64 `json:"top_p,omitempty"`
 }
 
 func (a *AzureOpenAI) GetType() string {
@@ -96,25 +144,86 @@ func (a *AzureOpenAI) GetType() string {
 }
 
 func (a *AzureOpenAI) MarshalJSON() ([]byte, error) {
-	return json.Marshal(map[string]any{
+	fields := map[string]any{
 		"type":    ModelTypeAzureOpenAI,
 		"model":   a.Model,
 		"headers": a.Headers,
-	})
+	}
+	if a.TokenCredential != "" {
+		fields["token_credential"] = a.TokenCredential
+	}
+	addSamplingFields(fields, a.MaxTokens, a.Temperature, a.TopP, nil)
+	addTransportFields(fields, &a.BaseModel)
+	addEnvField(fields, &a.BaseModel)
+	return json.Marshal(fields)
 }
 
 type Anthropic struct {
 	BaseModel
-	BaseUrl string `json:"base_url"`
+	BaseUrl     string   `json:"base_url"`
+	MaxTokens   *int     `json:"max_tokens,omitempty"`
+	Temperature *float64 `json:"temperature,omitempty"`
+	TopP        *float64 `json:"top_p,omitempty"`
+	TopK        *int     `json:"top_k,omitempty"`
 }
 
 func (a *Anthropic) MarshalJSON() ([]byte, error) {
-	return json.Marshal(map[string]any{
+	fields := map[string]any{
 		"type":     ModelTypeAnthropic,
 		"model":    a.Model,
 		"base_url": a.BaseUrl,
 		"headers":  a.Headers,
-	})
+	}
+	addSamplingFields(fields, a.MaxTokens, a.Temperature, a.TopP, a.TopK)
+	addTransportFields(fields, &a.BaseModel)
+	addEnvField(fields, &a.BaseModel)
+	return json.Marshal(fields)
+}
+
+// addSamplingFields adds the sampling parameters that are set to the fields of a model.
+func addSamplingFields(fields map[string]any, maxTokens *int, temperature, topP *float64, topK *int) {
+	if maxTokens != nil {
+		fields["max_tokens"] = *maxTokens
+	}
+	if temperature != nil {
+		fields["temperature"] = *temperature
+	}
+	if topP != nil {
+		fields["top_p"] = *topP
+	}
+	if topK != nil {
+		fields["top_k"] = *topK
+	}
+}
+
+// addTransportFields adds the TLS and proxy configuration that is set to the fields of a model.
+func addTransportFields(fields map[string]any, base *BaseModel) {
+	for name, value := range map[string]*string{
+		"tls_ca_cert_path":     base.TLSCACertPath,
+		"tls_client_cert_path": base.TLSClientCertPath,
+		"tls_client_key_path":  base.TLSClientKeyPath,
+		"proxy_url":            base.ProxyURL,
+	} {
+		if value != nil {
+			fields[name] = *value
+		}
+	}
+	if base.TLSDisableVerify != nil {
+		fields["tls_disable_verify"] = *base.TLSDisableVerify
+	}
+	if base.TLSDisableSystemCAs != nil {
+		fields["tls_disable_system_cas"] = *base.TLSDisableSystemCAs
+	}
+	if len(base.NoProxy) > 0 {
+		fields["no_proxy"] = base.NoProxy
+	}
+}
+
+// addEnvField adds the variables the model reads instead of those of its provider, if any.
+func addEnvField(fields map[string]any, base *BaseModel) {
+	if len(base.Env) > 0 {
+		fields["env"] = base.Env
+	}
 }
 
 func (a *Anthropic) GetType() string {
@@ -185,6 +294,58 @@ func (g *Gemini) GetType() string {
 	return ModelTypeGemini
 }
 
+type Bedrock struct {
+	BaseModel
+	Region           string   `json:"region"`
+	InferenceProfile string   `json:"inference_profile,omitempty"`
+	GuardrailID      string   `json:"guardrail_id,omitempty"`
+	GuardrailVersion string   `json:"guardrail_version,omitempty"`
+	MaxTokens        *int     `json:"max_tokens,omitempty"`
+	Temperature      *float64 `json:"temperature,omitempty"`
+	TopP             *float64 `json:"top_p,omitempty"`
+}
+
+func (b *Bedrock) MarshalJSON() ([]byte, error) {
+	type Alias Bedrock
+
+	return json.Marshal(&struct {
+		Type string `json:"type"`
+		*Alias
+	}{
+		Type:  ModelTypeBedrock,
+		Alias: (*Alias)(b),
+	})
+}
+
+func (b *Bedrock) GetType() string {
+	return ModelTypeBedrock
+}
+
+type OpenAICompatible struct {
+	BaseModel
+	BaseUrl     string   `json:"base_url"`
+	MaxTokens   *int     `json:"max_tokens,omitempty"`
+	Temperature *float64 `json:"temperature,omitempty"`
+	Timeout     *int     `json:"timeout,omitempty"`
+	TopP        *float64 `json:"top_p,omitempty"`
+}
+
+func (o *OpenAICompatible) MarshalJSON() ([]byte, error) {
+	type Alias OpenAICompatible
+
+	return json.Marshal(&struct {
+		Type string `json:"type"`
+		*Alias
+	}{
+		Type:  ModelTypeOpenAICompatible,
+		Alias: (*Alias)(o),
+	})
+}
+
+func (o *OpenAICompatible) GetType() string {
+	return ModelTypeOpenAICompatible
+}
+
 func ParseModel(bytes []byte) (Model, error) {
 	var model BaseModel
 	if err := json.Unmarshal(bytes, &model); err != nil {
@@ -233,6 +394,18 @@ func ParseModel(bytes []byte) (Model, error) {
 			return nil, err
 		}
 		return &ollama, nil
+	case ModelTypeBedrock:
+		var bedrock Bedrock
+		if err := json.Unmarshal(bytes, &bedrock); err != nil {
+			return nil, err
+		}
+		return &bedrock, nil
+	case ModelTypeOpenAICompatible:
+		var openAICompatible OpenAICompatible
+		if err := json.Unmarshal(bytes, &openAICompatible); err != nil {
+			return nil, err
+		}
+		return &openAICompatible, nil
 	}
 	return nil, fmt.Errorf("unknown model type: %s", model.Type)
 }
@@ -244,25 +417,66 @@ type RemoteAgentConfig struct {
 	Description string            `json:"description,omitempty"`
 }
 
+const (
+	MemoryTypePinecone = "pinecone"
+)
+
+type PineconeMemoryConfig struct {
+	IndexHost      string   `json:"index_host"`
+	TopK           int      `json:"top_k,omitempty"`
+	Namespace      string   `json:"namespace,omitempty"`
+	RecordFields   []string `json:"record_fields,omitempty"`
+	ScoreThreshold *float64 `json:"score_threshold,omitempty"`
+}
+
+type MemoryConfig struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+	// Name of the environment variable holding the memory provider API key
+	ApiKeyEnv string                `json:"api_key_env,omitempty"`
+	Pinecone  *PineconeMemoryConfig `json:"pinecone,omitempty"`
+}
+
+// ModelRetryPolicy is how a failed model call is retried before failing over
+// to the next model
+type ModelRetryPolicy struct {
+	MaxAttempts int `json:"max_attempts"`
+	// Backoffs are in seconds
+	InitialBackoff float64 `json:"initial_backoff"`
+	MaxBackoff     float64 `json:"max_backoff"`
+}
+
 // See `python/packages/kagent-adk/src/kagent/adk/types.py` for the python version of this
 type AgentConfig struct {
-	Model        Model                 `json:"model"`
-	Description  string                `json:"description"`
-	Instruction  string                `json:"instruction"`
-	HttpTools    []HttpMcpServerConfig `json:"http_tools"`
-	SseTools     []SseMcpServerConfig  `json:"sse_tools"`
-	RemoteAgents []RemoteAgentConfig   `json:"remote_agents"`
-	ExecuteCode  bool                  `json:"execute_code,omitempty"`
+	Model Model `json:"model"`
+	// Models tried in order when the model is rate limited, fails or times out
+	FallbackModels []Model               `json:"fallback_models,omitempty"`
+	ModelRetry     *ModelRetryPolicy     `json:"model_retry,omitempty"`
+	Description    string                `json:"description"`
+	Instruction    string                `json:"instruction"`
+	HttpTools      []HttpMcpServerConfig `json:"http_tools"`
+	SseTools       []SseMcpServerConfig  `json:"sse_tools"`
+	RemoteAgents   []RemoteAgentConfig   `json:"remote_agents"`
+	Memory         []MemoryConfig        `json:"memory,omitempty"`
+	ExecuteCode    bool                  `json:"execute_code,omitempty"`
+	// ModelConfigRef is the namespace/name of the ModelConfig of the agent, the runtime
+	// records it with the token usage of the model calls
+	ModelConfigRef string `json:"model_config_ref,omitempty"`
 }
 
 func (a *AgentConfig) UnmarshalJSON(data []byte) error {
 	var tmp struct {
-		Model        json.RawMessage       `json:"model"`
-		Description  string                `json:"description"`
-		Instruction  string                `json:"instruction"`
-		HttpTools    []HttpMcpServerConfig `json:"http_tools"`
-		SseTools     []SseMcpServerConfig  `json:"sse_tools"`
-		RemoteAgents []RemoteAgentConfig   `json:"remote_agents"`
+		Model          json.RawMessage       `json:"model"`
+		FallbackModels []json.RawMessage     `json:"fallback_models,omitempty"`
+		ModelRetry     *ModelRetryPolicy     `json:"model_retry,omitempty"`
+		Description    string                `json:"description"`
+		Instruction    string                `json:"instruction"`
+		HttpTools      []HttpMcpServerConfig `json:"http_tools"`
+		SseTools       []SseMcpServerConfig  `json:"sse_tools"`
+		RemoteAgents   []RemoteAgentConfig   `json:"remote_agents"`
+		Memory         []MemoryConfig        `json:"memory,omitempty"`
+		ExecuteCode    bool                  `json:"execute_code,omitempty"`
+		ModelConfigRef string                `json:"model_config_ref,omitempty"`
 	}
 	if err := json.Unmarshal(data, &tmp); err != nil {
 		return err
@@ -272,11 +486,22 @@ func (a *AgentConfig) UnmarshalJSON(data []byte) error {
 		return err
 	}
 	a.Model = model
+	for _, fallback := range tmp.FallbackModels {
+		model, err := ParseModel(fallback)
+		if err != nil {
+			return err
+		}
+		a.FallbackModels = append(a.FallbackModels, model)
+	}
+	a.ModelRetry = tmp.ModelRetry
 	a.Description = tmp.Description
 	a.Instruction = tmp.Instruction
 	a.HttpTools = tmp.HttpTools
 	a.SseTools = tmp.SseTools
 	a.RemoteAgents = tmp.RemoteAgents
+	a.Memory = tmp.Memory
+	a.ExecuteCode = tmp.ExecuteCode
+	a.ModelConfigRef = tmp.ModelConfigRef
 	return nil
 }
 
@@ -34,9 +34,12 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
+	kagentv1alpha1 "github.com/kagent-dev/kagent/go/api/v1alpha1"
 	"github.com/kagent-dev/kagent/go/api/v1alpha2"
+	"github.com/kagent-dev/kagent/go/internal/controller/predicates"
 	"github.com/kagent-dev/kagent/go/internal/controller/reconciler"
 	agent_translator "github.com/kagent-dev/kagent/go/internal/controller/translator/agent"
+	"github.com/kagent-dev/kagent/go/internal/utils"
 	"github.com/kagent-dev/kmcp/api/v1alpha1"
 )
 
@@ -54,14 +57,16 @@ type AgentController struct {
 // +kubebuilder:rbac:groups=kagent.dev,resources=agents,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=kagent.dev,resources=agents/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=kagent.dev,resources=agents/finalizers,verbs=update
+// +kubebuilder:rbac:groups=kagent.dev,resources=memories,verbs=get;list;watch
 // +kubebuilder:rbac:groups=core,resources=secrets,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=core,resources=serviceaccounts,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=core,resources=configmaps,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=core,resources=pods,verbs=get;list
 
 func (r *AgentController) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	_ = log.FromContext(ctx)
-	return ctrl.Result{}, r.Reconciler.ReconcileKagentAgent(ctx, req)
+	return r.Reconciler.ReconcileKagentAgent(ctx, req)
 }
 
 // SetupWithManager sets up the controller with the Manager.
@@ -119,6 +124,28 @@ func (r *AgentController) SetupWithManager(mgr ctrl.Manager) error {
 
 				return requests
 			}),
+			builder.WithPredicates(predicates.RemoteMCPServerChangedPredicate{}),
+		).
+		Watches(
+			&v1alpha2.RemoteAgent{},
+			handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, obj client.Object) []reconcile.Request {
+				requests := []reconcile.Request{}
+
+				for _, agent := range r.findAgentsUsingRemoteAgent(ctx, mgr.GetClient(), types.NamespacedName{
+					Name:      obj.GetName(),
+					Namespace: obj.GetNamespace(),
+				}) {
+					requests = append(requests, reconcile.Request{
+						NamespacedName: types.NamespacedName{
+							Name:      agent.Name,
+							Namespace: agent.Namespace,
+						},
+					})
+				}
+
+				return requests
+			}),
+			builder.WithPredicates(predicate.GenerationChangedPredicate{}),
 		).
 		Watches(
 			&corev1.Service{},
@@ -140,6 +167,50 @@ func (r *AgentController) SetupWithManager(mgr ctrl.Manager) error {
 				return requests
 			}),
 			builder.WithPredicates(predicate.ResourceVersionChangedPredicate{}),
+		).
+		Watches(
+			&kagentv1alpha1.Memory{},
+			handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, obj client.Object) []reconcile.Request {
+				requests := []reconcile.Request{}
+
+				for _, agent := range r.findAgentsUsingMemory(ctx, mgr.GetClient(), types.NamespacedName{
+					Name:      obj.GetName(),
+					Namespace: obj.GetNamespace(),
+				}) {
+					requests = append(requests, reconcile.Request{
+						NamespacedName: types.NamespacedName{
+							Name:      agent.Name,
+							Namespace: agent.Namespace,
+						},
+					})
+				}
+
+				return requests
+			}),
+			builder.WithPredicates(predicate.GenerationChangedPredicate{}),
+		).
+		Watches(
+			// the certificates and proxy credentials of MCP servers are inlined in the agent config,
+			// agents are translated again when they are rotated
+			&corev1.Secret{},
+			handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, obj client.Object) []reconcile.Request {
+				requests := []reconcile.Request{}
+
+				for _, agent := range r.findAgentsUsingTransportSecret(ctx, mgr.GetClient(), types.NamespacedName{
+					Name:      obj.GetName(),
+					Namespace: obj.GetNamespace(),
+				}) {
+					requests = append(requests, reconcile.Request{
+						NamespacedName: types.NamespacedName{
+							Name:      agent.Name,
+							Namespace: agent.Namespace,
+						},
+					})
+				}
+
+				return requests
+			}),
+			builder.WithPredicates(predicate.ResourceVersionChangedPredicate{}),
 		)
 
 	if _, err := mgr.GetRESTMapper().RESTMapping(mcpServerGK); err == nil {
@@ -181,10 +252,6 @@ func (r *AgentController) findAgentsUsingMCPServer(ctx context.Context, cl clien
 
 	var agents []*v1alpha2.Agent
 	for _, agent := range agentsList.Items {
-		if agent.Namespace != obj.Namespace {
-			continue
-		}
-
 		if agent.Spec.Type != v1alpha2.AgentType_Declarative {
 			continue
 		}
@@ -198,8 +265,9 @@ func (r *AgentController) findAgentsUsingMCPServer(ctx context.Context, cl clien
 				continue
 			}
 
-			if tool.McpServer.Name == obj.Name {
+			if refersTo(tool.McpServer.Name, agent.Namespace, obj) {
 				agents = append(agents, &agent)
+				break
 			}
 		}
 	}
@@ -226,14 +294,10 @@ func (r *AgentController) findAgentsUsingRemoteMCPServer(ctx context.Context, cl
 
 		for _, tool := range agent.Spec.Declarative.Tools {
 			if tool.McpServer == nil {
-				return
-			}
-
-			if agent.Namespace != obj.Namespace {
 				continue
 			}
 
-			if tool.McpServer.Name == obj.Name {
+			if refersTo(tool.McpServer.Name, agent.Namespace, obj) {
 				agents = append(agents, agent)
 				return
 			}
@@ -247,22 +311,90 @@ func (r *AgentController) findAgentsUsingRemoteMCPServer(ctx context.Context, cl
 	return agents
 }
 
-func (r *AgentController) findAgentsUsingMCPService(ctx context.Context, cl client.Client, obj types.NamespacedName) []*v1alpha2.Agent {
+func (r *AgentController) findAgentsUsingRemoteAgent(ctx context.Context, cl client.Client, obj types.NamespacedName) []*v1alpha2.Agent {
+	var agents []*v1alpha2.Agent
+
 	var agentsList v1alpha2.AgentList
 	if err := cl.List(
 		ctx,
 		&agentsList,
 	); err != nil {
-		agentControllerLog.Error(err, "failed to list agents in order to reconcile MCPService update")
+		agentControllerLog.Error(err, "failed to list Agents in order to reconcile RemoteAgent update")
+		return agents
+	}
+
+	for i := range agentsList.Items {
+		agent := &agentsList.Items[i]
+		if agent.Spec.Type != v1alpha2.AgentType_Declarative || agent.Spec.Declarative == nil {
+			continue
+		}
+
+		for _, tool := range agent.Spec.Declarative.Tools {
+			if tool.Agent == nil || tool.Agent.Kind != "RemoteAgent" {
+				continue
+			}
+
+			if refersTo(tool.Agent.Name, agent.Namespace, obj) {
+				agents = append(agents, agent)
+				break
+			}
+		}
+	}
+
+	return agents
+}
+
+// findAgentsUsingTransportSecret finds the agents using a RemoteMCPServer whose TLS or proxy
+// configuration references the Secret
+func (r *AgentController) findAgentsUsingTransportSecret(ctx context.Context, cl client.Client, obj types.NamespacedName) []*v1alpha2.Agent {
+	var serversList v1alpha2.RemoteMCPServerList
+	if err := cl.List(
+		ctx,
+		&serversList,
+		client.InNamespace(obj.Namespace),
+	); err != nil {
+		agentControllerLog.Error(err, "failed to list RemoteMCPServers in order to reconcile Secret update")
 		return nil
 	}
 
 	var agents []*v1alpha2.Agent
-	for _, agent := range agentsList.Items {
-		if agent.Namespace != obj.Namespace {
+	seen := map[types.NamespacedName]bool{}
+	for i := range serversList.Items {
+		server := &serversList.Items[i]
+		if !remoteMCPServerReferencesSecret(server, obj.Name) {
 			continue
 		}
+		for _, agent := range r.findAgentsUsingRemoteMCPServer(ctx, cl, types.NamespacedName{Namespace: server.Namespace, Name: server.Name}) {
+			key := types.NamespacedName{Namespace: agent.Namespace, Name: agent.Name}
+			if !seen[key] {
+				seen[key] = true
+				agents = append(agents, agent)
+			}
+		}
+	}
+
+	return agents
+}
+
+func remoteMCPServerReferencesSecret(server *v1alpha2.RemoteMCPServer, secretName string) bool {
+	if tls := server.Spec.TLS; tls != nil && (tls.CACertSecretRef == secretName || tls.ClientCertSecretRef == secretName) {
+		return true
+	}
+	return server.Spec.Proxy != nil && server.Spec.Proxy.CredentialsSecretRef == secretName
+}
+
+func (r *AgentController) findAgentsUsingMCPService(ctx context.Context, cl client.Client, obj types.NamespacedName) []*v1alpha2.Agent {
+	var agentsList v1alpha2.AgentList
+	if err := cl.List(
+		ctx,
+		&agentsList,
+	); err != nil {
+		agentControllerLog.Error(err, "failed to list agents in order to reconcile MCPService update")
+		return nil
+	}
 
+	var agents []*v1alpha2.Agent
+	for _, agent := range agentsList.Items {
 		if agent.Spec.Type != v1alpha2.AgentType_Declarative {
 			continue
 		}
@@ -276,8 +408,9 @@ func (r *AgentController) findAgentsUsingMCPService(ctx context.Context, cl clie
 				continue
 			}
 
-			if tool.McpServer.Name == obj.Name {
+			if refersTo(tool.McpServer.Name, agent.Namespace, obj) {
 				agents = append(agents, &agent)
+				break
 			}
 		}
 	}
@@ -297,6 +430,12 @@ func (r *AgentController) findAgentsUsingModelConfig(ctx context.Context, cl cli
 		return agents
 	}
 
+	// agents using a fallback chain that contains the model config are affected too
+	models := map[string]bool{obj.Name: true}
+	for _, model := range findFallbackModelsUsingModel(ctx, cl, obj) {
+		models[model.Name] = true
+	}
+
 	for i := range agentsList.Items {
 		agent := &agentsList.Items[i]
 		// Must be in the same namespace as the model config
@@ -308,7 +447,7 @@ func (r *AgentController) findAgentsUsingModelConfig(ctx context.Context, cl cli
 			continue
 		}
 
-		if agent.Spec.Declarative.ModelConfig == obj.Name {
+		if models[agent.Spec.Declarative.ModelConfig] {
 			agents = append(agents, agent)
 		}
 	}
@@ -316,6 +455,51 @@ func (r *AgentController) findAgentsUsingModelConfig(ctx context.Context, cl cli
 	return agents
 }
 
+func (r *AgentController) findAgentsUsingMemory(ctx context.Context, cl client.Client, obj types.NamespacedName) []*v1alpha2.Agent {
+	var agents []*v1alpha2.Agent
+
+	var agentsList v1alpha2.AgentList
+	if err := cl.List(
+		ctx,
+		&agentsList,
+	); err != nil {
+		agentControllerLog.Error(err, "failed to list Agents in order to reconcile Memory update")
+		return agents
+	}
+
+	for i := range agentsList.Items {
+		agent := &agentsList.Items[i]
+		if agent.Spec.Type != v1alpha2.AgentType_Declarative || agent.Spec.Declarative == nil {
+			continue
+		}
+
+		for _, memoryRef := range agent.Spec.Declarative.Memory {
+			// Memory references may point to another namespace
+			ref, err := utils.ParseRefString(memoryRef, agent.Namespace)
+			if err != nil {
+				continue
+			}
+
+			if ref == obj {
+				agents = append(agents, agent)
+				break
+			}
+		}
+	}
+
+	return agents
+}
+
+// refersTo reports whether ref, made from an object in namespace, points at obj.
+// Refs are either "<name>" in the same namespace or "<namespace>/<name>".
+func refersTo(ref, namespace string, obj types.NamespacedName) bool {
+	namespacedName, err := utils.ParseRefString(ref, namespace)
+	if err != nil {
+		return false
+	}
+	return namespacedName == obj
+}
+
 type ownedObjectPredicate = typedOwnedObjectPredicate[client.Object]
 
 type typedOwnedObjectPredicate[object metav1.Object] struct {
@@ -18,6 +18,7 @@ package controller
 
 import (
 	"context"
+	"slices"
 
 	"github.com/kagent-dev/kagent/go/internal/controller/reconciler"
 
@@ -54,7 +55,7 @@ type ModelConfigController struct {
 
 func (r *ModelConfigController) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	_ = log.FromContext(ctx)
-	return ctrl.Result{}, r.Reconciler.ReconcileKagentModelConfig(ctx, req)
+	return r.Reconciler.ReconcileKagentModelConfig(ctx, req)
 }
 
 // SetupWithManager sets up the controller with the Manager.
@@ -63,7 +64,7 @@ func (r *ModelConfigController) SetupWithManager(mgr ctrl.Manager) error {
 		WithOptions(controller.Options{
 			NeedLeaderElection: ptr.To(true),
 		}).
-		For(&v1alpha2.ModelConfig{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		For(&v1alpha2.ModelConfig{}, builder.WithPredicates(predicate.Or(predicate.GenerationChangedPredicate{}, predicate.AnnotationChangedPredicate{}))).
 		Watches(
 			&corev1.Secret{},
 			handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, obj client.Object) []reconcile.Request {
@@ -85,6 +86,27 @@ func (r *ModelConfigController) SetupWithManager(mgr ctrl.Manager) error {
 			}),
 			builder.WithPredicates(predicate.ResourceVersionChangedPredicate{}),
 		).
+		Watches(
+			&v1alpha2.ModelConfig{},
+			handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, obj client.Object) []reconcile.Request {
+				requests := []reconcile.Request{}
+
+				for _, model := range findFallbackModelsUsingModel(ctx, mgr.GetClient(), types.NamespacedName{
+					Name:      obj.GetName(),
+					Namespace: obj.GetNamespace(),
+				}) {
+					requests = append(requests, reconcile.Request{
+						NamespacedName: types.NamespacedName{
+							Name:      model.ObjectMeta.Name,
+							Namespace: model.ObjectMeta.Namespace,
+						},
+					})
+				}
+
+				return requests
+			}),
+			builder.WithPredicates(predicate.ResourceVersionChangedPredicate{}),
+		).
 		Named("modelconfig").
 		Complete(r)
 }
@@ -112,6 +134,40 @@ func (r *ModelConfigController) findModelsUsingSecret(ctx context.Context, cl cl
 	return models
 }
 
+// findFallbackModelsUsingModel returns the Fallback ModelConfigs that fail over to the
+// ModelConfig, directly or through other Fallback ModelConfigs.
+func findFallbackModelsUsingModel(ctx context.Context, cl client.Client, obj types.NamespacedName) []*v1alpha2.ModelConfig {
+	var models []*v1alpha2.ModelConfig
+
+	var modelsList v1alpha2.ModelConfigList
+	if err := cl.List(
+		ctx,
+		&modelsList,
+		client.InNamespace(obj.Namespace),
+	); err != nil {
+		modelConfigControllerLog.Error(err, "failed to list ModelConfigs in order to reconcile ModelConfig update")
+		return models
+	}
+
+	used := map[string]bool{obj.Name: true}
+	for found := true; found; {
+		found = false
+		for i := range modelsList.Items {
+			model := &modelsList.Items[i]
+			if used[model.Name] || model.Spec.Fallback == nil {
+				continue
+			}
+			if slices.ContainsFunc(model.Spec.Fallback.Models, func(name string) bool { return used[name] }) {
+				used[model.Name] = true
+				models = append(models, model)
+				found = true
+			}
+		}
+	}
+
+	return models
+}
+
 func modelReferencesSecret(model *v1alpha2.ModelConfig, secretObj types.NamespacedName) bool {
 	// secrets must be in the same namespace as the model
 	if model.Namespace != secretObj.Namespace {
@@ -123,8 +179,13 @@ func modelReferencesSecret(model *v1alpha2.ModelConfig, secretObj types.Namespac
 		return true
 	}
 
-	// check if secret is referenced as a TLS CA certificate
-	if model.Spec.TLS != nil && model.Spec.TLS.CACertSecretRef != "" && model.Spec.TLS.CACertSecretRef == secretObj.Name {
+	// check if secret is referenced as a TLS CA or client certificate
+	if model.Spec.TLS != nil && (model.Spec.TLS.CACertSecretRef == secretObj.Name || model.Spec.TLS.ClientCertSecretRef == secretObj.Name) {
+		return true
+	}
+
+	// check if secret is referenced as proxy credentials
+	if model.Spec.Proxy != nil && model.Spec.Proxy.CredentialsSecretRef == secretObj.Name {
 		return true
 	}
 
@@ -0,0 +1,57 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package predicates
+
+import (
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	"github.com/kagent-dev/kagent/go/api/v1alpha2"
+)
+
+// RemoteMCPServerChangedPredicate filters out the status updates of a RemoteMCPServer that
+// only record a successful health check. Agents depend on its spec, discovered tools and readiness.
+type RemoteMCPServerChangedPredicate struct {
+	predicate.Funcs
+}
+
+func (RemoteMCPServerChangedPredicate) Update(e event.UpdateEvent) bool {
+	oldServer, ok := e.ObjectOld.(*v1alpha2.RemoteMCPServer)
+	if !ok {
+		return true
+	}
+	newServer, ok := e.ObjectNew.(*v1alpha2.RemoteMCPServer)
+	if !ok {
+		return true
+	}
+
+	return oldServer.Generation != newServer.Generation ||
+		!equality.Semantic.DeepEqual(oldServer.Annotations, newServer.Annotations) ||
+		!equality.Semantic.DeepEqual(oldServer.Status.DiscoveredTools, newServer.Status.DiscoveredTools) ||
+		readyStatus(oldServer) != readyStatus(newServer)
+}
+
+func readyStatus(server *v1alpha2.RemoteMCPServer) metav1.ConditionStatus {
+	condition := meta.FindStatusCondition(server.Status.Conditions, v1alpha2.RemoteMCPServerConditionTypeReady)
+	if condition == nil {
+		return metav1.ConditionUnknown
+	}
+	return condition.Status
+}
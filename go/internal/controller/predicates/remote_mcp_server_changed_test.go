@@ -0,0 +1,88 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package predicates
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	"github.com/kagent-dev/kagent/go/api/v1alpha2"
+)
+
+func TestRemoteMCPServerChangedPredicate(t *testing.T) {
+	server := func(mutate func(*v1alpha2.RemoteMCPServer)) *v1alpha2.RemoteMCPServer {
+		s := &v1alpha2.RemoteMCPServer{
+			ObjectMeta: metav1.ObjectMeta{Name: "toolserver", Namespace: "test", Generation: 1},
+			Status: v1alpha2.RemoteMCPServerStatus{
+				Conditions: []metav1.Condition{
+					{Type: v1alpha2.RemoteMCPServerConditionTypeReady, Status: metav1.ConditionTrue},
+				},
+				DiscoveredTools:   []*v1alpha2.MCPTool{{Name: "get"}},
+				LastConnectedTime: &metav1.Time{Time: time.Unix(0, 0)},
+			},
+		}
+		if mutate != nil {
+			mutate(s)
+		}
+		return s
+	}
+
+	tests := []struct {
+		name     string
+		newObj   *v1alpha2.RemoteMCPServer
+		expected bool
+	}{
+		{
+			name: "health check only",
+			newObj: server(func(s *v1alpha2.RemoteMCPServer) {
+				s.Status.LastConnectedTime = &metav1.Time{Time: time.Unix(30, 0)}
+				s.Status.PingLatency = &metav1.Duration{Duration: time.Millisecond}
+			}),
+			expected: false,
+		},
+		{
+			name:     "spec changed",
+			newObj:   server(func(s *v1alpha2.RemoteMCPServer) { s.Generation = 2 }),
+			expected: true,
+		},
+		{
+			name: "tools changed",
+			newObj: server(func(s *v1alpha2.RemoteMCPServer) {
+				s.Status.DiscoveredTools = append(s.Status.DiscoveredTools, &v1alpha2.MCPTool{Name: "list"})
+			}),
+			expected: true,
+		},
+		{
+			name: "became unreachable",
+			newObj: server(func(s *v1alpha2.RemoteMCPServer) {
+				s.Status.Conditions[0].Status = metav1.ConditionFalse
+			}),
+			expected: true,
+		},
+	}
+
+	p := RemoteMCPServerChangedPredicate{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, p.Update(event.UpdateEvent{ObjectOld: server(nil), ObjectNew: tt.newObj}))
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package reconciler
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	"github.com/kagent-dev/kagent/go/api/v1alpha2"
+	agent_translator "github.com/kagent-dev/kagent/go/internal/controller/translator/agent"
+	"github.com/kagent-dev/kagent/go/internal/utils"
+)
+
+// finalizeAgent applies the retention policy of a deleted agent and then releases
+// it. Every step can be repeated, so a cleanup that was interrupted, for example by
+// a controller restart, is simply run again on the next reconcile.
+func (a *kagentReconciler) finalizeAgent(ctx context.Context, agent *v1alpha2.Agent) error {
+	if !controllerutil.ContainsFinalizer(agent, v1alpha2.AgentCleanupFinalizer) {
+		return nil
+	}
+
+	if err := a.cleanupAgent(ctx, agent); err != nil {
+		return fmt.Errorf("failed to clean up agent %s: %w", utils.GetObjectRef(agent), err)
+	}
+
+	controllerutil.RemoveFinalizer(agent, v1alpha2.AgentCleanupFinalizer)
+	if err := a.kube.Update(ctx, agent); err != nil {
+		return client.IgnoreNotFound(fmt.Errorf("failed to remove finalizer from agent %s: %w", utils.GetObjectRef(agent), err))
+	}
+
+	reconcileLog.Info("Agent was cleaned up", "agent", utils.GetObjectRef(agent), "retentionPolicy", agent.Spec.RetentionPolicy)
+	return nil
+}
+
+// cleanupAgent deletes or archives the sessions of the agent and the tools of the
+// MCP services it owns.
+func (a *kagentReconciler) cleanupAgent(ctx context.Context, agent *v1alpha2.Agent) error {
+	if agent.Spec.RetentionPolicy == v1alpha2.AgentRetentionPolicy_Keep {
+		return nil
+	}
+	archive := agent.Spec.RetentionPolicy == v1alpha2.AgentRetentionPolicy_Archive
+
+	services, err := a.ownedMCPServices(ctx, agent)
+	if err != nil {
+		return err
+	}
+	groupKind := schema.GroupKind{Group: "", Kind: "Service"}.String()
+	for _, service := range services {
+		if err := a.dbClient.DeleteToolServerData(utils.GetObjectRef(&service), groupKind, archive); err != nil {
+			return fmt.Errorf("failed to delete tools for mcp service %s: %w", utils.GetObjectRef(&service), err)
+		}
+	}
+
+	agentID := utils.ConvertToPythonIdentifier(utils.GetObjectRef(agent))
+	if err := a.dbClient.DeleteAgentData(agentID, archive); err != nil {
+		return fmt.Errorf("failed to delete sessions of agent: %w", err)
+	}
+	return nil
+}
+
+// ownedMCPServices returns the MCP services that have the agent as an owner.
+func (a *kagentReconciler) ownedMCPServices(ctx context.Context, agent *v1alpha2.Agent) ([]corev1.Service, error) {
+	services := &corev1.ServiceList{}
+	if err := a.kube.List(ctx, services,
+		client.InNamespace(agent.Namespace),
+		client.MatchingLabels{agent_translator.MCPServiceLabel: "true"},
+	); err != nil {
+		return nil, fmt.Errorf("failed to list mcp services: %w", err)
+	}
+
+	var owned []corev1.Service
+	for _, service := range services.Items {
+		for _, ref := range service.OwnerReferences {
+			if ref.UID == agent.UID {
+				owned = append(owned, service)
+				break
+			}
+		}
+	}
+	return owned, nil
+}
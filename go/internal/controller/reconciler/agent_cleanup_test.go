@@ -0,0 +1,128 @@
+package reconciler
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"trpc.group/trpc-go/trpc-a2a-go/protocol"
+
+	"github.com/kagent-dev/kagent/go/api/v1alpha2"
+	agent_translator "github.com/kagent-dev/kagent/go/internal/controller/translator/agent"
+	"github.com/kagent-dev/kagent/go/internal/database"
+	fakedb "github.com/kagent-dev/kagent/go/internal/database/fake"
+	"github.com/kagent-dev/kagent/go/internal/utils"
+)
+
+func TestFinalizeAgent(t *testing.T) {
+	serviceGroupKind := schema.GroupKind{Group: "", Kind: "Service"}.String()
+	mcpService := func(name string, owners ...metav1.OwnerReference) *corev1.Service {
+		return &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            name,
+				Namespace:       "test",
+				Labels:          map[string]string{agent_translator.MCPServiceLabel: "true"},
+				OwnerReferences: owners,
+			},
+		}
+	}
+
+	tests := []struct {
+		name            string
+		retentionPolicy v1alpha2.AgentRetentionPolicy
+		expectDeleted   bool
+	}{
+		{name: "default deletes", expectDeleted: true},
+		{name: "archive", retentionPolicy: v1alpha2.AgentRetentionPolicy_Archive, expectDeleted: true},
+		{name: "keep", retentionPolicy: v1alpha2.AgentRetentionPolicy_Keep},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			agent := &v1alpha2.Agent{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              "agent",
+					Namespace:         "test",
+					UID:               "agent-uid",
+					Finalizers:        []string{v1alpha2.AgentCleanupFinalizer},
+					DeletionTimestamp: ptr.To(metav1.Now()),
+				},
+				Spec: v1alpha2.AgentSpec{RetentionPolicy: tt.retentionPolicy},
+			}
+			owner := metav1.OwnerReference{APIVersion: "kagent.dev/v1alpha2", Kind: "Agent", Name: "agent", UID: "agent-uid"}
+
+			scheme := runtime.NewScheme()
+			require.NoError(t, clientgoscheme.AddToScheme(scheme))
+			require.NoError(t, v1alpha2.AddToScheme(scheme))
+			kubeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+				agent,
+				mcpService("owned", owner),
+				mcpService("shared"),
+			).Build()
+
+			dbClient := fakedb.NewClient()
+			agentID := utils.ConvertToPythonIdentifier("test/agent")
+			otherAgentID := utils.ConvertToPythonIdentifier("test/other")
+			require.NoError(t, dbClient.StoreAgent(&database.Agent{ID: agentID}))
+			require.NoError(t, dbClient.StoreSession(&database.Session{ID: "session", UserID: "user", AgentID: &agentID}))
+			require.NoError(t, dbClient.StoreSession(&database.Session{ID: "other-session", UserID: "user", AgentID: &otherAgentID}))
+			require.NoError(t, dbClient.StoreTask(&protocol.Task{ID: "task", ContextID: "session"}))
+			require.NoError(t, dbClient.StoreTask(&protocol.Task{ID: "other-task", ContextID: "other-session"}))
+			require.NoError(t, dbClient.StorePushNotification(&protocol.TaskPushNotificationConfig{TaskID: "task"}))
+			require.NoError(t, dbClient.StoreEvents(&database.Event{ID: "event", SessionID: "session", UserID: "user"}))
+			for _, server := range []string{"test/owned", "test/shared"} {
+				_, err := dbClient.StoreToolServer(&database.ToolServer{Name: server, GroupKind: serviceGroupKind})
+				require.NoError(t, err)
+				require.NoError(t, dbClient.RefreshToolsForServer(server, serviceGroupKind, &v1alpha2.MCPTool{Name: server + "/tool"}))
+			}
+
+			a := &kagentReconciler{kube: kubeClient, dbClient: dbClient}
+			require.NoError(t, a.finalizeAgent(context.Background(), agent))
+
+			// the fake client deletes the agent once its last finalizer is removed
+			err := kubeClient.Get(context.Background(), client.ObjectKeyFromObject(agent), &v1alpha2.Agent{})
+			assert.True(t, apierrors.IsNotFound(err))
+
+			// running the cleanup again, as after a restart, is a no-op
+			require.NoError(t, a.cleanupAgent(context.Background(), agent))
+
+			_, err = dbClient.GetAgent(agentID)
+			assert.Equal(t, tt.expectDeleted, err != nil)
+			sessions, err := dbClient.ListSessions("user")
+			require.NoError(t, err)
+			if tt.expectDeleted {
+				require.Len(t, sessions, 1)
+				assert.Equal(t, "other-session", sessions[0].ID)
+			} else {
+				assert.Len(t, sessions, 2)
+			}
+			_, err = dbClient.GetTask("task")
+			assert.Equal(t, tt.expectDeleted, err != nil)
+			_, err = dbClient.GetTask("other-task")
+			assert.NoError(t, err)
+			pushNotification, err := dbClient.GetPushNotification("task", "")
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectDeleted, pushNotification == nil)
+			events, err := dbClient.ListEventsForSession("session", "user", database.QueryOptions{})
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectDeleted, len(events) == 0)
+
+			ownedTools, err := dbClient.ListToolsForServer("test/owned", serviceGroupKind)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectDeleted, len(ownedTools) == 0)
+			sharedTools, err := dbClient.ListToolsForServer("test/shared", serviceGroupKind)
+			require.NoError(t, err)
+			assert.Len(t, sharedTools, 1)
+		})
+	}
+}
@@ -0,0 +1,219 @@
+package reconciler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"trpc.group/trpc-go/trpc-a2a-go/protocol"
+
+	"github.com/kagent-dev/kagent/go/api/v1alpha2"
+	agent_translator "github.com/kagent-dev/kagent/go/internal/controller/translator/agent"
+	"github.com/kagent-dev/kagent/go/internal/utils"
+)
+
+const (
+	// agentHealthCheckInterval is how often the readiness of agents is checked again
+	agentHealthCheckInterval = 30 * time.Second
+	agentCardProbeTimeout    = 5 * time.Second
+)
+
+// agentReadyCondition computes the Ready condition of an agent from its deployment
+// and pods and, in the Runtime readiness mode, from its agent card and model.
+func (a *kagentReconciler) agentReadyCondition(ctx context.Context, agent *v1alpha2.Agent) metav1.Condition {
+	condition := metav1.Condition{
+		Type:               v1alpha2.AgentConditionTypeReady,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: agent.Generation,
+	}
+
+	deployment := &appsv1.Deployment{}
+	if err := a.kube.Get(ctx, types.NamespacedName{Namespace: agent.Namespace, Name: agent.Name}, deployment); err != nil {
+		condition.Status = metav1.ConditionUnknown
+		condition.Reason = v1alpha2.AgentReadyReasonDeploymentNotFound
+		condition.Message = err.Error()
+		return condition
+	}
+
+	replicas := int32(1)
+	if deployment.Spec.Replicas != nil {
+		replicas = *deployment.Spec.Replicas
+	}
+	if deployment.Status.AvailableReplicas < replicas {
+		condition.Reason = v1alpha2.AgentReadyReasonDeploymentNotReady
+		condition.Message = fmt.Sprintf("Deployment is not ready, %d/%d pods are ready", deployment.Status.AvailableReplicas, replicas)
+		if reason, message := a.podFailure(ctx, deployment); reason != "" {
+			condition.Reason = reason
+			condition.Message = message
+		}
+		return condition
+	}
+
+	if unavailable := a.unavailableToolServers(ctx, agent); len(unavailable) > 0 {
+		condition.Reason = v1alpha2.AgentReadyReasonToolServerUnavailable
+		condition.Message = fmt.Sprintf("Tool servers are not reachable: %s", strings.Join(unavailable, ", "))
+		return condition
+	}
+
+	if agent.Spec.ReadinessMode == v1alpha2.AgentReadinessMode_Runtime {
+		if message := a.modelAuthFailure(ctx, agent); message != "" {
+			condition.Reason = v1alpha2.AgentReadyReasonModelAuthFailed
+			condition.Message = message
+			return condition
+		}
+
+		// the card is served by the mux without reaching the agent, which is probed as well
+		if err := a.probeAgentCard(ctx, fmt.Sprintf("%s/%s/%s", a.a2aURL, agent.Namespace, agent.Name)); err != nil {
+			condition.Reason = v1alpha2.AgentReadyReasonAgentCardUnavailable
+			condition.Message = fmt.Sprintf("Agent is not served by the controller: %v", err)
+			return condition
+		}
+		if err := a.probeAgentCard(ctx, agent_translator.GetA2AAgentCard(agent).URL); err != nil {
+			condition.Reason = v1alpha2.AgentReadyReasonAgentCardUnavailable
+			condition.Message = err.Error()
+			return condition
+		}
+
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = v1alpha2.AgentReadyReasonAgentReady
+		condition.Message = "Agent card is served"
+		return condition
+	}
+
+	condition.Status = metav1.ConditionTrue
+	condition.Reason = v1alpha2.AgentReadyReasonDeploymentReady
+	condition.Message = "Deployment is ready"
+	return condition
+}
+
+// agentRequeueAfter returns when the readiness of an agent should be checked again, or 0 when
+// watching its deployment is enough. The pods of an agent that is not ready yet and, in the
+// Runtime readiness mode, its agent card and model are not watched and are checked periodically.
+func agentRequeueAfter(agent *v1alpha2.Agent) time.Duration {
+	if agent.Spec.ReadinessMode == v1alpha2.AgentReadinessMode_Runtime ||
+		!meta.IsStatusConditionTrue(agent.Status.Conditions, v1alpha2.AgentConditionTypeReady) {
+		return agentHealthCheckInterval
+	}
+	return 0
+}
+
+// podFailure looks for pods of the deployment that are crash looping or
+// cannot pull their image.
+func (a *kagentReconciler) podFailure(ctx context.Context, deployment *appsv1.Deployment) (string, string) {
+	if deployment.Spec.Selector == nil {
+		return "", ""
+	}
+	selector, err := metav1.LabelSelectorAsSelector(deployment.Spec.Selector)
+	if err != nil {
+		return "", ""
+	}
+
+	pods := &corev1.PodList{}
+	if err := a.kube.List(ctx, pods, client.InNamespace(deployment.Namespace), client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		reconcileLog.Error(err, "failed to list pods of agent", "deployment", utils.GetObjectRef(deployment))
+		return "", ""
+	}
+
+	for _, pod := range pods.Items {
+		for _, status := range slices.Concat(pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses) {
+			if status.State.Waiting == nil {
+				continue
+			}
+			message := fmt.Sprintf("Container %s of pod %s: %s", status.Name, pod.Name, status.State.Waiting.Message)
+			switch status.State.Waiting.Reason {
+			case "CrashLoopBackOff":
+				return v1alpha2.AgentReadyReasonCrashLooping, message
+			case "ImagePullBackOff", "ErrImagePull", "InvalidImageName":
+				return v1alpha2.AgentReadyReasonImagePullBackOff, message
+			}
+		}
+	}
+	return "", ""
+}
+
+// unavailableToolServers returns the RemoteMCPServers used by the agent whose
+// last health check failed. Servers that cannot be found are left to the
+// Accepted condition.
+func (a *kagentReconciler) unavailableToolServers(ctx context.Context, agent *v1alpha2.Agent) []string {
+	if agent.Spec.Type != v1alpha2.AgentType_Declarative || agent.Spec.Declarative == nil {
+		return nil
+	}
+
+	var unavailable []string
+	for _, tool := range agent.Spec.Declarative.Tools {
+		if tool.McpServer == nil || tool.McpServer.Kind != "RemoteMCPServer" {
+			continue
+		}
+
+		ref, err := utils.ParseRefString(tool.McpServer.Name, agent.Namespace)
+		if err != nil {
+			continue
+		}
+
+		server := &v1alpha2.RemoteMCPServer{}
+		if err := a.kube.Get(ctx, ref, server); err != nil {
+			continue
+		}
+
+		if meta.IsStatusConditionFalse(server.Status.Conditions, v1alpha2.RemoteMCPServerConditionTypeReady) &&
+			!slices.Contains(unavailable, ref.String()) {
+			unavailable = append(unavailable, ref.String())
+		}
+	}
+	return unavailable
+}
+
+// modelAuthFailure returns why the provider of the model config of the agent rejects its
+// credentials, or an empty string when they were not rejected.
+func (a *kagentReconciler) modelAuthFailure(ctx context.Context, agent *v1alpha2.Agent) string {
+	if agent.Spec.Type != v1alpha2.AgentType_Declarative || agent.Spec.Declarative == nil || agent.Spec.Declarative.ModelConfig == "" {
+		return ""
+	}
+
+	ref, err := utils.ParseRefString(agent.Spec.Declarative.ModelConfig, agent.Namespace)
+	if err != nil {
+		return ""
+	}
+
+	modelConfig := &v1alpha2.ModelConfig{}
+	if err := a.kube.Get(ctx, ref, modelConfig); err != nil {
+		return ""
+	}
+
+	if condition := meta.FindStatusCondition(modelConfig.Status.Conditions, v1alpha2.ModelConfigConditionTypeCredentialsValid); condition != nil && condition.Status == metav1.ConditionFalse {
+		return fmt.Sprintf("Credentials of ModelConfig %s are not valid: %s", ref, condition.Message)
+	}
+	return ""
+}
+
+// probeAgentCard checks that an agent card is served at an A2A URL, either the URL of the agent on
+// the A2A mux of the controller, which clients reach it through, or the URL of the agent itself.
+func (a *kagentReconciler) probeAgentCard(ctx context.Context, url string) error {
+	ctx, cancel := context.WithTimeout(ctx, agentCardProbeTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(url, "/")+protocol.OldAgentCardPath, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create agent card request: %v", err)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to get agent card: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to get agent card: status %d", resp.StatusCode)
+	}
+	return nil
+}
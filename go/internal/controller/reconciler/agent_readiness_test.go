@@ -0,0 +1,290 @@
+package reconciler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/kagent-dev/kagent/go/api/v1alpha2"
+)
+
+func TestAgentReadyCondition(t *testing.T) {
+	agent := &v1alpha2.Agent{
+		ObjectMeta: metav1.ObjectMeta{Name: "agent", Namespace: "test", Generation: 1},
+		Spec: v1alpha2.AgentSpec{
+			Type: v1alpha2.AgentType_Declarative,
+			Declarative: &v1alpha2.DeclarativeAgentSpec{
+				ModelConfig: "model",
+			},
+		},
+	}
+	deployment := func(available int32) *appsv1.Deployment {
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "agent", Namespace: "test"},
+			Spec: appsv1.DeploymentSpec{
+				Replicas: ptr.To(int32(1)),
+				Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "agent"}},
+			},
+			Status: appsv1.DeploymentStatus{AvailableReplicas: available},
+		}
+	}
+	pod := func(waitingReason string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "agent-abc", Namespace: "test", Labels: map[string]string{"app": "agent"}},
+			Status: corev1.PodStatus{
+				ContainerStatuses: []corev1.ContainerStatus{{
+					Name:  "kagent",
+					State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: waitingReason, Message: "back-off"}},
+				}},
+			},
+		}
+	}
+	modelConfig := func(credentialsValid metav1.ConditionStatus) *v1alpha2.ModelConfig {
+		return &v1alpha2.ModelConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: "model", Namespace: "test"},
+			Status: v1alpha2.ModelConfigStatus{
+				Conditions: []metav1.Condition{{
+					Type:    v1alpha2.ModelConfigConditionTypeCredentialsValid,
+					Status:  credentialsValid,
+					Message: "OpenAI rejected the credentials with status 401",
+				}},
+			},
+		}
+	}
+	runtimeAgent := agent.DeepCopy()
+	runtimeAgent.Spec.ReadinessMode = v1alpha2.AgentReadinessMode_Runtime
+
+	// the A2A mux of the controller serves the card of the agent
+	muxServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/a2a/test/agent/.well-known/agent.json" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(`{"name":"agent"}`))
+	}))
+	defer muxServer.Close()
+
+	tests := []struct {
+		name            string
+		agent           *v1alpha2.Agent
+		objects         []client.Object
+		notServed       bool
+		expectedStatus  metav1.ConditionStatus
+		expectedReason  string
+		expectedMessage string
+	}{
+		{
+			name:           "deployment not found",
+			agent:          agent,
+			expectedStatus: metav1.ConditionUnknown,
+			expectedReason: v1alpha2.AgentReadyReasonDeploymentNotFound,
+		},
+		{
+			name:           "deployment ready",
+			agent:          agent,
+			objects:        []client.Object{deployment(1), modelConfig(metav1.ConditionFalse)},
+			expectedStatus: metav1.ConditionTrue,
+			expectedReason: v1alpha2.AgentReadyReasonDeploymentReady,
+		},
+		{
+			name:           "deployment not ready",
+			agent:          agent,
+			objects:        []client.Object{deployment(0), pod("ContainerCreating")},
+			expectedStatus: metav1.ConditionFalse,
+			expectedReason: v1alpha2.AgentReadyReasonDeploymentNotReady,
+		},
+		{
+			name:           "crash looping",
+			agent:          agent,
+			objects:        []client.Object{deployment(0), pod("CrashLoopBackOff")},
+			expectedStatus: metav1.ConditionFalse,
+			expectedReason: v1alpha2.AgentReadyReasonCrashLooping,
+		},
+		{
+			name:           "image pull back off",
+			agent:          agent,
+			objects:        []client.Object{deployment(0), pod("ErrImagePull")},
+			expectedStatus: metav1.ConditionFalse,
+			expectedReason: v1alpha2.AgentReadyReasonImagePullBackOff,
+		},
+		{
+			name:           "model auth failed in runtime mode",
+			agent:          runtimeAgent,
+			objects:        []client.Object{deployment(1), modelConfig(metav1.ConditionFalse)},
+			expectedStatus: metav1.ConditionFalse,
+			expectedReason: v1alpha2.AgentReadyReasonModelAuthFailed,
+		},
+		{
+			name:           "model credentials not checked in runtime mode",
+			agent:          runtimeAgent,
+			objects:        []client.Object{deployment(1), modelConfig(metav1.ConditionUnknown)},
+			expectedStatus: metav1.ConditionFalse,
+			expectedReason: v1alpha2.AgentReadyReasonAgentCardUnavailable,
+		},
+		{
+			name:            "agent not served by the controller in runtime mode",
+			agent:           runtimeAgent,
+			objects:         []client.Object{deployment(1), modelConfig(metav1.ConditionTrue)},
+			notServed:       true,
+			expectedStatus:  metav1.ConditionFalse,
+			expectedReason:  v1alpha2.AgentReadyReasonAgentCardUnavailable,
+			expectedMessage: "Agent is not served by the controller",
+		},
+		{
+			name:           "agent card not served in runtime mode",
+			agent:          runtimeAgent,
+			objects:        []client.Object{deployment(1), modelConfig(metav1.ConditionTrue)},
+			expectedStatus: metav1.ConditionFalse,
+			expectedReason: v1alpha2.AgentReadyReasonAgentCardUnavailable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scheme := runtime.NewScheme()
+			require.NoError(t, clientgoscheme.AddToScheme(scheme))
+			require.NoError(t, v1alpha2.AddToScheme(scheme))
+			kubeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(tt.objects...).Build()
+
+			a := &kagentReconciler{kube: kubeClient, a2aURL: muxServer.URL + "/api/a2a"}
+			if tt.notServed {
+				a.a2aURL = muxServer.URL + "/other"
+			}
+			condition := a.agentReadyCondition(context.Background(), tt.agent)
+
+			assert.Equal(t, v1alpha2.AgentConditionTypeReady, condition.Type)
+			assert.Equal(t, tt.expectedStatus, condition.Status)
+			assert.Equal(t, tt.expectedReason, condition.Reason)
+			assert.Equal(t, tt.agent.Generation, condition.ObservedGeneration)
+			assert.Contains(t, condition.Message, tt.expectedMessage)
+		})
+	}
+}
+
+func TestProbeAgentCard(t *testing.T) {
+	agentServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/.well-known/agent.json" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"name":"agent"}`))
+	}))
+	defer agentServer.Close()
+
+	a := &kagentReconciler{}
+	require.NoError(t, a.probeAgentCard(context.Background(), agentServer.URL))
+	require.NoError(t, a.probeAgentCard(context.Background(), agentServer.URL+"/"))
+	assert.ErrorContains(t, a.probeAgentCard(context.Background(), agentServer.URL+"/other"), "status 404")
+
+	agentServer.Close()
+	assert.Error(t, a.probeAgentCard(context.Background(), agentServer.URL))
+}
+
+func TestUnavailableToolServers(t *testing.T) {
+	toolServer := func(namespace, name string, ready metav1.ConditionStatus) *v1alpha2.RemoteMCPServer {
+		return &v1alpha2.RemoteMCPServer{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Status: v1alpha2.RemoteMCPServerStatus{
+				Conditions: []metav1.Condition{{
+					Type:   v1alpha2.RemoteMCPServerConditionTypeReady,
+					Status: ready,
+				}},
+			},
+		}
+	}
+	tool := func(name string) *v1alpha2.Tool {
+		return &v1alpha2.Tool{
+			Type: v1alpha2.ToolProviderType_McpServer,
+			McpServer: &v1alpha2.McpServerTool{
+				TypedLocalReference: v1alpha2.TypedLocalReference{
+					Kind:     "RemoteMCPServer",
+					ApiGroup: "kagent.dev",
+					Name:     name,
+				},
+			},
+		}
+	}
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, v1alpha2.AddToScheme(scheme))
+	kubeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		toolServer("test", "reachable", metav1.ConditionTrue),
+		toolServer("test", "unreachable", metav1.ConditionFalse),
+		toolServer("platform", "shared", metav1.ConditionFalse),
+	).Build()
+
+	a := &kagentReconciler{kube: kubeClient}
+	agent := &v1alpha2.Agent{
+		ObjectMeta: metav1.ObjectMeta{Name: "agent", Namespace: "test"},
+		Spec: v1alpha2.AgentSpec{
+			Type: v1alpha2.AgentType_Declarative,
+			Declarative: &v1alpha2.DeclarativeAgentSpec{
+				Tools: []*v1alpha2.Tool{
+					tool("reachable"),
+					tool("unreachable"),
+					tool("platform/shared"),
+					tool("missing"),
+				},
+			},
+		},
+	}
+
+	assert.Equal(t, []string{"test/unreachable", "platform/shared"}, a.unavailableToolServers(context.Background(), agent))
+}
+
+func TestAgentRequeueAfter(t *testing.T) {
+	ready := []metav1.Condition{{Type: v1alpha2.AgentConditionTypeReady, Status: metav1.ConditionTrue}}
+	notReady := []metav1.Condition{{Type: v1alpha2.AgentConditionTypeReady, Status: metav1.ConditionFalse}}
+
+	tests := []struct {
+		name          string
+		readinessMode v1alpha2.AgentReadinessMode
+		conditions    []metav1.Condition
+		expected      time.Duration
+	}{
+		{
+			name:       "ready deployment",
+			conditions: ready,
+			expected:   0,
+		},
+		{
+			name:       "deployment not ready",
+			conditions: notReady,
+			expected:   agentHealthCheckInterval,
+		},
+		{
+			name:     "readiness unknown",
+			expected: agentHealthCheckInterval,
+		},
+		{
+			name:          "ready in runtime mode",
+			readinessMode: v1alpha2.AgentReadinessMode_Runtime,
+			conditions:    ready,
+			expected:      agentHealthCheckInterval,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			agent := &v1alpha2.Agent{
+				Spec:   v1alpha2.AgentSpec{ReadinessMode: tt.readinessMode},
+				Status: v1alpha2.AgentStatus{Conditions: tt.conditions},
+			}
+			assert.Equal(t, tt.expected, agentRequeueAfter(agent))
+		})
+	}
+}
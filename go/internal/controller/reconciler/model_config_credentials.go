@@ -0,0 +1,135 @@
+package reconciler
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kagent-dev/kagent/go/api/v1alpha2"
+)
+
+const (
+	// minCredentialsCheckInterval is the minimum time between two probes of the
+	// credentials of a model config, so that quickly changing secrets do not flood the provider.
+	minCredentialsCheckInterval = time.Minute
+	// credentialsRetryInterval is the time after which a check that could not tell whether the
+	// credentials are valid, e.g. because the provider was unreachable, is retried.
+	credentialsRetryInterval = 5 * time.Minute
+)
+
+// reconcileModelConfigCredentials updates the CredentialsValid condition of the model
+// config, probing its provider when the spec or the secrets changed since the last check, or
+// when the last check was transient. It returns whether the condition changed and when to
+// requeue a check that was rate limited or transient.
+func (a *kagentReconciler) reconcileModelConfigCredentials(
+	ctx context.Context,
+	modelConfig *v1alpha2.ModelConfig,
+	reconcileErr error,
+	secretHash string,
+) (bool, time.Duration) {
+	key := client.ObjectKeyFromObject(modelConfig)
+	if modelConfig.Annotations[v1alpha2.ModelConfigSkipCredentialsCheckAnnotation] == "true" {
+		a.credentialsChecks.forget(key)
+		return meta.RemoveStatusCondition(&modelConfig.Status.Conditions, v1alpha2.ModelConfigConditionTypeCredentialsValid), 0
+	}
+
+	if reconcileErr != nil {
+		a.credentialsChecks.forget(key)
+		return meta.SetStatusCondition(&modelConfig.Status.Conditions, metav1.Condition{
+			Type:    v1alpha2.ModelConfigConditionTypeCredentialsValid,
+			Status:  metav1.ConditionUnknown,
+			Reason:  "NotChecked",
+			Message: "the model config is not accepted",
+		}), 0
+	}
+
+	version := fmt.Sprintf("%d/%s", modelConfig.Generation, secretHash)
+	due, wait := a.credentialsChecks.due(key, version, time.Now())
+	if !due {
+		return false, wait
+	}
+
+	check := a.modelCatalog.CheckCredentials(ctx, modelConfig)
+	a.credentialsChecks.done(key, version, time.Now(), check.Transient())
+	if check.Status == metav1.ConditionFalse || check.Transient() {
+		reconcileLog.Info("model config credentials check failed", "modelConfig", key.String(), "reason", check.Reason, "message", check.Message)
+	}
+	var requeueAfter time.Duration
+	if check.Transient() {
+		requeueAfter = credentialsRetryInterval
+	}
+	return meta.SetStatusCondition(&modelConfig.Status.Conditions, metav1.Condition{
+		Type:    v1alpha2.ModelConfigConditionTypeCredentialsValid,
+		Status:  check.Status,
+		Reason:  check.Reason,
+		Message: check.Message,
+	}), requeueAfter
+}
+
+// credentialsCheckTracker remembers which version of each model config had its
+// credentials checked, and when.
+type credentialsCheckTracker struct {
+	mu     sync.Mutex
+	checks map[types.NamespacedName]credentialsCheckRecord
+}
+
+type credentialsCheckRecord struct {
+	// version is the generation and secret hash of the model config that was checked
+	version   string
+	checkedAt time.Time
+	// transient is set when the check should be retried
+	transient bool
+}
+
+func newCredentialsCheckTracker() *credentialsCheckTracker {
+	return &credentialsCheckTracker{
+		checks: map[types.NamespacedName]credentialsCheckRecord{},
+	}
+}
+
+// due reports whether the credentials of the version of the model config should be
+// checked now. When the check is rate limited, or a transient check is not due for a
+// retry yet, it also returns how long to wait.
+func (t *credentialsCheckTracker) due(key types.NamespacedName, version string, now time.Time) (bool, time.Duration) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	record, ok := t.checks[key]
+	interval := minCredentialsCheckInterval
+	switch {
+	case !ok:
+		return true, 0
+	case record.version == version && !record.transient:
+		return false, 0
+	case record.version == version:
+		interval = credentialsRetryInterval
+	}
+
+	if elapsed := now.Sub(record.checkedAt); elapsed < interval {
+		return false, interval - elapsed
+	}
+	return true, 0
+}
+
+// done records a check of the credentials of the version of the model config, to be retried
+// if it is transient.
+func (t *credentialsCheckTracker) done(key types.NamespacedName, version string, now time.Time, transient bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.checks[key] = credentialsCheckRecord{version: version, checkedAt: now, transient: transient}
+}
+
+// forget makes the next reconcile of the model config check its credentials.
+func (t *credentialsCheckTracker) forget(key types.NamespacedName) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	delete(t.checks, key)
+}
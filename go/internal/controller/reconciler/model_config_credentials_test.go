@@ -0,0 +1,189 @@
+package reconciler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/kagent-dev/kagent/go/api/v1alpha2"
+	"github.com/kagent-dev/kagent/go/internal/modelcatalog"
+)
+
+func TestReconcileModelConfigCredentials(t *testing.T) {
+	var probes atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		probes.Add(1)
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, v1alpha2.AddToScheme(scheme))
+	kubeClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+	a := &kagentReconciler{
+		kube:              kubeClient,
+		modelCatalog:      modelcatalog.NewCatalog(kubeClient, modelcatalog.DefaultTTL),
+		credentialsChecks: newCredentialsCheckTracker(),
+	}
+
+	modelConfig := &v1alpha2.ModelConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "model", Namespace: "test", Generation: 1},
+		Spec: v1alpha2.ModelConfigSpec{
+			Provider: v1alpha2.ModelProviderOpenAICompatible,
+			OpenAICompatible: &v1alpha2.OpenAICompatibleConfig{
+				BaseURL: server.URL,
+			},
+		},
+	}
+
+	changed, requeueAfter := a.reconcileModelConfigCredentials(context.Background(), modelConfig, nil, "hash")
+	assert.True(t, changed)
+	assert.Zero(t, requeueAfter)
+	condition := meta.FindStatusCondition(modelConfig.Status.Conditions, v1alpha2.ModelConfigConditionTypeCredentialsValid)
+	require.NotNil(t, condition)
+	assert.Equal(t, metav1.ConditionFalse, condition.Status)
+	assert.Equal(t, modelcatalog.CredentialsReasonInvalid, condition.Reason)
+
+	changed, _ = a.reconcileModelConfigCredentials(context.Background(), modelConfig, nil, "hash")
+	assert.False(t, changed)
+	assert.EqualValues(t, 1, probes.Load(), "unchanged model config is not probed again")
+
+	_, requeueAfter = a.reconcileModelConfigCredentials(context.Background(), modelConfig, nil, "rotated")
+	assert.Positive(t, requeueAfter, "secret changed within the minimum interval")
+	assert.EqualValues(t, 1, probes.Load())
+
+	changed, _ = a.reconcileModelConfigCredentials(context.Background(), modelConfig, errors.New("secret not found"), "")
+	assert.True(t, changed)
+	condition = meta.FindStatusCondition(modelConfig.Status.Conditions, v1alpha2.ModelConfigConditionTypeCredentialsValid)
+	assert.Equal(t, metav1.ConditionUnknown, condition.Status)
+
+	modelConfig.Annotations = map[string]string{v1alpha2.ModelConfigSkipCredentialsCheckAnnotation: "true"}
+	changed, _ = a.reconcileModelConfigCredentials(context.Background(), modelConfig, nil, "hash")
+	assert.True(t, changed)
+	assert.Nil(t, meta.FindStatusCondition(modelConfig.Status.Conditions, v1alpha2.ModelConfigConditionTypeCredentialsValid))
+	assert.EqualValues(t, 1, probes.Load(), "opted out of the check")
+}
+
+func TestReconcileModelConfigCredentialsRetriesTransientChecks(t *testing.T) {
+	var probes atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		probes.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, v1alpha2.AddToScheme(scheme))
+	kubeClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+	a := &kagentReconciler{
+		kube:              kubeClient,
+		modelCatalog:      modelcatalog.NewCatalog(kubeClient, modelcatalog.DefaultTTL),
+		credentialsChecks: newCredentialsCheckTracker(),
+	}
+
+	modelConfig := &v1alpha2.ModelConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "model", Namespace: "test", Generation: 1},
+		Spec: v1alpha2.ModelConfigSpec{
+			Provider:         v1alpha2.ModelProviderOpenAICompatible,
+			OpenAICompatible: &v1alpha2.OpenAICompatibleConfig{BaseURL: server.URL},
+		},
+	}
+
+	changed, requeueAfter := a.reconcileModelConfigCredentials(context.Background(), modelConfig, nil, "hash")
+	assert.True(t, changed)
+	assert.Equal(t, credentialsRetryInterval, requeueAfter)
+	condition := meta.FindStatusCondition(modelConfig.Status.Conditions, v1alpha2.ModelConfigConditionTypeCredentialsValid)
+	require.NotNil(t, condition)
+	assert.Equal(t, metav1.ConditionUnknown, condition.Status)
+	assert.Equal(t, modelcatalog.CredentialsReasonUnreachable, condition.Reason)
+
+	_, requeueAfter = a.reconcileModelConfigCredentials(context.Background(), modelConfig, nil, "hash")
+	assert.Positive(t, requeueAfter, "the retry is not due yet")
+	assert.EqualValues(t, 1, probes.Load())
+}
+
+func TestReconcileDeletedModelConfigForgetsModels(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		_, _ = w.Write([]byte(`{"models":[{"name":"qwen3:8b"}]}`))
+	}))
+	defer server.Close()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, v1alpha2.AddToScheme(scheme))
+	kubeClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+	a := &kagentReconciler{
+		kube:              kubeClient,
+		modelCatalog:      modelcatalog.NewCatalog(kubeClient, modelcatalog.DefaultTTL),
+		credentialsChecks: newCredentialsCheckTracker(),
+	}
+
+	modelConfig := &v1alpha2.ModelConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "model", Namespace: "test", Generation: 1},
+		Spec: v1alpha2.ModelConfigSpec{
+			Provider: v1alpha2.ModelProviderOllama,
+			Ollama:   &v1alpha2.OllamaConfig{Host: server.URL},
+		},
+	}
+	_, err := a.modelCatalog.Models(context.Background(), modelConfig, false)
+	require.NoError(t, err)
+
+	// the ModelConfig is not found, i.e. it was deleted
+	_, err = a.ReconcileKagentModelConfig(context.Background(), ctrl.Request{NamespacedName: types.NamespacedName{Name: "model", Namespace: "test"}})
+	require.NoError(t, err)
+
+	// a ModelConfig created again with the same name and generation does not get the cached models
+	_, err = a.modelCatalog.Models(context.Background(), modelConfig, false)
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, requests.Load())
+}
+
+func TestCredentialsCheckTracker(t *testing.T) {
+	now := time.Now()
+	key := types.NamespacedName{Name: "model", Namespace: "test"}
+	tracker := newCredentialsCheckTracker()
+
+	due, _ := tracker.due(key, "1/a", now)
+	assert.True(t, due, "never checked")
+	tracker.done(key, "1/a", now, false)
+
+	due, wait := tracker.due(key, "1/a", now.Add(time.Hour))
+	assert.False(t, due, "already checked")
+	assert.Zero(t, wait)
+
+	due, wait = tracker.due(key, "1/b", now.Add(10*time.Second))
+	assert.False(t, due, "rate limited")
+	assert.Equal(t, minCredentialsCheckInterval-10*time.Second, wait)
+
+	due, _ = tracker.due(key, "1/b", now.Add(minCredentialsCheckInterval))
+	assert.True(t, due, "secret changed")
+
+	tracker.forget(key)
+	due, _ = tracker.due(key, "1/a", now)
+	assert.True(t, due, "forgotten")
+
+	tracker.done(key, "1/a", now, true)
+	due, wait = tracker.due(key, "1/a", now.Add(time.Minute))
+	assert.False(t, due, "transient check not due for a retry")
+	assert.Equal(t, credentialsRetryInterval-time.Minute, wait)
+	due, _ = tracker.due(key, "1/a", now.Add(credentialsRetryInterval))
+	assert.True(t, due, "transient check retried")
+}
@@ -4,27 +4,33 @@ import (
 	"context"
 	"crypto/sha256"
 	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"net/http"
 	"reflect"
 	"slices"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/hashicorp/go-multierror"
 	reconcilerutils "github.com/kagent-dev/kagent/go/internal/controller/reconciler/utils"
 	"github.com/kagent-dev/kmcp/api/v1alpha1"
-	appsv1 "k8s.io/api/apps/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/retry"
 
 	"github.com/kagent-dev/kagent/go/api/v1alpha2"
 	"github.com/kagent-dev/kagent/go/internal/controller/translator"
 	agent_translator "github.com/kagent-dev/kagent/go/internal/controller/translator/agent"
 	"github.com/kagent-dev/kagent/go/internal/database"
+	"github.com/kagent-dev/kagent/go/internal/egress"
+	"github.com/kagent-dev/kagent/go/internal/modelcatalog"
 	"github.com/kagent-dev/kagent/go/internal/utils"
 	"github.com/kagent-dev/kagent/go/internal/version"
 	mcp_client "github.com/mark3labs/mcp-go/client"
@@ -32,9 +38,11 @@ import (
 	"github.com/mark3labs/mcp-go/mcp"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 )
 
 var (
@@ -42,9 +50,10 @@ var (
 )
 
 type KagentReconciler interface {
-	ReconcileKagentAgent(ctx context.Context, req ctrl.Request) error
-	ReconcileKagentModelConfig(ctx context.Context, req ctrl.Request) error
-	ReconcileKagentRemoteMCPServer(ctx context.Context, req ctrl.Request) error
+	ReconcileKagentAgent(ctx context.Context, req ctrl.Request) (ctrl.Result, error)
+	ReconcileKagentModelConfig(ctx context.Context, req ctrl.Request) (ctrl.Result, error)
+	ReconcileKagentRemoteMCPServer(ctx context.Context, req ctrl.Request) (ctrl.Result, error)
+	ReconcileKagentRemoteAgent(ctx context.Context, req ctrl.Request) (ctrl.Result, error)
 	ReconcileKagentMCPService(ctx context.Context, req ctrl.Request) error
 	ReconcileKagentMCPServer(ctx context.Context, req ctrl.Request) error
 	GetOwnedResourceTypes() []client.Object
@@ -57,34 +66,69 @@ type kagentReconciler struct {
 	dbClient database.Client
 
 	defaultModelConfig types.NamespacedName
+	// a2aURL is the URL of the A2A mux of the controller, through which agent cards are probed
+	a2aURL string
+
+	recorder          record.EventRecorder
+	toolListWatcher   *toolListWatcher
+	discoveries       *discoveryTracker
+	modelCatalog      *modelcatalog.Catalog
+	credentialsChecks *credentialsCheckTracker
 
 	// TODO: Remove this lock since we have a DB which we can batch anyway
 	upsertLock sync.Mutex
 }
 
+// NewKagentReconciler creates the reconciler shared by the kagent controllers.
+// toolListChanges receives an event for every RemoteMCPServer that notifies a change
+// of its tool list, it may be nil to only rely on periodic rediscovery. a2aURL is the URL
+// the A2A mux of the controller serves the agents at. modelCatalog lists the models of the
+// providers of the ModelConfigs.
 func NewKagentReconciler(
 	translator agent_translator.AdkApiTranslator,
 	kube client.Client,
 	dbClient database.Client,
 	defaultModelConfig types.NamespacedName,
+	a2aURL string,
+	modelCatalog *modelcatalog.Catalog,
+	recorder record.EventRecorder,
+	toolListChanges chan<- event.GenericEvent,
 ) KagentReconciler {
+	discoveries := newDiscoveryTracker()
 	return &kagentReconciler{
 		adkTranslator:      translator,
 		kube:               kube,
 		dbClient:           dbClient,
 		defaultModelConfig: defaultModelConfig,
+		a2aURL:             a2aURL,
+		recorder:           recorder,
+		toolListWatcher:    newToolListWatcher(toolListChanges, discoveries.forget),
+		discoveries:        discoveries,
+		modelCatalog:       modelCatalog,
+		credentialsChecks:  newCredentialsCheckTracker(),
 	}
 }
 
-func (a *kagentReconciler) ReconcileKagentAgent(ctx context.Context, req ctrl.Request) error {
-	// TODO(sbx0r): missing finalizer logic
+func (a *kagentReconciler) ReconcileKagentAgent(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	agent := &v1alpha2.Agent{}
 	if err := a.kube.Get(ctx, req.NamespacedName, agent); err != nil {
 		if apierrors.IsNotFound(err) {
-			return a.handleAgentDeletion(req)
+			// the cleanup finalizer already applied the retention policy of the agent
+			reconcileLog.Info("Agent was deleted", "namespace", req.Namespace, "name", req.Name)
+			return ctrl.Result{}, nil
 		}
 
-		return fmt.Errorf("failed to get agent %s: %w", req.NamespacedName, err)
+		return ctrl.Result{}, fmt.Errorf("failed to get agent %s: %w", req.NamespacedName, err)
+	}
+
+	if !agent.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, a.finalizeAgent(ctx, agent)
+	}
+
+	if controllerutil.AddFinalizer(agent, v1alpha2.AgentCleanupFinalizer) {
+		if err := a.kube.Update(ctx, agent); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to add finalizer to agent %s: %w", req.NamespacedName, err)
+		}
 	}
 
 	err := a.reconcileAgent(ctx, agent)
@@ -92,17 +136,11 @@ func (a *kagentReconciler) ReconcileKagentAgent(ctx context.Context, req ctrl.Re
 		reconcileLog.Error(err, "failed to reconcile agent", "agent", req.NamespacedName)
 	}
 
-	return a.reconcileAgentStatus(ctx, agent, err)
-}
-
-func (a *kagentReconciler) handleAgentDeletion(req ctrl.Request) error {
-	if err := a.dbClient.DeleteAgent(req.String()); err != nil {
-		return fmt.Errorf("failed to delete agent %s: %w",
-			req.String(), err)
+	if err := a.reconcileAgentStatus(ctx, agent, err); err != nil {
+		return ctrl.Result{}, err
 	}
 
-	reconcileLog.Info("Agent was deleted", "namespace", req.Namespace, "name", req.Name)
-	return nil
+	return ctrl.Result{RequeueAfter: agentRequeueAfter(agent)}, nil
 }
 
 func (a *kagentReconciler) reconcileAgentStatus(ctx context.Context, agent *v1alpha2.Agent, err error) error {
@@ -128,36 +166,10 @@ func (a *kagentReconciler) reconcileAgentStatus(ctx context.Context, agent *v1al
 		ObservedGeneration: agent.Generation,
 	})
 
-	deployedCondition := metav1.Condition{
-		Type:               v1alpha2.AgentConditionTypeReady,
-		Status:             metav1.ConditionUnknown,
-		ObservedGeneration: agent.Generation,
+	if meta.SetStatusCondition(&agent.Status.Conditions, a.agentReadyCondition(ctx, agent)) {
+		conditionChanged = true
 	}
 
-	// Check if the deployment exists
-	deployment := &appsv1.Deployment{}
-	if err := a.kube.Get(ctx, types.NamespacedName{Namespace: agent.Namespace, Name: agent.Name}, deployment); err != nil {
-		deployedCondition.Status = metav1.ConditionUnknown
-		deployedCondition.Reason = "DeploymentNotFound"
-		deployedCondition.Message = err.Error()
-	} else {
-		replicas := int32(1)
-		if deployment.Spec.Replicas != nil {
-			replicas = *deployment.Spec.Replicas
-		}
-		if deployment.Status.AvailableReplicas >= replicas {
-			deployedCondition.Status = metav1.ConditionTrue
-			deployedCondition.Reason = "DeploymentReady"
-			deployedCondition.Message = "Deployment is ready"
-		} else {
-			deployedCondition.Status = metav1.ConditionFalse
-			deployedCondition.Reason = "DeploymentNotReady"
-			deployedCondition.Message = fmt.Sprintf("Deployment is not ready, %d/%d pods are ready", deployment.Status.AvailableReplicas, replicas)
-		}
-	}
-
-	conditionChanged = conditionChanged || meta.SetStatusCondition(&agent.Status.Conditions, deployedCondition)
-
 	// update the status if it has changed or the generation has changed
 	if conditionChanged || agent.Status.ObservedGeneration != agent.Generation {
 		agent.Status.ObservedGeneration = agent.Generation
@@ -199,7 +211,7 @@ func (a *kagentReconciler) ReconcileKagentMCPService(ctx context.Context, req ct
 	if remoteService, err := agent_translator.ConvertServiceToRemoteMCPServer(service); err != nil {
 		reconcileLog.Error(err, "failed to convert service to remote mcp service", "service", utils.GetObjectRef(service))
 	} else {
-		if _, err := a.upsertToolServerForRemoteMCPServer(ctx, dbService, remoteService, service.Namespace); err != nil {
+		if _, err := a.upsertToolServerForRemoteMCPServer(ctx, service, dbService, remoteService, service.Namespace); err != nil {
 			return fmt.Errorf("failed to upsert tool server for mcp service %s: %v", utils.GetObjectRef(service), err)
 		}
 	}
@@ -212,26 +224,28 @@ type secretRef struct {
 	Secret         *corev1.Secret
 }
 
-func (a *kagentReconciler) ReconcileKagentModelConfig(ctx context.Context, req ctrl.Request) error {
+func (a *kagentReconciler) ReconcileKagentModelConfig(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	modelConfig := &v1alpha2.ModelConfig{}
 	if err := a.kube.Get(ctx, req.NamespacedName, modelConfig); err != nil {
 		if apierrors.IsNotFound(err) {
-			return nil
+			a.credentialsChecks.forget(req.NamespacedName)
+			a.modelCatalog.Forget(req.NamespacedName)
+			return ctrl.Result{}, nil
 		}
 
-		return fmt.Errorf("failed to get model %s: %v", req.Name, err)
+		return ctrl.Result{}, fmt.Errorf("failed to get model %s: %v", req.Name, err)
 	}
 
 	var err error
 	var secrets []secretRef
 
-	// check for api key secret
-	if modelConfig.Spec.APIKeySecret != "" {
+	// check for the api key, tls and proxy credentials secrets
+	for _, name := range modelConfigSecretNames(modelConfig) {
 		secret := &corev1.Secret{}
-		namespacedName := types.NamespacedName{Namespace: modelConfig.Namespace, Name: modelConfig.Spec.APIKeySecret}
+		namespacedName := types.NamespacedName{Namespace: modelConfig.Namespace, Name: name}
 
 		if kubeErr := a.kube.Get(ctx, namespacedName, secret); kubeErr != nil {
-			err = multierror.Append(err, fmt.Errorf("failed to get secret %s: %v", modelConfig.Spec.APIKeySecret, kubeErr))
+			err = multierror.Append(err, fmt.Errorf("failed to get secret %s: %v", name, kubeErr))
 		} else {
 			secrets = append(secrets, secretRef{
 				NamespacedName: namespacedName,
@@ -240,32 +254,67 @@ func (a *kagentReconciler) ReconcileKagentModelConfig(ctx context.Context, req c
 		}
 	}
 
-	// check for tls cert secret
-	if modelConfig.Spec.TLS != nil && modelConfig.Spec.TLS.CACertSecretRef != "" {
-		secret := &corev1.Secret{}
-		namespacedName := types.NamespacedName{Namespace: modelConfig.Namespace, Name: modelConfig.Spec.TLS.CACertSecretRef}
-
-		if kubeErr := a.kube.Get(ctx, namespacedName, secret); kubeErr != nil {
-			err = multierror.Append(err, fmt.Errorf("failed to get secret %s: %v", modelConfig.Spec.TLS.CACertSecretRef, kubeErr))
-		} else {
-			secrets = append(secrets, secretRef{
-				NamespacedName: namespacedName,
-				Secret:         secret,
-			})
+	// check the models of a fallback chain
+	if modelConfig.Spec.Provider == v1alpha2.ModelProviderFallback {
+		if fallbackErr := a.validateFallbackModels(ctx, modelConfig); fallbackErr != nil {
+			err = multierror.Append(err, fallbackErr)
 		}
 	}
 
 	// compute the hash for the status
 	secretHash := computeStatusSecretHash(secrets)
 
-	return a.reconcileModelConfigStatus(
+	credentialsChanged, requeueAfter := a.reconcileModelConfigCredentials(ctx, modelConfig, err, secretHash)
+
+	return ctrl.Result{RequeueAfter: requeueAfter}, a.reconcileModelConfigStatus(
 		ctx,
 		modelConfig,
 		err,
 		secretHash,
+		credentialsChanged,
 	)
 }
 
+// modelConfigSecretNames returns the names of the distinct secrets referenced by the model config.
+func modelConfigSecretNames(modelConfig *v1alpha2.ModelConfig) []string {
+	var names []string
+	add := func(name string) {
+		if name != "" && !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+	}
+
+	add(modelConfig.Spec.APIKeySecret)
+	if tls := modelConfig.Spec.TLS; tls != nil {
+		add(tls.CACertSecretRef)
+		add(tls.ClientCertSecretRef)
+	}
+	if proxy := modelConfig.Spec.Proxy; proxy != nil {
+		add(proxy.CredentialsSecretRef)
+	}
+	return names
+}
+
+// validateFallbackModels checks that the fallback chain of the model config has no
+// cycles and that every model in it is accepted.
+func (a *kagentReconciler) validateFallbackModels(ctx context.Context, modelConfig *v1alpha2.ModelConfig) error {
+	models, err := agent_translator.ResolveModelChain(ctx, a.kube, modelConfig)
+	if err != nil {
+		return err
+	}
+
+	var notAccepted []string
+	for _, model := range models {
+		if !meta.IsStatusConditionTrue(model.Status.Conditions, v1alpha2.ModelConfigConditionTypeAccepted) {
+			notAccepted = append(notAccepted, model.Name)
+		}
+	}
+	if len(notAccepted) > 0 {
+		return fmt.Errorf("fallback ModelConfigs are not accepted: %s", strings.Join(notAccepted, ", "))
+	}
+	return nil
+}
+
 // computeStatusSecretHash computes a deterministic singular hash of the secrets the model config references for the status
 // this loses per-secret context (i.e. versioning/hash status per-secret), but simplifies the number of statuses tracked
 func computeStatusSecretHash(secrets []secretRef) string {
@@ -295,7 +344,7 @@ func computeStatusSecretHash(secrets []secretRef) string {
 	return hex.EncodeToString(hash.Sum(nil))
 }
 
-func (a *kagentReconciler) reconcileModelConfigStatus(ctx context.Context, modelConfig *v1alpha2.ModelConfig, err error, secretHash string) error {
+func (a *kagentReconciler) reconcileModelConfigStatus(ctx context.Context, modelConfig *v1alpha2.ModelConfig, err error, secretHash string, credentialsChanged bool) error {
 	var (
 		status  metav1.ConditionStatus
 		message string
@@ -326,7 +375,7 @@ func (a *kagentReconciler) reconcileModelConfigStatus(ctx context.Context, model
 	}
 
 	// update the status if it has changed or the generation has changed
-	if conditionChanged || modelConfig.Status.ObservedGeneration != modelConfig.Generation || secretHashChanged {
+	if conditionChanged || credentialsChanged || modelConfig.Status.ObservedGeneration != modelConfig.Generation || secretHashChanged {
 		modelConfig.Status.ObservedGeneration = modelConfig.Generation
 		if err := a.kube.Status().Update(ctx, modelConfig); err != nil {
 			return fmt.Errorf("failed to update model config status: %v", err)
@@ -365,7 +414,7 @@ func (a *kagentReconciler) ReconcileKagentMCPServer(ctx context.Context, req ctr
 	if remoteSpec, err := agent_translator.ConvertMCPServerToRemoteMCPServer(mcpServer); err != nil {
 		reconcileLog.Error(err, "failed to convert mcp server to remote mcp server", "mcpServer", utils.GetObjectRef(mcpServer))
 	} else {
-		if _, err := a.upsertToolServerForRemoteMCPServer(ctx, dbServer, remoteSpec, mcpServer.Namespace); err != nil {
+		if _, err := a.upsertToolServerForRemoteMCPServer(ctx, mcpServer, dbServer, remoteSpec, mcpServer.Namespace); err != nil {
 			return fmt.Errorf("failed to upsert tool server for remote mcp server %s: %v", utils.GetObjectRef(mcpServer), err)
 		}
 	}
@@ -373,7 +422,7 @@ func (a *kagentReconciler) ReconcileKagentMCPServer(ctx context.Context, req ctr
 	return nil
 }
 
-func (a *kagentReconciler) ReconcileKagentRemoteMCPServer(ctx context.Context, req ctrl.Request) error {
+func (a *kagentReconciler) ReconcileKagentRemoteMCPServer(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	nns := req.NamespacedName
 	serverRef := nns.String()
 	l := reconcileLog.WithValues("remoteMCPServer", serverRef)
@@ -396,10 +445,17 @@ func (a *kagentReconciler) ReconcileKagentRemoteMCPServer(ctx context.Context, r
 				l.Error(err, "failed to delete tools for remote mcp server")
 			}
 
-			return nil
+			a.toolListWatcher.stop(nns)
+
+			return ctrl.Result{}, nil
 		}
 
-		return fmt.Errorf("failed to get remote mcp server %s: %v", serverRef, err)
+		return ctrl.Result{}, fmt.Errorf("failed to get remote mcp server %s: %v", serverRef, err)
+	}
+
+	pingLatency, pingErr := a.pingRemoteMCPServer(ctx, server)
+	if pingErr != nil {
+		l.Info("remote mcp server is not reachable", "error", pingErr.Error())
 	}
 
 	dbServer := &database.ToolServer{
@@ -407,16 +463,52 @@ func (a *kagentReconciler) ReconcileKagentRemoteMCPServer(ctx context.Context, r
 		Description: server.Spec.Description,
 		GroupKind:   server.GroupVersionKind().GroupKind().String(),
 	}
+	if pingErr == nil {
+		now := time.Now()
+		dbServer.LastConnected = &now
+	}
 
-	tools, err := a.upsertToolServerForRemoteMCPServer(ctx, dbServer, &server.Spec, server.Namespace)
-	if err != nil {
-		l.Error(err, "failed to upsert tool server for remote mcp server")
+	var (
+		discovery *mcpDiscovery
+		err       error
+	)
+	if a.discoveries.due(server, time.Now()) {
+		discovery, err = a.upsertToolServerForRemoteMCPServer(ctx, server, dbServer, &server.Spec, server.Namespace)
+		if err != nil {
+			l.Error(err, "failed to upsert tool server for remote mcp server")
 
-		// Fetch previously discovered tools from database if possible
-		var discoveryErr error
-		tools, discoveryErr = a.getDiscoveredMCPTools(ctx, serverRef)
-		if discoveryErr != nil {
-			err = multierror.Append(err, discoveryErr)
+			// Fetch previously discovered tools from database if possible, the
+			// resources and prompts stay as they are in the status
+			tools, discoveryErr := a.getDiscoveredMCPTools(ctx, serverRef)
+			if discoveryErr != nil {
+				err = multierror.Append(err, discoveryErr)
+			}
+			discovery = &mcpDiscovery{
+				Tools:      tools,
+				Resources:  server.Status.DiscoveredResources,
+				Prompts:    server.Status.DiscoveredPrompts,
+				FromStatus: true,
+			}
+		} else {
+			a.discoveries.done(server, time.Now())
+			if watchErr := a.watchToolListChanges(ctx, server); watchErr != nil {
+				// Not fatal, the tools are still rediscovered periodically
+				l.Info("unable to watch tool list changes", "error", watchErr.Error())
+			}
+		}
+	} else {
+		// Only a health check, the tools in the status are still current
+		if pingErr == nil {
+			if _, err := a.dbClient.StoreToolServer(dbServer); err != nil {
+				l.Error(err, "failed to store tool server for remote mcp server")
+			}
+		}
+		discovery = &mcpDiscovery{
+			Tools:      server.Status.DiscoveredTools,
+			Resources:  server.Status.DiscoveredResources,
+			Prompts:    server.Status.DiscoveredPrompts,
+			Truncated:  meta.IsStatusConditionTrue(server.Status.Conditions, v1alpha2.RemoteMCPServerConditionTypeDiscoveryTruncated),
+			FromStatus: true,
 		}
 	}
 
@@ -424,21 +516,42 @@ func (a *kagentReconciler) ReconcileKagentRemoteMCPServer(ctx context.Context, r
 	if err := a.reconcileRemoteMCPServerStatus(
 		ctx,
 		server,
-		tools,
+		discovery,
 		err,
+		pingLatency,
+		pingErr,
 	); err != nil {
-		return fmt.Errorf("failed to reconcile remote mcp server status %s: %v", req.NamespacedName, err)
+		return ctrl.Result{}, fmt.Errorf("failed to reconcile remote mcp server status %s: %v", req.NamespacedName, err)
 	}
 
-	return nil
+	// loop to check the health of the server and pick up tools added to or removed from it
+	return ctrl.Result{RequeueAfter: remoteMCPServerRequeueAfter(&server.Spec)}, nil
+}
+
+func (a *kagentReconciler) watchToolListChanges(ctx context.Context, server *v1alpha2.RemoteMCPServer) error {
+	headers, err := server.Spec.ResolveHeaders(ctx, a.kube, server.Namespace)
+	if err != nil {
+		return err
+	}
+	httpClient, err := a.mcpHTTPClient(ctx, &server.Spec, server.Namespace)
+	if err != nil {
+		return err
+	}
+	return a.toolListWatcher.watch(ctx, client.ObjectKeyFromObject(server), &server.Spec, headers, httpClient)
 }
 
 func (a *kagentReconciler) reconcileRemoteMCPServerStatus(
 	ctx context.Context,
 	server *v1alpha2.RemoteMCPServer,
-	discoveredTools []*v1alpha2.MCPTool,
+	discovery *mcpDiscovery,
 	err error,
+	pingLatency time.Duration,
+	pingErr error,
 ) error {
+	discoveredTools, omittedSchemas, omittedTools := statusTools(discovery.Tools)
+	discoveredResources, omittedResources := boundList(discovery.Resources, v1alpha2.MaxStatusResources)
+	discoveredPrompts, omittedPrompts := boundList(discovery.Prompts, v1alpha2.MaxStatusPrompts)
+
 	var (
 		status  metav1.ConditionStatus
 		message string
@@ -453,22 +566,84 @@ func (a *kagentReconciler) reconcileRemoteMCPServerStatus(
 		reason = "Reconciled"
 	}
 	conditionChanged := meta.SetStatusCondition(&server.Status.Conditions, metav1.Condition{
-		Type:               v1alpha2.AgentConditionTypeAccepted,
+		Type:               v1alpha2.RemoteMCPServerConditionTypeAccepted,
 		Status:             status,
 		Reason:             reason,
 		Message:            message,
 		ObservedGeneration: server.Generation,
 	})
 
+	ready := metav1.Condition{
+		Type:               v1alpha2.RemoteMCPServerConditionTypeReady,
+		Status:             metav1.ConditionTrue,
+		Reason:             "PingSucceeded",
+		ObservedGeneration: server.Generation,
+	}
+	if pingErr != nil {
+		ready.Status = metav1.ConditionFalse
+		ready.Reason = "PingFailed"
+		ready.Message = pingErr.Error()
+	} else {
+		// a successful ping always updates the last connected time
+		conditionChanged = true
+		server.Status.LastConnectedTime = ptr.To(metav1.Now())
+		server.Status.PingLatency = &metav1.Duration{Duration: pingLatency.Round(time.Microsecond)}
+	}
+	if meta.SetStatusCondition(&server.Status.Conditions, ready) {
+		conditionChanged = true
+	}
+
+	// the truncation is only known after a successful discovery
+	if err == nil {
+		truncated := metav1.Condition{
+			Type:               v1alpha2.RemoteMCPServerConditionTypeDiscoveryTruncated,
+			Status:             metav1.ConditionFalse,
+			Reason:             "AllPagesListed",
+			ObservedGeneration: server.Generation,
+		}
+		if discovery.Truncated {
+			truncated.Status = metav1.ConditionTrue
+			truncated.Reason = "PageLimitReached"
+			truncated.Message = fmt.Sprintf("discovery stopped after %d pages, some tools, resources or prompts are missing", maxDiscoveryPages)
+		}
+		if meta.SetStatusCondition(&server.Status.Conditions, truncated) {
+			conditionChanged = true
+		}
+	}
+
+	// the lists kept from the status were bounded when they were discovered
+	if !discovery.FromStatus {
+		var omitted []string
+		if omittedTools > 0 {
+			omitted = append(omitted, fmt.Sprintf("%d tools", omittedTools))
+		}
+		if omittedSchemas > 0 {
+			omitted = append(omitted, fmt.Sprintf("the schemas of %d tools", omittedSchemas))
+		}
+		if omittedResources > 0 {
+			omitted = append(omitted, fmt.Sprintf("%d resources", omittedResources))
+		}
+		if omittedPrompts > 0 {
+			omitted = append(omitted, fmt.Sprintf("%d prompts", omittedPrompts))
+		}
+		if meta.SetStatusCondition(&server.Status.Conditions, statusTruncatedCondition(server, omitted)) {
+			conditionChanged = true
+		}
+	}
+
 	// only update if the status has changed to prevent looping the reconciler
 	if !conditionChanged &&
 		server.Status.ObservedGeneration == server.Generation &&
-		reflect.DeepEqual(server.Status.DiscoveredTools, discoveredTools) {
+		reflect.DeepEqual(server.Status.DiscoveredTools, discoveredTools) &&
+		reflect.DeepEqual(server.Status.DiscoveredResources, discoveredResources) &&
+		reflect.DeepEqual(server.Status.DiscoveredPrompts, discoveredPrompts) {
 		return nil
 	}
 
 	server.Status.ObservedGeneration = server.Generation
 	server.Status.DiscoveredTools = discoveredTools
+	server.Status.DiscoveredResources = discoveredResources
+	server.Status.DiscoveredPrompts = discoveredPrompts
 
 	if err := a.kube.Status().Update(ctx, server); err != nil {
 		return fmt.Errorf("failed to update remote mcp server status: %v", err)
@@ -638,30 +813,96 @@ func (a *kagentReconciler) upsertAgent(ctx context.Context, agent *v1alpha2.Agen
 	return nil
 }
 
-func (a *kagentReconciler) upsertToolServerForRemoteMCPServer(ctx context.Context, toolServer *database.ToolServer, remoteMcpServer *v1alpha2.RemoteMCPServerSpec, namespace string) ([]*v1alpha2.MCPTool, error) {
+func (a *kagentReconciler) upsertToolServerForRemoteMCPServer(ctx context.Context, owner client.Object, toolServer *database.ToolServer, remoteMcpServer *v1alpha2.RemoteMCPServerSpec, namespace string) (*mcpDiscovery, error) {
 	// lock to prevent races
 	a.upsertLock.Lock()
 	defer a.upsertLock.Unlock()
 
+	discovery, discoveryErr := a.discoverToolServer(ctx, toolServer, remoteMcpServer, namespace)
+	if discoveryErr == nil {
+		now := time.Now()
+		toolServer.LastConnected = &now
+	} else if toolServer.LastConnected == nil {
+		// keep the last connection time of the server
+		if existing, err := a.dbClient.GetToolServer(toolServer.Name); err == nil {
+			toolServer.LastConnected = existing.LastConnected
+		}
+	}
+
 	if _, err := a.dbClient.StoreToolServer(toolServer); err != nil {
 		return nil, fmt.Errorf("failed to store toolServer %s: %v", toolServer.Name, err)
 	}
 
+	if discoveryErr != nil {
+		return nil, discoveryErr
+	}
+	tools := discovery.Tools
+
+	existingTools, err := a.dbClient.ListToolsForServer(toolServer.Name, toolServer.GroupKind)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tools for toolServer %s: %v", toolServer.Name, err)
+	}
+
+	if err := a.dbClient.RefreshToolsForServer(toolServer.Name, toolServer.GroupKind, tools...); err != nil {
+		return nil, fmt.Errorf("failed to refresh tools for toolServer %s: %v", toolServer.Name, err)
+	}
+
+	if err := a.dbClient.RefreshResourcesForServer(toolServer.Name, toolServer.GroupKind, discovery.Resources...); err != nil {
+		return nil, fmt.Errorf("failed to refresh resources for toolServer %s: %v", toolServer.Name, err)
+	}
+
+	if err := a.dbClient.RefreshPromptsForServer(toolServer.Name, toolServer.GroupKind, discovery.Prompts...); err != nil {
+		return nil, fmt.Errorf("failed to refresh prompts for toolServer %s: %v", toolServer.Name, err)
+	}
+
+	if added, removed := diffTools(existingTools, tools); a.recorder != nil && (len(added) > 0 || len(removed) > 0) {
+		a.recorder.Eventf(owner, corev1.EventTypeNormal, "ToolsChanged", "Added tools: %v, removed tools: %v", added, removed)
+	}
+
+	if discovery.Truncated {
+		reconcileLog.Info("discovery stopped at the page limit", "toolServer", toolServer.Name, "maxPages", maxDiscoveryPages)
+	}
+
+	return discovery, nil
+}
+
+func (a *kagentReconciler) discoverToolServer(ctx context.Context, toolServer *database.ToolServer, remoteMcpServer *v1alpha2.RemoteMCPServerSpec, namespace string) (*mcpDiscovery, error) {
 	tsp, err := a.createMcpTransport(ctx, remoteMcpServer, namespace)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create client for toolServer %s: %v", toolServer.Name, err)
 	}
 
-	tools, err := a.listTools(ctx, tsp, toolServer)
+	discovery, err := a.discover(ctx, tsp, toolServer)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch tools for toolServer %s: %v", toolServer.Name, err)
 	}
+	return discovery, nil
+}
 
-	if err := a.dbClient.RefreshToolsForServer(toolServer.Name, toolServer.GroupKind, tools...); err != nil {
-		return nil, fmt.Errorf("failed to refresh tools for toolServer %s: %v", toolServer.Name, err)
+// diffTools returns the sorted names of the tools that were added and removed.
+func diffTools(existing []database.Tool, tools []*v1alpha2.MCPTool) (added, removed []string) {
+	names := make(map[string]struct{}, len(tools))
+	for _, tool := range tools {
+		names[tool.Name] = struct{}{}
+	}
+
+	existingNames := make(map[string]struct{}, len(existing))
+	for _, tool := range existing {
+		existingNames[tool.ID] = struct{}{}
+		if _, ok := names[tool.ID]; !ok {
+			removed = append(removed, tool.ID)
+		}
+	}
+
+	for name := range names {
+		if _, ok := existingNames[name]; !ok {
+			added = append(added, name)
+		}
 	}
 
-	return tools, nil
+	slices.Sort(added)
+	slices.Sort(removed)
+	return added, removed
 }
 
 func (a *kagentReconciler) createMcpTransport(ctx context.Context, s *v1alpha2.RemoteMCPServerSpec, namespace string) (transport.Interface, error) {
@@ -669,23 +910,52 @@ func (a *kagentReconciler) createMcpTransport(ctx context.Context, s *v1alpha2.R
 	if err != nil {
 		return nil, err
 	}
+	httpClient, err := a.mcpHTTPClient(ctx, s, namespace)
+	if err != nil {
+		return nil, err
+	}
 
 	switch s.Protocol {
 	case v1alpha2.RemoteMCPServerProtocolSse:
-		return transport.NewSSE(s.URL, transport.WithHeaders(headers))
+		return transport.NewSSE(s.URL, transport.WithHeaders(headers), transport.WithHTTPClient(httpClient))
 	default:
-		return transport.NewStreamableHTTP(s.URL, transport.WithHTTPHeaders(headers))
+		return transport.NewStreamableHTTP(s.URL, transport.WithHTTPHeaders(headers), transport.WithHTTPBasicClient(httpClient))
 	}
 }
 
-func (a *kagentReconciler) listTools(ctx context.Context, tsp transport.Interface, toolServer *database.ToolServer) ([]*v1alpha2.MCPTool, error) {
+// mcpHTTPClient returns the client for the connections to a MCP server, honouring its
+// TLS and proxy configuration.
+func (a *kagentReconciler) mcpHTTPClient(ctx context.Context, s *v1alpha2.RemoteMCPServerSpec, namespace string) (*http.Client, error) {
+	config, err := egress.Resolve(ctx, a.kube, namespace, s.TLS, s.Proxy)
+	if err != nil {
+		return nil, err
+	}
+	return config.HTTPClient()
+}
+
+// maxDiscoveryPages bounds the number of pages of tools, resources and prompts
+// requested from a single server during discovery.
+const maxDiscoveryPages = 100
+
+// mcpDiscovery is what was discovered on a MCP server.
+type mcpDiscovery struct {
+	Tools     []*v1alpha2.MCPTool
+	Resources []*v1alpha2.MCPResource
+	Prompts   []*v1alpha2.MCPPrompt
+	// Truncated is set when a list had more than maxDiscoveryPages pages
+	Truncated bool
+	// FromStatus is set when the lists are those of the status, which are already bounded
+	FromStatus bool
+}
+
+func (a *kagentReconciler) discover(ctx context.Context, tsp transport.Interface, toolServer *database.ToolServer) (*mcpDiscovery, error) {
 	client := mcp_client.NewClient(tsp)
 	err := client.Start(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to start client for toolServer %s: %v", toolServer.Name, err)
 	}
 	defer client.Close()
-	_, err = client.Initialize(ctx, mcp.InitializeRequest{
+	initResult, err := client.Initialize(ctx, mcp.InitializeRequest{
 		Params: mcp.InitializeParams{
 			ProtocolVersion: mcp.LATEST_PROTOCOL_VERSION,
 			Capabilities:    mcp.ClientCapabilities{},
@@ -698,20 +968,243 @@ func (a *kagentReconciler) listTools(ctx context.Context, tsp transport.Interfac
 	if err != nil {
 		return nil, fmt.Errorf("failed to initialize client for toolServer %s: %v", toolServer.Name, err)
 	}
-	result, err := client.ListTools(ctx, mcp.ListToolsRequest{})
+
+	discovery := &mcpDiscovery{}
+
+	tools, truncated, err := listAllPages(ctx, func(ctx context.Context, cursor mcp.Cursor) ([]mcp.Tool, mcp.Cursor, error) {
+		result, err := client.ListToolsByPage(ctx, mcp.ListToolsRequest{PaginatedRequest: paginatedRequest(cursor)})
+		if err != nil {
+			return nil, "", err
+		}
+		return result.Tools, result.NextCursor, nil
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to list tools for toolServer %s: %v", toolServer.Name, err)
 	}
+	discovery.Truncated = discovery.Truncated || truncated
+
+	discovery.Tools = make([]*v1alpha2.MCPTool, 0, len(tools))
+	for _, tool := range tools {
+		mcpTool, err := convertMCPTool(tool)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert tool %s for toolServer %s: %v", tool.Name, toolServer.Name, err)
+		}
+		discovery.Tools = append(discovery.Tools, mcpTool)
+	}
+
+	// Resources and prompts are only listed when the server offers them
+	if initResult.Capabilities.Resources != nil {
+		resources, truncated, err := listAllPages(ctx, func(ctx context.Context, cursor mcp.Cursor) ([]mcp.Resource, mcp.Cursor, error) {
+			result, err := client.ListResourcesByPage(ctx, mcp.ListResourcesRequest{PaginatedRequest: paginatedRequest(cursor)})
+			if err != nil {
+				return nil, "", err
+			}
+			return result.Resources, result.NextCursor, nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list resources for toolServer %s: %v", toolServer.Name, err)
+		}
+		discovery.Truncated = discovery.Truncated || truncated
+
+		for _, resource := range resources {
+			discovery.Resources = append(discovery.Resources, &v1alpha2.MCPResource{
+				URI:         resource.URI,
+				Name:        resource.Name,
+				Description: resource.Description,
+				MimeType:    resource.MIMEType,
+			})
+		}
+	}
 
-	tools := make([]*v1alpha2.MCPTool, 0, len(result.Tools))
-	for _, tool := range result.Tools {
-		tools = append(tools, &v1alpha2.MCPTool{
-			Name:        tool.Name,
-			Description: tool.Description,
+	if initResult.Capabilities.Prompts != nil {
+		prompts, truncated, err := listAllPages(ctx, func(ctx context.Context, cursor mcp.Cursor) ([]mcp.Prompt, mcp.Cursor, error) {
+			result, err := client.ListPromptsByPage(ctx, mcp.ListPromptsRequest{PaginatedRequest: paginatedRequest(cursor)})
+			if err != nil {
+				return nil, "", err
+			}
+			return result.Prompts, result.NextCursor, nil
 		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list prompts for toolServer %s: %v", toolServer.Name, err)
+		}
+		discovery.Truncated = discovery.Truncated || truncated
+
+		for _, prompt := range prompts {
+			mcpPrompt := &v1alpha2.MCPPrompt{
+				Name:        prompt.Name,
+				Description: prompt.Description,
+			}
+			for _, argument := range prompt.Arguments {
+				mcpPrompt.Arguments = append(mcpPrompt.Arguments, v1alpha2.MCPPromptArgument{
+					Name:        argument.Name,
+					Description: argument.Description,
+					Required:    argument.Required,
+				})
+			}
+			discovery.Prompts = append(discovery.Prompts, mcpPrompt)
+		}
+	}
+
+	return discovery, nil
+}
+
+func paginatedRequest(cursor mcp.Cursor) mcp.PaginatedRequest {
+	return mcp.PaginatedRequest{Params: mcp.PaginatedParams{Cursor: cursor}}
+}
+
+// listAllPages follows the cursors returned by listPage for at most maxDiscoveryPages
+// pages. It reports whether more pages were left when the limit was reached.
+func listAllPages[T any](ctx context.Context, listPage func(ctx context.Context, cursor mcp.Cursor) ([]T, mcp.Cursor, error)) ([]T, bool, error) {
+	var (
+		items  []T
+		cursor mcp.Cursor
+	)
+	for range maxDiscoveryPages {
+		page, next, err := listPage(ctx, cursor)
+		if err != nil {
+			return nil, false, err
+		}
+		items = append(items, page...)
+		if next == "" {
+			return items, false, nil
+		}
+		cursor = next
+	}
+	return items, true, nil
+}
+
+// convertMCPTool keeps the full input and output schemas of a listed tool,
+// including the raw schemas of servers that do not fit mcp.ToolInputSchema.
+func convertMCPTool(tool mcp.Tool) (*v1alpha2.MCPTool, error) {
+	mcpTool := &v1alpha2.MCPTool{
+		Name:        tool.Name,
+		Description: tool.Description,
 	}
 
-	return tools, nil
+	var inputSchema any = tool.InputSchema
+	if len(tool.RawInputSchema) > 0 {
+		inputSchema = tool.RawInputSchema
+	}
+	raw, err := normalizeSchema(inputSchema)
+	if err != nil {
+		return nil, fmt.Errorf("invalid input schema: %v", err)
+	}
+	mcpTool.InputSchema = &runtime.RawExtension{Raw: raw}
+
+	var outputSchema any
+	switch {
+	case len(tool.RawOutputSchema) > 0:
+		outputSchema = tool.RawOutputSchema
+	case tool.OutputSchema.Type != "":
+		outputSchema = tool.OutputSchema
+	}
+	if outputSchema != nil {
+		raw, err := normalizeSchema(outputSchema)
+		if err != nil {
+			return nil, fmt.Errorf("invalid output schema: %v", err)
+		}
+		mcpTool.OutputSchema = &runtime.RawExtension{Raw: raw}
+	}
+
+	if tool.Annotations != (mcp.ToolAnnotation{}) {
+		mcpTool.Annotations = &v1alpha2.MCPToolAnnotations{
+			Title:           tool.Annotations.Title,
+			ReadOnlyHint:    tool.Annotations.ReadOnlyHint,
+			DestructiveHint: tool.Annotations.DestructiveHint,
+			IdempotentHint:  tool.Annotations.IdempotentHint,
+			OpenWorldHint:   tool.Annotations.OpenWorldHint,
+		}
+	}
+
+	return mcpTool, nil
+}
+
+// normalizeSchema encodes a schema the way the API server stores it, compact and with
+// sorted keys, so that unchanged schemas compare equal to the ones read back from the status.
+func normalizeSchema(in any) ([]byte, error) {
+	data, err := json.Marshal(in)
+	if err != nil {
+		return nil, err
+	}
+	var out any
+	if err := json.Unmarshal(data, &out); err != nil {
+		return nil, err
+	}
+	return json.Marshal(out)
+}
+
+// statusTools bounds the discovered tools stored in a RemoteMCPServer status.
+// Schemas larger than v1alpha2.MaxStatusToolSchemaBytes are left out and the tool
+// is marked with SchemaOmitted, the database keeps the full schemas. Once the tools
+// reach v1alpha2.MaxStatusToolsBytes, the schemas of the next ones are left out too,
+// then the tools that still do not fit. It returns the number of tools whose schemas
+// were left out to stay under v1alpha2.MaxStatusToolsBytes and the number of tools omitted.
+func statusTools(tools []*v1alpha2.MCPTool) ([]*v1alpha2.MCPTool, int, int) {
+	if tools == nil {
+		return nil, 0, 0
+	}
+
+	bounded := make([]*v1alpha2.MCPTool, 0, len(tools))
+	total, omittedSchemas, omittedTools := 0, 0, 0
+	for _, tool := range tools {
+		if tool == nil {
+			continue
+		}
+		size := len(tool.Name) + len(tool.Description)
+		schemaSize := 0
+		if tool.InputSchema != nil {
+			schemaSize += len(tool.InputSchema.Raw)
+		}
+		if tool.OutputSchema != nil {
+			schemaSize += len(tool.OutputSchema.Raw)
+		}
+
+		if schemaSize <= v1alpha2.MaxStatusToolSchemaBytes && total+size+schemaSize <= v1alpha2.MaxStatusToolsBytes {
+			bounded = append(bounded, tool)
+			total += size + schemaSize
+			continue
+		}
+		if total+size > v1alpha2.MaxStatusToolsBytes {
+			omittedTools++
+			continue
+		}
+		if schemaSize <= v1alpha2.MaxStatusToolSchemaBytes {
+			omittedSchemas++
+		}
+
+		tool = tool.DeepCopy()
+		tool.InputSchema = nil
+		tool.OutputSchema = nil
+		tool.SchemaOmitted = true
+		bounded = append(bounded, tool)
+		total += size
+	}
+	return bounded, omittedSchemas, omittedTools
+}
+
+// boundList returns the first limit items of a list and the number of items it omits.
+func boundList[T any](items []T, limit int) ([]T, int) {
+	if len(items) <= limit {
+		return items, 0
+	}
+	return items[:limit], len(items) - limit
+}
+
+// statusTruncatedCondition returns the StatusTruncated condition of a server whose status omits
+// the given discovered items.
+func statusTruncatedCondition(server *v1alpha2.RemoteMCPServer, omitted []string) metav1.Condition {
+	condition := metav1.Condition{
+		Type:               v1alpha2.RemoteMCPServerConditionTypeStatusTruncated,
+		Status:             metav1.ConditionFalse,
+		Reason:             "AllListed",
+		ObservedGeneration: server.Generation,
+	}
+	if len(omitted) > 0 {
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = "StatusLimitReached"
+		condition.Message = fmt.Sprintf("the status omits %s, all of them are listed by the /api/toolservers endpoint", strings.Join(omitted, " and "))
+	}
+	return condition
 }
 
 func (a *kagentReconciler) getDiscoveredMCPTools(ctx context.Context, serverRef string) ([]*v1alpha2.MCPTool, error) {
@@ -734,8 +1227,5 @@ func (a *kagentReconciler) getDiscoveredMCPTools(ctx context.Context, serverRef
 }
 
 func convertTool(tool *database.Tool) (*v1alpha2.MCPTool, error) {
-	return &v1alpha2.MCPTool{
-		Name:        tool.ID,
-		Description: tool.Description,
-	}, nil
+	return tool.ToMCPTool(), nil
 }
@@ -1,11 +1,27 @@
 package reconciler
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
 	"testing"
 
+	"github.com/mark3labs/mcp-go/client/transport"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/kagent-dev/kagent/go/api/v1alpha2"
+	"github.com/kagent-dev/kagent/go/internal/database"
 )
 
 // TestComputeStatusSecretHash_Output verifies the output of the hash function
@@ -191,3 +207,203 @@ func TestComputeStatusSecretHash_Deterministic(t *testing.T) {
 		})
 	}
 }
+
+func TestConvertMCPTool(t *testing.T) {
+	t.Run("structured input schema and annotations", func(t *testing.T) {
+		tool := mcp.NewTool("k8s_get_resources",
+			mcp.WithDescription("Get Kubernetes resources"),
+			mcp.WithString("resource_type", mcp.Required()),
+			mcp.WithReadOnlyHintAnnotation(true),
+		)
+
+		got, err := convertMCPTool(tool)
+		require.NoError(t, err)
+
+		assert.Equal(t, "k8s_get_resources", got.Name)
+		assert.Equal(t, "Get Kubernetes resources", got.Description)
+		require.NotNil(t, got.InputSchema)
+		assert.JSONEq(t, `{"type":"object","properties":{"resource_type":{"type":"string"}},"required":["resource_type"]}`, string(got.InputSchema.Raw))
+		assert.Nil(t, got.OutputSchema)
+		require.NotNil(t, got.Annotations)
+		assert.Equal(t, ptr.To(true), got.Annotations.ReadOnlyHint)
+	})
+
+	t.Run("raw schemas are normalized", func(t *testing.T) {
+		tool := mcp.NewToolWithRawSchema("echo", "Echo the input",
+			json.RawMessage(`{ "type": "object", "properties": { "text": { "type": "string", "maxLength": 10 } } }`))
+		tool.RawOutputSchema = json.RawMessage(`{"type":"object","properties":{"text":{"type":"string"}}}`)
+
+		got, err := convertMCPTool(tool)
+		require.NoError(t, err)
+
+		assert.Equal(t, `{"properties":{"text":{"maxLength":10,"type":"string"}},"type":"object"}`, string(got.InputSchema.Raw))
+		require.NotNil(t, got.OutputSchema)
+		assert.Equal(t, `{"properties":{"text":{"type":"string"}},"type":"object"}`, string(got.OutputSchema.Raw))
+	})
+}
+
+func TestStatusTools(t *testing.T) {
+	small := &v1alpha2.MCPTool{
+		Name:        "small",
+		InputSchema: &runtime.RawExtension{Raw: []byte(`{"type":"object"}`)},
+	}
+	large := &v1alpha2.MCPTool{
+		Name:        "large",
+		InputSchema: &runtime.RawExtension{Raw: []byte(`{"type":"object","description":"` + strings.Repeat("x", v1alpha2.MaxStatusToolSchemaBytes) + `"}`)},
+		Annotations: &v1alpha2.MCPToolAnnotations{Title: "Large"},
+	}
+
+	got, omittedSchemas, omittedTools := statusTools([]*v1alpha2.MCPTool{small, large})
+
+	assert.Zero(t, omittedSchemas)
+	assert.Zero(t, omittedTools)
+	require.Len(t, got, 2)
+	assert.Equal(t, small, got[0])
+	assert.Equal(t, "large", got[1].Name)
+	assert.Nil(t, got[1].InputSchema)
+	assert.True(t, got[1].SchemaOmitted)
+	assert.Equal(t, large.Annotations, got[1].Annotations)
+	// the full schema is kept for the database
+	assert.NotNil(t, large.InputSchema)
+	assert.False(t, large.SchemaOmitted)
+}
+
+func TestStatusToolsBoundsTotalSize(t *testing.T) {
+	schema := &runtime.RawExtension{Raw: []byte(`{"type":"object","description":"` + strings.Repeat("x", 3*1024) + `"}`)}
+	description := strings.Repeat("d", 32*1024)
+
+	var tools []*v1alpha2.MCPTool
+	for i := range 100 {
+		tools = append(tools, &v1alpha2.MCPTool{Name: fmt.Sprintf("tool-%d", i), InputSchema: schema})
+	}
+	for i := range 10 {
+		tools = append(tools, &v1alpha2.MCPTool{Name: fmt.Sprintf("described-%d", i), Description: description})
+	}
+
+	got, omittedSchemas, omittedTools := statusTools(tools)
+
+	total := 0
+	for _, tool := range got {
+		total += len(tool.Name) + len(tool.Description)
+		if tool.InputSchema != nil {
+			total += len(tool.InputSchema.Raw)
+		}
+	}
+	assert.LessOrEqual(t, total, v1alpha2.MaxStatusToolsBytes)
+	assert.Positive(t, omittedSchemas)
+	assert.Positive(t, omittedTools)
+	assert.Len(t, got, len(tools)-omittedTools)
+	assert.NotNil(t, got[0].InputSchema)
+	assert.True(t, got[99].SchemaOmitted)
+	assert.Nil(t, got[99].InputSchema)
+}
+
+func TestReconcileRemoteMCPServerStatusBoundsLists(t *testing.T) {
+	server := &v1alpha2.RemoteMCPServer{ObjectMeta: metav1.ObjectMeta{Name: "toolserver", Namespace: "test", Generation: 1}}
+	scheme := runtime.NewScheme()
+	require.NoError(t, v1alpha2.AddToScheme(scheme))
+	kubeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(server).WithStatusSubresource(server).Build()
+	a := &kagentReconciler{kube: kubeClient}
+
+	discovery := &mcpDiscovery{}
+	for i := range v1alpha2.MaxStatusResources + 5 {
+		discovery.Resources = append(discovery.Resources, &v1alpha2.MCPResource{URI: fmt.Sprintf("file:///%d", i)})
+	}
+	discovery.Prompts = []*v1alpha2.MCPPrompt{{Name: "review"}}
+
+	require.NoError(t, a.reconcileRemoteMCPServerStatus(context.Background(), server, discovery, nil, 0, nil))
+	assert.Len(t, server.Status.DiscoveredResources, v1alpha2.MaxStatusResources)
+	assert.Len(t, server.Status.DiscoveredPrompts, 1)
+	condition := meta.FindStatusCondition(server.Status.Conditions, v1alpha2.RemoteMCPServerConditionTypeStatusTruncated)
+	require.NotNil(t, condition)
+	assert.Equal(t, metav1.ConditionTrue, condition.Status)
+	assert.Contains(t, condition.Message, "5 resources")
+
+	// the bounded lists kept from the status leave the condition as is
+	discovery = &mcpDiscovery{Resources: server.Status.DiscoveredResources, FromStatus: true}
+	require.NoError(t, a.reconcileRemoteMCPServerStatus(context.Background(), server, discovery, nil, 0, nil))
+	assert.True(t, meta.IsStatusConditionTrue(server.Status.Conditions, v1alpha2.RemoteMCPServerConditionTypeStatusTruncated))
+
+	discovery = &mcpDiscovery{Resources: discovery.Resources[:1]}
+	require.NoError(t, a.reconcileRemoteMCPServerStatus(context.Background(), server, discovery, nil, 0, nil))
+	assert.True(t, meta.IsStatusConditionFalse(server.Status.Conditions, v1alpha2.RemoteMCPServerConditionTypeStatusTruncated))
+
+	// tools past the size bound are reported too
+	discovery = &mcpDiscovery{Tools: []*v1alpha2.MCPTool{
+		{Name: "first", Description: strings.Repeat("d", v1alpha2.MaxStatusToolsBytes)},
+		{Name: "second", Description: strings.Repeat("d", v1alpha2.MaxStatusToolsBytes)},
+	}}
+	require.NoError(t, a.reconcileRemoteMCPServerStatus(context.Background(), server, discovery, nil, 0, nil))
+	assert.Empty(t, server.Status.DiscoveredTools)
+	condition = meta.FindStatusCondition(server.Status.Conditions, v1alpha2.RemoteMCPServerConditionTypeStatusTruncated)
+	require.NotNil(t, condition)
+	assert.Equal(t, metav1.ConditionTrue, condition.Status)
+	assert.Contains(t, condition.Message, "2 tools")
+}
+
+func TestDiscover(t *testing.T) {
+	mcpServer := server.NewMCPServer("test", "1.0.0",
+		server.WithToolCapabilities(false),
+		server.WithResourceCapabilities(false, false),
+		server.WithPromptCapabilities(false),
+		server.WithPaginationLimit(2),
+	)
+	for i := range 5 {
+		mcpServer.AddTool(echoTool(fmt.Sprintf("tool-%d", i)))
+	}
+	mcpServer.AddResource(mcp.NewResource("file:///readme.md", "readme", mcp.WithMIMEType("text/markdown")),
+		func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+			return nil, nil
+		})
+	mcpServer.AddPrompt(mcp.NewPrompt("review", mcp.WithPromptDescription("Review a change"), mcp.WithArgument("diff", mcp.RequiredArgument())),
+		func(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+			return nil, nil
+		})
+	testServer := server.NewTestServer(mcpServer)
+	defer testServer.Close()
+
+	tsp, err := transport.NewSSE(testServer.URL + "/sse")
+	require.NoError(t, err)
+
+	discovery, err := (&kagentReconciler{}).discover(context.Background(), tsp, &database.ToolServer{Name: "test/toolserver"})
+	require.NoError(t, err)
+
+	assert.False(t, discovery.Truncated)
+	require.Len(t, discovery.Tools, 5)
+	for i, tool := range discovery.Tools {
+		assert.Equal(t, fmt.Sprintf("tool-%d", i), tool.Name)
+	}
+	assert.Equal(t, []*v1alpha2.MCPResource{{URI: "file:///readme.md", Name: "readme", MimeType: "text/markdown"}}, discovery.Resources)
+	assert.Equal(t, []*v1alpha2.MCPPrompt{{
+		Name:        "review",
+		Description: "Review a change",
+		Arguments:   []v1alpha2.MCPPromptArgument{{Name: "diff", Required: true}},
+	}}, discovery.Prompts)
+}
+
+func TestListAllPages(t *testing.T) {
+	pages := func(count int) func(ctx context.Context, cursor mcp.Cursor) ([]int, mcp.Cursor, error) {
+		return func(ctx context.Context, cursor mcp.Cursor) ([]int, mcp.Cursor, error) {
+			page := 0
+			if cursor != "" {
+				_, err := fmt.Sscanf(string(cursor), "page-%d", &page)
+				require.NoError(t, err)
+			}
+			next := mcp.Cursor(fmt.Sprintf("page-%d", page+1))
+			if page+1 == count {
+				next = ""
+			}
+			return []int{page}, next, nil
+		}
+	}
+
+	items, truncated, err := listAllPages(context.Background(), pages(3))
+	require.NoError(t, err)
+	assert.False(t, truncated)
+	assert.Equal(t, []int{0, 1, 2}, items)
+
+	items, truncated, err = listAllPages(context.Background(), pages(maxDiscoveryPages+1))
+	require.NoError(t, err)
+	assert.True(t, truncated)
+	assert.Len(t, items, maxDiscoveryPages)
+}
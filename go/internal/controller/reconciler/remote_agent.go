@@ -0,0 +1,184 @@
+package reconciler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"trpc.group/trpc-go/trpc-a2a-go/server"
+
+	"github.com/kagent-dev/kagent/go/api/v1alpha2"
+	"github.com/kagent-dev/kagent/go/internal/database"
+	"github.com/kagent-dev/kagent/go/internal/remoteagent"
+	"github.com/kagent-dev/kagent/go/internal/utils"
+)
+
+// remoteAgentType is the type of the remote agents in the database
+const remoteAgentType = "RemoteAgent"
+
+// agentCardFetchTimeout bounds the fetch of the agent card of a remote agent
+const agentCardFetchTimeout = 10 * time.Second
+
+// ReconcileKagentRemoteAgent fetches the agent card of a RemoteAgent into its status, and stores
+// the agent in the database so that sessions can be started with it.
+func (a *kagentReconciler) ReconcileKagentRemoteAgent(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	agentRef := req.NamespacedName.String()
+	l := reconcileLog.WithValues("remoteAgent", agentRef)
+	id := utils.ConvertToPythonIdentifier(agentRef)
+
+	remoteAgent := &v1alpha2.RemoteAgent{}
+	if err := a.kube.Get(ctx, req.NamespacedName, remoteAgent); err != nil {
+		if apierrors.IsNotFound(err) {
+			// the database entry belongs to the Agent of the same name, if any
+			if conflictErr := a.checkRemoteAgentName(ctx, req.Namespace, req.Name); conflictErr != nil {
+				return ctrl.Result{}, nil
+			}
+			if err := a.dbClient.DeleteAgent(id); err != nil {
+				l.Error(err, "failed to delete remote agent")
+			}
+			return ctrl.Result{}, nil
+		}
+
+		return ctrl.Result{}, fmt.Errorf("failed to get remote agent %s: %v", agentRef, err)
+	}
+
+	var (
+		card       *server.AgentCard
+		httpClient *http.Client
+		fetchErr   error
+	)
+	err := a.checkRemoteAgentName(ctx, remoteAgent.Namespace, remoteAgent.Name)
+	if err == nil {
+		httpClient, err = remoteagent.HTTPClient(ctx, a.kube, remoteAgent)
+	}
+	if err == nil {
+		card, fetchErr = fetchRemoteAgentCard(ctx, httpClient, remoteAgent)
+	} else {
+		fetchErr = fmt.Errorf("remote agent is not accepted")
+	}
+	if err != nil {
+		l.Error(err, "failed to reconcile remote agent")
+	} else if fetchErr != nil {
+		l.Info("failed to fetch agent card of remote agent", "error", fetchErr.Error())
+	}
+
+	if card != nil {
+		if storeErr := a.dbClient.StoreAgent(&database.Agent{ID: id, Type: remoteAgentType}); storeErr != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to store remote agent %s: %v", agentRef, storeErr)
+		}
+	}
+
+	if err := a.reconcileRemoteAgentStatus(ctx, remoteAgent, card, err, fetchErr); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to reconcile remote agent status %s: %v", agentRef, err)
+	}
+
+	// loop to pick up the changes of the agent card
+	return ctrl.Result{RequeueAfter: remoteAgent.Spec.GetResyncInterval()}, nil
+}
+
+// checkRemoteAgentName fails when an Agent has the name of the remote agent, as they would be
+// served at the same A2A endpoint
+func (a *kagentReconciler) checkRemoteAgentName(ctx context.Context, namespace, name string) error {
+	agent := &v1alpha2.Agent{}
+	err := a.kube.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, agent)
+	switch {
+	case err == nil:
+		return fmt.Errorf("agent %s/%s already exists, remote agents must not have the name of an agent", namespace, name)
+	case apierrors.IsNotFound(err):
+		return nil
+	default:
+		return fmt.Errorf("failed to check for agent %s/%s: %v", namespace, name, err)
+	}
+}
+
+func fetchRemoteAgentCard(ctx context.Context, httpClient *http.Client, remoteAgent *v1alpha2.RemoteAgent) (*server.AgentCard, error) {
+	ctx, cancel := context.WithTimeout(ctx, agentCardFetchTimeout)
+	defer cancel()
+	return remoteagent.FetchAgentCard(ctx, httpClient, &remoteAgent.Spec)
+}
+
+func (a *kagentReconciler) reconcileRemoteAgentStatus(
+	ctx context.Context,
+	remoteAgent *v1alpha2.RemoteAgent,
+	card *server.AgentCard,
+	err error,
+	fetchErr error,
+) error {
+	accepted := metav1.Condition{
+		Type:               v1alpha2.RemoteAgentConditionTypeAccepted,
+		Status:             metav1.ConditionTrue,
+		Reason:             "Reconciled",
+		ObservedGeneration: remoteAgent.Generation,
+	}
+	if err != nil {
+		accepted.Status = metav1.ConditionFalse
+		accepted.Reason = "ReconcileFailed"
+		accepted.Message = err.Error()
+	}
+	conditionChanged := meta.SetStatusCondition(&remoteAgent.Status.Conditions, accepted)
+
+	ready := metav1.Condition{
+		Type:               v1alpha2.RemoteAgentConditionTypeReady,
+		Status:             metav1.ConditionTrue,
+		Reason:             "AgentCardFetched",
+		ObservedGeneration: remoteAgent.Generation,
+	}
+	agentCard, skills := remoteAgent.Status.AgentCard, remoteAgent.Status.DiscoveredSkills
+	if fetchErr != nil {
+		// the last agent card fetched is kept, the agent may only be unreachable for a while
+		ready.Status = metav1.ConditionFalse
+		ready.Reason = "AgentCardFetchFailed"
+		ready.Message = fetchErr.Error()
+	} else {
+		// a successful fetch always updates the last connected time
+		conditionChanged = true
+		remoteAgent.Status.LastConnectedTime = ptr.To(metav1.Now())
+		agentCard, skills = statusAgentCard(card)
+	}
+	if meta.SetStatusCondition(&remoteAgent.Status.Conditions, ready) {
+		conditionChanged = true
+	}
+
+	// only update if the status has changed to prevent looping the reconciler
+	if !conditionChanged &&
+		remoteAgent.Status.ObservedGeneration == remoteAgent.Generation &&
+		reflect.DeepEqual(remoteAgent.Status.AgentCard, agentCard) &&
+		reflect.DeepEqual(remoteAgent.Status.DiscoveredSkills, skills) {
+		return nil
+	}
+
+	remoteAgent.Status.ObservedGeneration = remoteAgent.Generation
+	remoteAgent.Status.AgentCard = agentCard
+	remoteAgent.Status.DiscoveredSkills = skills
+
+	if err := a.kube.Status().Update(ctx, remoteAgent); err != nil {
+		return fmt.Errorf("failed to update remote agent status: %v", err)
+	}
+
+	return nil
+}
+
+// statusAgentCard returns the agent card and the skills of an agent as kept in its status
+func statusAgentCard(card *server.AgentCard) (*v1alpha2.RemoteAgentCard, []v1alpha2.AgentSkill) {
+	agentCard := &v1alpha2.RemoteAgentCard{
+		Name:            card.Name,
+		Description:     card.Description,
+		URL:             card.URL,
+		Version:         card.Version,
+		ProtocolVersion: ptr.Deref(card.ProtocolVersion, ""),
+		Streaming:       ptr.Deref(card.Capabilities.Streaming, false),
+	}
+	var skills []v1alpha2.AgentSkill
+	for _, skill := range card.Skills {
+		skills = append(skills, v1alpha2.AgentSkill(skill))
+	}
+	return agentCard, skills
+}
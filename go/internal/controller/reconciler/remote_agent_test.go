@@ -0,0 +1,134 @@
+package reconciler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"trpc.group/trpc-go/trpc-a2a-go/server"
+
+	"github.com/kagent-dev/kagent/go/api/v1alpha2"
+	fakedb "github.com/kagent-dev/kagent/go/internal/database/fake"
+	"github.com/kagent-dev/kagent/go/internal/utils"
+)
+
+func TestReconcileKagentRemoteAgent(t *testing.T) {
+	cardAvailable := true
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !cardAvailable {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(server.AgentCard{
+			Name:         "remote",
+			Description:  "A remote agent",
+			URL:          "http://" + r.Host,
+			Version:      "1.0.0",
+			Capabilities: server.AgentCapabilities{Streaming: ptr.To(true)},
+			Skills:       []server.AgentSkill{{ID: "search", Name: "Search", Tags: []string{"web"}}},
+		})
+	}))
+	defer srv.Close()
+
+	remoteAgent := func(name string) *v1alpha2.RemoteAgent {
+		return &v1alpha2.RemoteAgent{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "test", Generation: 1},
+			Spec:       v1alpha2.RemoteAgentSpec{URL: srv.URL},
+		}
+	}
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, v1alpha2.AddToScheme(scheme))
+	kubeClient := fake.NewClientBuilder().WithScheme(scheme).
+		WithStatusSubresource(&v1alpha2.RemoteAgent{}).
+		WithObjects(
+			remoteAgent("remote"),
+			remoteAgent("conflict"),
+			&v1alpha2.Agent{ObjectMeta: metav1.ObjectMeta{Name: "conflict", Namespace: "test"}},
+		).Build()
+	dbClient := fakedb.NewClient()
+	a := &kagentReconciler{kube: kubeClient, dbClient: dbClient}
+
+	reconcile := func(name string) *v1alpha2.RemoteAgent {
+		key := types.NamespacedName{Namespace: "test", Name: name}
+		result, err := a.ReconcileKagentRemoteAgent(context.Background(), ctrl.Request{NamespacedName: key})
+		require.NoError(t, err)
+		assert.Equal(t, v1alpha2.DefaultRemoteAgentResyncInterval, result.RequeueAfter)
+
+		reconciled := &v1alpha2.RemoteAgent{}
+		require.NoError(t, kubeClient.Get(context.Background(), key, reconciled))
+		return reconciled
+	}
+
+	t.Run("fetches the agent card", func(t *testing.T) {
+		reconciled := reconcile("remote")
+		assert.True(t, meta.IsStatusConditionTrue(reconciled.Status.Conditions, v1alpha2.RemoteAgentConditionTypeAccepted))
+		assert.True(t, meta.IsStatusConditionTrue(reconciled.Status.Conditions, v1alpha2.RemoteAgentConditionTypeReady))
+		assert.Equal(t, &v1alpha2.RemoteAgentCard{
+			Name:        "remote",
+			Description: "A remote agent",
+			URL:         srv.URL,
+			Version:     "1.0.0",
+			Streaming:   true,
+		}, reconciled.Status.AgentCard)
+		assert.Equal(t, []v1alpha2.AgentSkill{{ID: "search", Name: "Search", Tags: []string{"web"}}}, reconciled.Status.DiscoveredSkills)
+		assert.NotNil(t, reconciled.Status.LastConnectedTime)
+
+		agent, err := dbClient.GetAgent(utils.ConvertToPythonIdentifier("test/remote"))
+		require.NoError(t, err)
+		assert.Equal(t, remoteAgentType, agent.Type)
+	})
+
+	t.Run("keeps the agent card when the agent is unreachable", func(t *testing.T) {
+		cardAvailable = false
+		defer func() { cardAvailable = true }()
+
+		reconciled := reconcile("remote")
+		assert.True(t, meta.IsStatusConditionTrue(reconciled.Status.Conditions, v1alpha2.RemoteAgentConditionTypeAccepted))
+		ready := meta.FindStatusCondition(reconciled.Status.Conditions, v1alpha2.RemoteAgentConditionTypeReady)
+		require.NotNil(t, ready)
+		assert.Equal(t, metav1.ConditionFalse, ready.Status)
+		assert.Equal(t, "AgentCardFetchFailed", ready.Reason)
+		assert.Contains(t, ready.Message, "status 503")
+		require.NotNil(t, reconciled.Status.AgentCard)
+		assert.Equal(t, "remote", reconciled.Status.AgentCard.Name)
+	})
+
+	t.Run("rejects the name of an agent", func(t *testing.T) {
+		reconciled := reconcile("conflict")
+		accepted := meta.FindStatusCondition(reconciled.Status.Conditions, v1alpha2.RemoteAgentConditionTypeAccepted)
+		require.NotNil(t, accepted)
+		assert.Equal(t, metav1.ConditionFalse, accepted.Status)
+		assert.Contains(t, accepted.Message, "already exists")
+		assert.Nil(t, reconciled.Status.AgentCard)
+
+		_, err := dbClient.GetAgent(utils.ConvertToPythonIdentifier("test/conflict"))
+		assert.Error(t, err)
+	})
+
+	t.Run("deletes the agent with the remote agent", func(t *testing.T) {
+		require.NoError(t, kubeClient.Delete(context.Background(), &v1alpha2.RemoteAgent{
+			ObjectMeta: metav1.ObjectMeta{Name: "remote", Namespace: "test"},
+		}, &client.DeleteOptions{}))
+
+		_, err := a.ReconcileKagentRemoteAgent(context.Background(), ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "test", Name: "remote"}})
+		require.NoError(t, err)
+
+		_, err = dbClient.GetAgent(utils.ConvertToPythonIdentifier("test/remote"))
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,126 @@
+package reconciler
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	mcp_client "github.com/mark3labs/mcp-go/client"
+	"github.com/mark3labs/mcp-go/mcp"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kagent-dev/kagent/go/api/v1alpha2"
+	"github.com/kagent-dev/kagent/go/internal/version"
+)
+
+const healthCheckTimeout = 10 * time.Second
+
+// pingRemoteMCPServer opens a session to the server and returns the round trip
+// time of a MCP ping.
+func (a *kagentReconciler) pingRemoteMCPServer(ctx context.Context, server *v1alpha2.RemoteMCPServer) (time.Duration, error) {
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+
+	tsp, err := a.createMcpTransport(ctx, &server.Spec, server.Namespace)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create transport: %v", err)
+	}
+
+	client := mcp_client.NewClient(tsp)
+	if err := client.Start(ctx); err != nil {
+		return 0, fmt.Errorf("failed to start client: %v", err)
+	}
+	defer client.Close()
+
+	if _, err := client.Initialize(ctx, mcp.InitializeRequest{
+		Params: mcp.InitializeParams{
+			ProtocolVersion: mcp.LATEST_PROTOCOL_VERSION,
+			Capabilities:    mcp.ClientCapabilities{},
+			ClientInfo: mcp.Implementation{
+				Name:    "kagent-controller",
+				Version: version.Version,
+			},
+		},
+	}); err != nil {
+		return 0, fmt.Errorf("failed to initialize client: %v", err)
+	}
+
+	start := time.Now()
+	if err := client.Ping(ctx); err != nil {
+		return 0, fmt.Errorf("ping failed: %v", err)
+	}
+	return time.Since(start), nil
+}
+
+// discoveryTracker remembers when the tools of each RemoteMCPServer were last
+// discovered, so that health checks, which run more often, only rediscover them
+// once the resync interval has passed or the server changed.
+type discoveryTracker struct {
+	mu          sync.Mutex
+	discoveries map[types.NamespacedName]discoveryRecord
+}
+
+type discoveryRecord struct {
+	generation         int64
+	refreshRequestedAt string
+	discoveredAt       time.Time
+}
+
+func newDiscoveryTracker() *discoveryTracker {
+	return &discoveryTracker{
+		discoveries: map[types.NamespacedName]discoveryRecord{},
+	}
+}
+
+// due reports whether the tools of the server should be discovered now.
+func (t *discoveryTracker) due(server *v1alpha2.RemoteMCPServer, now time.Time) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	record, ok := t.discoveries[client.ObjectKeyFromObject(server)]
+	if !ok ||
+		record.generation != server.Generation ||
+		record.refreshRequestedAt != server.Annotations[v1alpha2.RemoteMCPServerRefreshAnnotation] {
+		return true
+	}
+
+	resyncInterval := server.Spec.GetResyncInterval()
+	return resyncInterval > 0 && now.Sub(record.discoveredAt) >= resyncInterval
+}
+
+// done records a successful discovery of the tools of the server.
+func (t *discoveryTracker) done(server *v1alpha2.RemoteMCPServer, now time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.discoveries[client.ObjectKeyFromObject(server)] = discoveryRecord{
+		generation:         server.Generation,
+		refreshRequestedAt: server.Annotations[v1alpha2.RemoteMCPServerRefreshAnnotation],
+		discoveredAt:       now,
+	}
+}
+
+// forget makes the next reconcile of the server rediscover its tools.
+func (t *discoveryTracker) forget(server types.NamespacedName) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	delete(t.discoveries, server)
+}
+
+// remoteMCPServerRequeueAfter returns the shortest of the enabled resync and
+// health check intervals, or 0 when both are disabled.
+func remoteMCPServerRequeueAfter(spec *v1alpha2.RemoteMCPServerSpec) time.Duration {
+	resyncInterval := spec.GetResyncInterval()
+	healthCheckInterval := spec.GetHealthCheckInterval()
+	switch {
+	case resyncInterval == 0:
+		return healthCheckInterval
+	case healthCheckInterval == 0:
+		return resyncInterval
+	default:
+		return min(resyncInterval, healthCheckInterval)
+	}
+}
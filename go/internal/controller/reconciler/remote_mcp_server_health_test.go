@@ -0,0 +1,112 @@
+package reconciler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/kagent-dev/kagent/go/api/v1alpha2"
+)
+
+func TestPingRemoteMCPServer(t *testing.T) {
+	testServer := server.NewTestServer(server.NewMCPServer("test", "1.0.0"))
+	defer testServer.Close()
+
+	a := &kagentReconciler{}
+	remoteMCPServer := &v1alpha2.RemoteMCPServer{
+		ObjectMeta: metav1.ObjectMeta{Name: "toolserver", Namespace: "test"},
+		Spec: v1alpha2.RemoteMCPServerSpec{
+			Protocol: v1alpha2.RemoteMCPServerProtocolSse,
+			URL:      testServer.URL + "/sse",
+		},
+	}
+
+	latency, err := a.pingRemoteMCPServer(context.Background(), remoteMCPServer)
+	require.NoError(t, err)
+	assert.Positive(t, latency)
+
+	testServer.Close()
+	_, err = a.pingRemoteMCPServer(context.Background(), remoteMCPServer)
+	assert.Error(t, err)
+}
+
+func TestDiscoveryTracker(t *testing.T) {
+	now := time.Now()
+	tracker := newDiscoveryTracker()
+	remoteMCPServer := &v1alpha2.RemoteMCPServer{
+		ObjectMeta: metav1.ObjectMeta{Name: "toolserver", Namespace: "test", Generation: 1},
+		Spec:       v1alpha2.RemoteMCPServerSpec{URL: "http://toolserver.test:8084/mcp"},
+	}
+
+	assert.True(t, tracker.due(remoteMCPServer, now), "never discovered")
+
+	tracker.done(remoteMCPServer, now)
+	assert.False(t, tracker.due(remoteMCPServer, now.Add(v1alpha2.DefaultRemoteMCPServerHealthCheckInterval)))
+	assert.True(t, tracker.due(remoteMCPServer, now.Add(v1alpha2.DefaultRemoteMCPServerResyncInterval)))
+
+	remoteMCPServer.Annotations = map[string]string{v1alpha2.RemoteMCPServerRefreshAnnotation: now.String()}
+	assert.True(t, tracker.due(remoteMCPServer, now), "refresh requested")
+	tracker.done(remoteMCPServer, now)
+
+	remoteMCPServer.Generation = 2
+	assert.True(t, tracker.due(remoteMCPServer, now), "spec changed")
+	tracker.done(remoteMCPServer, now)
+
+	tracker.forget(types.NamespacedName{Name: "toolserver", Namespace: "test"})
+	assert.True(t, tracker.due(remoteMCPServer, now), "tool list changed")
+	tracker.done(remoteMCPServer, now)
+
+	remoteMCPServer.Spec.ResyncInterval = &metav1.Duration{}
+	assert.False(t, tracker.due(remoteMCPServer, now.Add(time.Hour)), "periodic rediscovery disabled")
+}
+
+func TestRemoteMCPServerRequeueAfter(t *testing.T) {
+	tests := []struct {
+		name                string
+		resyncInterval      *metav1.Duration
+		healthCheckInterval *metav1.Duration
+		expected            time.Duration
+	}{
+		{
+			name:     "defaults",
+			expected: v1alpha2.DefaultRemoteMCPServerHealthCheckInterval,
+		},
+		{
+			name:           "resync more often than health checks",
+			resyncInterval: &metav1.Duration{Duration: 10 * time.Second},
+			expected:       10 * time.Second,
+		},
+		{
+			name:                "health checks disabled",
+			healthCheckInterval: &metav1.Duration{},
+			expected:            v1alpha2.DefaultRemoteMCPServerResyncInterval,
+		},
+		{
+			name:           "resync disabled",
+			resyncInterval: &metav1.Duration{},
+			expected:       v1alpha2.DefaultRemoteMCPServerHealthCheckInterval,
+		},
+		{
+			name:                "both disabled",
+			resyncInterval:      &metav1.Duration{},
+			healthCheckInterval: &metav1.Duration{},
+			expected:            0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := &v1alpha2.RemoteMCPServerSpec{
+				ResyncInterval:      tt.resyncInterval,
+				HealthCheckInterval: tt.healthCheckInterval,
+			}
+			assert.Equal(t, tt.expected, remoteMCPServerRequeueAfter(spec))
+		})
+	}
+}
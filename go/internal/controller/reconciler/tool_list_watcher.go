@@ -0,0 +1,180 @@
+package reconciler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	mcp_client "github.com/mark3labs/mcp-go/client"
+	"github.com/mark3labs/mcp-go/client/transport"
+	"github.com/mark3labs/mcp-go/mcp"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	"github.com/kagent-dev/kagent/go/api/v1alpha2"
+	"github.com/kagent-dev/kagent/go/internal/version"
+)
+
+const toolListWatchTimeout = 10 * time.Second
+
+// toolListWatcher keeps a session open to the RemoteMCPServers that announce the
+// tools listChanged capability, and requests a reconcile of the server whenever
+// it sends notifications/tools/list_changed.
+type toolListWatcher struct {
+	mu       sync.Mutex
+	sessions map[types.NamespacedName]*toolListSession
+	events   chan<- event.GenericEvent
+	// onChange is called before the reconcile of a server that changed is requested
+	onChange func(types.NamespacedName)
+}
+
+type toolListSession struct {
+	spec v1alpha2.RemoteMCPServerSpec
+	// nil when the server does not support tool list notifications
+	client *mcp_client.Client
+	cancel context.CancelFunc
+}
+
+func newToolListWatcher(events chan<- event.GenericEvent, onChange func(types.NamespacedName)) *toolListWatcher {
+	return &toolListWatcher{
+		sessions: map[types.NamespacedName]*toolListSession{},
+		events:   events,
+		onChange: onChange,
+	}
+}
+
+// watch makes sure a session is open for the server. Existing sessions are kept
+// while the spec is unchanged and the server still answers pings.
+func (w *toolListWatcher) watch(ctx context.Context, server types.NamespacedName, spec *v1alpha2.RemoteMCPServerSpec, headers map[string]string, httpClient *http.Client) error {
+	if w == nil || w.events == nil {
+		return nil
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if session, ok := w.sessions[server]; ok {
+		if equality.Semantic.DeepEqual(&session.spec, spec) {
+			if session.client == nil {
+				return nil
+			}
+			pingCtx, cancel := context.WithTimeout(ctx, toolListWatchTimeout)
+			err := session.client.Ping(pingCtx)
+			cancel()
+			if err == nil {
+				return nil
+			}
+		}
+		session.close()
+		delete(w.sessions, server)
+	}
+
+	session, err := w.connect(ctx, server, spec, headers, httpClient)
+	if err != nil {
+		return err
+	}
+	w.sessions[server] = session
+	return nil
+}
+
+// stop closes the session of a server, if any.
+func (w *toolListWatcher) stop(server types.NamespacedName) {
+	if w == nil {
+		return
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if session, ok := w.sessions[server]; ok {
+		session.close()
+		delete(w.sessions, server)
+	}
+}
+
+func (w *toolListWatcher) connect(ctx context.Context, server types.NamespacedName, spec *v1alpha2.RemoteMCPServerSpec, headers map[string]string, httpClient *http.Client) (*toolListSession, error) {
+	var (
+		tsp transport.Interface
+		err error
+	)
+	switch spec.Protocol {
+	case v1alpha2.RemoteMCPServerProtocolSse:
+		tsp, err = transport.NewSSE(spec.URL, transport.WithHeaders(headers), transport.WithHTTPClient(httpClient))
+	default:
+		tsp, err = transport.NewStreamableHTTP(spec.URL, transport.WithHTTPHeaders(headers), transport.WithHTTPBasicClient(httpClient), transport.WithContinuousListening())
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create transport: %v", err)
+	}
+
+	// The session outlives the reconcile that opened it
+	sessionCtx, cancel := context.WithCancel(context.Background())
+	session := &toolListSession{
+		spec:   *spec.DeepCopy(),
+		cancel: cancel,
+	}
+
+	client := mcp_client.NewClient(tsp)
+	if err := client.Start(sessionCtx); err != nil {
+		cancel()
+		return nil, fmt.Errorf("failed to start client: %v", err)
+	}
+
+	initCtx, initCancel := context.WithTimeout(ctx, toolListWatchTimeout)
+	defer initCancel()
+	result, err := client.Initialize(initCtx, mcp.InitializeRequest{
+		Params: mcp.InitializeParams{
+			ProtocolVersion: mcp.LATEST_PROTOCOL_VERSION,
+			Capabilities:    mcp.ClientCapabilities{},
+			ClientInfo: mcp.Implementation{
+				Name:    "kagent-controller",
+				Version: version.Version,
+			},
+		},
+	})
+	if err != nil {
+		client.Close()
+		cancel()
+		return nil, fmt.Errorf("failed to initialize client: %v", err)
+	}
+
+	if result.Capabilities.Tools == nil || !result.Capabilities.Tools.ListChanged {
+		// Only periodic rediscovery is possible for this server
+		client.Close()
+		cancel()
+		return session, nil
+	}
+
+	client.OnNotification(func(notification mcp.JSONRPCNotification) {
+		if notification.Method != mcp.MethodNotificationToolsListChanged {
+			return
+		}
+		reconcileLog.Info("tool list changed", "remoteMCPServer", server.String())
+		if w.onChange != nil {
+			w.onChange(server)
+		}
+		select {
+		case w.events <- event.GenericEvent{
+			Object: &v1alpha2.RemoteMCPServer{
+				ObjectMeta: metav1.ObjectMeta{Namespace: server.Namespace, Name: server.Name},
+			},
+		}:
+		default:
+			// The periodic resync will pick the change up
+			reconcileLog.Info("dropped tool list change, reconcile queue is full", "remoteMCPServer", server.String())
+		}
+	})
+	session.client = client
+	return session, nil
+}
+
+func (s *toolListSession) close() {
+	if s.client != nil {
+		s.client.Close()
+	}
+	s.cancel()
+}
@@ -0,0 +1,94 @@
+package reconciler
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	"github.com/kagent-dev/kagent/go/api/v1alpha2"
+	"github.com/kagent-dev/kagent/go/internal/database"
+)
+
+func echoTool(name string) (mcp.Tool, server.ToolHandlerFunc) {
+	return mcp.NewTool(name), func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return mcp.NewToolResultText(name), nil
+	}
+}
+
+func TestToolListWatcher(t *testing.T) {
+	mcpServer := server.NewMCPServer("test", "1.0.0", server.WithToolCapabilities(true))
+	mcpServer.AddTool(echoTool("first"))
+	testServer := server.NewTestServer(mcpServer)
+	defer testServer.Close()
+
+	events := make(chan event.GenericEvent, 1)
+	watcher := newToolListWatcher(events, nil)
+	serverRef := types.NamespacedName{Namespace: "test", Name: "toolserver"}
+	spec := &v1alpha2.RemoteMCPServerSpec{
+		Protocol: v1alpha2.RemoteMCPServerProtocolSse,
+		URL:      testServer.URL + "/sse",
+	}
+
+	require.NoError(t, watcher.watch(context.Background(), serverRef, spec, nil, http.DefaultClient))
+	defer watcher.stop(serverRef)
+	require.NotNil(t, watcher.sessions[serverRef].client)
+
+	// watching again with the same spec keeps the session
+	session := watcher.sessions[serverRef]
+	require.NoError(t, watcher.watch(context.Background(), serverRef, spec, nil, http.DefaultClient))
+	assert.Same(t, session, watcher.sessions[serverRef])
+
+	mcpServer.AddTool(echoTool("second"))
+
+	select {
+	case e := <-events:
+		assert.Equal(t, serverRef.Name, e.Object.GetName())
+		assert.Equal(t, serverRef.Namespace, e.Object.GetNamespace())
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected a tool list change event")
+	}
+
+	watcher.stop(serverRef)
+	assert.NotContains(t, watcher.sessions, serverRef)
+}
+
+func TestToolListWatcherWithoutListChanged(t *testing.T) {
+	mcpServer := server.NewMCPServer("test", "1.0.0", server.WithToolCapabilities(false))
+	mcpServer.AddTool(echoTool("first"))
+	testServer := server.NewTestServer(mcpServer)
+	defer testServer.Close()
+
+	watcher := newToolListWatcher(make(chan event.GenericEvent, 1), nil)
+	serverRef := types.NamespacedName{Namespace: "test", Name: "toolserver"}
+	spec := &v1alpha2.RemoteMCPServerSpec{
+		Protocol: v1alpha2.RemoteMCPServerProtocolSse,
+		URL:      testServer.URL + "/sse",
+	}
+
+	require.NoError(t, watcher.watch(context.Background(), serverRef, spec, nil, http.DefaultClient))
+	defer watcher.stop(serverRef)
+	require.Contains(t, watcher.sessions, serverRef)
+	assert.Nil(t, watcher.sessions[serverRef].client)
+}
+
+func TestDiffTools(t *testing.T) {
+	existing := []database.Tool{{ID: "get"}, {ID: "delete"}, {ID: "list"}}
+	tools := []*v1alpha2.MCPTool{{Name: "list"}, {Name: "get"}, {Name: "watch"}, {Name: "apply"}}
+
+	added, removed := diffTools(existing, tools)
+
+	assert.Equal(t, []string{"apply", "watch"}, added)
+	assert.Equal(t, []string{"delete"}, removed)
+
+	added, removed = diffTools(existing, []*v1alpha2.MCPTool{{Name: "get"}, {Name: "delete"}, {Name: "list"}})
+	assert.Empty(t, added)
+	assert.Empty(t, removed)
+}
@@ -0,0 +1,61 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	"github.com/kagent-dev/kagent/go/api/v1alpha2"
+	"github.com/kagent-dev/kagent/go/internal/controller/reconciler"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+// RemoteAgentController reconciles a RemoteAgent object
+type RemoteAgentController struct {
+	Scheme     *runtime.Scheme
+	Reconciler reconciler.KagentReconciler
+}
+
+// +kubebuilder:rbac:groups=kagent.dev,resources=remoteagents,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=kagent.dev,resources=remoteagents/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=kagent.dev,resources=remoteagents/finalizers,verbs=update
+
+func (r *RemoteAgentController) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	_ = log.FromContext(ctx)
+
+	// requeues after the resync interval of the agent to refresh its agent card
+	return r.Reconciler.ReconcileKagentRemoteAgent(ctx, req)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *RemoteAgentController) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		WithOptions(controller.Options{
+			NeedLeaderElection: ptr.To(true),
+		}).
+		// status updates must not trigger a reconcile, the agent is requeued to refresh its agent card
+		For(&v1alpha2.RemoteAgent{}, builder.WithPredicates(predicate.Or(predicate.GenerationChangedPredicate{}, predicate.AnnotationChangedPredicate{}))).
+		Named("remoteagent").
+		Complete(r)
+}
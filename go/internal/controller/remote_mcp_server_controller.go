@@ -18,7 +18,6 @@ package controller
 
 import (
 	"context"
-	"time"
 
 	"github.com/kagent-dev/kagent/go/api/v1alpha2"
 	"github.com/kagent-dev/kagent/go/internal/controller/reconciler"
@@ -26,36 +25,49 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 )
 
 // RemoteMCPServerController reconciles a RemoteMCPServer object
 type RemoteMCPServerController struct {
 	Scheme     *runtime.Scheme
 	Reconciler reconciler.KagentReconciler
+	// ToolListChanges receives the servers that notified a change of their tools
+	ToolListChanges <-chan event.GenericEvent
 }
 
 // +kubebuilder:rbac:groups=kagent.dev,resources=remotemcpservers,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=kagent.dev,resources=remotemcpservers/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=kagent.dev,resources=remotemcpservers/finalizers,verbs=update
 
+// +kubebuilder:rbac:groups=core,resources=events,verbs=create;patch
+
 func (r *RemoteMCPServerController) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	_ = log.FromContext(ctx)
 
-	return ctrl.Result{
-		// loop forever because we need to refresh tools server status
-		RequeueAfter: 60 * time.Second,
-	}, r.Reconciler.ReconcileKagentRemoteMCPServer(ctx, req)
+	// requeues after the resync interval of the server to refresh its tools
+	return r.Reconciler.ReconcileKagentRemoteMCPServer(ctx, req)
 }
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *RemoteMCPServerController) SetupWithManager(mgr ctrl.Manager) error {
-	return ctrl.NewControllerManagedBy(mgr).
+	build := ctrl.NewControllerManagedBy(mgr).
 		WithOptions(controller.Options{
 			NeedLeaderElection: ptr.To(true),
 		}).
-		For(&v1alpha2.RemoteMCPServer{}).
-		Named("remotemcpserver").
-		Complete(r)
+		// status updates must not trigger a reconcile, the server is requeued for its health checks
+		For(&v1alpha2.RemoteMCPServer{}, builder.WithPredicates(predicate.Or(predicate.GenerationChangedPredicate{}, predicate.AnnotationChangedPredicate{}))).
+		Named("remotemcpserver")
+
+	if r.ToolListChanges != nil {
+		build = build.WatchesRawSource(source.Channel(r.ToolListChanges, &handler.EnqueueRequestForObject{}))
+	}
+
+	return build.Complete(r)
 }
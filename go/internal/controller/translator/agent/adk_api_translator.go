@@ -14,9 +14,11 @@ import (
 	"strconv"
 	"strings"
 
+	kagentv1alpha1 "github.com/kagent-dev/kagent/go/api/v1alpha1"
 	"github.com/kagent-dev/kagent/go/api/v1alpha2"
 	"github.com/kagent-dev/kagent/go/internal/adk"
 	"github.com/kagent-dev/kagent/go/internal/controller/translator/labels"
+	"github.com/kagent-dev/kagent/go/internal/egress"
 	"github.com/kagent-dev/kagent/go/internal/utils"
 	"github.com/kagent-dev/kagent/go/internal/version"
 	"github.com/kagent-dev/kagent/go/pkg/translator"
@@ -68,6 +70,8 @@ type AdkApiTranslator interface {
 		ctx context.Context,
 		agent *v1alpha2.Agent,
 	) (*AgentOutputs, error)
+	// ValidateAgent checks the references of an agent without translating it.
+	ValidateAgent(ctx context.Context, agent *v1alpha2.Agent, opts ValidationOptions) error
 	GetOwnedResourceTypes() []client.Object
 }
 
@@ -98,10 +102,13 @@ type tState struct {
 	visitedAgents []string
 }
 
+// with returns the state for the tools of agent. The state is copied so that
+// sibling tools do not see each other's agents as visited.
 func (s *tState) with(agent *v1alpha2.Agent) *tState {
-	s.depth++
-	s.visitedAgents = append(s.visitedAgents, utils.GetObjectRef(agent))
-	return s
+	return &tState{
+		depth:         s.depth + 1,
+		visitedAgents: append(slices.Clone(s.visitedAgents), utils.GetObjectRef(agent)),
+	}
 }
 
 func (t *tState) isVisited(agentName string) bool {
@@ -184,6 +191,7 @@ func (a *adkApiTranslator) validateAgent(ctx context.Context, agent *v1alpha2.Ag
 		return nil
 	}
 
+	toolState := state.with(agent)
 	for _, tool := range agent.Spec.Declarative.Tools {
 		if tool.Type != v1alpha2.ToolProviderType_Agent {
 			continue
@@ -193,22 +201,35 @@ func (a *adkApiTranslator) validateAgent(ctx context.Context, agent *v1alpha2.Ag
 			return fmt.Errorf("tool must have an agent reference")
 		}
 
-		agentRef := types.NamespacedName{
-			Namespace: agent.Namespace,
-			Name:      tool.Agent.Name,
+		// Remote agents are not part of the agent tool chain
+		if isRemoteAgentReference(tool.Agent) {
+			if err := a.getReferencedObject(ctx, "RemoteAgent", tool.Agent.Name, agent.Namespace, &v1alpha2.RemoteAgent{}); err != nil {
+				return err
+			}
+			continue
+		}
+
+		agentRef, err := utils.ParseRefString(tool.Agent.Name, agent.Namespace)
+		if err != nil {
+			return fmt.Errorf("invalid agent tool reference %q: %w", tool.Agent.Name, err)
 		}
 
 		if agentRef.Namespace == agent.Namespace && agentRef.Name == agent.Name {
 			return fmt.Errorf("agent tool cannot be used to reference itself, %s", agentRef)
 		}
 
+		// Check before fetching, the agent being admitted may not be stored yet
+		if toolState.isVisited(utils.ResourceRefString(agentRef.Namespace, agentRef.Name)) {
+			return fmt.Errorf("cycle detected in agent tool chain: %s -> %s", utils.GetObjectRef(agent), agentRef)
+		}
+
 		toolAgent := &v1alpha2.Agent{}
-		err := a.kube.Get(ctx, agentRef, toolAgent)
+		err = a.getReferencedObject(ctx, "Agent", tool.Agent.Name, agent.Namespace, toolAgent)
 		if err != nil {
 			return err
 		}
 
-		err = a.validateAgent(ctx, toolAgent, state.with(agent))
+		err = a.validateAgent(ctx, toolAgent, toolState)
 		if err != nil {
 			return err
 		}
@@ -295,12 +316,20 @@ func (a *adkApiTranslator) buildManifest(
 	})
 
 	// Service Account
+	serviceAccountMeta := objMeta()
+	if len(dep.ServiceAccountAnnotations) > 0 {
+		serviceAccountMeta.Annotations = maps.Clone(serviceAccountMeta.Annotations)
+		if serviceAccountMeta.Annotations == nil {
+			serviceAccountMeta.Annotations = map[string]string{}
+		}
+		maps.Copy(serviceAccountMeta.Annotations, dep.ServiceAccountAnnotations)
+	}
 	outputs.Manifest = append(outputs.Manifest, &corev1.ServiceAccount{
 		TypeMeta: metav1.TypeMeta{
 			APIVersion: "v1",
 			Kind:       "ServiceAccount",
 		},
-		ObjectMeta: objMeta(),
+		ObjectMeta: serviceAccountMeta,
 	})
 
 	// Base env for both types
@@ -497,7 +526,7 @@ func (a *adkApiTranslator) buildManifest(
 }
 
 func (a *adkApiTranslator) translateInlineAgent(ctx context.Context, agent *v1alpha2.Agent) (*adk.AgentConfig, *modelDeploymentData, []byte, error) {
-	model, mdd, secretHashBytes, err := a.translateModel(ctx, agent.Namespace, agent.Spec.Declarative.ModelConfig)
+	models, modelRetry, mdd, secretHashBytes, err := a.translateModels(ctx, agent.Namespace, agent.Spec.Declarative.ModelConfig, agent.Spec.Declarative.ModelOverrides)
 	if err != nil {
 		return nil, nil, nil, err
 	}
@@ -508,10 +537,13 @@ func (a *adkApiTranslator) translateInlineAgent(ctx context.Context, agent *v1al
 	}
 
 	cfg := &adk.AgentConfig{
-		Description: agent.Spec.Description,
-		Instruction: systemMessage,
-		Model:       model,
-		ExecuteCode: ptr.Deref(agent.Spec.Declarative.ExecuteCodeBlocks, false),
+		Description:    agent.Spec.Description,
+		Instruction:    systemMessage,
+		Model:          models[0],
+		FallbackModels: models[1:],
+		ModelRetry:     modelRetry,
+		ExecuteCode:    ptr.Deref(agent.Spec.Declarative.ExecuteCodeBlocks, false),
+		ModelConfigRef: types.NamespacedName{Namespace: agent.Namespace, Name: agent.Spec.Declarative.ModelConfig}.String(),
 	}
 
 	for _, tool := range agent.Spec.Declarative.Tools {
@@ -522,10 +554,14 @@ func (a *adkApiTranslator) translateInlineAgent(ctx context.Context, agent *v1al
 			if err != nil {
 				return nil, nil, nil, err
 			}
+		case tool.Agent != nil && isRemoteAgentReference(tool.Agent):
+			if err := a.translateRemoteAgentTool(ctx, cfg, agent.Namespace, tool); err != nil {
+				return nil, nil, nil, err
+			}
 		case tool.Agent != nil:
-			agentRef := types.NamespacedName{
-				Namespace: agent.Namespace,
-				Name:      tool.Agent.Name,
+			agentRef, err := utils.ParseRefString(tool.Agent.Name, agent.Namespace)
+			if err != nil {
+				return nil, nil, nil, fmt.Errorf("invalid agent tool reference %q: %w", tool.Agent.Name, err)
 			}
 
 			if agentRef.Namespace == agent.Namespace && agentRef.Name == agent.Name {
@@ -534,7 +570,7 @@ func (a *adkApiTranslator) translateInlineAgent(ctx context.Context, agent *v1al
 
 			// Translate a nested tool
 			toolAgent := &v1alpha2.Agent{}
-			err := a.kube.Get(ctx, agentRef, toolAgent)
+			err = a.getReferencedObject(ctx, "Agent", tool.Agent.Name, agent.Namespace, toolAgent)
 			if err != nil {
 				return nil, nil, nil, err
 			}
@@ -562,9 +598,125 @@ func (a *adkApiTranslator) translateInlineAgent(ctx context.Context, agent *v1al
 		}
 	}
 
+	for _, memoryRef := range agent.Spec.Declarative.Memory {
+		memory, envVar, memorySecretHash, err := a.translateMemory(ctx, agent.Namespace, memoryRef)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		cfg.Memory = append(cfg.Memory, *memory)
+		if envVar != nil {
+			mdd.EnvVars = append(mdd.EnvVars, *envVar)
+		}
+		secretHashBytes = append(secretHashBytes, memorySecretHash...)
+	}
+
 	return cfg, mdd, secretHashBytes, nil
 }
 
+// isRemoteAgentReference returns whether an agent tool references a RemoteAgent
+func isRemoteAgentReference(ref *v1alpha2.TypedLocalReference) bool {
+	return ref.Kind == "RemoteAgent" && (ref.ApiGroup == "" || ref.ApiGroup == "kagent.dev")
+}
+
+// translateRemoteAgentTool adds a remote agent to the agent. The agent calls it through the A2A
+// endpoint of the controller, which authenticates the requests with the credentials of the
+// remote agent.
+func (a *adkApiTranslator) translateRemoteAgentTool(ctx context.Context, cfg *adk.AgentConfig, agentNamespace string, tool *v1alpha2.Tool) error {
+	remoteAgent := &v1alpha2.RemoteAgent{}
+	if err := a.getReferencedObject(ctx, "RemoteAgent", tool.Agent.Name, agentNamespace, remoteAgent); err != nil {
+		return err
+	}
+
+	headers, err := tool.ResolveHeaders(ctx, a.kube, agentNamespace)
+	if err != nil {
+		return err
+	}
+
+	description := remoteAgent.Spec.Description
+	if description == "" && remoteAgent.Status.AgentCard != nil {
+		description = remoteAgent.Status.AgentCard.Description
+	}
+
+	cfg.RemoteAgents = append(cfg.RemoteAgents, adk.RemoteAgentConfig{
+		Name: utils.ConvertToPythonIdentifier(utils.GetObjectRef(remoteAgent)),
+		Url: fmt.Sprintf("http://%s.%s:8083/api/a2a/%s/%s",
+			utils.GetControllerName(), utils.GetResourceNamespace(), remoteAgent.Namespace, remoteAgent.Name),
+		Headers:     headers,
+		Description: description,
+	})
+	return nil
+}
+
+// translateMemory resolves a Memory reference into the memory config for the agent,
+// the environment variable carrying its API key and a hash of that key.
+// The API key is injected via a SecretKeyRef, so its Secret must live in the agent namespace.
+func (a *adkApiTranslator) translateMemory(ctx context.Context, agentNamespace, memoryRef string) (*adk.MemoryConfig, *corev1.EnvVar, []byte, error) {
+	memoryNamespacedName, err := utils.ParseRefString(memoryRef, agentNamespace)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("invalid memory reference %q: %w", memoryRef, err)
+	}
+
+	memory := &kagentv1alpha1.Memory{}
+	if err := a.kube.Get(ctx, memoryNamespacedName, memory); err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to get Memory %s: %w", memoryNamespacedName, err)
+	}
+
+	cfg := &adk.MemoryConfig{
+		Name: utils.ConvertToPythonIdentifier(utils.GetObjectRef(memory)),
+	}
+
+	switch memory.Spec.Provider {
+	case kagentv1alpha1.Pinecone:
+		if memory.Spec.Pinecone == nil {
+			return nil, nil, nil, fmt.Errorf("memory %s has provider %s but no pinecone config", memoryNamespacedName, memory.Spec.Provider)
+		}
+		cfg.Type = adk.MemoryTypePinecone
+		cfg.Pinecone = &adk.PineconeMemoryConfig{
+			IndexHost:      memory.Spec.Pinecone.IndexHost,
+			TopK:           memory.Spec.Pinecone.TopK,
+			Namespace:      memory.Spec.Pinecone.Namespace,
+			RecordFields:   memory.Spec.Pinecone.RecordFields,
+			ScoreThreshold: utils.ParseStringToFloat64(memory.Spec.Pinecone.ScoreThreshold),
+		}
+	default:
+		return nil, nil, nil, fmt.Errorf("unsupported memory provider: %s", memory.Spec.Provider)
+	}
+
+	if memory.Spec.APIKeySecretRef == "" {
+		return cfg, nil, nil, nil
+	}
+
+	// Secret references are resolved relative to the Memory, as in v1alpha1
+	secretRef, err := utils.ParseRefString(memory.Spec.APIKeySecretRef, memory.Namespace)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("invalid API key secret reference for memory %s: %w", memoryNamespacedName, err)
+	}
+	if secretRef.Namespace != agentNamespace {
+		return nil, nil, nil, fmt.Errorf("API key secret %s of memory %s must be in the agent namespace %s", secretRef, memoryNamespacedName, agentNamespace)
+	}
+
+	apiKey, err := utils.GetSecretValue(ctx, a.kube, secretRef, memory.Spec.APIKeySecretKey)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	secretHash := sha256.Sum256([]byte(apiKey))
+
+	cfg.ApiKeyEnv = fmt.Sprintf("MEMORY_%s_API_KEY", strings.ToUpper(cfg.Name))
+	envVar := &corev1.EnvVar{
+		Name: cfg.ApiKeyEnv,
+		ValueFrom: &corev1.EnvVarSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{
+					Name: secretRef.Name,
+				},
+				Key: memory.Spec.APIKeySecretKey,
+			},
+		},
+	}
+
+	return cfg, envVar, secretHash[:], nil
+}
+
 func (a *adkApiTranslator) resolveSystemMessage(ctx context.Context, agent *v1alpha2.Agent) (string, error) {
 	if agent.Spec.Declarative.SystemMessageFrom != nil {
 		return agent.Spec.Declarative.SystemMessageFrom.Resolve(ctx, a.kube, agent.Namespace)
@@ -579,8 +731,104 @@ const (
 	googleCredsVolumeName = "google-creds"
 	tlsCACertVolumeName   = "tls-ca-cert"
 	tlsCACertMountPath    = "/etc/ssl/certs/custom"
+
+	tlsClientCertVolumeName = "tls-client-cert"
+	tlsClientCertMountPath  = "/etc/ssl/certs/client"
+
+	// the proxy credentials are passed as environment variables rather than in the agent config
+	modelProxyUsernameEnv = "MODEL_PROXY_USERNAME"
+	modelProxyPasswordEnv = "MODEL_PROXY_PASSWORD"
+
+	awsTokenVolumeName = "aws-token"
+	awsTokenMountPath  = "/var/run/secrets/sts.amazonaws.com/serviceaccount"
+	// awsTokenExpirationSeconds is the lifetime of the projected service account token used for STS
+	awsTokenExpirationSeconds = 86400
+
+	// the volume, audience and annotations of Azure Workload Identity, so that agents
+	// behave the same as pods mutated by its webhook
+	azureTokenVolumeName        = "azure-identity-token"
+	azureTokenMountPath         = "/var/run/secrets/azure/tokens"
+	azureTokenAudience          = "api://AzureADTokenExchange"
+	azureTokenExpirationSeconds = 3600
+	azureDefaultAuthorityHost   = "https://login.microsoftonline.com/"
+	azureClientIDAnnotation     = "azure.workload.identity/client-id"
+	azureTenantIDAnnotation     = "azure.workload.identity/tenant-id"
+	gkeServiceAccountAnnotation = "iam.gke.io/gcp-service-account"
 )
 
+// addAzureIdentity adds the environment of the token credential of an Azure OpenAI model
+// and returns the credential the agent should use, or "" when it uses an API key.
+func addAzureIdentity(modelDeploymentData *modelDeploymentData, identity *v1alpha2.AzureIdentityConfig) string {
+	if identity == nil {
+		return ""
+	}
+
+	if identity.Mode == v1alpha2.AzureIdentityModeManagedIdentity {
+		if identity.ClientID != "" {
+			modelDeploymentData.EnvVars = append(modelDeploymentData.EnvVars, corev1.EnvVar{
+				Name:  "AZURE_CLIENT_ID",
+				Value: identity.ClientID,
+			})
+		}
+		return adk.AzureTokenCredentialManagedIdentity
+	}
+
+	authorityHost := identity.AuthorityHost
+	if authorityHost == "" {
+		authorityHost = azureDefaultAuthorityHost
+	}
+	modelDeploymentData.EnvVars = append(modelDeploymentData.EnvVars,
+		corev1.EnvVar{
+			Name:  "AZURE_CLIENT_ID",
+			Value: identity.ClientID,
+		},
+		corev1.EnvVar{
+			Name:  "AZURE_TENANT_ID",
+			Value: identity.TenantID,
+		},
+		corev1.EnvVar{
+			Name:  "AZURE_FEDERATED_TOKEN_FILE",
+			Value: azureTokenMountPath + "/" + azureTokenVolumeName,
+		},
+		corev1.EnvVar{
+			Name:  "AZURE_AUTHORITY_HOST",
+			Value: authorityHost,
+		},
+	)
+	modelDeploymentData.Volumes = append(modelDeploymentData.Volumes, corev1.Volume{
+		Name: azureTokenVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			Projected: &corev1.ProjectedVolumeSource{
+				Sources: []corev1.VolumeProjection{{
+					ServiceAccountToken: &corev1.ServiceAccountTokenProjection{
+						Audience:          azureTokenAudience,
+						ExpirationSeconds: ptr.To(int64(azureTokenExpirationSeconds)),
+						Path:              azureTokenVolumeName,
+					},
+				}},
+			},
+		},
+	})
+	modelDeploymentData.VolumeMounts = append(modelDeploymentData.VolumeMounts, corev1.VolumeMount{
+		Name:      azureTokenVolumeName,
+		MountPath: azureTokenMountPath,
+		ReadOnly:  true,
+	})
+	modelDeploymentData.addServiceAccountAnnotation(azureClientIDAnnotation, identity.ClientID)
+	modelDeploymentData.addServiceAccountAnnotation(azureTenantIDAnnotation, identity.TenantID)
+	return adk.AzureTokenCredentialWorkloadIdentity
+}
+
+// addGKEWorkloadIdentity binds the service account of the agent to a Google service account.
+// Credentials are then served by the GKE metadata server to the default credential chain,
+// so no key needs to be mounted.
+func addGKEWorkloadIdentity(modelDeploymentData *modelDeploymentData, workloadIdentity *v1alpha2.GKEWorkloadIdentityConfig) {
+	if workloadIdentity == nil || workloadIdentity.ServiceAccount == "" {
+		return
+	}
+	modelDeploymentData.addServiceAccountAnnotation(gkeServiceAccountAnnotation, workloadIdentity.ServiceAccount)
+}
+
 // populateTLSFields populates TLS configuration fields in the BaseModel
 // from the ModelConfig TLS spec.
 func populateTLSFields(baseModel *adk.BaseModel, tlsConfig *v1alpha2.TLSConfig) {
@@ -597,6 +845,39 @@ func populateTLSFields(baseModel *adk.BaseModel, tlsConfig *v1alpha2.TLSConfig)
 		certPath := fmt.Sprintf("%s/%s", tlsCACertMountPath, tlsConfig.CACertSecretKey)
 		baseModel.TLSCACertPath = &certPath
 	}
+
+	// Set client cert and key paths for mutual TLS
+	if tlsConfig.ClientCertSecretRef != "" {
+		baseModel.TLSClientCertPath = ptr.To(fmt.Sprintf("%s/%s", tlsClientCertMountPath, corev1.TLSCertKey))
+		baseModel.TLSClientKeyPath = ptr.To(fmt.Sprintf("%s/%s", tlsClientCertMountPath, corev1.TLSPrivateKeyKey))
+	}
+}
+
+// populateProxyFields populates the proxy fields in the BaseModel from the ModelConfig proxy spec.
+// The credentials are set as environment variables by addProxyConfiguration.
+func populateProxyFields(baseModel *adk.BaseModel, proxy *v1alpha2.ProxyConfig) {
+	if proxy == nil {
+		return
+	}
+
+	baseModel.ProxyURL = &proxy.URL
+	baseModel.NoProxy = proxy.NoProxy
+}
+
+// checkModelTransport fails for the proxy and the client certificate of the providers whose model
+// clients cannot use them, rather than connecting to the provider without them.
+func checkModelTransport(model *v1alpha2.ModelConfig) error {
+	switch model.Spec.Provider {
+	case v1alpha2.ModelProviderOpenAI, v1alpha2.ModelProviderAzureOpenAI, v1alpha2.ModelProviderOpenAICompatible:
+		return nil
+	}
+	if model.Spec.Proxy != nil && model.Spec.Proxy.URL != "" {
+		return fmt.Errorf("ModelConfig %s: proxy is not supported by the %s provider", model.Name, model.Spec.Provider)
+	}
+	if model.Spec.TLS != nil && model.Spec.TLS.ClientCertSecretRef != "" {
+		return fmt.Errorf("ModelConfig %s: tls.clientCertSecretRef is not supported by the %s provider", model.Name, model.Spec.Provider)
+	}
+	return nil
 }
 
 // addTLSConfiguration adds TLS certificate volume mounts to modelDeploymentData
@@ -628,15 +909,67 @@ func addTLSConfiguration(modelDeploymentData *modelDeploymentData, tlsConfig *v1
 			ReadOnly:  true,
 		})
 	}
+
+	// Add the client certificate Secret for mutual TLS
+	if tlsConfig.ClientCertSecretRef != "" {
+		modelDeploymentData.Volumes = append(modelDeploymentData.Volumes, corev1.Volume{
+			Name: tlsClientCertVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName:  tlsConfig.ClientCertSecretRef,
+					DefaultMode: ptr.To(int32(0440)), // the private key is not readable by others
+				},
+			},
+		})
+		modelDeploymentData.VolumeMounts = append(modelDeploymentData.VolumeMounts, corev1.VolumeMount{
+			Name:      tlsClientCertVolumeName,
+			MountPath: tlsClientCertMountPath,
+			ReadOnly:  true,
+		})
+	}
+}
+
+// addProxyConfiguration adds the proxy credentials from the referenced basic-auth Secret
+// as environment variables to modelDeploymentData.
+func addProxyConfiguration(modelDeploymentData *modelDeploymentData, proxy *v1alpha2.ProxyConfig) {
+	if proxy == nil || proxy.CredentialsSecretRef == "" {
+		return
+	}
+
+	for _, env := range []struct{ name, key string }{
+		{modelProxyUsernameEnv, corev1.BasicAuthUsernameKey},
+		{modelProxyPasswordEnv, corev1.BasicAuthPasswordKey},
+	} {
+		modelDeploymentData.EnvVars = append(modelDeploymentData.EnvVars, corev1.EnvVar{
+			Name: env.name,
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: proxy.CredentialsSecretRef,
+					},
+					Key: env.key,
+				},
+			},
+		})
+	}
 }
 
-func (a *adkApiTranslator) translateModel(ctx context.Context, namespace, modelConfig string) (adk.Model, *modelDeploymentData, []byte, error) {
+// translateModel translates a ModelConfig, with the overrides of the agent applied, into
+// the model of the agent and the deployment data it needs.
+func (a *adkApiTranslator) translateModel(ctx context.Context, namespace, modelConfig string, overrides *v1alpha2.ModelOverrides) (adk.Model, *modelDeploymentData, []byte, error) {
 	model := &v1alpha2.ModelConfig{}
 	err := a.kube.Get(ctx, types.NamespacedName{Namespace: namespace, Name: modelConfig}, model)
 	if err != nil {
 		return nil, nil, nil, err
 	}
 
+	if err := applyModelOverrides(model, overrides); err != nil {
+		return nil, nil, nil, err
+	}
+	if err := checkModelTransport(model); err != nil {
+		return nil, nil, nil, err
+	}
+
 	// Decode hex-encoded secret hash to bytes
 	var secretHashBytes []byte
 	if model.Status.SecretHash != "" {
@@ -649,8 +982,9 @@ func (a *adkApiTranslator) translateModel(ctx context.Context, namespace, modelC
 
 	modelDeploymentData := &modelDeploymentData{}
 
-	// Add TLS configuration if present
+	// Add TLS and proxy configuration if present
 	addTLSConfiguration(modelDeploymentData, model.Spec.TLS)
+	addProxyConfiguration(modelDeploymentData, model.Spec.Proxy)
 
 	switch model.Spec.Provider {
 	case v1alpha2.ModelProviderOpenAI:
@@ -675,6 +1009,7 @@ func (a *adkApiTranslator) translateModel(ctx context.Context, namespace, modelC
 		}
 		// Populate TLS fields in BaseModel
 		populateTLSFields(&openai.BaseModel, model.Spec.TLS)
+		populateProxyFields(&openai.BaseModel, model.Spec.Proxy)
 
 		if model.Spec.OpenAI != nil {
 			openai.BaseUrl = model.Spec.OpenAI.BaseURL
@@ -733,23 +1068,33 @@ func (a *adkApiTranslator) translateModel(ctx context.Context, namespace, modelC
 
 		if model.Spec.Anthropic != nil {
 			anthropic.BaseUrl = model.Spec.Anthropic.BaseURL
+			anthropic.Temperature = utils.ParseStringToFloat64(model.Spec.Anthropic.Temperature)
+			anthropic.TopP = utils.ParseStringToFloat64(model.Spec.Anthropic.TopP)
+			if model.Spec.Anthropic.MaxTokens > 0 {
+				anthropic.MaxTokens = &model.Spec.Anthropic.MaxTokens
+			}
+			if model.Spec.Anthropic.TopK > 0 {
+				anthropic.TopK = &model.Spec.Anthropic.TopK
+			}
 		}
 		return anthropic, modelDeploymentData, secretHashBytes, nil
 	case v1alpha2.ModelProviderAzureOpenAI:
 		if model.Spec.AzureOpenAI == nil {
 			return nil, nil, nil, fmt.Errorf("AzureOpenAI model config is required")
 		}
-		modelDeploymentData.EnvVars = append(modelDeploymentData.EnvVars, corev1.EnvVar{
-			Name: "AZURE_OPENAI_API_KEY",
-			ValueFrom: &corev1.EnvVarSource{
-				SecretKeyRef: &corev1.SecretKeySelector{
-					LocalObjectReference: corev1.LocalObjectReference{
-						Name: model.Spec.APIKeySecret,
+		if model.Spec.AzureOpenAI.Identity == nil {
+			modelDeploymentData.EnvVars = append(modelDeploymentData.EnvVars, corev1.EnvVar{
+				Name: "AZURE_OPENAI_API_KEY",
+				ValueFrom: &corev1.EnvVarSource{
+					SecretKeyRef: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{
+							Name: model.Spec.APIKeySecret,
+						},
+						Key: model.Spec.APIKeySecretKey,
 					},
-					Key: model.Spec.APIKeySecretKey,
 				},
-			},
-		})
+			})
+		}
 		if model.Spec.AzureOpenAI.AzureADToken != "" {
 			modelDeploymentData.EnvVars = append(modelDeploymentData.EnvVars, corev1.EnvVar{
 				Name:  "AZURE_AD_TOKEN",
@@ -773,9 +1118,14 @@ func (a *adkApiTranslator) translateModel(ctx context.Context, namespace, modelC
 				Model:   model.Spec.AzureOpenAI.DeploymentName,
 				Headers: model.Spec.DefaultHeaders,
 			},
+			TokenCredential: addAzureIdentity(modelDeploymentData, model.Spec.AzureOpenAI.Identity),
+			MaxTokens:       model.Spec.AzureOpenAI.MaxTokens,
+			Temperature:     utils.ParseStringToFloat64(model.Spec.AzureOpenAI.Temperature),
+			TopP:            utils.ParseStringToFloat64(model.Spec.AzureOpenAI.TopP),
 		}
 		// Populate TLS fields in BaseModel
 		populateTLSFields(&azureOpenAI.BaseModel, model.Spec.TLS)
+		populateProxyFields(&azureOpenAI.BaseModel, model.Spec.Proxy)
 
 		return azureOpenAI, modelDeploymentData, secretHashBytes, nil
 	case v1alpha2.ModelProviderGeminiVertexAI:
@@ -790,6 +1140,7 @@ func (a *adkApiTranslator) translateModel(ctx context.Context, namespace, modelC
 			Name:  "GOOGLE_CLOUD_LOCATION",
 			Value: model.Spec.GeminiVertexAI.Location,
 		})
+		addGKEWorkloadIdentity(modelDeploymentData, model.Spec.GeminiVertexAI.WorkloadIdentity)
 		modelDeploymentData.EnvVars = append(modelDeploymentData.EnvVars, corev1.EnvVar{
 			Name:  "GOOGLE_GENAI_USE_VERTEXAI",
 			Value: "true",
@@ -834,6 +1185,7 @@ func (a *adkApiTranslator) translateModel(ctx context.Context, namespace, modelC
 			Name:  "GOOGLE_CLOUD_LOCATION",
 			Value: model.Spec.AnthropicVertexAI.Location,
 		})
+		addGKEWorkloadIdentity(modelDeploymentData, model.Spec.AnthropicVertexAI.WorkloadIdentity)
 		if model.Spec.APIKeySecret != "" {
 			modelDeploymentData.EnvVars = append(modelDeploymentData.EnvVars, corev1.EnvVar{
 				Name:  "GOOGLE_APPLICATION_CREDENTIALS",
@@ -902,6 +1254,120 @@ func (a *adkApiTranslator) translateModel(ctx context.Context, namespace, modelC
 		populateTLSFields(&gemini.BaseModel, model.Spec.TLS)
 
 		return gemini, modelDeploymentData, secretHashBytes, nil
+	case v1alpha2.ModelProviderBedrock:
+		if model.Spec.Bedrock == nil {
+			return nil, nil, nil, fmt.Errorf("bedrock model config is required")
+		}
+		modelDeploymentData.EnvVars = append(modelDeploymentData.EnvVars, corev1.EnvVar{
+			Name:  "AWS_REGION",
+			Value: model.Spec.Bedrock.Region,
+		})
+		if model.Spec.APIKeySecret != "" {
+			modelDeploymentData.EnvVars = append(modelDeploymentData.EnvVars, corev1.EnvVar{
+				Name: "AWS_BEARER_TOKEN_BEDROCK",
+				ValueFrom: &corev1.EnvVarSource{
+					SecretKeyRef: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{
+							Name: model.Spec.APIKeySecret,
+						},
+						Key: model.Spec.APIKeySecretKey,
+					},
+				},
+			})
+		}
+		if model.Spec.Bedrock.RoleARN != "" {
+			// web identity federation, picked up by the default AWS credential chain
+			modelDeploymentData.EnvVars = append(modelDeploymentData.EnvVars,
+				corev1.EnvVar{
+					Name:  "AWS_ROLE_ARN",
+					Value: model.Spec.Bedrock.RoleARN,
+				},
+				corev1.EnvVar{
+					Name:  "AWS_WEB_IDENTITY_TOKEN_FILE",
+					Value: awsTokenMountPath + "/token",
+				},
+			)
+			modelDeploymentData.Volumes = append(modelDeploymentData.Volumes, corev1.Volume{
+				Name: awsTokenVolumeName,
+				VolumeSource: corev1.VolumeSource{
+					Projected: &corev1.ProjectedVolumeSource{
+						Sources: []corev1.VolumeProjection{{
+							ServiceAccountToken: &corev1.ServiceAccountTokenProjection{
+								Audience:          "sts.amazonaws.com",
+								ExpirationSeconds: ptr.To(int64(awsTokenExpirationSeconds)),
+								Path:              "token",
+							},
+						}},
+					},
+				},
+			})
+			modelDeploymentData.VolumeMounts = append(modelDeploymentData.VolumeMounts, corev1.VolumeMount{
+				Name:      awsTokenVolumeName,
+				MountPath: awsTokenMountPath,
+				ReadOnly:  true,
+			})
+		}
+		bedrock := &adk.Bedrock{
+			BaseModel: adk.BaseModel{
+				Model:   model.Spec.Model,
+				Headers: model.Spec.DefaultHeaders,
+			},
+			Region:           model.Spec.Bedrock.Region,
+			InferenceProfile: model.Spec.Bedrock.InferenceProfile,
+			GuardrailID:      model.Spec.Bedrock.GuardrailID,
+			GuardrailVersion: model.Spec.Bedrock.GuardrailVersion,
+			Temperature:      utils.ParseStringToFloat64(model.Spec.Bedrock.Temperature),
+			TopP:             utils.ParseStringToFloat64(model.Spec.Bedrock.TopP),
+		}
+		if model.Spec.Bedrock.MaxTokens > 0 {
+			bedrock.MaxTokens = &model.Spec.Bedrock.MaxTokens
+		}
+		// Populate TLS fields in BaseModel
+		populateTLSFields(&bedrock.BaseModel, model.Spec.TLS)
+
+		return bedrock, modelDeploymentData, secretHashBytes, nil
+	case v1alpha2.ModelProviderOpenAICompatible:
+		if model.Spec.OpenAICompatible == nil {
+			return nil, nil, nil, fmt.Errorf("openAICompatible model config is required")
+		}
+		if model.Spec.APIKeySecret != "" {
+			modelDeploymentData.EnvVars = append(modelDeploymentData.EnvVars, corev1.EnvVar{
+				Name: "OPENAI_API_KEY",
+				ValueFrom: &corev1.EnvVarSource{
+					SecretKeyRef: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{
+							Name: model.Spec.APIKeySecret,
+						},
+						Key: model.Spec.APIKeySecretKey,
+					},
+				},
+			})
+		}
+		capabilities := model.Spec.OpenAICompatible.GetCapabilities()
+		openAICompatible := &adk.OpenAICompatible{
+			BaseModel: adk.BaseModel{
+				Model:   model.Spec.Model,
+				Headers: model.Spec.DefaultHeaders,
+				Capabilities: &adk.ModelCapabilities{
+					FunctionCalling: ptr.Deref(capabilities.FunctionCalling, true),
+					Streaming:       ptr.Deref(capabilities.Streaming, true),
+					Vision:          ptr.Deref(capabilities.Vision, false),
+					JSONMode:        ptr.Deref(capabilities.JSONMode, false),
+				},
+			},
+			BaseUrl:     model.Spec.OpenAICompatible.GetBaseURL(),
+			Temperature: utils.ParseStringToFloat64(model.Spec.OpenAICompatible.Temperature),
+			TopP:        utils.ParseStringToFloat64(model.Spec.OpenAICompatible.TopP),
+			Timeout:     model.Spec.OpenAICompatible.Timeout,
+		}
+		if model.Spec.OpenAICompatible.MaxTokens > 0 {
+			openAICompatible.MaxTokens = &model.Spec.OpenAICompatible.MaxTokens
+		}
+		// Populate TLS fields in BaseModel
+		populateTLSFields(&openAICompatible.BaseModel, model.Spec.TLS)
+		populateProxyFields(&openAICompatible.BaseModel, model.Spec.Proxy)
+
+		return openAICompatible, modelDeploymentData, secretHashBytes, nil
 	}
 
 	return nil, nil, nil, fmt.Errorf("unknown model provider: %s", model.Spec.Provider)
@@ -967,7 +1433,7 @@ func (a *adkApiTranslator) translateMCPServerTarget(ctx context.Context, agent *
 		Kind:  "MCPServer",
 	}:
 		mcpServer := &v1alpha1.MCPServer{}
-		err := a.kube.Get(ctx, types.NamespacedName{Namespace: agentNamespace, Name: toolServer.Name}, mcpServer)
+		err := a.getReferencedObject(ctx, "MCPServer", toolServer.Name, agentNamespace, mcpServer)
 		if err != nil {
 			return err
 		}
@@ -990,12 +1456,16 @@ func (a *adkApiTranslator) translateMCPServerTarget(ctx context.Context, agent *
 		Kind:  "RemoteMCPServer",
 	}:
 		remoteMcpServer := &v1alpha2.RemoteMCPServer{}
-		err := a.kube.Get(ctx, types.NamespacedName{Namespace: agentNamespace, Name: toolServer.Name}, remoteMcpServer)
+		err := a.getReferencedObject(ctx, "RemoteMCPServer", toolServer.Name, agentNamespace, remoteMcpServer)
 		if err != nil {
 			return err
 		}
 
-		remoteMcpServer.Spec.HeadersFrom = append(remoteMcpServer.Spec.HeadersFrom, toolHeaders...)
+		headers, err := a.remoteMCPServerHeaders(ctx, remoteMcpServer, agentNamespace)
+		if err != nil {
+			return err
+		}
+		remoteMcpServer.Spec.HeadersFrom = append(headers, toolHeaders...)
 
 		return a.translateRemoteMCPServerTarget(ctx, agent, agentNamespace, &remoteMcpServer.Spec, toolServer.ToolNames)
 	case schema.GroupKind{
@@ -1008,7 +1478,7 @@ func (a *adkApiTranslator) translateMCPServerTarget(ctx context.Context, agent *
 		Kind:  "Service",
 	}:
 		svc := &corev1.Service{}
-		err := a.kube.Get(ctx, types.NamespacedName{Namespace: agentNamespace, Name: toolServer.Name}, svc)
+		err := a.getReferencedObject(ctx, "Service", toolServer.Name, agentNamespace, svc)
 		if err != nil {
 			return err
 		}
@@ -1027,6 +1497,47 @@ func (a *adkApiTranslator) translateMCPServerTarget(ctx context.Context, agent *
 	}
 }
 
+// getReferencedObject fetches the object referenced by ref, either "<name>" in the agent
+// namespace or "<namespace>/<name>". Objects in another namespace must allow references
+// from the agent namespace through the utils.AllowedNamespacesAnnotation.
+func (a *adkApiTranslator) getReferencedObject(ctx context.Context, kind, ref, agentNamespace string, obj client.Object) error {
+	namespacedName, err := utils.ParseRefString(ref, agentNamespace)
+	if err != nil {
+		return fmt.Errorf("invalid %s reference %q: %w", kind, ref, err)
+	}
+
+	if err := a.kube.Get(ctx, namespacedName, obj); err != nil {
+		return err
+	}
+
+	if !utils.IsReferenceAllowed(obj, agentNamespace) {
+		return fmt.Errorf("%s %s does not allow references from namespace %s, add it to the %s annotation",
+			kind, namespacedName, agentNamespace, utils.AllowedNamespacesAnnotation)
+	}
+
+	return nil
+}
+
+// remoteMCPServerHeaders returns the headers configured on a RemoteMCPServer.
+// Headers of a server in another namespace are resolved in that namespace,
+// since the agent namespace cannot be expected to hold the server's secrets.
+func (a *adkApiTranslator) remoteMCPServerHeaders(ctx context.Context, remoteMcpServer *v1alpha2.RemoteMCPServer, agentNamespace string) ([]v1alpha2.ValueRef, error) {
+	if remoteMcpServer.Namespace == agentNamespace {
+		return remoteMcpServer.Spec.HeadersFrom, nil
+	}
+
+	resolved, err := remoteMcpServer.Spec.ResolveHeaders(ctx, a.kube, remoteMcpServer.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	headers := make([]v1alpha2.ValueRef, 0, len(resolved))
+	for _, name := range slices.Sorted(maps.Keys(resolved)) {
+		headers = append(headers, v1alpha2.ValueRef{Name: name, Value: resolved[name]})
+	}
+	return headers, nil
+}
+
 func ConvertServiceToRemoteMCPServer(svc *corev1.Service) (*v1alpha2.RemoteMCPServerSpec, error) {
 	// Check wellknown annotations
 	port := int64(0)
@@ -1086,6 +1597,11 @@ func ConvertMCPServerToRemoteMCPServer(mcpServer *v1alpha1.MCPServer) (*v1alpha2
 }
 
 func (a *adkApiTranslator) translateRemoteMCPServerTarget(ctx context.Context, agent *adk.AgentConfig, agentNamespace string, remoteMcpServer *v1alpha2.RemoteMCPServerSpec, toolNames []string) error {
+	transport, err := a.translateMcpTransport(ctx, remoteMcpServer, agentNamespace)
+	if err != nil {
+		return err
+	}
+
 	switch remoteMcpServer.Protocol {
 	case v1alpha2.RemoteMCPServerProtocolSse:
 		tool, err := a.translateSseHttpTool(ctx, remoteMcpServer, agentNamespace)
@@ -1093,8 +1609,9 @@ func (a *adkApiTranslator) translateRemoteMCPServerTarget(ctx context.Context, a
 			return err
 		}
 		agent.SseTools = append(agent.SseTools, adk.SseMcpServerConfig{
-			Params: *tool,
-			Tools:  toolNames,
+			Params:    *tool,
+			Tools:     toolNames,
+			Transport: transport,
 		})
 	default:
 		tool, err := a.translateStreamableHttpTool(ctx, remoteMcpServer, agentNamespace)
@@ -1102,13 +1619,46 @@ func (a *adkApiTranslator) translateRemoteMCPServerTarget(ctx context.Context, a
 			return err
 		}
 		agent.HttpTools = append(agent.HttpTools, adk.HttpMcpServerConfig{
-			Params: *tool,
-			Tools:  toolNames,
+			Params:    *tool,
+			Tools:     toolNames,
+			Transport: transport,
 		})
 	}
 	return nil
 }
 
+// translateMcpTransport resolves the TLS and proxy configuration of a MCP server, which
+// is nil when the server has neither.
+func (a *adkApiTranslator) translateMcpTransport(ctx context.Context, tool *v1alpha2.RemoteMCPServerSpec, namespace string) (*adk.McpTransportConfig, error) {
+	if tool.TLS == nil && tool.Proxy == nil {
+		return nil, nil
+	}
+
+	config, err := egress.Resolve(ctx, a.kube, namespace, tool.TLS, tool.Proxy)
+	if err != nil {
+		return nil, err
+	}
+	proxyURL, err := config.ProxyURL()
+	if err != nil {
+		return nil, err
+	}
+
+	transport := &adk.McpTransportConfig{
+		TLSCACert:     string(config.CACert),
+		TLSClientCert: string(config.ClientCert),
+		TLSClientKey:  string(config.ClientKey),
+	}
+	if tool.TLS != nil {
+		transport.TLSDisableVerify = tool.TLS.DisableVerify
+		transport.TLSDisableSystemCAs = tool.TLS.DisableSystemCAs
+	}
+	if proxyURL != nil {
+		transport.ProxyURL = proxyURL.String()
+		transport.NoProxy = tool.Proxy.NoProxy
+	}
+	return transport, nil
+}
+
 // Helper functions
 
 func computeConfigHash(agentCfg, agentCard, secretData []byte) uint64 {
@@ -1151,6 +1701,16 @@ type modelDeploymentData struct {
 	EnvVars      []corev1.EnvVar
 	Volumes      []corev1.Volume
 	VolumeMounts []corev1.VolumeMount
+	// ServiceAccountAnnotations are added to the service account of the agent,
+	// e.g. to bind it to a cloud identity
+	ServiceAccountAnnotations map[string]string
+}
+
+func (m *modelDeploymentData) addServiceAccountAnnotation(key, value string) {
+	if m.ServiceAccountAnnotations == nil {
+		m.ServiceAccountAnnotations = map[string]string{}
+	}
+	m.ServiceAccountAnnotations[key] = value
 }
 
 // Internal to translator – a unified deployment spec for any agent.
@@ -1170,10 +1730,12 @@ type resolvedDeployment struct {
 	Labels           map[string]string
 	Annotations      map[string]string
 	Env              []corev1.EnvVar
-	Resources        corev1.ResourceRequirements
-	Tolerations      []corev1.Toleration
-	Affinity         *corev1.Affinity
-	NodeSelector     map[string]string
+	// Annotations of the service account of the agent
+	ServiceAccountAnnotations map[string]string
+	Resources                 corev1.ResourceRequirements
+	Tolerations               []corev1.Toleration
+	Affinity                  *corev1.Affinity
+	NodeSelector              map[string]string
 }
 
 // getDefaultResources sets default resource requirements if not specified
@@ -1256,6 +1818,8 @@ func (a *adkApiTranslator) resolveInlineDeployment(agent *v1alpha2.Agent, mdd *m
 		Tolerations:      slices.Clone(spec.Tolerations),
 		Affinity:         spec.Affinity,
 		NodeSelector:     maps.Clone(spec.NodeSelector),
+
+		ServiceAccountAnnotations: maps.Clone(mdd.ServiceAccountAnnotations),
 	}
 
 	return dep, nil
@@ -0,0 +1,214 @@
+package agent
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kagent-dev/kagent/go/api/v1alpha2"
+	"github.com/kagent-dev/kagent/go/internal/adk"
+)
+
+const (
+	defaultModelRetryInitialBackoff = 1.0
+	defaultModelRetryMaxBackoff     = 30.0
+)
+
+// ResolveModelChain returns the ModelConfigs an agent using the given ModelConfig
+// tries, in order. Fallback ModelConfigs are replaced by their models, recursively,
+// and a ModelConfig that appears more than once is only tried the first time.
+func ResolveModelChain(ctx context.Context, kube client.Client, modelConfig *v1alpha2.ModelConfig) ([]*v1alpha2.ModelConfig, error) {
+	var models []*v1alpha2.ModelConfig
+	if err := resolveModelChain(ctx, kube, modelConfig, nil, &models); err != nil {
+		return nil, err
+	}
+	return models, nil
+}
+
+func resolveModelChain(ctx context.Context, kube client.Client, modelConfig *v1alpha2.ModelConfig, path []string, models *[]*v1alpha2.ModelConfig) error {
+	if modelConfig.Spec.Provider != v1alpha2.ModelProviderFallback {
+		if !slices.ContainsFunc(*models, func(m *v1alpha2.ModelConfig) bool { return m.Name == modelConfig.Name }) {
+			*models = append(*models, modelConfig)
+		}
+		return nil
+	}
+
+	if modelConfig.Spec.Fallback == nil || len(modelConfig.Spec.Fallback.Models) == 0 {
+		return fmt.Errorf("fallback ModelConfig %s has no models", modelConfig.Name)
+	}
+
+	path = append(slices.Clone(path), modelConfig.Name)
+	for _, name := range modelConfig.Spec.Fallback.Models {
+		if slices.Contains(path, name) {
+			return fmt.Errorf("fallback chain has a cycle: %s", strings.Join(append(path, name), " -> "))
+		}
+
+		member := &v1alpha2.ModelConfig{}
+		if err := kube.Get(ctx, types.NamespacedName{Namespace: modelConfig.Namespace, Name: name}, member); err != nil {
+			return fmt.Errorf("failed to get fallback ModelConfig %s: %w", name, err)
+		}
+		if err := resolveModelChain(ctx, kube, member, path, models); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// translateModels translates the ModelConfig of an agent into the models it tries in order,
+// and the retry policy applied to each of them. The overrides apply to every model.
+func (a *adkApiTranslator) translateModels(ctx context.Context, namespace, modelConfig string, overrides *v1alpha2.ModelOverrides) ([]adk.Model, *adk.ModelRetryPolicy, *modelDeploymentData, []byte, error) {
+	root := &v1alpha2.ModelConfig{}
+	if err := a.kube.Get(ctx, types.NamespacedName{Namespace: namespace, Name: modelConfig}, root); err != nil {
+		return nil, nil, nil, nil, err
+	}
+
+	if root.Spec.Provider != v1alpha2.ModelProviderFallback {
+		model, mdd, secretHashBytes, err := a.translateModel(ctx, namespace, modelConfig, overrides)
+		if err != nil {
+			return nil, nil, nil, nil, err
+		}
+		return []adk.Model{model}, nil, mdd, secretHashBytes, nil
+	}
+
+	members, err := ResolveModelChain(ctx, a.kube, root)
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+
+	var (
+		models          []adk.Model
+		secretHashBytes []byte
+	)
+	mdd := &modelDeploymentData{}
+	for _, member := range members {
+		model, memberMdd, memberSecretHash, err := a.translateModel(ctx, namespace, member.Name, overrides)
+		if err != nil {
+			return nil, nil, nil, nil, fmt.Errorf("failed to translate fallback ModelConfig %s: %w", member.Name, err)
+		}
+		namespaceModelEnv(model, memberMdd, member.Name)
+		if err := mdd.merge(memberMdd); err != nil {
+			return nil, nil, nil, nil, fmt.Errorf("fallback ModelConfig %s conflicts with an earlier model: %w", member.Name, err)
+		}
+		models = append(models, model)
+		secretHashBytes = append(secretHashBytes, memberSecretHash...)
+	}
+
+	return models, translateModelRetryPolicy(root.Spec.Fallback.Retry), mdd, secretHashBytes, nil
+}
+
+func translateModelRetryPolicy(retry *v1alpha2.ModelRetryPolicy) *adk.ModelRetryPolicy {
+	if retry == nil {
+		return nil
+	}
+
+	policy := &adk.ModelRetryPolicy{
+		MaxAttempts:    max(retry.MaxAttempts, 1),
+		InitialBackoff: defaultModelRetryInitialBackoff,
+		MaxBackoff:     defaultModelRetryMaxBackoff,
+	}
+	if retry.InitialBackoff != nil {
+		policy.InitialBackoff = retry.InitialBackoff.Seconds()
+	}
+	if retry.MaxBackoff != nil {
+		policy.MaxBackoff = retry.MaxBackoff.Seconds()
+	}
+	return policy
+}
+
+// modelEnvVars are the variables of each model type that the runtime reads from the variables
+// the config of the model points at, see adk.BaseModel.Env
+var modelEnvVars = map[string][]string{
+	adk.ModelTypeOpenAI:           {"OPENAI_API_KEY"},
+	adk.ModelTypeOpenAICompatible: {"OPENAI_API_KEY"},
+	adk.ModelTypeAnthropic:        {"ANTHROPIC_API_KEY"},
+	adk.ModelTypeAzureOpenAI:      {"AZURE_OPENAI_API_KEY", "OPENAI_API_VERSION", "AZURE_OPENAI_ENDPOINT"},
+}
+
+// namespaceModelEnv suffixes the variables of a member of a fallback chain that its config can
+// point at with the name of the member, so that members of the same provider can set different
+// values, e.g. API keys from different secrets.
+func namespaceModelEnv(model adk.Model, mdd *modelDeploymentData, member string) {
+	base := baseModel(model)
+	if base == nil {
+		return
+	}
+	suffix := envVarSuffix(member)
+	for i, envVar := range mdd.EnvVars {
+		if !slices.Contains(modelEnvVars[model.GetType()], envVar.Name) {
+			continue
+		}
+		if base.Env == nil {
+			base.Env = map[string]string{}
+		}
+		base.Env[envVar.Name] = envVar.Name + "_" + suffix
+		mdd.EnvVars[i].Name = base.Env[envVar.Name]
+	}
+}
+
+// baseModel returns the base of the models whose config can point at other variables
+func baseModel(model adk.Model) *adk.BaseModel {
+	switch m := model.(type) {
+	case *adk.OpenAI:
+		return &m.BaseModel
+	case *adk.OpenAICompatible:
+		return &m.BaseModel
+	case *adk.Anthropic:
+		return &m.BaseModel
+	case *adk.AzureOpenAI:
+		return &m.BaseModel
+	}
+	return nil
+}
+
+// envVarSuffix turns the name of a ModelConfig into a suffix of environment variable names
+func envVarSuffix(name string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' {
+			return r - 'a' + 'A'
+		}
+		if r >= '0' && r <= '9' {
+			return r
+		}
+		return '_'
+	}, name)
+}
+
+// merge adds the environment variables and volumes of other. Models of a fallback chain share
+// the agent container, so they must agree on the values of the variables their config cannot
+// point at (see namespaceModelEnv), e.g. the project of Vertex AI, and on their volumes.
+func (m *modelDeploymentData) merge(other *modelDeploymentData) error {
+	for _, envVar := range other.EnvVars {
+		idx := slices.IndexFunc(m.EnvVars, func(e corev1.EnvVar) bool { return e.Name == envVar.Name })
+		if idx < 0 {
+			m.EnvVars = append(m.EnvVars, envVar)
+		} else if !reflect.DeepEqual(m.EnvVars[idx], envVar) {
+			return fmt.Errorf("environment variable %s is set to different values", envVar.Name)
+		}
+	}
+	for _, volume := range other.Volumes {
+		idx := slices.IndexFunc(m.Volumes, func(v corev1.Volume) bool { return v.Name == volume.Name })
+		if idx < 0 {
+			m.Volumes = append(m.Volumes, volume)
+		} else if !reflect.DeepEqual(m.Volumes[idx], volume) {
+			return fmt.Errorf("volume %s is mounted from different sources", volume.Name)
+		}
+	}
+	for _, mount := range other.VolumeMounts {
+		if !slices.Contains(m.VolumeMounts, mount) {
+			m.VolumeMounts = append(m.VolumeMounts, mount)
+		}
+	}
+	for key, value := range other.ServiceAccountAnnotations {
+		if existing, ok := m.ServiceAccountAnnotations[key]; ok && existing != value {
+			return fmt.Errorf("service account annotation %s is set to different values", key)
+		}
+		m.addServiceAccountAnnotation(key, value)
+	}
+	return nil
+}
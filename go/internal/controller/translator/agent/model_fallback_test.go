@@ -0,0 +1,106 @@
+package agent_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/kagent-dev/kagent/go/api/v1alpha2"
+	translator "github.com/kagent-dev/kagent/go/internal/controller/translator/agent"
+)
+
+func fallbackModelConfig(name string, models ...string) *v1alpha2.ModelConfig {
+	return &v1alpha2.ModelConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "test"},
+		Spec: v1alpha2.ModelConfigSpec{
+			Provider: v1alpha2.ModelProviderFallback,
+			Fallback: &v1alpha2.FallbackConfig{Models: models},
+		},
+	}
+}
+
+func openAIModelConfig(name string) *v1alpha2.ModelConfig {
+	return &v1alpha2.ModelConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "test"},
+		Spec: v1alpha2.ModelConfigSpec{
+			Provider: v1alpha2.ModelProviderOpenAI,
+			Model:    name,
+		},
+	}
+}
+
+func TestResolveModelChain(t *testing.T) {
+	tests := []struct {
+		name        string
+		root        string
+		objects     []client.Object
+		want        []string
+		errContains string
+	}{
+		{
+			name:    "single model",
+			root:    "gpt-4o",
+			objects: []client.Object{openAIModelConfig("gpt-4o")},
+			want:    []string{"gpt-4o"},
+		},
+		{
+			name: "nested chains are flattened in order",
+			root: "fallback",
+			objects: []client.Object{
+				fallbackModelConfig("fallback", "gpt-4o", "nested", "gpt-4o-mini"),
+				fallbackModelConfig("nested", "gpt-4.1", "gpt-4o"),
+				openAIModelConfig("gpt-4o"),
+				openAIModelConfig("gpt-4.1"),
+				openAIModelConfig("gpt-4o-mini"),
+			},
+			want: []string{"gpt-4o", "gpt-4.1", "gpt-4o-mini"},
+		},
+		{
+			name: "cycle",
+			root: "fallback",
+			objects: []client.Object{
+				fallbackModelConfig("fallback", "gpt-4o", "nested"),
+				fallbackModelConfig("nested", "fallback"),
+				openAIModelConfig("gpt-4o"),
+			},
+			errContains: "fallback chain has a cycle: fallback -> nested -> fallback",
+		},
+		{
+			name:        "missing model",
+			root:        "fallback",
+			objects:     []client.Object{fallbackModelConfig("fallback", "missing")},
+			errContains: "failed to get fallback ModelConfig missing",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scheme := runtime.NewScheme()
+			require.NoError(t, v1alpha2.AddToScheme(scheme))
+			kubeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(tt.objects...).Build()
+
+			root := &v1alpha2.ModelConfig{}
+			require.NoError(t, kubeClient.Get(context.Background(), client.ObjectKey{Namespace: "test", Name: tt.root}, root))
+
+			models, err := translator.ResolveModelChain(context.Background(), kubeClient, root)
+			if tt.errContains != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errContains)
+				return
+			}
+			require.NoError(t, err)
+
+			var names []string
+			for _, model := range models {
+				names = append(names, model.Name)
+			}
+			assert.Equal(t, tt.want, names)
+		})
+	}
+}
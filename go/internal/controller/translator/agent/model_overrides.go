@@ -0,0 +1,149 @@
+package agent
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/kagent-dev/kagent/go/api/v1alpha2"
+)
+
+const (
+	// openAIMaxTemperature is the highest temperature of the OpenAI APIs
+	openAIMaxTemperature = 2
+	// anthropicMaxTemperature is the highest temperature of the Anthropic API and of the models
+	// served by Bedrock, Claude included
+	anthropicMaxTemperature = 1
+)
+
+// applyModelOverrides sets the parameters of the overrides of an agent on the typed config
+// of the model config, which must be a copy as it is modified. Parameters the typed config
+// of the provider does not have, or the agent runtime does not apply, are rejected.
+func applyModelOverrides(model *v1alpha2.ModelConfig, overrides *v1alpha2.ModelOverrides) error {
+	if overrides == nil {
+		return nil
+	}
+
+	var unsupported []string
+	reject := func(set bool, field string) {
+		if set {
+			unsupported = append(unsupported, field)
+		}
+	}
+
+	switch model.Spec.Provider {
+	case v1alpha2.ModelProviderOpenAI:
+		if err := validateModelOverrides(overrides, openAIMaxTemperature); err != nil {
+			return err
+		}
+		if model.Spec.OpenAI == nil {
+			model.Spec.OpenAI = &v1alpha2.OpenAIConfig{}
+		}
+		overrideString(&model.Spec.OpenAI.Temperature, overrides.Temperature)
+		overrideInt(&model.Spec.OpenAI.MaxTokens, overrides.MaxTokens)
+		overrideString(&model.Spec.OpenAI.TopP, overrides.TopP)
+		if overrides.ReasoningEffort != nil {
+			model.Spec.OpenAI.ReasoningEffort = overrides.ReasoningEffort
+		}
+		reject(overrides.TopK != nil, "topK")
+	case v1alpha2.ModelProviderAnthropic:
+		if err := validateModelOverrides(overrides, anthropicMaxTemperature); err != nil {
+			return err
+		}
+		if model.Spec.Anthropic == nil {
+			model.Spec.Anthropic = &v1alpha2.AnthropicConfig{}
+		}
+		overrideString(&model.Spec.Anthropic.Temperature, overrides.Temperature)
+		overrideInt(&model.Spec.Anthropic.MaxTokens, overrides.MaxTokens)
+		overrideString(&model.Spec.Anthropic.TopP, overrides.TopP)
+		overrideInt(&model.Spec.Anthropic.TopK, overrides.TopK)
+		reject(overrides.ReasoningEffort != nil, "reasoningEffort")
+	case v1alpha2.ModelProviderAzureOpenAI:
+		if err := validateModelOverrides(overrides, openAIMaxTemperature); err != nil {
+			return err
+		}
+		if model.Spec.AzureOpenAI == nil {
+			return fmt.Errorf("AzureOpenAI model config is required")
+		}
+		overrideString(&model.Spec.AzureOpenAI.Temperature, overrides.Temperature)
+		if overrides.MaxTokens != nil {
+			model.Spec.AzureOpenAI.MaxTokens = overrides.MaxTokens
+		}
+		overrideString(&model.Spec.AzureOpenAI.TopP, overrides.TopP)
+		reject(overrides.TopK != nil, "topK")
+		reject(overrides.ReasoningEffort != nil, "reasoningEffort")
+	case v1alpha2.ModelProviderBedrock:
+		if err := validateModelOverrides(overrides, anthropicMaxTemperature); err != nil {
+			return err
+		}
+		if model.Spec.Bedrock == nil {
+			return fmt.Errorf("bedrock model config is required")
+		}
+		overrideString(&model.Spec.Bedrock.Temperature, overrides.Temperature)
+		overrideInt(&model.Spec.Bedrock.MaxTokens, overrides.MaxTokens)
+		overrideString(&model.Spec.Bedrock.TopP, overrides.TopP)
+		reject(overrides.TopK != nil, "topK")
+		reject(overrides.ReasoningEffort != nil, "reasoningEffort")
+	case v1alpha2.ModelProviderOpenAICompatible:
+		if err := validateModelOverrides(overrides, openAIMaxTemperature); err != nil {
+			return err
+		}
+		if model.Spec.OpenAICompatible == nil {
+			return fmt.Errorf("openAICompatible model config is required")
+		}
+		overrideString(&model.Spec.OpenAICompatible.Temperature, overrides.Temperature)
+		overrideInt(&model.Spec.OpenAICompatible.MaxTokens, overrides.MaxTokens)
+		overrideString(&model.Spec.OpenAICompatible.TopP, overrides.TopP)
+		reject(overrides.TopK != nil, "topK")
+		reject(overrides.ReasoningEffort != nil, "reasoningEffort")
+	default:
+		// Ollama and Gemini have no typed generation parameters, and the agent runtime does not
+		// apply those of the Vertex AI providers
+		reject(overrides.Temperature != "", "temperature")
+		reject(overrides.MaxTokens != nil, "maxTokens")
+		reject(overrides.TopP != "", "topP")
+		reject(overrides.TopK != nil, "topK")
+		reject(overrides.ReasoningEffort != nil, "reasoningEffort")
+	}
+
+	if len(unsupported) > 0 {
+		return fmt.Errorf("model overrides %s are not supported by %s ModelConfig %s",
+			strings.Join(unsupported, ", "), model.Spec.Provider, model.Name)
+	}
+	return nil
+}
+
+// validateModelOverrides checks that the sampling parameters of the overrides are numbers in the
+// ranges accepted by the provider, whose temperature goes up to maxTemperature
+func validateModelOverrides(overrides *v1alpha2.ModelOverrides, maxTemperature float64) error {
+	if err := validateFloatRange("temperature", overrides.Temperature, 0, maxTemperature); err != nil {
+		return err
+	}
+	return validateFloatRange("topP", overrides.TopP, 0, 1)
+}
+
+func validateFloatRange(field, value string, minValue, maxValue float64) error {
+	if value == "" {
+		return nil
+	}
+	parsed, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return fmt.Errorf("model override %s %q is not a number", field, value)
+	}
+	if parsed < minValue || parsed > maxValue {
+		return fmt.Errorf("model override %s %s is not between %g and %g", field, value, minValue, maxValue)
+	}
+	return nil
+}
+
+func overrideString(dst *string, value string) {
+	if value != "" {
+		*dst = value
+	}
+}
+
+func overrideInt(dst *int, value *int) {
+	if value != nil {
+		*dst = *value
+	}
+}
@@ -155,3 +155,51 @@ func Test_addTLSConfiguration_AllFieldsCombined(t *testing.T) {
 	mount := mdd.VolumeMounts[0]
 	assert.Equal(t, tlsCACertMountPath, mount.MountPath, "Mount path should be TLS cert mount path")
 }
+
+// Test_checkModelTransport verifies the proxy and the client certificate are rejected for the
+// providers which cannot use them
+func Test_checkModelTransport(t *testing.T) {
+	tests := []struct {
+		name     string
+		provider v1alpha2.ModelProvider
+		tls      *v1alpha2.TLSConfig
+		proxy    *v1alpha2.ProxyConfig
+		wantErr  string
+	}{
+		{
+			name:     "openai with proxy and client certificate",
+			provider: v1alpha2.ModelProviderOpenAI,
+			tls:      &v1alpha2.TLSConfig{ClientCertSecretRef: "client-cert"},
+			proxy:    &v1alpha2.ProxyConfig{URL: "http://proxy:3128"},
+		},
+		{
+			name:     "anthropic with custom CA",
+			provider: v1alpha2.ModelProviderAnthropic,
+			tls:      &v1alpha2.TLSConfig{CACertSecretRef: "ca", CACertSecretKey: "ca.crt"},
+		},
+		{
+			name:     "anthropic with proxy",
+			provider: v1alpha2.ModelProviderAnthropic,
+			proxy:    &v1alpha2.ProxyConfig{URL: "http://proxy:3128"},
+			wantErr:  "proxy is not supported by the Anthropic provider",
+		},
+		{
+			name:     "bedrock with client certificate",
+			provider: v1alpha2.ModelProviderBedrock,
+			tls:      &v1alpha2.TLSConfig{ClientCertSecretRef: "client-cert"},
+			wantErr:  "tls.clientCertSecretRef is not supported by the Bedrock provider",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			model := &v1alpha2.ModelConfig{Spec: v1alpha2.ModelConfigSpec{Provider: tt.provider, TLS: tt.tls, Proxy: tt.proxy}}
+			err := checkModelTransport(model)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -33,3 +33,37 @@ func GetA2AAgentCard(agent *v1alpha2.Agent) *server.AgentCard {
 	}
 	return &card
 }
+
+// GetRemoteA2AAgentCard returns the agent card of a remote agent from the agent card fetched into
+// its status, nil if none was fetched yet. Its URL is the URL of the remote agent.
+func GetRemoteA2AAgentCard(remoteAgent *v1alpha2.RemoteAgent) *server.AgentCard {
+	fetched := remoteAgent.Status.AgentCard
+	if fetched == nil {
+		return nil
+	}
+	description := remoteAgent.Spec.Description
+	if description == "" {
+		description = fetched.Description
+	}
+	card := server.AgentCard{
+		Name:        strings.ReplaceAll(remoteAgent.Name, "-", "_"),
+		Description: description,
+		URL:         fetched.URL,
+		Version:     fetched.Version,
+		Capabilities: server.AgentCapabilities{
+			Streaming:              ptr.To(fetched.Streaming),
+			PushNotifications:      ptr.To(false),
+			StateTransitionHistory: ptr.To(false),
+		},
+		Skills: slices.Collect(utils.Map(slices.Values(remoteAgent.Status.DiscoveredSkills), func(skill v1alpha2.AgentSkill) server.AgentSkill {
+			return server.AgentSkill(skill)
+		})),
+		DefaultInputModes:  []string{"text"},
+		DefaultOutputModes: []string{"text"},
+	}
+	if card.Skills == nil {
+		// Can't be null for Python, so set to empty list
+		card.Skills = []server.AgentSkill{}
+	}
+	return &card
+}
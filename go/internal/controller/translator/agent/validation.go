@@ -0,0 +1,175 @@
+package agent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/kagent-dev/kagent/go/api/v1alpha2"
+	"github.com/kagent-dev/kagent/go/internal/database"
+	"github.com/kagent-dev/kagent/go/internal/utils"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// ToolLister lists the tools stored in the database for a tool server
+type ToolLister interface {
+	ListToolsForServer(serverName string, groupKind string) ([]database.Tool, error)
+}
+
+// ValidationOptions holds what the validation of an agent depends on besides its references
+type ValidationOptions struct {
+	// Tools lists all the tools discovered on the RemoteMCPServers. Without it, the tools listed
+	// in their status are used, which may omit some of them.
+	Tools ToolLister
+	// Previous is the agent being updated, if any. The tool names it already requests are not
+	// checked again, since the server may have stopped providing them in the meantime.
+	Previous *v1alpha2.Agent
+}
+
+// ValidateAgent runs the agent tool graph validation done at translation time,
+// and additionally checks that the referenced ModelConfig exists, that its fallback
+// chain can be resolved, that its models support the model overrides of the agent,
+// and that the tool names requested from a RemoteMCPServer have been discovered on it.
+func (a *adkApiTranslator) ValidateAgent(ctx context.Context, agent *v1alpha2.Agent, opts ValidationOptions) error {
+	if err := a.validateAgent(ctx, agent, &tState{}); err != nil {
+		return err
+	}
+
+	if agent.Spec.Type != v1alpha2.AgentType_Declarative || agent.Spec.Declarative == nil {
+		return nil
+	}
+
+	var errs []error
+	if modelConfig := agent.Spec.Declarative.ModelConfig; modelConfig != "" {
+		ref := types.NamespacedName{Namespace: agent.Namespace, Name: modelConfig}
+		model := &v1alpha2.ModelConfig{}
+		if err := a.kube.Get(ctx, ref, model); err != nil {
+			if apierrors.IsNotFound(err) {
+				errs = append(errs, fmt.Errorf("ModelConfig %s not found", ref))
+			} else {
+				errs = append(errs, fmt.Errorf("failed to get ModelConfig %s: %w", ref, err))
+			}
+		} else {
+			models := []*v1alpha2.ModelConfig{model}
+			if model.Spec.Provider == v1alpha2.ModelProviderFallback {
+				var err error
+				if models, err = ResolveModelChain(ctx, a.kube, model); err != nil {
+					errs = append(errs, fmt.Errorf("invalid fallback chain of ModelConfig %s: %w", ref, err))
+				}
+			}
+			for _, model := range models {
+				if err := applyModelOverrides(model.DeepCopy(), agent.Spec.Declarative.ModelOverrides); err != nil {
+					errs = append(errs, err)
+				}
+			}
+		}
+	}
+
+	for _, tool := range agent.Spec.Declarative.Tools {
+		if tool == nil || tool.McpServer == nil {
+			continue
+		}
+		if err := a.validateToolNames(ctx, agent.Namespace, tool.McpServer, opts); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// validateToolNames checks the toolNames added to an agent against the tools discovered on a
+// RemoteMCPServer. Servers that have not discovered any tools yet are not checked, so that an
+// agent can be applied together with the server it uses.
+func (a *adkApiTranslator) validateToolNames(ctx context.Context, namespace string, toolServer *v1alpha2.McpServerTool, opts ValidationOptions) error {
+	switch toolServer.GroupKind() {
+	case schema.GroupKind{Group: "", Kind: "RemoteMCPServer"}, schema.GroupKind{Group: "kagent.dev", Kind: "RemoteMCPServer"}:
+	default:
+		return nil
+	}
+
+	ref, err := utils.ParseRefString(toolServer.Name, namespace)
+	if err != nil {
+		return fmt.Errorf("invalid RemoteMCPServer reference %q: %w", toolServer.Name, err)
+	}
+
+	remoteMcpServer := &v1alpha2.RemoteMCPServer{}
+	if err := a.getReferencedObject(ctx, "RemoteMCPServer", toolServer.Name, namespace, remoteMcpServer); err != nil {
+		if apierrors.IsNotFound(err) {
+			return fmt.Errorf("RemoteMCPServer %s not found", ref)
+		}
+		return err
+	}
+
+	toolNames := newToolNames(toolServer.ToolNames, ref, opts.Previous)
+	if len(toolNames) == 0 {
+		return nil
+	}
+
+	discovered := make(map[string]struct{})
+	if opts.Tools != nil {
+		tools, err := opts.Tools.ListToolsForServer(ref.String(), schema.GroupKind{Group: "kagent.dev", Kind: "RemoteMCPServer"}.String())
+		if err != nil {
+			return fmt.Errorf("failed to list the tools of RemoteMCPServer %s: %w", ref, err)
+		}
+		for _, tool := range tools {
+			discovered[tool.ID] = struct{}{}
+		}
+	} else {
+		// the status lists only some of the tools once truncated
+		if meta.IsStatusConditionTrue(remoteMcpServer.Status.Conditions, v1alpha2.RemoteMCPServerConditionTypeStatusTruncated) {
+			return nil
+		}
+		for _, tool := range remoteMcpServer.Status.DiscoveredTools {
+			if tool != nil {
+				discovered[tool.Name] = struct{}{}
+			}
+		}
+	}
+	if len(discovered) == 0 {
+		return nil
+	}
+
+	var unknown []string
+	for _, toolName := range toolNames {
+		if _, ok := discovered[toolName]; !ok {
+			unknown = append(unknown, toolName)
+		}
+	}
+	if len(unknown) > 0 {
+		return fmt.Errorf("tools %v are not provided by RemoteMCPServer %s", unknown, ref)
+	}
+
+	return nil
+}
+
+// newToolNames returns the tool names requested from a tool server that the previous version
+// of the agent, if any, did not already request from it
+func newToolNames(toolNames []string, ref types.NamespacedName, previous *v1alpha2.Agent) []string {
+	if previous == nil || previous.Spec.Declarative == nil {
+		return toolNames
+	}
+
+	requested := make(map[string]struct{})
+	for _, tool := range previous.Spec.Declarative.Tools {
+		if tool == nil || tool.McpServer == nil || tool.McpServer.GroupKind().Kind != "RemoteMCPServer" {
+			continue
+		}
+		if previousRef, err := utils.ParseRefString(tool.McpServer.Name, previous.Namespace); err != nil || previousRef != ref {
+			continue
+		}
+		for _, toolName := range tool.McpServer.ToolNames {
+			requested[toolName] = struct{}{}
+		}
+	}
+
+	var added []string
+	for _, toolName := range toolNames {
+		if _, ok := requested[toolName]; !ok {
+			added = append(added, toolName)
+		}
+	}
+	return added
+}
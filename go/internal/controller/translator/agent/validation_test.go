@@ -0,0 +1,440 @@
+package agent_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/kagent-dev/kagent/go/api/v1alpha2"
+	translator "github.com/kagent-dev/kagent/go/internal/controller/translator/agent"
+	"github.com/kagent-dev/kagent/go/internal/database"
+	database_fake "github.com/kagent-dev/kagent/go/internal/database/fake"
+	"github.com/kagent-dev/kagent/go/internal/utils"
+)
+
+func declarativeAgent(name string, tools ...*v1alpha2.Tool) *v1alpha2.Agent {
+	return &v1alpha2.Agent{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "test"},
+		Spec: v1alpha2.AgentSpec{
+			Type: v1alpha2.AgentType_Declarative,
+			Declarative: &v1alpha2.DeclarativeAgentSpec{
+				SystemMessage: "You are a helpful agent.",
+				ModelConfig:   "model",
+				Tools:         tools,
+			},
+		},
+	}
+}
+
+func agentTool(name string) *v1alpha2.Tool {
+	return &v1alpha2.Tool{
+		Type:  v1alpha2.ToolProviderType_Agent,
+		Agent: &v1alpha2.TypedLocalReference{Name: name},
+	}
+}
+
+func remoteAgentTool(name string) *v1alpha2.Tool {
+	return &v1alpha2.Tool{
+		Type:  v1alpha2.ToolProviderType_Agent,
+		Agent: &v1alpha2.TypedLocalReference{Kind: "RemoteAgent", Name: name},
+	}
+}
+
+func remoteMCPServerTool(name string, toolNames ...string) *v1alpha2.Tool {
+	return &v1alpha2.Tool{
+		Type: v1alpha2.ToolProviderType_McpServer,
+		McpServer: &v1alpha2.McpServerTool{
+			TypedLocalReference: v1alpha2.TypedLocalReference{
+				Kind:     "RemoteMCPServer",
+				ApiGroup: "kagent.dev",
+				Name:     name,
+			},
+			ToolNames: toolNames,
+		},
+	}
+}
+
+func TestValidateAgent(t *testing.T) {
+	modelConfig := &v1alpha2.ModelConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "model", Namespace: "test"},
+		Spec: v1alpha2.ModelConfigSpec{
+			Provider: v1alpha2.ModelProviderOpenAI,
+			Model:    "gpt-4o",
+		},
+	}
+	toolServer := &v1alpha2.RemoteMCPServer{
+		ObjectMeta: metav1.ObjectMeta{Name: "toolserver", Namespace: "test"},
+		Spec: v1alpha2.RemoteMCPServerSpec{
+			URL: "http://toolserver.test:8084/mcp",
+		},
+		Status: v1alpha2.RemoteMCPServerStatus{
+			DiscoveredTools: []*v1alpha2.MCPTool{
+				{Name: "k8s_get_resources"},
+				{Name: "k8s_get_pod_logs"},
+			},
+		},
+	}
+	sharedToolServer := &v1alpha2.RemoteMCPServer{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "shared-toolserver",
+			Namespace:   "platform",
+			Annotations: map[string]string{utils.AllowedNamespacesAnnotation: "test"},
+		},
+		Spec: v1alpha2.RemoteMCPServerSpec{
+			URL: "http://shared-toolserver.platform:8084/mcp",
+		},
+		Status: v1alpha2.RemoteMCPServerStatus{
+			DiscoveredTools: []*v1alpha2.MCPTool{{Name: "k8s_get_resources"}},
+		},
+	}
+	privateToolServer := &v1alpha2.RemoteMCPServer{
+		ObjectMeta: metav1.ObjectMeta{Name: "private-toolserver", Namespace: "platform"},
+		Spec: v1alpha2.RemoteMCPServerSpec{
+			URL: "http://private-toolserver.platform:8084/mcp",
+		},
+	}
+	undiscoveredToolServer := &v1alpha2.RemoteMCPServer{
+		ObjectMeta: metav1.ObjectMeta{Name: "new-toolserver", Namespace: "test"},
+		Spec: v1alpha2.RemoteMCPServerSpec{
+			URL: "http://new-toolserver.test:8084/mcp",
+		},
+	}
+
+	tests := []struct {
+		name        string
+		agent       *v1alpha2.Agent
+		objects     []client.Object
+		wantErr     bool
+		errContains string
+	}{
+		{
+			name:  "valid agent with tools",
+			agent: declarativeAgent("agent", remoteMCPServerTool("toolserver", "k8s_get_resources"), agentTool("helper")),
+			objects: []client.Object{
+				declarativeAgent("helper"),
+			},
+		},
+		{
+			name:        "self referencing agent tool",
+			agent:       declarativeAgent("agent", agentTool("agent")),
+			wantErr:     true,
+			errContains: "cannot be used to reference itself",
+		},
+		{
+			name:  "cycle across nested agent tools",
+			agent: declarativeAgent("agent", agentTool("a")),
+			objects: []client.Object{
+				declarativeAgent("a", agentTool("b")),
+				declarativeAgent("b", agentTool("agent")),
+			},
+			wantErr:     true,
+			errContains: "cycle detected",
+		},
+		{
+			name:  "shared agent tool is not a cycle",
+			agent: declarativeAgent("agent", agentTool("a"), agentTool("b")),
+			objects: []client.Object{
+				declarativeAgent("a", agentTool("c")),
+				declarativeAgent("b", agentTool("a")),
+				declarativeAgent("c"),
+			},
+		},
+		{
+			name: "missing model config",
+			agent: func() *v1alpha2.Agent {
+				agent := declarativeAgent("agent")
+				agent.Spec.Declarative.ModelConfig = "missing-model"
+				return agent
+			}(),
+			wantErr:     true,
+			errContains: "ModelConfig test/missing-model not found",
+		},
+		{
+			name: "cycle in model fallback chain",
+			agent: func() *v1alpha2.Agent {
+				agent := declarativeAgent("agent")
+				agent.Spec.Declarative.ModelConfig = "fallback"
+				return agent
+			}(),
+			objects: []client.Object{
+				fallbackModelConfig("fallback", "model", "nested"),
+				fallbackModelConfig("nested", "fallback"),
+			},
+			wantErr:     true,
+			errContains: "fallback chain has a cycle: fallback -> nested -> fallback",
+		},
+		{
+			name: "model override not supported by provider",
+			agent: func() *v1alpha2.Agent {
+				agent := declarativeAgent("agent")
+				agent.Spec.Declarative.ModelOverrides = &v1alpha2.ModelOverrides{Temperature: "0.2", TopK: ptr.To(40)}
+				return agent
+			}(),
+			wantErr:     true,
+			errContains: "model overrides topK are not supported by OpenAI ModelConfig model",
+		},
+		{
+			name: "model override out of range",
+			agent: func() *v1alpha2.Agent {
+				agent := declarativeAgent("agent")
+				agent.Spec.Declarative.ModelOverrides = &v1alpha2.ModelOverrides{Temperature: "0.2", TopP: "1.5"}
+				return agent
+			}(),
+			wantErr:     true,
+			errContains: "model override topP 1.5 is not between 0 and 1",
+		},
+		{
+			name: "temperature in the range of the provider",
+			agent: func() *v1alpha2.Agent {
+				agent := declarativeAgent("agent")
+				agent.Spec.Declarative.ModelOverrides = &v1alpha2.ModelOverrides{Temperature: "1.5"}
+				return agent
+			}(),
+		},
+		{
+			name: "temperature out of the range of the provider",
+			agent: func() *v1alpha2.Agent {
+				agent := declarativeAgent("agent")
+				agent.Spec.Declarative.ModelConfig = "claude"
+				agent.Spec.Declarative.ModelOverrides = &v1alpha2.ModelOverrides{Temperature: "1.5"}
+				return agent
+			}(),
+			objects: []client.Object{
+				&v1alpha2.ModelConfig{
+					ObjectMeta: metav1.ObjectMeta{Name: "claude", Namespace: "test"},
+					Spec: v1alpha2.ModelConfigSpec{
+						Provider: v1alpha2.ModelProviderAnthropic,
+						Model:    "claude-sonnet-4-5",
+					},
+				},
+			},
+			wantErr:     true,
+			errContains: "model override temperature 1.5 is not between 0 and 1",
+		},
+		{
+			name: "model override not a number",
+			agent: func() *v1alpha2.Agent {
+				agent := declarativeAgent("agent")
+				agent.Spec.Declarative.ModelOverrides = &v1alpha2.ModelOverrides{Temperature: "warm"}
+				return agent
+			}(),
+			wantErr:     true,
+			errContains: `model override temperature "warm" is not a number`,
+		},
+		{
+			name: "model override not applied by vertex ai provider",
+			agent: func() *v1alpha2.Agent {
+				agent := declarativeAgent("agent")
+				agent.Spec.Declarative.ModelConfig = "vertex"
+				agent.Spec.Declarative.ModelOverrides = &v1alpha2.ModelOverrides{Temperature: "0.2"}
+				return agent
+			}(),
+			objects: []client.Object{
+				&v1alpha2.ModelConfig{
+					ObjectMeta: metav1.ObjectMeta{Name: "vertex", Namespace: "test"},
+					Spec: v1alpha2.ModelConfigSpec{
+						Provider:       v1alpha2.ModelProviderGeminiVertexAI,
+						Model:          "gemini-2.5-pro",
+						GeminiVertexAI: &v1alpha2.GeminiVertexAIConfig{},
+					},
+				},
+			},
+			wantErr:     true,
+			errContains: "model overrides temperature are not supported by GeminiVertexAI ModelConfig vertex",
+		},
+		{
+			name: "model override not supported by fallback member",
+			agent: func() *v1alpha2.Agent {
+				agent := declarativeAgent("agent")
+				agent.Spec.Declarative.ModelConfig = "fallback"
+				agent.Spec.Declarative.ModelOverrides = &v1alpha2.ModelOverrides{ReasoningEffort: ptr.To(v1alpha2.OpenAIReasoningEffort("low"))}
+				return agent
+			}(),
+			objects: []client.Object{
+				fallbackModelConfig("fallback", "model", "ollama"),
+				&v1alpha2.ModelConfig{
+					ObjectMeta: metav1.ObjectMeta{Name: "ollama", Namespace: "test"},
+					Spec:       v1alpha2.ModelConfigSpec{Provider: v1alpha2.ModelProviderOllama, Model: "llama3"},
+				},
+			},
+			wantErr:     true,
+			errContains: "model overrides reasoningEffort are not supported by Ollama ModelConfig ollama",
+		},
+		{
+			name:        "tool not discovered on remote mcp server",
+			agent:       declarativeAgent("agent", remoteMCPServerTool("toolserver", "k8s_get_resources", "k8s_delete_everything")),
+			wantErr:     true,
+			errContains: "tools [k8s_delete_everything] are not provided by RemoteMCPServer test/toolserver",
+		},
+		{
+			name:  "remote agent tool",
+			agent: declarativeAgent("agent", remoteAgentTool("remote")),
+			objects: []client.Object{
+				&v1alpha2.RemoteAgent{
+					ObjectMeta: metav1.ObjectMeta{Name: "remote", Namespace: "test"},
+					Spec:       v1alpha2.RemoteAgentSpec{URL: "https://agents.example.com/remote"},
+				},
+			},
+		},
+		{
+			name:        "missing remote agent",
+			agent:       declarativeAgent("agent", remoteAgentTool("missing-remote")),
+			wantErr:     true,
+			errContains: `"missing-remote" not found`,
+		},
+		{
+			name:        "missing remote mcp server",
+			agent:       declarativeAgent("agent", remoteMCPServerTool("missing-toolserver", "k8s_get_resources")),
+			wantErr:     true,
+			errContains: "RemoteMCPServer test/missing-toolserver not found",
+		},
+		{
+			name:  "cross namespace tools allowed by target",
+			agent: declarativeAgent("agent", remoteMCPServerTool("platform/shared-toolserver", "k8s_get_resources"), agentTool("platform/helper")),
+			objects: []client.Object{
+				func() *v1alpha2.Agent {
+					helper := declarativeAgent("helper")
+					helper.Namespace = "platform"
+					helper.Annotations = map[string]string{utils.AllowedNamespacesAnnotation: "*"}
+					return helper
+				}(),
+			},
+		},
+		{
+			name:        "cross namespace tool server not allowed by target",
+			agent:       declarativeAgent("agent", remoteMCPServerTool("platform/private-toolserver", "k8s_get_resources")),
+			wantErr:     true,
+			errContains: "RemoteMCPServer platform/private-toolserver does not allow references from namespace test",
+		},
+		{
+			name:  "cross namespace agent tool not allowed by target",
+			agent: declarativeAgent("agent", agentTool("platform/helper")),
+			objects: []client.Object{
+				func() *v1alpha2.Agent {
+					helper := declarativeAgent("helper")
+					helper.Namespace = "platform"
+					return helper
+				}(),
+			},
+			wantErr:     true,
+			errContains: "Agent platform/helper does not allow references from namespace test",
+		},
+		{
+			name:  "tool names are not checked before discovery",
+			agent: declarativeAgent("agent", remoteMCPServerTool("new-toolserver", "anything")),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scheme := runtime.NewScheme()
+			require.NoError(t, v1alpha2.AddToScheme(scheme))
+
+			objects := append([]client.Object{modelConfig.DeepCopy(), toolServer.DeepCopy(), sharedToolServer.DeepCopy(), privateToolServer.DeepCopy(), undiscoveredToolServer.DeepCopy()}, tt.objects...)
+			kubeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build()
+
+			tr := translator.NewAdkApiTranslator(kubeClient, types.NamespacedName{Namespace: "test", Name: "model"}, nil)
+			err := tr.ValidateAgent(context.Background(), tt.agent, translator.ValidationOptions{})
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errContains)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestValidateAgentToolNames(t *testing.T) {
+	modelConfig := &v1alpha2.ModelConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "model", Namespace: "test"},
+		Spec: v1alpha2.ModelConfigSpec{
+			Provider: v1alpha2.ModelProviderOpenAI,
+			Model:    "gpt-4o",
+		},
+	}
+	// the status of the server lists only the first of its tools
+	toolServer := &v1alpha2.RemoteMCPServer{
+		ObjectMeta: metav1.ObjectMeta{Name: "toolserver", Namespace: "test"},
+		Spec: v1alpha2.RemoteMCPServerSpec{
+			URL: "http://toolserver.test:8084/mcp",
+		},
+		Status: v1alpha2.RemoteMCPServerStatus{
+			Conditions: []metav1.Condition{{
+				Type:   v1alpha2.RemoteMCPServerConditionTypeStatusTruncated,
+				Status: metav1.ConditionTrue,
+				Reason: "StatusLimitReached",
+			}},
+			DiscoveredTools: []*v1alpha2.MCPTool{{Name: "k8s_get_resources"}},
+		},
+	}
+	dbClient := database_fake.NewClient()
+	_, err := dbClient.StoreToolServer(&database.ToolServer{Name: "test/toolserver", GroupKind: "RemoteMCPServer.kagent.dev"})
+	require.NoError(t, err)
+	require.NoError(t, dbClient.RefreshToolsForServer("test/toolserver", "RemoteMCPServer.kagent.dev",
+		&v1alpha2.MCPTool{Name: "k8s_get_resources"}, &v1alpha2.MCPTool{Name: "k8s_get_pod_logs"}))
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, v1alpha2.AddToScheme(scheme))
+	kubeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(modelConfig, toolServer).Build()
+	tr := translator.NewAdkApiTranslator(kubeClient, types.NamespacedName{Namespace: "test", Name: "model"}, nil)
+
+	tests := []struct {
+		name        string
+		agent       *v1alpha2.Agent
+		opts        translator.ValidationOptions
+		errContains string
+	}{
+		{
+			name:  "tools stored in the database",
+			agent: declarativeAgent("agent", remoteMCPServerTool("toolserver", "k8s_get_pod_logs")),
+			opts:  translator.ValidationOptions{Tools: dbClient},
+		},
+		{
+			name:        "tool unknown to the database",
+			agent:       declarativeAgent("agent", remoteMCPServerTool("toolserver", "k8s_delete_resources")),
+			opts:        translator.ValidationOptions{Tools: dbClient},
+			errContains: "tools [k8s_delete_resources] are not provided by RemoteMCPServer test/toolserver",
+		},
+		{
+			name:  "truncated status is not checked",
+			agent: declarativeAgent("agent", remoteMCPServerTool("toolserver", "k8s_get_pod_logs")),
+		},
+		{
+			name:  "tools already requested are not checked again",
+			agent: declarativeAgent("agent", remoteMCPServerTool("toolserver", "k8s_removed_tool", "k8s_get_resources")),
+			opts: translator.ValidationOptions{
+				Tools:    dbClient,
+				Previous: declarativeAgent("agent", remoteMCPServerTool("toolserver", "k8s_removed_tool")),
+			},
+		},
+		{
+			name:  "tools added by an update are checked",
+			agent: declarativeAgent("agent", remoteMCPServerTool("toolserver", "k8s_removed_tool", "k8s_other_tool")),
+			opts: translator.ValidationOptions{
+				Tools:    dbClient,
+				Previous: declarativeAgent("agent", remoteMCPServerTool("toolserver", "k8s_removed_tool")),
+			},
+			errContains: "tools [k8s_other_tool] are not provided",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tr.ValidateAgent(context.Background(), tt.agent, tt.opts)
+			if tt.errContains != "" {
+				assert.ErrorContains(t, err, tt.errContains)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -5,10 +5,12 @@ import (
 	"errors"
 	"fmt"
 	"slices"
+	"strings"
 	"time"
 
 	"github.com/kagent-dev/kagent/go/api/v1alpha2"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 	"trpc.group/trpc-go/trpc-a2a-go/protocol"
 )
 
@@ -18,9 +20,12 @@ type Client interface {
 	StoreSession(session *Session) error
 	StoreAgent(agent *Agent) error
 	StoreTask(task *protocol.Task) error
+	CancelTask(task *protocol.Task, canceledBy string) error
 	StorePushNotification(config *protocol.TaskPushNotificationConfig) error
 	StoreToolServer(toolServer *ToolServer) (*ToolServer, error)
 	StoreEvents(messages ...*Event) error
+	StoreUsage(usage *Usage) (bool, error)
+	StoreStreamEvents(events ...*StreamEvent) error
 
 	// Delete methods
 	DeleteSession(sessionName string, userID string) error
@@ -29,6 +34,8 @@ type Client interface {
 	DeleteTask(taskID string) error
 	DeletePushNotification(taskID string) error
 	DeleteToolsForServer(serverName string, groupKind string) error
+	DeleteAgentData(agentID string, archive bool) error
+	DeleteToolServerData(serverName string, groupKind string, archive bool) error
 
 	// Get methods
 	GetSession(name string, userID string) (*Session, error)
@@ -47,11 +54,32 @@ type Client interface {
 	ListAgents() ([]Agent, error)
 	ListToolServers() ([]ToolServer, error)
 	ListToolsForServer(serverName string, groupKind string) ([]Tool, error)
+	ListResourcesForServer(serverName string, groupKind string) ([]Resource, error)
+	ListPromptsForServer(serverName string, groupKind string) ([]Prompt, error)
 	ListEventsForSession(sessionID, userID string, options QueryOptions) ([]*Event, error)
 	ListPushNotifications(taskID string) ([]*protocol.TaskPushNotificationConfig, error)
+	ListPushNotificationDeliveries(taskID string) ([]PushNotificationDelivery, error)
+	ListDuePushNotificationDeliveries(now time.Time, limit int) ([]PushNotificationDelivery, error)
+	ListStreamEvents(taskID, userID string, afterID uint) ([]StreamEvent, error)
+	AggregateUsage(query UsageQuery) ([]UsageSummary, error)
+
+	// Push notification delivery methods
+	UpdatePushNotificationDelivery(delivery *PushNotificationDelivery) error
+	DeleteExpiredPushNotificationDeliveries(deliveredBefore, deadLetteredBefore time.Time) error
+
+	// Stream event methods
+	DeleteStreamEvents(before time.Time) error
+
+	// Rate limit methods
+	IncrementRateLimitCounter(key string, windowStart time.Time, limit int64) (bool, error)
+	AcquireConcurrencyLease(key string, limit int64, ttl time.Duration) (*ConcurrencyLease, error)
+	ReleaseConcurrencyLease(id uint) error
+	BindConcurrencyLease(id uint, taskID string, ttl time.Duration) error
 
 	// Helper methods
 	RefreshToolsForServer(serverName string, groupKind string, tools ...*v1alpha2.MCPTool) error
+	RefreshResourcesForServer(serverName string, groupKind string, resources ...*v1alpha2.MCPResource) error
+	RefreshPromptsForServer(serverName string, groupKind string, prompts ...*v1alpha2.MCPPrompt) error
 
 	// LangGraph Checkpoint methods
 	StoreCheckpoint(checkpoint *LangGraphCheckpoint) error
@@ -125,9 +153,17 @@ func (c *clientImpl) StoreTool(tool *Tool) error {
 	return save(c.db, tool)
 }
 
-// DeleteTask deletes a task by ID
+// DeleteTask deletes a task by ID, together with its push notification deliveries
 func (c *clientImpl) DeleteTask(taskID string) error {
-	return delete[Task](c.db, Clause{Key: "id", Value: taskID})
+	return c.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("task_id = ?", taskID).Delete(&PushNotificationDelivery{}).Error; err != nil {
+			return fmt.Errorf("failed to delete push notification deliveries: %w", err)
+		}
+		if err := tx.Where("task_id = ?", taskID).Delete(&ConcurrencyLease{}).Error; err != nil {
+			return fmt.Errorf("failed to release concurrency leases: %w", err)
+		}
+		return delete[Task](tx, Clause{Key: "id", Value: taskID})
+	})
 }
 
 // DeleteSession deletes a session by id and user ID
@@ -149,10 +185,91 @@ func (c *clientImpl) DeleteToolServer(serverName string, groupKind string) error
 		Clause{Key: "group_kind", Value: groupKind})
 }
 
+// DeleteToolsForServer deletes the tools, resources and prompts discovered on a tool server
 func (c *clientImpl) DeleteToolsForServer(serverName string, groupKind string) error {
-	return delete[Tool](c.db,
-		Clause{Key: "server_name", Value: serverName},
-		Clause{Key: "group_kind", Value: groupKind})
+	return c.db.Transaction(func(tx *gorm.DB) error {
+		clauses := []Clause{
+			{Key: "server_name", Value: serverName},
+			{Key: "group_kind", Value: groupKind},
+		}
+		if err := delete[Tool](tx, clauses...); err != nil {
+			return err
+		}
+		if err := delete[Resource](tx, clauses...); err != nil {
+			return err
+		}
+		return delete[Prompt](tx, clauses...)
+	})
+}
+
+// DeleteAgentData deletes an agent together with its sessions and their tasks, events
+// and push notifications. Archived rows are soft deleted, otherwise they are removed
+// for good, including rows that were archived before.
+func (c *clientImpl) DeleteAgentData(agentID string, archive bool) error {
+	return c.db.Transaction(func(tx *gorm.DB) error {
+		if !archive {
+			tx = tx.Unscoped().Session(&gorm.Session{})
+		}
+
+		var sessionIDs []string
+		if err := tx.Model(&Session{}).Where("agent_id = ?", agentID).Pluck("id", &sessionIDs).Error; err != nil {
+			return fmt.Errorf("failed to list sessions for agent: %w", err)
+		}
+
+		if len(sessionIDs) > 0 {
+			var taskIDs []string
+			if err := tx.Model(&Task{}).Where("session_id IN ?", sessionIDs).Pluck("id", &taskIDs).Error; err != nil {
+				return fmt.Errorf("failed to list tasks for agent: %w", err)
+			}
+			if len(taskIDs) > 0 {
+				if err := tx.Where("task_id IN ?", taskIDs).Delete(&PushNotification{}).Error; err != nil {
+					return fmt.Errorf("failed to delete push notifications for agent: %w", err)
+				}
+				if err := tx.Where("task_id IN ?", taskIDs).Delete(&PushNotificationDelivery{}).Error; err != nil {
+					return fmt.Errorf("failed to delete push notification deliveries for agent: %w", err)
+				}
+			}
+			if err := tx.Where("session_id IN ?", sessionIDs).Delete(&Task{}).Error; err != nil {
+				return fmt.Errorf("failed to delete tasks for agent: %w", err)
+			}
+			if err := tx.Where("session_id IN ?", sessionIDs).Delete(&Event{}).Error; err != nil {
+				return fmt.Errorf("failed to delete events for agent: %w", err)
+			}
+			if err := delete[Session](tx, Clause{Key: "agent_id", Value: agentID}); err != nil {
+				return err
+			}
+		}
+
+		return delete[Agent](tx, Clause{Key: "id", Value: agentID})
+	})
+}
+
+// DeleteToolServerData deletes a tool server together with the tools, resources and
+// prompts discovered on it. Archived rows are soft deleted, otherwise they are removed
+// for good, including rows that were archived before.
+func (c *clientImpl) DeleteToolServerData(serverName string, groupKind string, archive bool) error {
+	return c.db.Transaction(func(tx *gorm.DB) error {
+		if !archive {
+			tx = tx.Unscoped().Session(&gorm.Session{})
+		}
+
+		clauses := []Clause{
+			{Key: "server_name", Value: serverName},
+			{Key: "group_kind", Value: groupKind},
+		}
+		if err := delete[Tool](tx, clauses...); err != nil {
+			return err
+		}
+		if err := delete[Resource](tx, clauses...); err != nil {
+			return err
+		}
+		if err := delete[Prompt](tx, clauses...); err != nil {
+			return err
+		}
+		return delete[ToolServer](tx,
+			Clause{Key: "name", Value: serverName},
+			Clause{Key: "group_kind", Value: groupKind})
+	})
 }
 
 // GetTaskMessages retrieves messages for a specific task
@@ -216,6 +333,16 @@ func (c *clientImpl) StoreEvents(events ...*Event) error {
 	return nil
 }
 
+// StoreUsage records the token usage of a model call. It returns false when the usage of the
+// event is already recorded, e.g. when an agent stores the same event again.
+func (c *clientImpl) StoreUsage(usage *Usage) (bool, error) {
+	result := c.db.Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "event_id"}}, DoNothing: true}).Create(usage)
+	if result.Error != nil {
+		return false, fmt.Errorf("failed to store usage: %w", result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
+
 // ListSessionRuns lists all runs for a specific session
 func (c *clientImpl) ListTasksForSession(sessionID string) ([]*protocol.Task, error) {
 	tasks, err := list[Task](c.db,
@@ -261,6 +388,67 @@ func (c *clientImpl) ListToolsForServer(serverName string, groupKind string) ([]
 		Clause{Key: "group_kind", Value: groupKind})
 }
 
+// ListResourcesForServer lists all resources for a specific server and group kind
+func (c *clientImpl) ListResourcesForServer(serverName string, groupKind string) ([]Resource, error) {
+	return list[Resource](c.db,
+		Clause{Key: "server_name", Value: serverName},
+		Clause{Key: "group_kind", Value: groupKind})
+}
+
+// ListPromptsForServer lists all prompts for a specific server and group kind
+func (c *clientImpl) ListPromptsForServer(serverName string, groupKind string) ([]Prompt, error) {
+	return list[Prompt](c.db,
+		Clause{Key: "server_name", Value: serverName},
+		Clause{Key: "group_kind", Value: groupKind})
+}
+
+// RefreshResourcesForServer replaces the resources stored for a tool server
+func (c *clientImpl) RefreshResourcesForServer(serverName string, groupKind string, resources ...*v1alpha2.MCPResource) error {
+	return c.db.Transaction(func(tx *gorm.DB) error {
+		if err := delete[Resource](tx.Unscoped(),
+			Clause{Key: "server_name", Value: serverName},
+			Clause{Key: "group_kind", Value: groupKind}); err != nil {
+			return err
+		}
+		for _, resource := range resources {
+			if err := save(tx, &Resource{
+				URI:         resource.URI,
+				ServerName:  serverName,
+				GroupKind:   groupKind,
+				Name:        resource.Name,
+				Description: resource.Description,
+				MimeType:    resource.MimeType,
+			}); err != nil {
+				return fmt.Errorf("failed to create resource %s: %v", resource.URI, err)
+			}
+		}
+		return nil
+	})
+}
+
+// RefreshPromptsForServer replaces the prompts stored for a tool server
+func (c *clientImpl) RefreshPromptsForServer(serverName string, groupKind string, prompts ...*v1alpha2.MCPPrompt) error {
+	return c.db.Transaction(func(tx *gorm.DB) error {
+		if err := delete[Prompt](tx.Unscoped(),
+			Clause{Key: "server_name", Value: serverName},
+			Clause{Key: "group_kind", Value: groupKind}); err != nil {
+			return err
+		}
+		for _, prompt := range prompts {
+			if err := save(tx, &Prompt{
+				Name:        prompt.Name,
+				ServerName:  serverName,
+				GroupKind:   groupKind,
+				Description: prompt.Description,
+				Arguments:   prompt.Arguments,
+			}); err != nil {
+				return fmt.Errorf("failed to create prompt %s: %v", prompt.Name, err)
+			}
+		}
+		return nil
+	})
+}
+
 // RefreshToolsForServer refreshes a tool server
 // TODO: Use a transaction to ensure atomicity
 func (c *clientImpl) RefreshToolsForServer(serverName string, groupKind string, tools ...*v1alpha2.MCPTool) error {
@@ -281,18 +469,19 @@ func (c *clientImpl) RefreshToolsForServer(serverName string, groupKind string,
 			existingTool := existingTools[existingToolIndex]
 			existingTool.ServerName = serverName
 			existingTool.GroupKind = groupKind
-			existingTool.Description = tool.Description
+			existingTool.SetFromMCPTool(tool)
 			err = save(c.db, &existingTool)
 			if err != nil {
 				return err
 			}
 		} else {
-			err = save(c.db, &Tool{
-				ID:          tool.Name,
-				ServerName:  serverName,
-				GroupKind:   groupKind,
-				Description: tool.Description,
-			})
+			newTool := &Tool{
+				ID:         tool.Name,
+				ServerName: serverName,
+				GroupKind:  groupKind,
+			}
+			newTool.SetFromMCPTool(tool)
+			err = save(c.db, newTool)
 			if err != nil {
 				return fmt.Errorf("failed to create tool %s: %v", tool.Name, err)
 			}
@@ -361,6 +550,196 @@ func (c *clientImpl) ListEventsForSession(sessionID, userID string, options Quer
 	return protocolEvents, nil
 }
 
+// UsageGroupBy is a dimension token usage is aggregated by
+type UsageGroupBy string
+
+const (
+	UsageGroupByAgent       UsageGroupBy = "agent"
+	UsageGroupBySession     UsageGroupBy = "session"
+	UsageGroupByUser        UsageGroupBy = "user"
+	UsageGroupByModelConfig UsageGroupBy = "model_config"
+	UsageGroupByModel       UsageGroupBy = "model"
+)
+
+// UsageGroupByColumns maps the dimensions usage can be aggregated by to their columns
+var UsageGroupByColumns = map[UsageGroupBy]string{
+	UsageGroupByAgent:       "agent_id",
+	UsageGroupBySession:     "session_id",
+	UsageGroupByUser:        "user_id",
+	UsageGroupByModelConfig: "model_config",
+	UsageGroupByModel:       "model",
+}
+
+// UsageQuery selects the usage to aggregate. The time range includes From and excludes To,
+// zero times leave it open. Empty filters match everything.
+type UsageQuery struct {
+	From    time.Time
+	To      time.Time
+	GroupBy []UsageGroupBy
+
+	AgentID     string
+	SessionID   string
+	UserID      string
+	ModelConfig string
+}
+
+// UsageSummary is the usage aggregated over a group. Only the dimensions the usage is
+// grouped by are set.
+type UsageSummary struct {
+	AgentID     string `json:"agent_id,omitempty"`
+	SessionID   string `json:"session_id,omitempty"`
+	UserID      string `json:"user_id,omitempty"`
+	ModelConfig string `json:"model_config,omitempty"`
+	Model       string `json:"model,omitempty"`
+
+	Requests     int64   `json:"requests"`
+	InputTokens  int64   `json:"input_tokens"`
+	OutputTokens int64   `json:"output_tokens"`
+	CachedTokens int64   `json:"cached_tokens"`
+	Cost         float64 `json:"cost"`
+	// AvgLatencyMs is averaged over the calls with a known latency
+	AvgLatencyMs float64 `json:"avg_latency_ms"`
+}
+
+// AggregateUsage sums the usage matching the query by the dimensions it is grouped by
+func (c *clientImpl) AggregateUsage(query UsageQuery) ([]UsageSummary, error) {
+	columns := make([]string, 0, len(query.GroupBy))
+	for _, groupBy := range query.GroupBy {
+		column, ok := UsageGroupByColumns[groupBy]
+		if !ok {
+			return nil, fmt.Errorf("unsupported usage group by %q", groupBy)
+		}
+		if !slices.Contains(columns, column) {
+			columns = append(columns, column)
+		}
+	}
+
+	selects := append(slices.Clone(columns),
+		"COUNT(*) AS requests",
+		"COALESCE(SUM(input_tokens), 0) AS input_tokens",
+		"COALESCE(SUM(output_tokens), 0) AS output_tokens",
+		"COALESCE(SUM(cached_tokens), 0) AS cached_tokens",
+		"COALESCE(SUM(cost), 0) AS cost",
+		"COALESCE(AVG(NULLIF(latency_ms, 0)), 0) AS avg_latency_ms",
+	)
+	db := c.db.Model(&Usage{}).Select(strings.Join(selects, ", "))
+
+	if !query.From.IsZero() {
+		db = db.Where("created_at >= ?", query.From)
+	}
+	if !query.To.IsZero() {
+		db = db.Where("created_at < ?", query.To)
+	}
+	for _, clause := range []Clause{
+		{Key: "agent_id", Value: query.AgentID},
+		{Key: "session_id", Value: query.SessionID},
+		{Key: "user_id", Value: query.UserID},
+		{Key: "model_config", Value: query.ModelConfig},
+	} {
+		if clause.Value != "" {
+			db = db.Where(fmt.Sprintf("%s = ?", clause.Key), clause.Value)
+		}
+	}
+	if len(columns) > 0 {
+		db = db.Group(strings.Join(columns, ", ")).Order(strings.Join(columns, ", "))
+	}
+
+	var summaries []UsageSummary
+	if err := db.Scan(&summaries).Error; err != nil {
+		return nil, fmt.Errorf("failed to aggregate usage: %w", err)
+	}
+	// without grouping, no usage still yields a row
+	return slices.DeleteFunc(summaries, func(s UsageSummary) bool { return s.Requests == 0 }), nil
+}
+
+// IncrementRateLimitCounter counts a request under the key in the window starting at windowStart
+// if less than limit requests were counted in the window, and returns whether it was counted.
+// Counters of past windows are deleted along the way.
+func (c *clientImpl) IncrementRateLimitCounter(key string, windowStart time.Time, limit int64) (bool, error) {
+	counter := RateLimitCounter{Key: key, WindowStart: windowStart, Count: 1}
+	counted := false
+	err := c.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("key = ? AND window_start < ?", key, windowStart).Delete(&RateLimitCounter{}).Error; err != nil {
+			return err
+		}
+		// the count is only incremented below the limit, the rejected requests are not counted
+		result := tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "key"}, {Name: "window_start"}},
+			DoUpdates: clause.Assignments(map[string]any{"count": gorm.Expr("rate_limit_counter.count + 1")}),
+			Where:     clause.Where{Exprs: []clause.Expression{gorm.Expr("rate_limit_counter.count < ?", limit)}},
+		}).Create(&counter)
+		if result.Error != nil {
+			return result.Error
+		}
+		counted = result.RowsAffected > 0
+		return nil
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to increment rate limit counter: %w", err)
+	}
+	return counted, nil
+}
+
+// AcquireConcurrencyLease acquires a lease under the key expiring after ttl if less than limit
+// unexpired leases are held under it. It returns nil if the limit is reached.
+func (c *clientImpl) AcquireConcurrencyLease(key string, limit int64, ttl time.Duration) (*ConcurrencyLease, error) {
+	var lease *ConcurrencyLease
+	err := c.db.Transaction(func(tx *gorm.DB) error {
+		// the leases under the key are counted and taken by one transaction at a time, so that
+		// concurrent requests cannot all see a free slot. SQLite serializes the transactions writing.
+		if tx.Dialector.Name() == "postgres" {
+			if err := tx.Exec("SELECT pg_advisory_xact_lock(hashtext(?))", key).Error; err != nil {
+				return err
+			}
+		}
+		now := time.Now()
+		if err := tx.Where("key = ? AND expires_at <= ?", key, now).Delete(&ConcurrencyLease{}).Error; err != nil {
+			return err
+		}
+		var held int64
+		if err := tx.Model(&ConcurrencyLease{}).Where("key = ?", key).Count(&held).Error; err != nil {
+			return err
+		}
+		if held >= limit {
+			return nil
+		}
+		lease = &ConcurrencyLease{Key: key, ExpiresAt: now.Add(ttl)}
+		return tx.Create(lease).Error
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire concurrency lease: %w", err)
+	}
+	return lease, nil
+}
+
+// ReleaseConcurrencyLease releases a lease acquired with AcquireConcurrencyLease
+func (c *clientImpl) ReleaseConcurrencyLease(id uint) error {
+	return delete[ConcurrencyLease](c.db, Clause{Key: "id", Value: id})
+}
+
+// BindConcurrencyLease binds a lease to the task it was acquired for, extending it by ttl. The
+// lease is then released when the task is stored in a state the agent stopped working on it in.
+func (c *clientImpl) BindConcurrencyLease(id uint, taskID string, ttl time.Duration) error {
+	if err := c.db.Model(&ConcurrencyLease{}).Where("id = ?", id).Updates(map[string]any{
+		"task_id":    taskID,
+		"expires_at": time.Now().Add(ttl),
+	}).Error; err != nil {
+		return fmt.Errorf("failed to bind concurrency lease: %w", err)
+	}
+	return nil
+}
+
+// releasesConcurrencyLeases returns whether the agent stopped working on a task in the given
+// state, until it gets a new message if the task is interrupted
+func releasesConcurrencyLeases(state protocol.TaskState) bool {
+	switch state {
+	case protocol.TaskStateCompleted, protocol.TaskStateCanceled, protocol.TaskStateFailed, protocol.TaskStateRejected,
+		protocol.TaskStateInputRequired, protocol.TaskStateAuthRequired:
+		return true
+	}
+	return false
+}
+
 // GetMessage retrieves a protocol message from the database
 func (c *clientImpl) GetMessage(messageID string) (*protocol.Message, error) {
 	dbMessage, err := get[Event](c.db, Clause{Key: "id", Value: messageID})
@@ -409,18 +788,149 @@ func (c *clientImpl) ListMessagesByContextID(contextID string, limit int) ([]pro
 
 // StoreTask stores a MemoryCancellableTask in the database
 func (c *clientImpl) StoreTask(task *protocol.Task) error {
+	return c.storeTask(task, nil, "")
+}
+
+// CancelTask stores a canceled task and the user who canceled it. The cancellation is final:
+// later updates of the task, e.g. by an agent still working on it, are ignored.
+func (c *clientImpl) CancelTask(task *protocol.Task, canceledBy string) error {
+	now := time.Now()
+	return c.storeTask(task, &now, canceledBy)
+}
+
+func (c *clientImpl) storeTask(task *protocol.Task, canceledAt *time.Time, canceledBy string) error {
 	data, err := json.Marshal(task)
 	if err != nil {
 		return fmt.Errorf("failed to serialize task: %w", err)
 	}
 
 	dbTask := Task{
-		ID:        task.ID,
-		Data:      string(data),
-		SessionID: task.ContextID,
+		ID:         task.ID,
+		Data:       string(data),
+		SessionID:  task.ContextID,
+		CanceledAt: canceledAt,
+		CanceledBy: canceledBy,
 	}
 
-	return save(c.db, &dbTask)
+	return c.db.Transaction(func(tx *gorm.DB) error {
+		previous, err := get[Task](tx, Clause{Key: "id", Value: task.ID})
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+		if previous != nil && previous.CanceledAt != nil {
+			return nil
+		}
+		if err := save(tx, &dbTask); err != nil {
+			return err
+		}
+		if releasesConcurrencyLeases(task.Status.State) {
+			if err := tx.Where("task_id = ?", task.ID).Delete(&ConcurrencyLease{}).Error; err != nil {
+				return fmt.Errorf("failed to release concurrency leases: %w", err)
+			}
+		}
+		if previous != nil {
+			if previousTask, err := previous.Parse(); err == nil && previousTask.Status.State == task.Status.State {
+				return nil
+			}
+		}
+		return enqueuePushNotificationDeliveries(tx, task, string(data))
+	})
+}
+
+// enqueuePushNotificationDeliveries enqueues the delivery of the new state of a task to each push
+// notification config of the task
+func enqueuePushNotificationDeliveries(tx *gorm.DB, task *protocol.Task, payload string) error {
+	configs, err := list[PushNotification](tx, Clause{Key: "task_id", Value: task.ID})
+	if err != nil {
+		return err
+	}
+	for _, config := range configs {
+		if err := tx.Create(newPushNotificationDelivery(task, config.Data, payload)).Error; err != nil {
+			return fmt.Errorf("failed to enqueue push notification delivery: %w", err)
+		}
+	}
+	return nil
+}
+
+// newPushNotificationDelivery returns the delivery of the state of a task to a push notification config
+func newPushNotificationDelivery(task *protocol.Task, config string, payload string) *PushNotificationDelivery {
+	return &PushNotificationDelivery{
+		TaskID:        task.ID,
+		TaskState:     string(task.Status.State),
+		Config:        config,
+		Payload:       payload,
+		NextAttemptAt: time.Now(),
+	}
+}
+
+// ListDuePushNotificationDeliveries lists the pending deliveries due at the given time, oldest first
+func (c *clientImpl) ListDuePushNotificationDeliveries(now time.Time, limit int) ([]PushNotificationDelivery, error) {
+	var deliveries []PushNotificationDelivery
+	err := c.db.
+		Where("delivered_at IS NULL AND dead_lettered_at IS NULL AND next_attempt_at <= ?", now).
+		Order("id ASC").
+		Limit(limit).
+		Find(&deliveries).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list due push notification deliveries: %w", err)
+	}
+	return deliveries, nil
+}
+
+// ListPushNotificationDeliveries lists the deliveries of the push notifications of a task
+func (c *clientImpl) ListPushNotificationDeliveries(taskID string) ([]PushNotificationDelivery, error) {
+	return list[PushNotificationDelivery](c.db, Clause{Key: "task_id", Value: taskID})
+}
+
+// UpdatePushNotificationDelivery records the outcome of an attempt to deliver a push notification
+func (c *clientImpl) UpdatePushNotificationDelivery(delivery *PushNotificationDelivery) error {
+	if err := c.db.Save(delivery).Error; err != nil {
+		return fmt.Errorf("failed to update push notification delivery: %w", err)
+	}
+	return nil
+}
+
+// DeleteExpiredPushNotificationDeliveries deletes the deliveries delivered, and the dead letters
+// dead lettered, before the given times.
+// Dead letters are kept.
+func (c *clientImpl) DeleteExpiredPushNotificationDeliveries(deliveredBefore, deadLetteredBefore time.Time) error {
+	if err := c.db.Where("delivered_at < ? OR dead_lettered_at < ?", deliveredBefore, deadLetteredBefore).
+		Delete(&PushNotificationDelivery{}).Error; err != nil {
+		return fmt.Errorf("failed to delete expired push notification deliveries: %w", err)
+	}
+	return nil
+}
+
+// StoreStreamEvents stores events streamed by an agent in one insert, setting their IDs
+func (c *clientImpl) StoreStreamEvents(events ...*StreamEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+	if err := c.db.Create(events).Error; err != nil {
+		return fmt.Errorf("failed to store stream events: %w", err)
+	}
+	return nil
+}
+
+// ListStreamEvents lists the events of a task streamed to a user after the event with the given ID
+func (c *clientImpl) ListStreamEvents(taskID, userID string, afterID uint) ([]StreamEvent, error) {
+	var events []StreamEvent
+	err := c.db.
+		Where("task_id = ? AND user_id = ? AND id > ?", taskID, userID, afterID).
+		Order("id ASC").
+		Find(&events).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list stream events: %w", err)
+	}
+	return events, nil
+}
+
+// DeleteStreamEvents deletes the events streamed before the given time
+func (c *clientImpl) DeleteStreamEvents(before time.Time) error {
+	if err := c.db.Where("created_at < ?", before).Delete(&StreamEvent{}).Error; err != nil {
+		return fmt.Errorf("failed to delete stream events: %w", err)
+	}
+	return nil
 }
 
 // GetTask retrieves a MemoryCancellableTask from the database
@@ -458,7 +968,32 @@ func (c *clientImpl) StorePushNotification(config *protocol.TaskPushNotification
 		Data:   string(data),
 	}
 
-	return save(c.db, &dbPushNotification)
+	return c.db.Transaction(func(tx *gorm.DB) error {
+		previous, err := get[PushNotification](tx, Clause{Key: "id", Value: dbPushNotification.ID})
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+		if err := save(tx, &dbPushNotification); err != nil {
+			return err
+		}
+		if previous != nil {
+			return nil
+		}
+
+		// the task may have changed state before its config was known, e.g. when the config is set
+		// once a blocking request returns, so its current state is notified to a new config
+		task, err := get[Task](tx, Clause{Key: "id", Value: config.TaskID})
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		protocolTask, err := task.Parse()
+		if err != nil {
+			return err
+		}
+		return tx.Create(newPushNotificationDelivery(&protocolTask, dbPushNotification.Data, task.Data)).Error
+	})
 }
 
 // GetPushNotification retrieves a push notification configuration from the database
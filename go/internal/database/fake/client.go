@@ -6,6 +6,7 @@ import (
 	"slices"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/kagent-dev/kagent/go/api/v1alpha2"
 	"github.com/kagent-dev/kagent/go/internal/database"
@@ -15,21 +16,31 @@ import (
 
 // InMemoryFakeClient is a fake implementation of database.Client for testing
 type InMemoryFakeClient struct {
-	mu                sync.RWMutex
-	feedback          map[string]*database.Feedback
-	tasks             map[string]*database.Task    // changed from runs, key: taskID
-	sessions          map[string]*database.Session // key: sessionID_userID
-	agents            map[string]*database.Agent   // changed from teams
-	toolServers       map[string]*database.ToolServer
-	tools             map[string]*database.Tool
-	eventsBySession   map[string][]*database.Event                    // key: sessionId
-	events            map[string]*database.Event                      // key: eventID
-	pushNotifications map[string]*protocol.TaskPushNotificationConfig // key: taskID
-	checkpoints       map[string]*database.LangGraphCheckpoint        // key: user_id:thread_id:checkpoint_ns:checkpoint_id
-	checkpointWrites  map[string][]*database.LangGraphCheckpointWrite // key: user_id:thread_id:checkpoint_ns:checkpoint_id
-	crewaiMemory      map[string][]*database.CrewAIAgentMemory        // key: user_id:thread_id:agent_id
-	crewaiFlowStates  map[string]*database.CrewAIFlowState            // key: user_id:thread_id
-	nextFeedbackID    int
+	mu                         sync.RWMutex
+	feedback                   map[string]*database.Feedback
+	tasks                      map[string]*database.Task    // changed from runs, key: taskID
+	sessions                   map[string]*database.Session // key: sessionID_userID
+	agents                     map[string]*database.Agent   // changed from teams
+	toolServers                map[string]*database.ToolServer
+	tools                      map[string]*database.Tool
+	resources                  map[string][]database.Resource                  // key: server_name:group_kind
+	prompts                    map[string][]database.Prompt                    // key: server_name:group_kind
+	eventsBySession            map[string][]*database.Event                    // key: sessionId
+	events                     map[string]*database.Event                      // key: eventID
+	pushNotifications          map[string]*protocol.TaskPushNotificationConfig // key: taskID
+	usage                      []*database.Usage
+	pushNotificationDeliveries []*database.PushNotificationDelivery
+	nextDeliveryID             uint
+	streamEvents               []*database.StreamEvent
+	nextStreamEventID          uint
+	rateLimitCounters          map[string]*database.RateLimitCounter // key: key
+	leases                     map[uint]*database.ConcurrencyLease
+	nextLeaseID                uint
+	checkpoints                map[string]*database.LangGraphCheckpoint        // key: user_id:thread_id:checkpoint_ns:checkpoint_id
+	checkpointWrites           map[string][]*database.LangGraphCheckpointWrite // key: user_id:thread_id:checkpoint_ns:checkpoint_id
+	crewaiMemory               map[string][]*database.CrewAIAgentMemory        // key: user_id:thread_id:agent_id
+	crewaiFlowStates           map[string]*database.CrewAIFlowState            // key: user_id:thread_id
+	nextFeedbackID             int
 }
 
 // NewClient creates a new fake database client
@@ -41,9 +52,13 @@ func NewClient() database.Client {
 		agents:            make(map[string]*database.Agent),
 		toolServers:       make(map[string]*database.ToolServer),
 		tools:             make(map[string]*database.Tool),
+		resources:         make(map[string][]database.Resource),
+		prompts:           make(map[string][]database.Prompt),
 		eventsBySession:   make(map[string][]*database.Event),
 		events:            make(map[string]*database.Event),
 		pushNotifications: make(map[string]*protocol.TaskPushNotificationConfig),
+		rateLimitCounters: make(map[string]*database.RateLimitCounter),
+		leases:            make(map[uint]*database.ConcurrencyLease),
 		checkpoints:       make(map[string]*database.LangGraphCheckpoint),
 		checkpointWrites:  make(map[string][]*database.LangGraphCheckpointWrite),
 		crewaiMemory:      make(map[string][]*database.CrewAIAgentMemory),
@@ -52,6 +67,10 @@ func NewClient() database.Client {
 	}
 }
 
+func (c *InMemoryFakeClient) toolServerKey(serverName, groupKind string) string {
+	return fmt.Sprintf("%s:%s", serverName, groupKind)
+}
+
 func (c *InMemoryFakeClient) sessionKey(sessionID, userID string) string {
 	return fmt.Sprintf("%s_%s", sessionID, userID)
 }
@@ -92,6 +111,10 @@ func (c *InMemoryFakeClient) DeleteTask(taskID string) error {
 	defer c.mu.Unlock()
 
 	delete(c.tasks, taskID)
+	c.releaseTaskLeases(taskID)
+	c.pushNotificationDeliveries = slices.DeleteFunc(c.pushNotificationDeliveries, func(delivery *database.PushNotificationDelivery) bool {
+		return delivery.TaskID == taskID
+	})
 	return nil
 }
 
@@ -123,6 +146,26 @@ func (c *InMemoryFakeClient) StoreEvents(events ...*database.Event) error {
 	return nil
 }
 
+// StoreUsage records the token usage of a model call. It returns false when the usage of the
+// event is already recorded, e.g. when an agent stores the same event again.
+func (c *InMemoryFakeClient) StoreUsage(usage *database.Usage) (bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, existing := range c.usage {
+		if existing.EventID == usage.EventID {
+			return false, nil
+		}
+	}
+
+	if usage.CreatedAt.IsZero() {
+		usage.CreatedAt = time.Now()
+	}
+	usage.ID = uint(len(c.usage) + 1)
+	c.usage = append(c.usage, usage)
+	return true, nil
+}
+
 // StoreSession creates a new session record
 func (c *InMemoryFakeClient) StoreSession(session *database.Session) error {
 	c.mu.Lock()
@@ -144,6 +187,16 @@ func (c *InMemoryFakeClient) StoreAgent(agent *database.Agent) error {
 
 // StoreTask creates a new task record
 func (c *InMemoryFakeClient) StoreTask(task *protocol.Task) error {
+	return c.storeTask(task, nil, "")
+}
+
+// CancelTask stores a canceled task, ignoring its later updates
+func (c *InMemoryFakeClient) CancelTask(task *protocol.Task, canceledBy string) error {
+	now := time.Now()
+	return c.storeTask(task, &now, canceledBy)
+}
+
+func (c *InMemoryFakeClient) storeTask(task *protocol.Task, canceledAt *time.Time, canceledBy string) error {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
@@ -151,10 +204,50 @@ func (c *InMemoryFakeClient) StoreTask(task *protocol.Task) error {
 	if err != nil {
 		return err
 	}
+	previous, exists := c.tasks[task.ID]
+	if exists && previous.CanceledAt != nil {
+		return nil
+	}
 	c.tasks[task.ID] = &database.Task{
-		ID:   task.ID,
-		Data: string(jsn),
+		ID:         task.ID,
+		Data:       string(jsn),
+		SessionID:  task.ContextID,
+		CanceledAt: canceledAt,
+		CanceledBy: canceledBy,
+	}
+	switch task.Status.State {
+	case protocol.TaskStateCompleted, protocol.TaskStateCanceled, protocol.TaskStateFailed, protocol.TaskStateRejected,
+		protocol.TaskStateInputRequired, protocol.TaskStateAuthRequired:
+		c.releaseTaskLeases(task.ID)
+	}
+
+	if exists {
+		if previousTask, err := previous.Parse(); err == nil && previousTask.Status.State == task.Status.State {
+			return nil
+		}
+	}
+	if config, ok := c.pushNotifications[task.ID]; ok {
+		return c.enqueueDelivery(task, config, string(jsn))
+	}
+	return nil
+}
+
+// enqueueDelivery enqueues the delivery of the state of a task to a push notification config
+func (c *InMemoryFakeClient) enqueueDelivery(task *protocol.Task, config *protocol.TaskPushNotificationConfig, payload string) error {
+	configData, err := json.Marshal(config)
+	if err != nil {
+		return err
 	}
+	c.nextDeliveryID++
+	c.pushNotificationDeliveries = append(c.pushNotificationDeliveries, &database.PushNotificationDelivery{
+		ID:            c.nextDeliveryID,
+		CreatedAt:     time.Now(),
+		TaskID:        task.ID,
+		TaskState:     string(task.Status.State),
+		Config:        string(configData),
+		Payload:       payload,
+		NextAttemptAt: time.Now(),
+	})
 	return nil
 }
 
@@ -163,7 +256,20 @@ func (c *InMemoryFakeClient) StorePushNotification(config *protocol.TaskPushNoti
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
+	previous, exists := c.pushNotifications[config.TaskID]
 	c.pushNotifications[config.TaskID] = config
+	if exists && previous.PushNotificationConfig.ID == config.PushNotificationConfig.ID {
+		return nil
+	}
+
+	// the current state of the task is notified to a new config
+	if dbTask, ok := c.tasks[config.TaskID]; ok {
+		task, err := dbTask.Parse()
+		if err != nil {
+			return err
+		}
+		return c.enqueueDelivery(&task, config, dbTask.Data)
+	}
 	return nil
 }
 
@@ -230,6 +336,54 @@ func (c *InMemoryFakeClient) DeleteToolsForServer(serverName string, groupKind s
 			delete(c.tools, toolID)
 		}
 	}
+	delete(c.resources, c.toolServerKey(serverName, groupKind))
+	delete(c.prompts, c.toolServerKey(serverName, groupKind))
+	return nil
+}
+
+// DeleteAgentData deletes an agent together with its sessions and their tasks, events
+// and push notifications. The fake does not distinguish archived rows.
+func (c *InMemoryFakeClient) DeleteAgentData(agentID string, archive bool) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for key, session := range c.sessions {
+		if session.AgentID == nil || *session.AgentID != agentID {
+			continue
+		}
+		for taskID, task := range c.tasks {
+			if task.SessionID == session.ID {
+				delete(c.pushNotifications, taskID)
+				delete(c.tasks, taskID)
+				c.pushNotificationDeliveries = slices.DeleteFunc(c.pushNotificationDeliveries, func(delivery *database.PushNotificationDelivery) bool {
+					return delivery.TaskID == taskID
+				})
+			}
+		}
+		for _, event := range c.eventsBySession[session.ID] {
+			delete(c.events, event.ID)
+		}
+		delete(c.eventsBySession, session.ID)
+		delete(c.sessions, key)
+	}
+	delete(c.agents, agentID)
+	return nil
+}
+
+// DeleteToolServerData deletes a tool server together with the tools, resources and
+// prompts discovered on it. The fake does not distinguish archived rows.
+func (c *InMemoryFakeClient) DeleteToolServerData(serverName string, groupKind string, archive bool) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for toolID, tool := range c.tools {
+		if tool.ServerName == serverName && tool.GroupKind == groupKind {
+			delete(c.tools, toolID)
+		}
+	}
+	delete(c.resources, c.toolServerKey(serverName, groupKind))
+	delete(c.prompts, c.toolServerKey(serverName, groupKind))
+	delete(c.toolServers, serverName)
 	return nil
 }
 
@@ -427,6 +581,72 @@ func (c *InMemoryFakeClient) ListPushNotifications(taskID string) ([]*protocol.T
 	return result, nil
 }
 
+// AggregateUsage sums the usage matching the query by the dimensions it is grouped by
+func (c *InMemoryFakeClient) AggregateUsage(query database.UsageQuery) ([]database.UsageSummary, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	for _, groupBy := range query.GroupBy {
+		if _, ok := database.UsageGroupByColumns[groupBy]; !ok {
+			return nil, fmt.Errorf("unsupported usage group by %q", groupBy)
+		}
+	}
+
+	var keys []string
+	summaries := make(map[string]*database.UsageSummary)
+	latencies := make(map[string]int64)
+	for _, usage := range c.usage {
+		if (!query.From.IsZero() && usage.CreatedAt.Before(query.From)) ||
+			(!query.To.IsZero() && !usage.CreatedAt.Before(query.To)) ||
+			(query.AgentID != "" && usage.AgentID != query.AgentID) ||
+			(query.SessionID != "" && usage.SessionID != query.SessionID) ||
+			(query.UserID != "" && usage.UserID != query.UserID) ||
+			(query.ModelConfig != "" && usage.ModelConfig != query.ModelConfig) {
+			continue
+		}
+
+		group := database.UsageSummary{}
+		for _, groupBy := range query.GroupBy {
+			switch groupBy {
+			case database.UsageGroupByAgent:
+				group.AgentID = usage.AgentID
+			case database.UsageGroupBySession:
+				group.SessionID = usage.SessionID
+			case database.UsageGroupByUser:
+				group.UserID = usage.UserID
+			case database.UsageGroupByModelConfig:
+				group.ModelConfig = usage.ModelConfig
+			case database.UsageGroupByModel:
+				group.Model = usage.Model
+			}
+		}
+		key := strings.Join([]string{group.AgentID, group.SessionID, group.UserID, group.ModelConfig, group.Model}, "\x00")
+		summary, exists := summaries[key]
+		if !exists {
+			summary = &group
+			summaries[key] = summary
+			keys = append(keys, key)
+		}
+
+		summary.Requests++
+		summary.InputTokens += usage.InputTokens
+		summary.OutputTokens += usage.OutputTokens
+		summary.CachedTokens += usage.CachedTokens
+		summary.Cost += usage.Cost
+		if usage.LatencyMs > 0 {
+			summary.AvgLatencyMs = (summary.AvgLatencyMs*float64(latencies[key]) + float64(usage.LatencyMs)) / float64(latencies[key]+1)
+			latencies[key]++
+		}
+	}
+
+	slices.Sort(keys)
+	result := make([]database.UsageSummary, 0, len(keys))
+	for _, key := range keys {
+		result = append(result, *summaries[key])
+	}
+	return result, nil
+}
+
 // ListEventsForSession retrieves events for a specific session
 func (c *InMemoryFakeClient) ListEventsForSession(sessionID, userID string, options database.QueryOptions) ([]*database.Event, error) {
 	c.mu.RLock()
@@ -440,6 +660,61 @@ func (c *InMemoryFakeClient) ListEventsForSession(sessionID, userID string, opti
 	return events, nil
 }
 
+// ListResourcesForServer lists all resources for a specific server and toolserver type
+func (c *InMemoryFakeClient) ListResourcesForServer(serverName string, groupKind string) ([]database.Resource, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return slices.Clone(c.resources[c.toolServerKey(serverName, groupKind)]), nil
+}
+
+// ListPromptsForServer lists all prompts for a specific server and toolserver type
+func (c *InMemoryFakeClient) ListPromptsForServer(serverName string, groupKind string) ([]database.Prompt, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return slices.Clone(c.prompts[c.toolServerKey(serverName, groupKind)]), nil
+}
+
+// RefreshResourcesForServer replaces the resources of a tool server
+func (c *InMemoryFakeClient) RefreshResourcesForServer(serverName string, groupKind string, resources ...*v1alpha2.MCPResource) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	result := make([]database.Resource, 0, len(resources))
+	for _, resource := range resources {
+		result = append(result, database.Resource{
+			URI:         resource.URI,
+			ServerName:  serverName,
+			GroupKind:   groupKind,
+			Name:        resource.Name,
+			Description: resource.Description,
+			MimeType:    resource.MimeType,
+		})
+	}
+	c.resources[c.toolServerKey(serverName, groupKind)] = result
+	return nil
+}
+
+// RefreshPromptsForServer replaces the prompts of a tool server
+func (c *InMemoryFakeClient) RefreshPromptsForServer(serverName string, groupKind string, prompts ...*v1alpha2.MCPPrompt) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	result := make([]database.Prompt, 0, len(prompts))
+	for _, prompt := range prompts {
+		result = append(result, database.Prompt{
+			Name:        prompt.Name,
+			ServerName:  serverName,
+			GroupKind:   groupKind,
+			Description: prompt.Description,
+			Arguments:   prompt.Arguments,
+		})
+	}
+	c.prompts[c.toolServerKey(serverName, groupKind)] = result
+	return nil
+}
+
 // RefreshToolsForServer refreshes a tool server
 func (c *InMemoryFakeClient) RefreshToolsForServer(serverName string, groupKind string, tools ...*v1alpha2.MCPTool) error {
 	c.mu.Lock()
@@ -454,12 +729,13 @@ func (c *InMemoryFakeClient) RefreshToolsForServer(serverName string, groupKind
 
 	// Add new tools
 	for _, tool := range tools {
-		c.tools[tool.Name] = &database.Tool{
-			ID:          tool.Name,
-			ServerName:  serverName,
-			GroupKind:   groupKind,
-			Description: tool.Description,
+		dbTool := &database.Tool{
+			ID:         tool.Name,
+			ServerName: serverName,
+			GroupKind:  groupKind,
 		}
+		dbTool.SetFromMCPTool(tool)
+		c.tools[tool.Name] = dbTool
 	}
 
 	return nil
@@ -529,14 +805,190 @@ func (c *InMemoryFakeClient) Clear() {
 	c.agents = make(map[string]*database.Agent)
 	c.toolServers = make(map[string]*database.ToolServer)
 	c.tools = make(map[string]*database.Tool)
+	c.resources = make(map[string][]database.Resource)
+	c.prompts = make(map[string][]database.Prompt)
 	c.eventsBySession = make(map[string][]*database.Event)
 	c.events = make(map[string]*database.Event)
 	c.pushNotifications = make(map[string]*protocol.TaskPushNotificationConfig)
+	c.usage = nil
+	c.pushNotificationDeliveries = nil
+	c.streamEvents = nil
+	c.rateLimitCounters = make(map[string]*database.RateLimitCounter)
+	c.leases = make(map[uint]*database.ConcurrencyLease)
 	c.checkpoints = make(map[string]*database.LangGraphCheckpoint)
 	c.checkpointWrites = make(map[string][]*database.LangGraphCheckpointWrite)
 	c.nextFeedbackID = 1
 }
 
+// ListDuePushNotificationDeliveries lists the pending deliveries due at the given time, oldest first
+func (c *InMemoryFakeClient) ListDuePushNotificationDeliveries(now time.Time, limit int) ([]database.PushNotificationDelivery, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	var result []database.PushNotificationDelivery
+	for _, delivery := range c.pushNotificationDeliveries {
+		if len(result) == limit {
+			break
+		}
+		if delivery.DeliveredAt == nil && delivery.DeadLetteredAt == nil && !delivery.NextAttemptAt.After(now) {
+			result = append(result, *delivery)
+		}
+	}
+	return result, nil
+}
+
+// ListPushNotificationDeliveries lists the deliveries of the push notifications of a task
+func (c *InMemoryFakeClient) ListPushNotificationDeliveries(taskID string) ([]database.PushNotificationDelivery, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	var result []database.PushNotificationDelivery
+	for _, delivery := range c.pushNotificationDeliveries {
+		if delivery.TaskID == taskID {
+			result = append(result, *delivery)
+		}
+	}
+	return result, nil
+}
+
+// UpdatePushNotificationDelivery records the outcome of an attempt to deliver a push notification
+func (c *InMemoryFakeClient) UpdatePushNotificationDelivery(delivery *database.PushNotificationDelivery) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for i, existing := range c.pushNotificationDeliveries {
+		if existing.ID == delivery.ID {
+			updated := *delivery
+			c.pushNotificationDeliveries[i] = &updated
+			return nil
+		}
+	}
+	return gorm.ErrRecordNotFound
+}
+
+// DeleteExpiredPushNotificationDeliveries deletes the deliveries delivered, and the dead letters
+// dead lettered, before the given times
+func (c *InMemoryFakeClient) DeleteExpiredPushNotificationDeliveries(deliveredBefore, deadLetteredBefore time.Time) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.pushNotificationDeliveries = slices.DeleteFunc(c.pushNotificationDeliveries, func(delivery *database.PushNotificationDelivery) bool {
+		return (delivery.DeliveredAt != nil && delivery.DeliveredAt.Before(deliveredBefore)) ||
+			(delivery.DeadLetteredAt != nil && delivery.DeadLetteredAt.Before(deadLetteredBefore))
+	})
+	return nil
+}
+
+// StoreStreamEvents stores events streamed by an agent, setting their IDs
+func (c *InMemoryFakeClient) StoreStreamEvents(events ...*database.StreamEvent) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, event := range events {
+		c.nextStreamEventID++
+		event.ID = c.nextStreamEventID
+		event.CreatedAt = time.Now()
+		stored := *event
+		c.streamEvents = append(c.streamEvents, &stored)
+	}
+	return nil
+}
+
+// ListStreamEvents lists the events of a task streamed to a user after the event with the given ID
+func (c *InMemoryFakeClient) ListStreamEvents(taskID, userID string, afterID uint) ([]database.StreamEvent, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	var result []database.StreamEvent
+	for _, event := range c.streamEvents {
+		if event.TaskID == taskID && event.UserID == userID && event.ID > afterID {
+			result = append(result, *event)
+		}
+	}
+	return result, nil
+}
+
+// DeleteStreamEvents deletes the events streamed before the given time
+func (c *InMemoryFakeClient) DeleteStreamEvents(before time.Time) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.streamEvents = slices.DeleteFunc(c.streamEvents, func(event *database.StreamEvent) bool {
+		return event.CreatedAt.Before(before)
+	})
+	return nil
+}
+
+// IncrementRateLimitCounter counts a request under the key in the window starting at windowStart
+// if less than limit requests were counted in the window
+func (c *InMemoryFakeClient) IncrementRateLimitCounter(key string, windowStart time.Time, limit int64) (bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	counter, ok := c.rateLimitCounters[key]
+	if !ok || !counter.WindowStart.Equal(windowStart) {
+		counter = &database.RateLimitCounter{Key: key, WindowStart: windowStart}
+		c.rateLimitCounters[key] = counter
+	}
+	if counter.Count >= limit {
+		return false, nil
+	}
+	counter.Count++
+	return true, nil
+}
+
+// AcquireConcurrencyLease acquires a lease under the key if less than limit unexpired leases are held under it
+func (c *InMemoryFakeClient) AcquireConcurrencyLease(key string, limit int64, ttl time.Duration) (*database.ConcurrencyLease, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	var held int64
+	for id, lease := range c.leases {
+		if !lease.ExpiresAt.After(now) {
+			delete(c.leases, id)
+		} else if lease.Key == key {
+			held++
+		}
+	}
+	if held >= limit {
+		return nil, nil
+	}
+	c.nextLeaseID++
+	lease := &database.ConcurrencyLease{ID: c.nextLeaseID, Key: key, CreatedAt: now, ExpiresAt: now.Add(ttl)}
+	c.leases[lease.ID] = lease
+	return lease, nil
+}
+
+// ReleaseConcurrencyLease releases a lease acquired with AcquireConcurrencyLease
+func (c *InMemoryFakeClient) ReleaseConcurrencyLease(id uint) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.leases, id)
+	return nil
+}
+
+// BindConcurrencyLease binds a lease to a task, releasing it when the task stops being worked on
+func (c *InMemoryFakeClient) BindConcurrencyLease(id uint, taskID string, ttl time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if lease, ok := c.leases[id]; ok {
+		lease.TaskID = taskID
+		lease.ExpiresAt = time.Now().Add(ttl)
+	}
+	return nil
+}
+
+func (c *InMemoryFakeClient) releaseTaskLeases(taskID string) {
+	for id, lease := range c.leases {
+		if lease.TaskID == taskID {
+			delete(c.leases, id)
+		}
+	}
+}
+
 // UpsertAgent upserts an agent record
 func (c *InMemoryFakeClient) UpsertAgent(agent *database.Agent) error {
 	c.mu.Lock()
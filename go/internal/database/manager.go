@@ -97,9 +97,16 @@ func (m *Manager) Initialize() error {
 		&Task{},
 		&Event{},
 		&PushNotification{},
+		&PushNotificationDelivery{},
+		&StreamEvent{},
+		&Usage{},
+		&RateLimitCounter{},
+		&ConcurrencyLease{},
 		&Feedback{},
 		&Tool{},
 		&ToolServer{},
+		&Resource{},
+		&Prompt{},
 		&LangGraphCheckpoint{},
 		&LangGraphCheckpointWrite{},
 		&CrewAIAgentMemory{},
@@ -127,9 +134,16 @@ func (m *Manager) Reset(recreateTables bool) error {
 		&Task{},
 		&Event{},
 		&PushNotification{},
+		&PushNotificationDelivery{},
+		&StreamEvent{},
+		&Usage{},
+		&RateLimitCounter{},
+		&ConcurrencyLease{},
 		&Feedback{},
 		&Tool{},
 		&ToolServer{},
+		&Resource{},
+		&Prompt{},
 		&LangGraphCheckpoint{},
 		&LangGraphCheckpointWrite{},
 		&CrewAIAgentMemory{},
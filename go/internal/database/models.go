@@ -4,8 +4,10 @@ import (
 	"encoding/json"
 	"time"
 
+	"github.com/kagent-dev/kagent/go/api/v1alpha2"
 	"github.com/kagent-dev/kagent/go/internal/adk"
 	"gorm.io/gorm"
+	"k8s.io/apimachinery/pkg/runtime"
 	"trpc.group/trpc-go/trpc-a2a-go/protocol"
 )
 
@@ -73,6 +75,9 @@ type Task struct {
 	DeletedAt gorm.DeletedAt `gorm:"index" json:"deleted_at"`
 	Data      string         `gorm:"type:text;not null" json:"data"` // JSON serialized task data
 	SessionID string         `gorm:"index" json:"session_id"`
+	// CanceledAt and CanceledBy record the cancellation of the task and the user who canceled it
+	CanceledAt *time.Time `gorm:"index" json:"canceled_at,omitempty"`
+	CanceledBy string     `json:"canceled_by,omitempty"`
 }
 
 func (t *Task) Parse() (protocol.Task, error) {
@@ -105,6 +110,73 @@ type PushNotification struct {
 	Data      string         `gorm:"type:text;not null" json:"data"` // JSON serialized push notification config
 }
 
+// PushNotificationDelivery is a notification of a new state of a task to deliver to the URL of a
+// push notification config of the task. Deliveries failing after all their attempts are kept as
+// dead letters. Deliveries are deleted with their task, or once expired by the deliverer.
+type PushNotificationDelivery struct {
+	ID        uint      `gorm:"primaryKey;autoIncrement" json:"id"`
+	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+	TaskID    string    `gorm:"not null;index" json:"task_id"`
+	TaskState string    `json:"task_state"`
+	Config    string    `gorm:"type:text;not null" json:"config"`  // JSON serialized push notification config
+	Payload   string    `gorm:"type:text;not null" json:"payload"` // JSON serialized task
+
+	Attempts       int        `json:"attempts"`
+	NextAttemptAt  time.Time  `gorm:"index" json:"next_attempt_at"`
+	LastError      string     `json:"last_error,omitempty"`
+	DeliveredAt    *time.Time `gorm:"index" json:"delivered_at,omitempty"`
+	DeadLetteredAt *time.Time `gorm:"index" json:"dead_lettered_at,omitempty"`
+}
+
+// StreamEvent is an event of a task streamed by an agent to a user, kept for the user resubscribing
+// to the task to replay the events they missed
+type StreamEvent struct {
+	ID        uint      `gorm:"primaryKey;autoIncrement" json:"id"`
+	CreatedAt time.Time `gorm:"autoCreateTime;index" json:"created_at"`
+	TaskID    string    `gorm:"not null;index" json:"task_id"`
+	UserID    string    `gorm:"index" json:"user_id"`
+	Data      string    `gorm:"type:text;not null" json:"data"` // JSON serialized streaming event
+}
+
+// Usage records the tokens a model call of an agent used, parsed from the usage metadata
+// of the event the agent stored for it. The usage of an event is recorded once.
+type Usage struct {
+	ID        uint      `gorm:"primaryKey;autoIncrement" json:"id"`
+	CreatedAt time.Time `gorm:"autoCreateTime;index" json:"created_at"`
+	EventID   string    `gorm:"uniqueIndex" json:"event_id"`
+	SessionID string    `gorm:"index" json:"session_id"`
+	UserID    string    `gorm:"index" json:"user_id"`
+	AgentID   string    `gorm:"index" json:"agent_id"`
+	// ModelConfig is the namespace/name of the ModelConfig of the agent
+	ModelConfig  string  `gorm:"index" json:"model_config"`
+	Model        string  `json:"model"`
+	InputTokens  int64   `json:"input_tokens"`
+	OutputTokens int64   `json:"output_tokens"`
+	CachedTokens int64   `json:"cached_tokens"`
+	LatencyMs    int64   `json:"latency_ms"`
+	Cost         float64 `json:"cost"`
+}
+
+// RateLimitCounter counts the requests made under a rate limit key in a window of time,
+// shared by all the controller replicas
+type RateLimitCounter struct {
+	Key         string    `gorm:"primaryKey;not null" json:"key"`
+	WindowStart time.Time `gorm:"primaryKey;not null;index" json:"window_start"`
+	Count       int64     `gorm:"not null" json:"count"`
+}
+
+// ConcurrencyLease holds one of the concurrent slots of a concurrency limit key until it is
+// released, or until it expires if the replica holding it goes away. A lease bound to a task is
+// released once the task is stored in a state the agent stopped working on it in.
+type ConcurrencyLease struct {
+	ID        uint      `gorm:"primaryKey;autoIncrement" json:"id"`
+	Key       string    `gorm:"not null;index" json:"key"`
+	TaskID    string    `gorm:"index" json:"task_id,omitempty"`
+	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
+	ExpiresAt time.Time `gorm:"not null;index" json:"expires_at"`
+}
+
 // FeedbackIssueType represents the category of feedback issue
 type FeedbackIssueType string
 
@@ -134,6 +206,84 @@ type Tool struct {
 	UpdatedAt   time.Time      `gorm:"autoUpdateTime" json:"updated_at"`
 	DeletedAt   gorm.DeletedAt `gorm:"index" json:"deleted_at"`
 	Description string         `json:"description"`
+	// The full JSON schemas and annotations reported by the MCP server
+	InputSchema  json.RawMessage              `gorm:"type:json;serializer:json" json:"input_schema,omitempty"`
+	OutputSchema json.RawMessage              `gorm:"type:json;serializer:json" json:"output_schema,omitempty"`
+	Annotations  *v1alpha2.MCPToolAnnotations `gorm:"type:json;serializer:json" json:"annotations,omitempty"`
+}
+
+// SetFromMCPTool copies the description, schemas and annotations of a discovered tool.
+func (t *Tool) SetFromMCPTool(tool *v1alpha2.MCPTool) {
+	t.Description = tool.Description
+	t.InputSchema = nil
+	if tool.InputSchema != nil {
+		t.InputSchema = tool.InputSchema.Raw
+	}
+	t.OutputSchema = nil
+	if tool.OutputSchema != nil {
+		t.OutputSchema = tool.OutputSchema.Raw
+	}
+	t.Annotations = tool.Annotations
+}
+
+// ToMCPTool converts the stored tool back into its MCPTool form.
+func (t *Tool) ToMCPTool() *v1alpha2.MCPTool {
+	tool := &v1alpha2.MCPTool{
+		Name:        t.ID,
+		Description: t.Description,
+		Annotations: t.Annotations,
+	}
+	if len(t.InputSchema) > 0 {
+		tool.InputSchema = &runtime.RawExtension{Raw: t.InputSchema}
+	}
+	if len(t.OutputSchema) > 0 {
+		tool.OutputSchema = &runtime.RawExtension{Raw: t.OutputSchema}
+	}
+	return tool
+}
+
+// Resource represents a resource that a tool server offers to read
+type Resource struct {
+	URI         string         `gorm:"primaryKey;not null" json:"uri"`
+	ServerName  string         `gorm:"primaryKey;not null" json:"server_name"`
+	GroupKind   string         `gorm:"primaryKey;not null" json:"group_kind"`
+	CreatedAt   time.Time      `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt   time.Time      `gorm:"autoUpdateTime" json:"updated_at"`
+	DeletedAt   gorm.DeletedAt `gorm:"index" json:"deleted_at"`
+	Name        string         `json:"name"`
+	Description string         `json:"description"`
+	MimeType    string         `json:"mime_type,omitempty"`
+}
+
+// Prompt represents a prompt or prompt template offered by a tool server
+type Prompt struct {
+	Name        string                       `gorm:"primaryKey;not null" json:"name"`
+	ServerName  string                       `gorm:"primaryKey;not null" json:"server_name"`
+	GroupKind   string                       `gorm:"primaryKey;not null" json:"group_kind"`
+	CreatedAt   time.Time                    `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt   time.Time                    `gorm:"autoUpdateTime" json:"updated_at"`
+	DeletedAt   gorm.DeletedAt               `gorm:"index" json:"deleted_at"`
+	Description string                       `json:"description"`
+	Arguments   []v1alpha2.MCPPromptArgument `gorm:"type:json;serializer:json" json:"arguments,omitempty"`
+}
+
+// ToMCPResource converts the stored resource back into its MCPResource form.
+func (r *Resource) ToMCPResource() *v1alpha2.MCPResource {
+	return &v1alpha2.MCPResource{
+		URI:         r.URI,
+		Name:        r.Name,
+		Description: r.Description,
+		MimeType:    r.MimeType,
+	}
+}
+
+// ToMCPPrompt converts the stored prompt back into its MCPPrompt form.
+func (p *Prompt) ToMCPPrompt() *v1alpha2.MCPPrompt {
+	return &v1alpha2.MCPPrompt{
+		Name:        p.Name,
+		Description: p.Description,
+		Arguments:   p.Arguments,
+	}
 }
 
 // ToolServer represents a tool server that provides tools
@@ -208,9 +358,16 @@ func (Event) TableName() string                    { return "event" }
 func (Session) TableName() string                  { return "session" }
 func (Task) TableName() string                     { return "task" }
 func (PushNotification) TableName() string         { return "push_notification" }
+func (PushNotificationDelivery) TableName() string { return "push_notification_delivery" }
+func (StreamEvent) TableName() string              { return "stream_event" }
+func (Usage) TableName() string                    { return "usage" }
+func (RateLimitCounter) TableName() string         { return "rate_limit_counter" }
+func (ConcurrencyLease) TableName() string         { return "concurrency_lease" }
 func (Feedback) TableName() string                 { return "feedback" }
 func (Tool) TableName() string                     { return "tool" }
 func (ToolServer) TableName() string               { return "toolserver" }
+func (Resource) TableName() string                 { return "resource" }
+func (Prompt) TableName() string                   { return "prompt" }
 func (LangGraphCheckpoint) TableName() string      { return "lg_checkpoint" }
 func (LangGraphCheckpointWrite) TableName() string { return "lg_checkpoint_write" }
 func (CrewAIAgentMemory) TableName() string        { return "crewai_agent_memory" }
@@ -0,0 +1,187 @@
+// Package egress builds the HTTP clients of the connections the controller makes to model
+// providers and MCP servers, honouring their TLS and proxy configuration.
+package egress
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"golang.org/x/net/http/httpproxy"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kagent-dev/kagent/go/api/v1alpha2"
+)
+
+// Config is the TLS and proxy configuration of a connection with the referenced secrets resolved.
+type Config struct {
+	TLS   *v1alpha2.TLSConfig
+	Proxy *v1alpha2.ProxyConfig
+
+	// CACert is the PEM encoded CA certificate, unset when verification is disabled
+	CACert []byte
+	// ClientCert and ClientKey are the PEM encoded client certificate and key for mutual TLS
+	ClientCert []byte
+	ClientKey  []byte
+
+	ProxyUsername string
+	ProxyPassword string
+}
+
+// Resolve reads the secrets referenced by the TLS and proxy configuration from the namespace.
+func Resolve(ctx context.Context, kube client.Client, namespace string, tlsConfig *v1alpha2.TLSConfig, proxy *v1alpha2.ProxyConfig) (*Config, error) {
+	config := &Config{TLS: tlsConfig, Proxy: proxy}
+
+	if tlsConfig != nil {
+		if tlsConfig.CACertSecretRef != "" && tlsConfig.CACertSecretKey != "" && !tlsConfig.DisableVerify {
+			secret, err := getSecret(ctx, kube, namespace, tlsConfig.CACertSecretRef)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get CA certificate: %w", err)
+			}
+			if config.CACert, err = secretValue(secret, tlsConfig.CACertSecretKey); err != nil {
+				return nil, fmt.Errorf("failed to get CA certificate: %w", err)
+			}
+		}
+		if tlsConfig.ClientCertSecretRef != "" {
+			secret, err := getSecret(ctx, kube, namespace, tlsConfig.ClientCertSecretRef)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get client certificate: %w", err)
+			}
+			if config.ClientCert, err = secretValue(secret, corev1.TLSCertKey); err != nil {
+				return nil, fmt.Errorf("failed to get client certificate: %w", err)
+			}
+			if config.ClientKey, err = secretValue(secret, corev1.TLSPrivateKeyKey); err != nil {
+				return nil, fmt.Errorf("failed to get client certificate: %w", err)
+			}
+		}
+	}
+
+	if proxy != nil && proxy.CredentialsSecretRef != "" {
+		secret, err := getSecret(ctx, kube, namespace, proxy.CredentialsSecretRef)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get proxy credentials: %w", err)
+		}
+		username, err := secretValue(secret, corev1.BasicAuthUsernameKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get proxy credentials: %w", err)
+		}
+		password, err := secretValue(secret, corev1.BasicAuthPasswordKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get proxy credentials: %w", err)
+		}
+		config.ProxyUsername, config.ProxyPassword = string(username), string(password)
+	}
+
+	return config, nil
+}
+
+// ProxyURL returns the URL of the proxy with its credentials, or nil when there is no proxy.
+func (c *Config) ProxyURL() (*url.URL, error) {
+	if c.Proxy == nil || c.Proxy.URL == "" {
+		return nil, nil
+	}
+	proxyURL, err := url.Parse(c.Proxy.URL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid proxy URL: %w", err)
+	}
+	if c.ProxyUsername != "" {
+		proxyURL.User = url.UserPassword(c.ProxyUsername, c.ProxyPassword)
+	}
+	return proxyURL, nil
+}
+
+// HTTPClient returns a client honouring the configuration. The default client is returned
+// when there is nothing to configure.
+func (c *Config) HTTPClient() (*http.Client, error) {
+	if c.isDefault() {
+		return http.DefaultClient, nil
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	tlsConfig, err := c.tlsClientConfig()
+	if err != nil {
+		return nil, err
+	}
+	if tlsConfig != nil {
+		transport.TLSClientConfig = tlsConfig
+	}
+
+	proxyURL, err := c.ProxyURL()
+	if err != nil {
+		return nil, err
+	}
+	if proxyURL != nil {
+		proxyFunc := (&httpproxy.Config{
+			HTTPProxy:  proxyURL.String(),
+			HTTPSProxy: proxyURL.String(),
+			NoProxy:    strings.Join(c.Proxy.NoProxy, ","),
+		}).ProxyFunc()
+		transport.Proxy = func(req *http.Request) (*url.URL, error) {
+			return proxyFunc(req.URL)
+		}
+	}
+
+	return &http.Client{Transport: transport}, nil
+}
+
+func (c *Config) isDefault() bool {
+	hasTLS := c.TLS != nil && (c.TLS.DisableVerify || c.TLS.DisableSystemCAs || len(c.CACert) > 0 || len(c.ClientCert) > 0)
+	hasProxy := c.Proxy != nil && c.Proxy.URL != ""
+	return !hasTLS && !hasProxy
+}
+
+// tlsClientConfig returns nil when the default TLS configuration should be used.
+func (c *Config) tlsClientConfig() (*tls.Config, error) {
+	if c.TLS == nil {
+		return nil, nil
+	}
+
+	config := &tls.Config{MinVersion: tls.VersionTLS12}
+	if c.TLS.DisableVerify {
+		config.InsecureSkipVerify = true //nolint:gosec // explicitly requested by the TLS configuration
+	} else if len(c.CACert) > 0 || c.TLS.DisableSystemCAs {
+		pool := x509.NewCertPool()
+		if !c.TLS.DisableSystemCAs {
+			if systemPool, err := x509.SystemCertPool(); err == nil {
+				pool = systemPool
+			}
+		}
+		if len(c.CACert) > 0 && !pool.AppendCertsFromPEM(c.CACert) {
+			return nil, fmt.Errorf("no valid certificates found in CA certificate")
+		}
+		config.RootCAs = pool
+	}
+
+	if len(c.ClientCert) > 0 {
+		certificate, err := tls.X509KeyPair(c.ClientCert, c.ClientKey)
+		if err != nil {
+			return nil, fmt.Errorf("invalid client certificate: %w", err)
+		}
+		config.Certificates = []tls.Certificate{certificate}
+	}
+
+	return config, nil
+}
+
+func getSecret(ctx context.Context, kube client.Client, namespace, name string) (*corev1.Secret, error) {
+	secret := &corev1.Secret{}
+	if err := kube.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, secret); err != nil {
+		return nil, err
+	}
+	return secret, nil
+}
+
+func secretValue(secret *corev1.Secret, key string) ([]byte, error) {
+	value, ok := secret.Data[key]
+	if !ok {
+		return nil, fmt.Errorf("key %s not found in secret %s", key, secret.Name)
+	}
+	return value, nil
+}
@@ -89,3 +89,21 @@ func NewForbiddenError(message string, err error) *APIError {
 		Err:     err,
 	}
 }
+
+// NewBadGatewayError creates a new error for a failed request to an upstream server
+func NewBadGatewayError(message string, err error) *APIError {
+	return &APIError{
+		Code:    http.StatusBadGateway,
+		Message: message,
+		Err:     err,
+	}
+}
+
+// NewTooManyRequestsError creates a new error for a request rejected by a rate limit
+func NewTooManyRequestsError(message string, err error) *APIError {
+	return &APIError{
+		Code:    http.StatusTooManyRequests,
+		Message: message,
+		Err:     err,
+	}
+}
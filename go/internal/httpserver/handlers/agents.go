@@ -12,6 +12,8 @@ import (
 	"github.com/kagent-dev/kagent/go/pkg/auth"
 	"github.com/kagent-dev/kagent/go/pkg/client/api"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	ctrllog "sigs.k8s.io/controller-runtime/pkg/log"
@@ -54,6 +56,15 @@ func (h *AgentsHandler) HandleListAgents(w ErrorResponseWriter, r *http.Request)
 		agentsWithID = append(agentsWithID, agentResponse)
 	}
 
+	remoteAgentList := &v1alpha2.RemoteAgentList{}
+	if err := h.KubeClient.List(r.Context(), remoteAgentList); err != nil {
+		w.RespondWithError(errors.NewInternalServerError("Failed to list RemoteAgents from Kubernetes", err))
+		return
+	}
+	for _, remoteAgent := range remoteAgentList.Items {
+		agentsWithID = append(agentsWithID, getRemoteAgentResponse(&remoteAgent))
+	}
+
 	log.Info("Successfully listed agents", "count", len(agentsWithID))
 	data := api.NewResponse(agentsWithID, "Successfully listed agents", false)
 	RespondWithJSON(w, http.StatusOK, data)
@@ -64,9 +75,16 @@ func (h *AgentsHandler) getAgentResponse(ctx context.Context, log logr.Logger, a
 	log.V(1).Info("Processing Agent", "agentRef", agentRef)
 
 	deploymentReady := false
+	var readyReason, readyMessage string
 	for _, condition := range agent.Status.Conditions {
-		if condition.Type == "Ready" && condition.Reason == "DeploymentReady" && condition.Status == "True" {
-			deploymentReady = true
+		if condition.Type == "Ready" {
+			// The reason depends on the readiness mode of the agent
+			deploymentReady = condition.Status == "True" &&
+				(condition.Reason == v1alpha2.AgentReadyReasonDeploymentReady || condition.Reason == v1alpha2.AgentReadyReasonAgentReady)
+			if condition.Status != "True" {
+				readyReason = condition.Reason
+				readyMessage = condition.Message
+			}
 			break
 		}
 	}
@@ -85,6 +103,8 @@ func (h *AgentsHandler) getAgentResponse(ctx context.Context, log logr.Logger, a
 		Agent:           agent,
 		DeploymentReady: deploymentReady,
 		Accepted:        accepted,
+		ReadyReason:     readyReason,
+		ReadyMessage:    readyMessage,
 	}
 
 	if agent.Spec.Type == v1alpha2.AgentType_Declarative {
@@ -115,6 +135,26 @@ func (h *AgentsHandler) getAgentResponse(ctx context.Context, log logr.Logger, a
 	return response, nil
 }
 
+// getRemoteAgentResponse returns the response of an agent imported with a RemoteAgent, which is
+// ready once its agent card has been fetched
+func getRemoteAgentResponse(remoteAgent *v1alpha2.RemoteAgent) api.AgentResponse {
+	response := api.AgentResponse{
+		ID:          utils.ConvertToPythonIdentifier(utils.GetObjectRef(remoteAgent)),
+		RemoteAgent: remoteAgent,
+	}
+	if condition := meta.FindStatusCondition(remoteAgent.Status.Conditions, v1alpha2.RemoteAgentConditionTypeAccepted); condition != nil {
+		response.Accepted = condition.Status == metav1.ConditionTrue
+	}
+	if condition := meta.FindStatusCondition(remoteAgent.Status.Conditions, v1alpha2.RemoteAgentConditionTypeReady); condition != nil {
+		response.DeploymentReady = condition.Status == metav1.ConditionTrue
+		if !response.DeploymentReady {
+			response.ReadyReason = condition.Reason
+			response.ReadyMessage = condition.Message
+		}
+	}
+	return response
+}
+
 // HandleGetAgent handles GET /api/agents/{namespace}/{name} requests using database
 func (h *AgentsHandler) HandleGetAgent(w ErrorResponseWriter, r *http.Request) {
 	log := ctrllog.FromContext(r.Context()).WithName("agents-handler").WithValues("operation", "get-db")
@@ -137,23 +177,25 @@ func (h *AgentsHandler) HandleGetAgent(w ErrorResponseWriter, r *http.Request) {
 		w.RespondWithError(err)
 		return
 	}
-	agent := &v1alpha2.Agent{}
-	if err := h.KubeClient.Get(
-		r.Context(),
-		client.ObjectKey{
-			Namespace: agentNamespace,
-			Name:      agentName,
-		},
-		agent,
-	); err != nil {
-		w.RespondWithError(errors.NewNotFoundError("Agent not found", err))
-		return
+	objKey := client.ObjectKey{
+		Namespace: agentNamespace,
+		Name:      agentName,
 	}
-
-	agentResponse, err := h.getAgentResponse(r.Context(), log, agent)
-	if err != nil {
-		w.RespondWithError(err)
-		return
+	var agentResponse api.AgentResponse
+	agent := &v1alpha2.Agent{}
+	if err := h.KubeClient.Get(r.Context(), objKey, agent); err != nil {
+		// the agent may have been imported with a RemoteAgent
+		remoteAgent := &v1alpha2.RemoteAgent{}
+		if !apierrors.IsNotFound(err) || h.KubeClient.Get(r.Context(), objKey, remoteAgent) != nil {
+			w.RespondWithError(errors.NewNotFoundError("Agent not found", err))
+			return
+		}
+		agentResponse = getRemoteAgentResponse(remoteAgent)
+	} else {
+		if agentResponse, err = h.getAgentResponse(r.Context(), log, agent); err != nil {
+			w.RespondWithError(err)
+			return
+		}
 	}
 
 	log.Info("Successfully retrieved agent")
@@ -180,6 +180,7 @@ func TestHandleGetAgent(t *testing.T) {
 		err := json.Unmarshal(w.Body.Bytes(), &response)
 		require.NoError(t, err)
 		require.False(t, response.Data.DeploymentReady)
+		require.Equal(t, "DeploymentReady", response.Data.ReadyReason)
 	})
 
 	t.Run("gets agent with DeploymentReady=false when reason is not DeploymentReady", func(t *testing.T) {
@@ -211,6 +212,26 @@ func TestHandleGetAgent(t *testing.T) {
 		require.False(t, response.Data.DeploymentReady)
 	})
 
+	t.Run("gets remote agent", func(t *testing.T) {
+		remoteAgent := &v1alpha2.RemoteAgent{
+			ObjectMeta: metav1.ObjectMeta{Name: "remote-agent", Namespace: "default"},
+			Spec:       v1alpha2.RemoteAgentSpec{URL: "https://agents.example.com/remote"},
+		}
+		handler, _ := setupTestHandler(remoteAgent)
+
+		req := httptest.NewRequest("GET", "/api/agents/default/remote-agent", nil)
+		req = mux.SetURLVars(req, map[string]string{"namespace": "default", "name": "remote-agent"})
+		req = setUser(req, "test-user")
+		w := httptest.NewRecorder()
+
+		handler.HandleGetAgent(&testErrorResponseWriter{w}, req)
+
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var response api.StandardResponse[api.AgentResponse]
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		require.Equal(t, "https://agents.example.com/remote", response.Data.RemoteAgent.Spec.URL)
+	})
+
 	t.Run("returns 404 for missing agent", func(t *testing.T) {
 		handler, _ := setupTestHandler()
 
@@ -332,6 +353,41 @@ func TestHandleListAgents(t *testing.T) {
 		require.Equal(t, false, response.Data[0].Accepted)
 		require.Equal(t, true, response.Data[0].DeploymentReady)
 	})
+
+	t.Run("lists remote agents", func(t *testing.T) {
+		remoteAgent := &v1alpha2.RemoteAgent{
+			ObjectMeta: metav1.ObjectMeta{Name: "remote-agent", Namespace: "default"},
+			Spec:       v1alpha2.RemoteAgentSpec{URL: "https://agents.example.com/remote"},
+			Status: v1alpha2.RemoteAgentStatus{
+				Conditions: []metav1.Condition{
+					{Type: v1alpha2.RemoteAgentConditionTypeAccepted, Status: "True", Reason: "Reconciled"},
+					{Type: v1alpha2.RemoteAgentConditionTypeReady, Status: "False", Reason: "AgentCardFetchFailed", Message: "status 503"},
+				},
+			},
+		}
+
+		handler, _ := setupTestHandler(remoteAgent)
+
+		req := httptest.NewRequest("GET", "/api/agents", nil)
+		req = setUser(req, "test-user")
+
+		w := httptest.NewRecorder()
+
+		handler.HandleListAgents(&testErrorResponseWriter{w}, req)
+
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var response api.StandardResponse[[]api.AgentResponse]
+		err := json.Unmarshal(w.Body.Bytes(), &response)
+		require.NoError(t, err)
+		require.Len(t, response.Data, 1)
+		require.Nil(t, response.Data[0].Agent)
+		require.Equal(t, "remote-agent", response.Data[0].RemoteAgent.Name)
+		require.Equal(t, common.ConvertToPythonIdentifier("default/remote-agent"), response.Data[0].ID)
+		require.Equal(t, true, response.Data[0].Accepted)
+		require.Equal(t, false, response.Data[0].DeploymentReady)
+		require.Equal(t, "AgentCardFetchFailed", response.Data[0].ReadyReason)
+	})
 }
 
 func TestHandleUpdateAgent(t *testing.T) {
@@ -5,6 +5,8 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/kagent-dev/kagent/go/internal/database"
+	"github.com/kagent-dev/kagent/go/internal/modelcatalog"
+	"github.com/kagent-dev/kagent/go/internal/usage"
 	"github.com/kagent-dev/kagent/go/pkg/auth"
 )
 
@@ -25,6 +27,7 @@ type Handlers struct {
 	Tasks           *TasksHandler
 	Checkpoints     *CheckpointsHandler
 	CrewAI          *CrewAIHandler
+	Usage           *UsageHandler
 }
 
 // Base holds common dependencies for all handlers
@@ -33,15 +36,23 @@ type Base struct {
 	DefaultModelConfig types.NamespacedName
 	DatabaseService    database.Client
 	Authorizer         auth.Authorizer // Interface for authorization checks
+	ModelCatalog       *modelcatalog.Catalog
+	// Prices are used to cost the token usage of agents
+	Prices usage.PriceTable
+	// TaskCanceler cancels the tasks of agents
+	TaskCanceler TaskCanceler
 }
 
 // NewHandlers creates a new Handlers instance with all handler components
-func NewHandlers(kubeClient client.Client, defaultModelConfig types.NamespacedName, dbService database.Client, watchedNamespaces []string, authorizer auth.Authorizer) *Handlers {
+func NewHandlers(kubeClient client.Client, defaultModelConfig types.NamespacedName, dbService database.Client, watchedNamespaces []string, authorizer auth.Authorizer, prices usage.PriceTable, taskCanceler TaskCanceler, modelCatalog *modelcatalog.Catalog) *Handlers {
 	base := &Base{
 		KubeClient:         kubeClient,
 		DefaultModelConfig: defaultModelConfig,
 		DatabaseService:    dbService,
 		Authorizer:         authorizer,
+		ModelCatalog:       modelCatalog,
+		Prices:             prices,
+		TaskCanceler:       taskCanceler,
 	}
 
 	return &Handlers{
@@ -60,5 +71,6 @@ func NewHandlers(kubeClient client.Client, defaultModelConfig types.NamespacedNa
 		Tasks:           NewTasksHandler(base),
 		Checkpoints:     NewCheckpointsHandler(base),
 		CrewAI:          NewCrewAIHandler(base),
+		Usage:           NewUsageHandler(base),
 	}
 }
@@ -60,6 +60,12 @@ func (h *ModelConfigHandler) HandleListModelConfigs(w ErrorResponseWriter, r *ht
 		if config.Spec.Ollama != nil {
 			FlattenStructToMap(config.Spec.Ollama, modelParams)
 		}
+		if config.Spec.Bedrock != nil {
+			FlattenStructToMap(config.Spec.Bedrock, modelParams)
+		}
+		if config.Spec.OpenAICompatible != nil {
+			FlattenStructToMap(config.Spec.OpenAICompatible, modelParams)
+		}
 
 		responseItem := api.ModelConfigResponse{
 			Ref:             common.GetObjectRef(&config),
@@ -143,6 +149,12 @@ func (h *ModelConfigHandler) HandleGetModelConfig(w ErrorResponseWriter, r *http
 	if modelConfig.Spec.Ollama != nil {
 		FlattenStructToMap(modelConfig.Spec.Ollama, modelParams)
 	}
+	if modelConfig.Spec.Bedrock != nil {
+		FlattenStructToMap(modelConfig.Spec.Bedrock, modelParams)
+	}
+	if modelConfig.Spec.OpenAICompatible != nil {
+		FlattenStructToMap(modelConfig.Spec.OpenAICompatible, modelParams)
+	}
 
 	responseItem := api.ModelConfigResponse{
 		Ref:             common.GetObjectRef(modelConfig),
@@ -159,6 +171,105 @@ func (h *ModelConfigHandler) HandleGetModelConfig(w ErrorResponseWriter, r *http
 	RespondWithJSON(w, http.StatusOK, data)
 }
 
+// HandleListModelConfigModels handles GET /api/modelconfigs/{namespace}/{name}/models requests.
+// It lists the models the provider of the ModelConfig serves, merged with the default ones.
+// The cached models are refreshed when the refresh query parameter is true.
+func (h *ModelConfigHandler) HandleListModelConfigModels(w ErrorResponseWriter, r *http.Request) {
+	log := ctrllog.FromContext(r.Context()).WithName("modelconfig-handler").WithValues("operation", "list-models")
+
+	namespace, err := GetPathParam(r, "namespace")
+	if err != nil {
+		w.RespondWithError(errors.NewBadRequestError("Failed to get namespace from path", err))
+		return
+	}
+
+	configName, err := GetPathParam(r, "name")
+	if err != nil {
+		w.RespondWithError(errors.NewBadRequestError("Failed to get name from path", err))
+		return
+	}
+
+	log = log.WithValues(
+		"configNamespace", namespace,
+		"configName", configName,
+	)
+
+	if err := Check(h.Authorizer, r, auth.Resource{Type: "ModelConfig", Name: types.NamespacedName{Namespace: namespace, Name: configName}.String()}); err != nil {
+		w.RespondWithError(err)
+		return
+	}
+
+	modelConfig := &v1alpha2.ModelConfig{}
+	if err := h.KubeClient.Get(r.Context(), client.ObjectKey{Namespace: namespace, Name: configName}, modelConfig); err != nil {
+		if apierrors.IsNotFound(err) {
+			w.RespondWithError(errors.NewNotFoundError("ModelConfig not found", nil))
+			return
+		}
+		w.RespondWithError(errors.NewInternalServerError("Failed to get ModelConfig", err))
+		return
+	}
+
+	refresh := r.URL.Query().Get("refresh") == "true"
+	models, err := h.ModelCatalog.Models(r.Context(), modelConfig, refresh)
+	if err != nil {
+		log.Error(err, "Failed to list models")
+		w.RespondWithError(errors.NewBadGatewayError("Failed to list models of the provider", err))
+		return
+	}
+
+	log.Info("Successfully listed models", "count", len(models))
+	data := api.NewResponse(models, "Successfully listed models", false)
+	RespondWithJSON(w, http.StatusOK, data)
+}
+
+// HandleTestModelConfig handles POST /api/modelconfigs/{namespace}/{name}/test requests.
+// It checks the credentials of the ModelConfig against its provider, bypassing the
+// rate limit of the checks made by the controller.
+func (h *ModelConfigHandler) HandleTestModelConfig(w ErrorResponseWriter, r *http.Request) {
+	log := ctrllog.FromContext(r.Context()).WithName("modelconfig-handler").WithValues("operation", "test")
+
+	namespace, err := GetPathParam(r, "namespace")
+	if err != nil {
+		w.RespondWithError(errors.NewBadRequestError("Failed to get namespace from path", err))
+		return
+	}
+
+	configName, err := GetPathParam(r, "name")
+	if err != nil {
+		w.RespondWithError(errors.NewBadRequestError("Failed to get name from path", err))
+		return
+	}
+
+	log = log.WithValues(
+		"configNamespace", namespace,
+		"configName", configName,
+	)
+
+	if err := Check(h.Authorizer, r, auth.Resource{Type: "ModelConfig", Name: types.NamespacedName{Namespace: namespace, Name: configName}.String()}); err != nil {
+		w.RespondWithError(err)
+		return
+	}
+
+	modelConfig := &v1alpha2.ModelConfig{}
+	if err := h.KubeClient.Get(r.Context(), client.ObjectKey{Namespace: namespace, Name: configName}, modelConfig); err != nil {
+		if apierrors.IsNotFound(err) {
+			w.RespondWithError(errors.NewNotFoundError("ModelConfig not found", nil))
+			return
+		}
+		w.RespondWithError(errors.NewInternalServerError("Failed to get ModelConfig", err))
+		return
+	}
+
+	check := h.ModelCatalog.CheckCredentials(r.Context(), modelConfig)
+	log.Info("Checked credentials", "status", check.Status, "reason", check.Reason)
+	data := api.NewResponse(api.TestModelConfigResponse{
+		Status:  string(check.Status),
+		Reason:  check.Reason,
+		Message: check.Message,
+	}, "Successfully checked credentials", false)
+	RespondWithJSON(w, http.StatusOK, data)
+}
+
 // Helper function to get all JSON keys from a struct type
 func getStructJSONKeys(structType reflect.Type) []string {
 	keys := []string{}
@@ -312,6 +423,20 @@ func (h *ModelConfigHandler) HandleCreateModelConfig(w ErrorResponseWriter, r *h
 		} else {
 			log.V(1).Info("No AnthropicVertexAI params provided in create.")
 		}
+	case v1alpha2.ModelProviderBedrock:
+		if req.BedrockParams == nil || req.BedrockParams.Region == "" {
+			providerConfigErr = fmt.Errorf("bedrock parameters with a region are required for Bedrock provider")
+		} else {
+			modelConfig.Spec.Bedrock = req.BedrockParams
+			log.V(1).Info("Assigned Bedrock params to spec")
+		}
+	case v1alpha2.ModelProviderOpenAICompatible:
+		if req.OpenAICompatibleParams == nil || req.OpenAICompatibleParams.GetBaseURL() == "" {
+			providerConfigErr = fmt.Errorf("openAICompatible parameters with a baseUrl are required for OpenAICompatible provider")
+		} else {
+			modelConfig.Spec.OpenAICompatible = req.OpenAICompatibleParams
+			log.V(1).Info("Assigned OpenAICompatible params to spec")
+		}
 	default:
 		providerConfigErr = fmt.Errorf("unsupported provider type: %s", req.Provider.Type)
 	}
@@ -432,6 +557,8 @@ func (h *ModelConfigHandler) HandleUpdateModelConfig(w ErrorResponseWriter, r *h
 		Gemini:            nil,
 		GeminiVertexAI:    nil,
 		AnthropicVertexAI: nil,
+		Bedrock:           nil,
+		OpenAICompatible:  nil,
 	}
 
 	// --- Update Secret if API Key is provided (and not Ollama) ---
@@ -511,6 +638,20 @@ func (h *ModelConfigHandler) HandleUpdateModelConfig(w ErrorResponseWriter, r *h
 		} else {
 			log.V(1).Info("No AnthropicVertexAI params provided in update.")
 		}
+	case v1alpha2.ModelProviderBedrock:
+		if req.BedrockParams == nil || req.BedrockParams.Region == "" {
+			providerConfigErr = fmt.Errorf("bedrock parameters with a region are required when provider is Bedrock")
+		} else {
+			modelConfig.Spec.Bedrock = req.BedrockParams
+			log.V(1).Info("Assigned updated Bedrock params to spec")
+		}
+	case v1alpha2.ModelProviderOpenAICompatible:
+		if req.OpenAICompatibleParams == nil || req.OpenAICompatibleParams.GetBaseURL() == "" {
+			providerConfigErr = fmt.Errorf("openAICompatible parameters with a baseUrl are required when provider is OpenAICompatible")
+		} else {
+			modelConfig.Spec.OpenAICompatible = req.OpenAICompatibleParams
+			log.V(1).Info("Assigned updated OpenAICompatible params to spec")
+		}
 	default:
 		providerConfigErr = fmt.Errorf("unsupported provider type specified: %s", req.Provider.Type)
 	}
@@ -536,6 +677,10 @@ func (h *ModelConfigHandler) HandleUpdateModelConfig(w ErrorResponseWriter, r *h
 		FlattenStructToMap(modelConfig.Spec.AzureOpenAI, updatedParams)
 	} else if modelConfig.Spec.Ollama != nil {
 		FlattenStructToMap(modelConfig.Spec.Ollama, updatedParams)
+	} else if modelConfig.Spec.Bedrock != nil {
+		FlattenStructToMap(modelConfig.Spec.Bedrock, updatedParams)
+	} else if modelConfig.Spec.OpenAICompatible != nil {
+		FlattenStructToMap(modelConfig.Spec.OpenAICompatible, updatedParams)
 	}
 
 	responseItem := api.ModelConfigResponse{
@@ -21,6 +21,7 @@ import (
 	"github.com/kagent-dev/kagent/go/api/v1alpha2"
 	"github.com/kagent-dev/kagent/go/internal/httpserver/auth"
 	"github.com/kagent-dev/kagent/go/internal/httpserver/handlers"
+	"github.com/kagent-dev/kagent/go/internal/modelcatalog"
 	"github.com/kagent-dev/kagent/go/pkg/client/api"
 )
 
@@ -38,6 +39,7 @@ func TestModelConfigHandler(t *testing.T) {
 			KubeClient:         kubeClient,
 			DefaultModelConfig: types.NamespacedName{Namespace: "default", Name: "default"},
 			Authorizer:         &auth.NoopAuthorizer{},
+			ModelCatalog:       modelcatalog.NewCatalog(kubeClient, modelcatalog.DefaultTTL),
 		}
 		handler := handlers.NewModelConfigHandler(base)
 		responseRecorder := newMockErrorResponseWriter()
@@ -230,6 +232,57 @@ func TestModelConfigHandler(t *testing.T) {
 			assert.Equal(t, v1alpha2.ModelProviderAzureOpenAI, config.Data.Spec.Provider)
 		})
 
+		t.Run("Success_Bedrock_NoAPIKey", func(t *testing.T) {
+			handler, _, responseRecorder := setupHandler()
+
+			reqBody := api.CreateModelConfigRequest{
+				Ref:      "default/test-bedrock",
+				Provider: api.Provider{Type: "Bedrock"},
+				Model:    "anthropic.claude-sonnet-4-20250514-v1:0",
+				BedrockParams: &v1alpha2.BedrockConfig{
+					Region:  "us-east-1",
+					RoleARN: "arn:aws:iam::123456789012:role/kagent-bedrock",
+				},
+			}
+
+			jsonBody, _ := json.Marshal(reqBody)
+			req := httptest.NewRequest("POST", "/api/modelconfigs/", bytes.NewBuffer(jsonBody))
+			req = setUser(req, "test-user")
+			req.Header.Set("Content-Type", "application/json")
+
+			handler.HandleCreateModelConfig(responseRecorder, req)
+
+			assert.Equal(t, http.StatusCreated, responseRecorder.Code, responseRecorder.Body.String())
+
+			var config api.StandardResponse[v1alpha2.ModelConfig]
+			err := json.Unmarshal(responseRecorder.Body.Bytes(), &config)
+			require.NoError(t, err)
+			assert.Equal(t, v1alpha2.ModelProviderBedrock, config.Data.Spec.Provider)
+			assert.Equal(t, "us-east-1", config.Data.Spec.Bedrock.Region)
+			assert.Empty(t, config.Data.Spec.APIKeySecret)
+		})
+
+		t.Run("Bedrock_MissingRegion", func(t *testing.T) {
+			handler, _, responseRecorder := setupHandler()
+
+			reqBody := api.CreateModelConfigRequest{
+				Ref:           "default/test-bedrock",
+				Provider:      api.Provider{Type: "Bedrock"},
+				Model:         "anthropic.claude-sonnet-4-20250514-v1:0",
+				BedrockParams: &v1alpha2.BedrockConfig{},
+			}
+
+			jsonBody, _ := json.Marshal(reqBody)
+			req := httptest.NewRequest("POST", "/api/modelconfigs/", bytes.NewBuffer(jsonBody))
+			req = setUser(req, "test-user")
+			req.Header.Set("Content-Type", "application/json")
+
+			handler.HandleCreateModelConfig(responseRecorder, req)
+
+			assert.Equal(t, http.StatusBadRequest, responseRecorder.Code)
+			assert.NotNil(t, responseRecorder.errorReceived)
+		})
+
 		t.Run("InvalidJSON", func(t *testing.T) {
 			handler, _, responseRecorder := setupHandler()
 
@@ -562,4 +615,60 @@ func TestModelConfigHandler(t *testing.T) {
 			assert.NotNil(t, responseRecorder.errorReceived)
 		})
 	})
+
+	t.Run("HandleTestModelConfig", func(t *testing.T) {
+		testModelConfig := func(handler *handlers.ModelConfigHandler, responseRecorder *mockErrorResponseWriter, name string) {
+			req := httptest.NewRequest("POST", "/api/modelconfigs/default/"+name+"/test", nil)
+			req = setUser(req, "test-user")
+
+			router := mux.NewRouter()
+			router.HandleFunc("/api/modelconfigs/{namespace}/{name}/test", func(w http.ResponseWriter, r *http.Request) {
+				handler.HandleTestModelConfig(responseRecorder, r)
+			}).Methods("POST")
+
+			router.ServeHTTP(responseRecorder, req)
+		}
+
+		t.Run("InvalidCredentials", func(t *testing.T) {
+			provider := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusUnauthorized)
+				_, _ = w.Write([]byte(`{"error":{"message":"Incorrect API key provided: sk-wrong"}}`))
+			}))
+			defer provider.Close()
+
+			handler, kubeClient, responseRecorder := setupHandler()
+			require.NoError(t, kubeClient.Create(context.Background(), &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-secret", Namespace: "default"},
+				Data:       map[string][]byte{"OPENAI_API_KEY": []byte("sk-wrong")},
+			}))
+			require.NoError(t, kubeClient.Create(context.Background(), &v1alpha2.ModelConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-config", Namespace: "default"},
+				Spec: v1alpha2.ModelConfigSpec{
+					Model:           "gpt-4",
+					Provider:        v1alpha2.ModelProviderOpenAI,
+					APIKeySecret:    "test-secret",
+					APIKeySecretKey: "OPENAI_API_KEY",
+					OpenAI:          &v1alpha2.OpenAIConfig{BaseURL: provider.URL},
+				},
+			}))
+
+			testModelConfig(handler, responseRecorder, "test-config")
+
+			require.Equal(t, http.StatusOK, responseRecorder.Code, responseRecorder.Body.String())
+			var response api.StandardResponse[api.TestModelConfigResponse]
+			require.NoError(t, json.Unmarshal(responseRecorder.Body.Bytes(), &response))
+			assert.Equal(t, "False", response.Data.Status)
+			assert.Equal(t, modelcatalog.CredentialsReasonInvalid, response.Data.Reason)
+			assert.NotContains(t, responseRecorder.Body.String(), "sk-wrong")
+		})
+
+		t.Run("NotFound", func(t *testing.T) {
+			handler, _, responseRecorder := setupHandler()
+
+			testModelConfig(handler, responseRecorder, "nonexistent")
+
+			assert.Equal(t, http.StatusNotFound, responseRecorder.Code)
+			assert.NotNil(t, responseRecorder.errorReceived)
+		})
+	})
 }
@@ -387,6 +387,12 @@ func (h *SessionsHandler) HandleAddEventToSession(w ErrorResponseWriter, r *http
 		return
 	}
 
+	agentID := utils.ConvertToPythonIdentifier(principal.Agent.ID)
+	if session.AgentID != nil {
+		agentID = *session.AgentID
+	}
+	h.recordUsage(log, event, agentID)
+
 	log.Info("Successfully added event to session")
 	data := api.NewResponse(event, "Event added to session successfully", false)
 	RespondWithJSON(w, http.StatusCreated, data)
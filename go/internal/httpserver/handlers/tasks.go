@@ -1,14 +1,31 @@
 package handlers
 
 import (
+	"context"
+	"encoding/json"
+	stderrors "errors"
 	"net/http"
+	"strconv"
+	"strings"
 
+	"github.com/kagent-dev/kagent/go/api/v1alpha2"
 	"github.com/kagent-dev/kagent/go/internal/httpserver/errors"
+	"github.com/kagent-dev/kagent/go/internal/ratelimit"
+	"github.com/kagent-dev/kagent/go/internal/utils"
+	"github.com/kagent-dev/kagent/go/pkg/auth"
 	"github.com/kagent-dev/kagent/go/pkg/client/api"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
 	ctrllog "sigs.k8s.io/controller-runtime/pkg/log"
 	"trpc.group/trpc-go/trpc-a2a-go/protocol"
 )
 
+// TaskCanceler cancels the tasks of agents
+type TaskCanceler interface {
+	CancelTask(ctx context.Context, agentRef string, taskID string) (*protocol.Task, error)
+}
+
 // TasksHandler handles task-related requests
 type TasksHandler struct {
 	*Base
@@ -53,6 +70,20 @@ func (h *TasksHandler) HandleCreateTask(w ErrorResponseWriter, r *http.Request)
 	}
 	log = log.WithValues("task_id", task.ID)
 
+	// updates of existing tasks are not limited, so that the tasks in progress can complete
+	if _, err := h.DatabaseService.GetTask(task.ID); err != nil {
+		err := h.checkTokenBudget(r)
+		var limitErr *ratelimit.LimitExceededError
+		if stderrors.As(err, &limitErr) {
+			w.Header().Set("Retry-After", strconv.FormatInt(limitErr.RetryAfterSeconds(), 10))
+			w.RespondWithError(errors.NewTooManyRequestsError("Daily token budget of the agent exceeded", err))
+			return
+		} else if err != nil {
+			w.RespondWithError(errors.NewInternalServerError("Failed to check token budget", err))
+			return
+		}
+	}
+
 	if err := h.DatabaseService.StoreTask(&task); err != nil {
 		w.RespondWithError(errors.NewInternalServerError("Failed to create task", err))
 		return
@@ -63,6 +94,139 @@ func (h *TasksHandler) HandleCreateTask(w ErrorResponseWriter, r *http.Request)
 	RespondWithJSON(w, http.StatusCreated, data)
 }
 
+// HandleListPushNotificationDeliveries lists the push notifications of a task pending delivery,
+// delivered or dead lettered
+func (h *TasksHandler) HandleListPushNotificationDeliveries(w ErrorResponseWriter, r *http.Request) {
+	log := ctrllog.FromContext(r.Context()).WithName("tasks-handler").WithValues("operation", "list-push-notifications")
+
+	taskID, err := GetPathParam(r, "task_id")
+	if err != nil {
+		w.RespondWithError(errors.NewBadRequestError("Failed to get task ID from path", err))
+		return
+	}
+	log = log.WithValues("task_id", taskID)
+
+	userID, err := GetUserID(r)
+	if err != nil {
+		w.RespondWithError(errors.NewBadRequestError("Failed to get user ID", err))
+		return
+	}
+
+	// only the user of the session of the task can list its push notifications
+	task, err := h.DatabaseService.GetTask(taskID)
+	if err != nil {
+		w.RespondWithError(errors.NewNotFoundError("Task not found", err))
+		return
+	}
+	session, err := h.DatabaseService.GetSession(task.ContextID, userID)
+	if err != nil {
+		w.RespondWithError(errors.NewNotFoundError("Session of task not found", err))
+		return
+	}
+	if session.AgentID != nil {
+		agentRef := utils.ConvertToKubernetesIdentifier(*session.AgentID)
+		if err := Check(h.Authorizer, r, auth.Resource{Type: "Agent", Name: agentRef}); err != nil {
+			w.RespondWithError(err)
+			return
+		}
+	}
+
+	deliveries, err := h.DatabaseService.ListPushNotificationDeliveries(taskID)
+	if err != nil {
+		w.RespondWithError(errors.NewInternalServerError("Failed to list push notifications", err))
+		return
+	}
+	for i := range deliveries {
+		deliveries[i].Config = redactPushNotificationConfig(deliveries[i].Config)
+	}
+
+	log.Info("Successfully listed push notifications")
+	data := api.NewResponse(deliveries, "Successfully listed push notifications", false)
+	RespondWithJSON(w, http.StatusOK, data)
+}
+
+// redacted replaces the secrets of the push notification configs listed
+const redacted = "[REDACTED]"
+
+// redactPushNotificationConfig removes the token and the credentials from a serialized push
+// notification config
+func redactPushNotificationConfig(data string) string {
+	var config protocol.TaskPushNotificationConfig
+	if err := json.Unmarshal([]byte(data), &config); err != nil {
+		return ""
+	}
+	if config.PushNotificationConfig.Token != "" {
+		config.PushNotificationConfig.Token = redacted
+	}
+	if authentication := config.PushNotificationConfig.Authentication; authentication != nil && authentication.Credentials != nil {
+		redactedAuthentication := *authentication
+		redactedAuthentication.Credentials = ptr.To(redacted)
+		config.PushNotificationConfig.Authentication = &redactedAuthentication
+	}
+	redactedData, err := json.Marshal(config)
+	if err != nil {
+		return ""
+	}
+	return string(redactedData)
+}
+
+// HandleCancelTask cancels a task of a session of the user through the agent of the session
+func (h *TasksHandler) HandleCancelTask(w ErrorResponseWriter, r *http.Request) {
+	log := ctrllog.FromContext(r.Context()).WithName("tasks-handler").WithValues("operation", "cancel-task")
+
+	taskID, err := GetPathParam(r, "task_id")
+	if err != nil {
+		w.RespondWithError(errors.NewBadRequestError("Failed to get task ID from path", err))
+		return
+	}
+	log = log.WithValues("task_id", taskID)
+
+	userID, err := GetUserID(r)
+	if err != nil {
+		w.RespondWithError(errors.NewBadRequestError("Failed to get user ID", err))
+		return
+	}
+
+	task, err := h.DatabaseService.GetTask(taskID)
+	if err != nil {
+		w.RespondWithError(errors.NewNotFoundError("Task not found", err))
+		return
+	}
+
+	session, err := h.DatabaseService.GetSession(task.ContextID, userID)
+	if err != nil {
+		w.RespondWithError(errors.NewNotFoundError("Session of task not found", err))
+		return
+	}
+	if session.AgentID == nil {
+		w.RespondWithError(errors.NewBadRequestError("Session of task has no agent", nil))
+		return
+	}
+	agentRef := utils.ConvertToKubernetesIdentifier(*session.AgentID)
+	log = log.WithValues("agentRef", agentRef)
+
+	if err := Check(h.Authorizer, r, auth.Resource{Type: "Agent", Name: agentRef}); err != nil {
+		w.RespondWithError(err)
+		return
+	}
+
+	if task.Status.State == protocol.TaskStateCanceled {
+		data := api.NewResponse(task, "Task already canceled", false)
+		RespondWithJSON(w, http.StatusOK, data)
+		return
+	}
+
+	canceled, err := h.TaskCanceler.CancelTask(r.Context(), agentRef, taskID)
+	if err != nil {
+		w.RespondWithError(errors.NewConflictError("Failed to cancel task", err))
+		return
+	}
+
+	log.Info("Successfully canceled task")
+	data := api.NewResponse(canceled, "Successfully canceled task", false)
+	RespondWithJSON(w, http.StatusOK, data)
+}
+
 func (h *TasksHandler) HandleDeleteTask(w ErrorResponseWriter, r *http.Request) {
 	log := ctrllog.FromContext(r.Context()).WithName("tasks-handler").WithValues("operation", "delete-task")
 
@@ -81,3 +245,27 @@ func (h *TasksHandler) HandleDeleteTask(w ErrorResponseWriter, r *http.Request)
 	log.Info("Successfully deleted task")
 	w.WriteHeader(http.StatusNoContent)
 }
+
+// checkTokenBudget checks that the user has not used the daily token budget of the agent
+// creating the task. Requests not made by an agent are not limited.
+func (h *TasksHandler) checkTokenBudget(r *http.Request) error {
+	principal, err := GetPrincipal(r)
+	if err != nil {
+		return nil
+	}
+	namespace, name, ok := strings.Cut(principal.Agent.ID, "/")
+	if !ok {
+		return nil
+	}
+
+	agent := &v1alpha2.Agent{}
+	if err := h.KubeClient.Get(r.Context(), types.NamespacedName{Namespace: namespace, Name: name}, agent); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+
+	limiter := ratelimit.NewLimiter(h.DatabaseService)
+	return limiter.CheckBudget(utils.ConvertToPythonIdentifier(principal.Agent.ID), principal.User.ID, agent.Spec.RateLimits)
+}
@@ -0,0 +1,169 @@
+package handlers_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"trpc.group/trpc-go/trpc-a2a-go/protocol"
+
+	"github.com/kagent-dev/kagent/go/api/v1alpha2"
+	"github.com/kagent-dev/kagent/go/internal/database"
+	database_fake "github.com/kagent-dev/kagent/go/internal/database/fake"
+	authimpl "github.com/kagent-dev/kagent/go/internal/httpserver/auth"
+	"github.com/kagent-dev/kagent/go/internal/httpserver/handlers"
+	"github.com/kagent-dev/kagent/go/pkg/auth"
+	"github.com/kagent-dev/kagent/go/pkg/client/api"
+)
+
+func TestTasksHandlerTokenBudget(t *testing.T) {
+	agent := &v1alpha2.Agent{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-agent", Namespace: "default"},
+		Spec: v1alpha2.AgentSpec{
+			Type:       v1alpha2.AgentType_Declarative,
+			RateLimits: &v1alpha2.RateLimits{DailyTokenBudget: ptr.To[int64](1000)},
+		},
+	}
+	kubeClient := fake.NewClientBuilder().WithScheme(setupScheme()).WithObjects(agent).Build()
+	dbClient := database_fake.NewClient()
+	handler := handlers.NewTasksHandler(&handlers.Base{
+		KubeClient:      kubeClient,
+		DatabaseService: dbClient,
+		Authorizer:      &authimpl.NoopAuthorizer{},
+	})
+
+	createTask := func(userID, taskID string) *mockErrorResponseWriter {
+		body, err := json.Marshal(protocol.Task{ID: taskID, ContextID: "session-1"})
+		require.NoError(t, err)
+		req := httptest.NewRequest(http.MethodPost, "/api/tasks", bytes.NewReader(body))
+		req = req.WithContext(auth.AuthSessionTo(req.Context(), &authimpl.SimpleSession{
+			P: auth.Principal{User: auth.User{ID: userID}, Agent: auth.Agent{ID: "default/test-agent"}},
+		}))
+		responseRecorder := newMockErrorResponseWriter()
+		handler.HandleCreateTask(responseRecorder, req)
+		return responseRecorder
+	}
+
+	require.Equal(t, http.StatusCreated, createTask("alice", "task-1").Code)
+
+	_, err := dbClient.StoreUsage(&database.Usage{
+		AgentID:      "default__NS__test_agent",
+		UserID:       "alice",
+		InputTokens:  800,
+		OutputTokens: 200,
+	})
+	require.NoError(t, err)
+
+	responseRecorder := createTask("alice", "task-2")
+	assert.Equal(t, http.StatusTooManyRequests, responseRecorder.Code)
+	assert.NotEmpty(t, responseRecorder.Header().Get("Retry-After"))
+
+	// the task in progress can still be updated, and other users are not limited
+	assert.Equal(t, http.StatusCreated, createTask("alice", "task-1").Code)
+	assert.Equal(t, http.StatusCreated, createTask("bob", "task-3").Code)
+}
+
+// fakeTaskCanceler cancels the tasks, recording the agents they were canceled through
+type fakeTaskCanceler struct {
+	agentRefs []string
+}
+
+func (c *fakeTaskCanceler) CancelTask(ctx context.Context, agentRef string, taskID string) (*protocol.Task, error) {
+	c.agentRefs = append(c.agentRefs, agentRef)
+	return &protocol.Task{ID: taskID, Status: protocol.TaskStatus{State: protocol.TaskStateCanceled}}, nil
+}
+
+func TestTasksHandlerCancelTask(t *testing.T) {
+	dbClient := database_fake.NewClient()
+	canceler := &fakeTaskCanceler{}
+	handler := handlers.NewTasksHandler(&handlers.Base{
+		DatabaseService: dbClient,
+		Authorizer:      &authimpl.NoopAuthorizer{},
+		TaskCanceler:    canceler,
+	})
+
+	require.NoError(t, dbClient.StoreSession(&database.Session{ID: "session-1", UserID: "alice", AgentID: ptr.To("default__NS__test_agent")}))
+	require.NoError(t, dbClient.StoreTask(&protocol.Task{ID: "task-1", ContextID: "session-1", Status: protocol.TaskStatus{State: protocol.TaskStateWorking}}))
+
+	cancelTask := func(userID, taskID string) *mockErrorResponseWriter {
+		req := httptest.NewRequest(http.MethodPost, "/api/tasks/"+taskID+"/cancel", nil)
+		req = mux.SetURLVars(req, map[string]string{"task_id": taskID})
+		req = setUser(req, userID)
+		responseRecorder := newMockErrorResponseWriter()
+		handler.HandleCancelTask(responseRecorder, req)
+		return responseRecorder
+	}
+
+	// only the user of the session can cancel its tasks
+	assert.Equal(t, http.StatusNotFound, cancelTask("bob", "task-1").Code)
+	assert.Equal(t, http.StatusNotFound, cancelTask("alice", "task-2").Code)
+
+	responseRecorder := cancelTask("alice", "task-1")
+	require.Equal(t, http.StatusOK, responseRecorder.Code)
+	assert.Equal(t, []string{"default/test-agent"}, canceler.agentRefs)
+
+	var response api.StandardResponse[protocol.Task]
+	require.NoError(t, json.Unmarshal(responseRecorder.Body.Bytes(), &response))
+	assert.Equal(t, protocol.TaskStateCanceled, response.Data.Status.State)
+
+	// a canceled task is only returned to the user of its session
+	require.NoError(t, dbClient.CancelTask(&protocol.Task{ID: "task-1", ContextID: "session-1", Status: protocol.TaskStatus{State: protocol.TaskStateCanceled}}, "alice"))
+	assert.Equal(t, http.StatusNotFound, cancelTask("bob", "task-1").Code)
+	assert.Equal(t, http.StatusOK, cancelTask("alice", "task-1").Code)
+	assert.Len(t, canceler.agentRefs, 1)
+}
+
+func TestTasksHandlerListPushNotificationDeliveries(t *testing.T) {
+	dbClient := database_fake.NewClient()
+	handler := handlers.NewTasksHandler(&handlers.Base{
+		DatabaseService: dbClient,
+		Authorizer:      &authimpl.NoopAuthorizer{},
+	})
+
+	require.NoError(t, dbClient.StoreSession(&database.Session{ID: "session-1", UserID: "alice", AgentID: ptr.To("default__NS__test_agent")}))
+	require.NoError(t, dbClient.StorePushNotification(&protocol.TaskPushNotificationConfig{
+		TaskID: "task-1",
+		PushNotificationConfig: protocol.PushNotificationConfig{
+			ID:             "config-1",
+			URL:            "https://client.example.com/notifications",
+			Token:          "token",
+			Authentication: &protocol.AuthenticationInfo{Schemes: []string{"Bearer"}, Credentials: ptr.To("secret")},
+		},
+	}))
+	require.NoError(t, dbClient.StoreTask(&protocol.Task{ID: "task-1", ContextID: "session-1", Status: protocol.TaskStatus{State: protocol.TaskStateCompleted}}))
+
+	listDeliveries := func(userID string) *mockErrorResponseWriter {
+		req := httptest.NewRequest(http.MethodGet, "/api/tasks/task-1/push-notifications", nil)
+		req = mux.SetURLVars(req, map[string]string{"task_id": "task-1"})
+		req = setUser(req, userID)
+		responseRecorder := newMockErrorResponseWriter()
+		handler.HandleListPushNotificationDeliveries(responseRecorder, req)
+		return responseRecorder
+	}
+
+	// only the user of the session can list the push notifications of its tasks
+	assert.Equal(t, http.StatusNotFound, listDeliveries("bob").Code)
+
+	responseRecorder := listDeliveries("alice")
+	require.Equal(t, http.StatusOK, responseRecorder.Code)
+	var response api.StandardResponse[[]database.PushNotificationDelivery]
+	require.NoError(t, json.Unmarshal(responseRecorder.Body.Bytes(), &response))
+	require.Len(t, response.Data, 1)
+
+	// the secrets of the config are not returned
+	var config protocol.TaskPushNotificationConfig
+	require.NoError(t, json.Unmarshal([]byte(response.Data[0].Config), &config))
+	assert.Equal(t, "https://client.example.com/notifications", config.PushNotificationConfig.URL)
+	assert.Equal(t, "[REDACTED]", config.PushNotificationConfig.Token)
+	assert.Equal(t, "[REDACTED]", *config.PushNotificationConfig.Authentication.Credentials)
+	assert.NotContains(t, responseRecorder.Body.String(), "secret")
+}
@@ -20,6 +20,8 @@ func setupScheme() *runtime.Scheme {
 		&v1alpha2.AgentList{},
 		&v1alpha2.ModelConfig{},
 		&v1alpha2.ModelConfigList{},
+		&v1alpha2.RemoteAgent{},
+		&v1alpha2.RemoteAgentList{},
 	)
 
 	metav1.AddToGroupVersion(s, schema.GroupVersion{Group: "kagent.dev", Version: "v1alpha1"})
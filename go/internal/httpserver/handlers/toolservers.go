@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"net/http"
 	"slices"
+	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/kagent-dev/kagent/go/api/v1alpha2"
@@ -66,10 +67,19 @@ func (h *ToolServersHandler) HandleListToolServers(w ErrorResponseWriter, r *htt
 
 		discoveredTools := make([]*v1alpha2.MCPTool, len(tools))
 		for j, tool := range tools {
-			discoveredTools[j] = &v1alpha2.MCPTool{
-				Name:        tool.ID,
-				Description: tool.Description,
-			}
+			discoveredTools[j] = tool.ToMCPTool()
+		}
+
+		resources, err := h.DatabaseService.ListResourcesForServer(toolServer.Name, toolServer.GroupKind)
+		if err != nil {
+			w.RespondWithError(errors.NewInternalServerError("Failed to list resources for ToolServer from database", err))
+			return
+		}
+
+		prompts, err := h.DatabaseService.ListPromptsForServer(toolServer.Name, toolServer.GroupKind)
+		if err != nil {
+			w.RespondWithError(errors.NewInternalServerError("Failed to list prompts for ToolServer from database", err))
+			return
 		}
 
 		toolServerWithTools[i] = api.ToolServerResponse{
@@ -77,6 +87,12 @@ func (h *ToolServersHandler) HandleListToolServers(w ErrorResponseWriter, r *htt
 			GroupKind:       toolServer.GroupKind,
 			DiscoveredTools: discoveredTools,
 		}
+		for _, resource := range resources {
+			toolServerWithTools[i].DiscoveredResources = append(toolServerWithTools[i].DiscoveredResources, resource.ToMCPResource())
+		}
+		for _, prompt := range prompts {
+			toolServerWithTools[i].DiscoveredPrompts = append(toolServerWithTools[i].DiscoveredPrompts, prompt.ToMCPPrompt())
+		}
 	}
 
 	log.Info("Successfully listed ToolServers", "count", len(toolServerWithTools))
@@ -337,3 +353,58 @@ func (h *ToolServersHandler) HandleDeleteToolServer(w ErrorResponseWriter, r *ht
 	data := api.NewResponse(struct{}{}, "Successfully deleted ToolServer", false)
 	RespondWithJSON(w, http.StatusOK, data)
 }
+
+// HandleRefreshToolServer handles POST /api/toolservers/{namespace}/{name}/refresh requests.
+// The tools are rediscovered asynchronously by the controller.
+func (h *ToolServersHandler) HandleRefreshToolServer(w ErrorResponseWriter, r *http.Request) {
+	log := ctrllog.FromContext(r.Context()).WithName("toolservers-handler").WithValues("operation", "refresh")
+	log.Info("Received request to refresh ToolServer")
+
+	namespace, err := GetPathParam(r, "namespace")
+	if err != nil {
+		w.RespondWithError(errors.NewBadRequestError("Failed to get namespace from path", err))
+		return
+	}
+
+	toolServerName, err := GetPathParam(r, "name")
+	if err != nil {
+		w.RespondWithError(errors.NewBadRequestError("Failed to get name from path", err))
+		return
+	}
+
+	log = log.WithValues(
+		"toolServerNamespace", namespace,
+		"toolServerName", toolServerName,
+	)
+	if err := Check(h.Authorizer, r, auth.Resource{Type: "ToolServer", Name: types.NamespacedName{Namespace: namespace, Name: toolServerName}.String()}); err != nil {
+		w.RespondWithError(err)
+		return
+	}
+
+	toolServer := &v1alpha2.RemoteMCPServer{}
+	if err := h.KubeClient.Get(r.Context(), client.ObjectKey{Namespace: namespace, Name: toolServerName}, toolServer); err != nil {
+		if apierrors.IsNotFound(err) {
+			w.RespondWithError(errors.NewNotFoundError("RemoteMCPServer not found", nil))
+			return
+		}
+		log.Error(err, "Failed to get RemoteMCPServer")
+		w.RespondWithError(errors.NewInternalServerError("Failed to get RemoteMCPServer", err))
+		return
+	}
+
+	// Any change of the annotation makes the controller rediscover the tools
+	patch := client.MergeFrom(toolServer.DeepCopy())
+	if toolServer.Annotations == nil {
+		toolServer.Annotations = map[string]string{}
+	}
+	toolServer.Annotations[v1alpha2.RemoteMCPServerRefreshAnnotation] = time.Now().UTC().Format(time.RFC3339Nano)
+	if err := h.KubeClient.Patch(r.Context(), toolServer, patch); err != nil {
+		log.Error(err, "Failed to request refresh of RemoteMCPServer")
+		w.RespondWithError(errors.NewInternalServerError("Failed to request refresh of RemoteMCPServer", err))
+		return
+	}
+
+	log.Info("Successfully requested refresh of ToolServer")
+	data := api.NewResponse(struct{}{}, "Successfully requested refresh of ToolServer", false)
+	RespondWithJSON(w, http.StatusAccepted, data)
+}
@@ -97,10 +97,19 @@ func TestToolServersHandler(t *testing.T) {
 				ServerName:  "default/test-toolserver-1",
 				GroupKind:   "kagent.dev/RemoteMCPServer",
 				Description: "Test tool",
+				InputSchema: json.RawMessage(`{"properties":{"name":{"type":"string"}},"type":"object"}`),
+				Annotations: &v1alpha2.MCPToolAnnotations{ReadOnlyHint: ptr.To(true)},
 			}
 			err = dbClient.CreateTool(tool1)
 			require.NoError(t, err)
 
+			resource := &v1alpha2.MCPResource{URI: "file:///readme.md", Name: "readme"}
+			err = dbClient.RefreshResourcesForServer(toolServer1.Name, toolServer1.GroupKind, resource)
+			require.NoError(t, err)
+			prompt := &v1alpha2.MCPPrompt{Name: "review", Arguments: []v1alpha2.MCPPromptArgument{{Name: "diff", Required: true}}}
+			err = dbClient.RefreshPromptsForServer(toolServer1.Name, toolServer1.GroupKind, prompt)
+			require.NoError(t, err)
+
 			req := httptest.NewRequest("GET", "/api/toolservers/", nil)
 			req = setUser(req, "test-user")
 			handler.HandleListToolServers(responseRecorder, req)
@@ -117,10 +126,16 @@ func TestToolServersHandler(t *testing.T) {
 			require.Equal(t, "default/test-toolserver-1", toolServer.Ref)
 			require.Len(t, toolServer.DiscoveredTools, 1)
 			require.Equal(t, "test-tool", toolServer.DiscoveredTools[0].Name)
+			require.NotNil(t, toolServer.DiscoveredTools[0].InputSchema)
+			require.JSONEq(t, string(tool1.InputSchema), string(toolServer.DiscoveredTools[0].InputSchema.Raw))
+			require.Equal(t, tool1.Annotations, toolServer.DiscoveredTools[0].Annotations)
+			require.Equal(t, []*v1alpha2.MCPResource{resource}, toolServer.DiscoveredResources)
+			require.Equal(t, []*v1alpha2.MCPPrompt{prompt}, toolServer.DiscoveredPrompts)
 
 			// Verify second tool server response
 			toolServer = toolServers.Data[1]
 			require.Equal(t, "test-ns/test-toolserver-2", toolServer.Ref)
+			require.Empty(t, toolServer.DiscoveredResources)
 		})
 
 		t.Run("EmptyList", func(t *testing.T) {
@@ -508,4 +523,56 @@ func TestToolServersHandler(t *testing.T) {
 			require.NotNil(t, responseRecorder.errorReceived)
 		})
 	})
+	t.Run("HandleRefreshToolServer", func(t *testing.T) {
+		t.Run("Success", func(t *testing.T) {
+			handler, kubeClient, _, responseRecorder := setupHandler()
+
+			toolServer := &v1alpha2.RemoteMCPServer{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-toolserver",
+					Namespace: "default",
+				},
+				Spec: v1alpha2.RemoteMCPServerSpec{
+					Description: "Tool server to refresh",
+					URL:         "https://example.com/mcp",
+				},
+			}
+			err := kubeClient.Create(context.Background(), toolServer)
+			require.NoError(t, err)
+
+			req := httptest.NewRequest("POST", "/api/toolservers/default/test-toolserver/refresh", nil)
+			req = setUser(req, "test-user")
+
+			router := mux.NewRouter()
+			router.HandleFunc("/api/toolservers/{namespace}/{name}/refresh", func(w http.ResponseWriter, r *http.Request) {
+				handler.HandleRefreshToolServer(responseRecorder, r)
+			}).Methods("POST")
+
+			router.ServeHTTP(responseRecorder, req)
+
+			require.Equal(t, http.StatusAccepted, responseRecorder.Code, responseRecorder.Body.String())
+
+			updated := &v1alpha2.RemoteMCPServer{}
+			err = kubeClient.Get(context.Background(), types.NamespacedName{Namespace: "default", Name: "test-toolserver"}, updated)
+			require.NoError(t, err)
+			require.Contains(t, updated.Annotations, v1alpha2.RemoteMCPServerRefreshAnnotation)
+		})
+
+		t.Run("NotFound", func(t *testing.T) {
+			handler, _, _, responseRecorder := setupHandler()
+
+			req := httptest.NewRequest("POST", "/api/toolservers/default/nonexistent/refresh", nil)
+			req = setUser(req, "test-user")
+
+			router := mux.NewRouter()
+			router.HandleFunc("/api/toolservers/{namespace}/{name}/refresh", func(w http.ResponseWriter, r *http.Request) {
+				handler.HandleRefreshToolServer(responseRecorder, r)
+			}).Methods("POST")
+
+			router.ServeHTTP(responseRecorder, req)
+
+			require.Equal(t, http.StatusNotFound, responseRecorder.Code)
+			require.NotNil(t, responseRecorder.errorReceived)
+		})
+	})
 }
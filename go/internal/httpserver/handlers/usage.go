@@ -0,0 +1,138 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	ctrllog "sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/kagent-dev/kagent/go/internal/database"
+	"github.com/kagent-dev/kagent/go/internal/httpserver/errors"
+	"github.com/kagent-dev/kagent/go/internal/metrics"
+	"github.com/kagent-dev/kagent/go/internal/usage"
+	"github.com/kagent-dev/kagent/go/internal/utils"
+	"github.com/kagent-dev/kagent/go/pkg/auth"
+	"github.com/kagent-dev/kagent/go/pkg/client/api"
+)
+
+// UsageHandler handles token usage requests
+type UsageHandler struct {
+	*Base
+}
+
+// NewUsageHandler creates a new UsageHandler
+func NewUsageHandler(base *Base) *UsageHandler {
+	return &UsageHandler{Base: base}
+}
+
+// allUsersUsage is the name of the Usage resource a principal must be allowed to get to read
+// the usage of every user, the usage of other principals is limited to their own
+const allUsersUsage = "*"
+
+// HandleGetUsage handles GET /api/usage requests. The usage is aggregated by the comma
+// separated dimensions of the group_by parameter over the RFC 3339 time range [from, to),
+// optionally filtered by agent, session, user and model_config.
+func (h *UsageHandler) HandleGetUsage(w ErrorResponseWriter, r *http.Request) {
+	log := ctrllog.FromContext(r.Context()).WithName("usage-handler").WithValues("operation", "get-usage")
+
+	if err := Check(h.Authorizer, r, auth.Resource{Type: "Usage"}); err != nil {
+		w.RespondWithError(err)
+		return
+	}
+	principal, err := GetPrincipal(r)
+	if err != nil {
+		w.RespondWithError(errors.NewBadRequestError("Failed to get user ID", err))
+		return
+	}
+
+	query, err := parseUsageQuery(r)
+	if err != nil {
+		w.RespondWithError(errors.NewBadRequestError("Invalid usage query", err))
+		return
+	}
+	if err := h.Authorizer.Check(r.Context(), principal, auth.VerbGet, auth.Resource{Type: "Usage", Name: allUsersUsage}); err != nil {
+		query.UserID = principal.User.ID
+	}
+
+	summaries, err := h.DatabaseService.AggregateUsage(query)
+	if err != nil {
+		w.RespondWithError(errors.NewInternalServerError("Failed to aggregate usage", err))
+		return
+	}
+
+	log.Info("Successfully aggregated usage", "groups", len(summaries))
+	data := api.NewResponse(summaries, "Successfully aggregated usage", false)
+	RespondWithJSON(w, http.StatusOK, data)
+}
+
+func parseUsageQuery(r *http.Request) (database.UsageQuery, error) {
+	params := r.URL.Query()
+	query := database.UsageQuery{
+		SessionID:   params.Get("session"),
+		UserID:      params.Get("user"),
+		ModelConfig: params.Get("model_config"),
+	}
+	// agents are referred to as namespace/name, and stored as python identifiers
+	if agent := params.Get("agent"); agent != "" {
+		query.AgentID = utils.ConvertToPythonIdentifier(agent)
+	}
+
+	var err error
+	if from := params.Get("from"); from != "" {
+		if query.From, err = time.Parse(time.RFC3339, from); err != nil {
+			return query, fmt.Errorf("invalid from: %w", err)
+		}
+	}
+	if to := params.Get("to"); to != "" {
+		if query.To, err = time.Parse(time.RFC3339, to); err != nil {
+			return query, fmt.Errorf("invalid to: %w", err)
+		}
+	}
+	if !query.From.IsZero() && !query.To.IsZero() && !query.From.Before(query.To) {
+		return query, fmt.Errorf("from must be before to")
+	}
+
+	if groupBy := params.Get("group_by"); groupBy != "" {
+		for _, dimension := range strings.Split(groupBy, ",") {
+			dimension := database.UsageGroupBy(strings.TrimSpace(dimension))
+			if _, ok := database.UsageGroupByColumns[dimension]; !ok {
+				return query, fmt.Errorf("unsupported group_by %q", dimension)
+			}
+			query.GroupBy = append(query.GroupBy, dimension)
+		}
+	}
+
+	return query, nil
+}
+
+// recordUsage stores the token usage carried by an event of an agent, if any, and adds it
+// to the usage metrics. The ModelConfig is the one the agent recorded in the event, BYO agents
+// do not record any. Failing to record usage does not fail storing the event.
+func (b *Base) recordUsage(log logr.Logger, event *database.Event, agentID string) {
+	record, err := usage.FromEvent(event.Data)
+	if err != nil {
+		log.Error(err, "Failed to parse usage of event")
+		return
+	}
+	if record == nil {
+		return
+	}
+
+	record.EventID = event.ID
+	record.SessionID = event.SessionID
+	record.UserID = event.UserID
+	record.AgentID = agentID
+	record.Cost = b.Prices.Cost(record.Model, record.InputTokens, record.OutputTokens, record.CachedTokens)
+
+	stored, err := b.DatabaseService.StoreUsage(record)
+	if err != nil {
+		log.Error(err, "Failed to store usage of event")
+		return
+	}
+	if stored {
+		metrics.RecordUsage(record)
+	}
+}
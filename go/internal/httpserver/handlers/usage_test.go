@@ -0,0 +1,164 @@
+package handlers_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/kagent-dev/kagent/go/internal/database"
+	database_fake "github.com/kagent-dev/kagent/go/internal/database/fake"
+	authimpl "github.com/kagent-dev/kagent/go/internal/httpserver/auth"
+	"github.com/kagent-dev/kagent/go/internal/httpserver/handlers"
+	"github.com/kagent-dev/kagent/go/internal/usage"
+	"github.com/kagent-dev/kagent/go/pkg/auth"
+	"github.com/kagent-dev/kagent/go/pkg/client/api"
+)
+
+func TestUsageHandler(t *testing.T) {
+	agentID := "default__NS__test_agent"
+
+	setupHandlers := func() (*handlers.SessionsHandler, *handlers.UsageHandler, database.Client) {
+		kubeClient := fake.NewClientBuilder().WithScheme(setupScheme()).Build()
+		dbClient := database_fake.NewClient()
+		base := &handlers.Base{
+			KubeClient:      kubeClient,
+			DatabaseService: dbClient,
+			Authorizer:      &authimpl.NoopAuthorizer{},
+			Prices:          usage.PriceTable{"gpt-4o": {Input: 2.5, Output: 10}},
+		}
+		return handlers.NewSessionsHandler(base), handlers.NewUsageHandler(base), dbClient
+	}
+
+	addEvent := func(t *testing.T, handler *handlers.SessionsHandler, sessionID, userID, eventID, data string) {
+		body, err := json.Marshal(map[string]string{"id": eventID, "data": data})
+		require.NoError(t, err)
+		req := httptest.NewRequest(http.MethodPost, "/api/sessions/"+sessionID+"/events?user_id="+userID, bytes.NewReader(body))
+		req = mux.SetURLVars(req, map[string]string{"session_id": sessionID})
+		req = req.WithContext(auth.AuthSessionTo(req.Context(), &authimpl.SimpleSession{
+			P: auth.Principal{User: auth.User{ID: userID}, Agent: auth.Agent{ID: "default/test-agent"}},
+		}))
+		responseRecorder := newMockErrorResponseWriter()
+		handler.HandleAddEventToSession(responseRecorder, req)
+		require.Equal(t, http.StatusCreated, responseRecorder.Code, responseRecorder.Body.String())
+	}
+
+	getUsage := func(handler *handlers.UsageHandler, query string, userID string) *mockErrorResponseWriter {
+		req := httptest.NewRequest(http.MethodGet, "/api/usage?"+query, nil)
+		req = setUser(req, userID)
+		responseRecorder := newMockErrorResponseWriter()
+		handler.HandleGetUsage(responseRecorder, req)
+		return responseRecorder
+	}
+
+	t.Run("RecordsUsageOfEvents", func(t *testing.T) {
+		sessionsHandler, usageHandler, dbClient := setupHandlers()
+		for _, session := range []*database.Session{
+			{ID: "session-1", UserID: "alice", AgentID: ptr.To(agentID)},
+			{ID: "session-2", UserID: "bob", AgentID: ptr.To(agentID)},
+		} {
+			require.NoError(t, dbClient.StoreSession(session))
+		}
+
+		modelEvent := `{"author":"test_agent","usage_metadata":{"prompt_token_count":1000000,"candidates_token_count":100000},` +
+			`"custom_metadata":{"kagent_model":"gpt-4o","kagent_model_config":"default/openai","kagent_latency_ms":1200}}`
+		addEvent(t, sessionsHandler, "session-1", "alice", "event-1", modelEvent)
+		addEvent(t, sessionsHandler, "session-1", "alice", "event-2", `{"author":"user","content":{"parts":[{"text":"hi"}]}}`)
+		addEvent(t, sessionsHandler, "session-2", "bob", "event-3", modelEvent)
+		// an event stored again is accounted once
+		addEvent(t, sessionsHandler, "session-2", "bob", "event-3", modelEvent)
+
+		responseRecorder := getUsage(usageHandler, "group_by=agent,model_config", "admin")
+		require.Equal(t, http.StatusOK, responseRecorder.Code, responseRecorder.Body.String())
+		var response api.StandardResponse[[]database.UsageSummary]
+		require.NoError(t, json.Unmarshal(responseRecorder.Body.Bytes(), &response))
+		require.Len(t, response.Data, 1)
+		summary := response.Data[0]
+		assert.Equal(t, agentID, summary.AgentID)
+		assert.Equal(t, "default/openai", summary.ModelConfig)
+		assert.Equal(t, int64(2), summary.Requests)
+		assert.Equal(t, int64(2000000), summary.InputTokens)
+		assert.Equal(t, int64(200000), summary.OutputTokens)
+		assert.InDelta(t, 2*(2.5+1), summary.Cost, 1e-9)
+		assert.InDelta(t, 1200, summary.AvgLatencyMs, 1e-9)
+
+		responseRecorder = getUsage(usageHandler, "group_by=user&agent=default/test-agent", "admin")
+		require.Equal(t, http.StatusOK, responseRecorder.Code, responseRecorder.Body.String())
+		require.NoError(t, json.Unmarshal(responseRecorder.Body.Bytes(), &response))
+		require.Len(t, response.Data, 2)
+		assert.Equal(t, "alice", response.Data[0].UserID)
+		assert.Equal(t, "bob", response.Data[1].UserID)
+	})
+
+	t.Run("FiltersByTimeRange", func(t *testing.T) {
+		_, usageHandler, dbClient := setupHandlers()
+		for _, day := range []int{1, 15, 31} {
+			_, err := dbClient.StoreUsage(&database.Usage{
+				EventID:     fmt.Sprintf("event-%d", day),
+				AgentID:     agentID,
+				InputTokens: 100,
+				CreatedAt:   time.Date(2025, time.January, day, 0, 0, 0, 0, time.UTC),
+			})
+			require.NoError(t, err)
+		}
+
+		responseRecorder := getUsage(usageHandler, "from=2025-01-01T00:00:00Z&to=2025-01-31T00:00:00Z", "admin")
+		require.Equal(t, http.StatusOK, responseRecorder.Code, responseRecorder.Body.String())
+		var response api.StandardResponse[[]database.UsageSummary]
+		require.NoError(t, json.Unmarshal(responseRecorder.Body.Bytes(), &response))
+		require.Len(t, response.Data, 1)
+		assert.Equal(t, int64(2), response.Data[0].Requests)
+		assert.Equal(t, int64(200), response.Data[0].InputTokens)
+	})
+
+	t.Run("LimitsUsersToTheirOwnUsage", func(t *testing.T) {
+		_, usageHandler, dbClient := setupHandlers()
+		usageHandler.Authorizer = &ownUsageAuthorizer{}
+		for i, userID := range []string{"alice", "bob", "bob"} {
+			_, err := dbClient.StoreUsage(&database.Usage{EventID: fmt.Sprintf("event-%d", i), AgentID: agentID, UserID: userID, InputTokens: 100})
+			require.NoError(t, err)
+		}
+
+		for _, query := range []string{"group_by=user", "group_by=user&user=alice"} {
+			responseRecorder := getUsage(usageHandler, query, "bob")
+			require.Equal(t, http.StatusOK, responseRecorder.Code, responseRecorder.Body.String())
+			var response api.StandardResponse[[]database.UsageSummary]
+			require.NoError(t, json.Unmarshal(responseRecorder.Body.Bytes(), &response))
+			require.Len(t, response.Data, 1, query)
+			assert.Equal(t, "bob", response.Data[0].UserID)
+			assert.Equal(t, int64(2), response.Data[0].Requests)
+		}
+	})
+
+	t.Run("InvalidQuery", func(t *testing.T) {
+		_, usageHandler, _ := setupHandlers()
+		for _, query := range []string{
+			"group_by=team",
+			"from=yesterday",
+			"from=2025-02-01T00:00:00Z&to=2025-01-01T00:00:00Z",
+		} {
+			responseRecorder := getUsage(usageHandler, query, "admin")
+			assert.Equal(t, http.StatusBadRequest, responseRecorder.Code, query)
+		}
+	})
+}
+
+// ownUsageAuthorizer allows everything but getting the usage of all users
+type ownUsageAuthorizer struct{}
+
+func (a *ownUsageAuthorizer) Check(ctx context.Context, principal auth.Principal, verb auth.Verb, resource auth.Resource) error {
+	if resource.Type == "Usage" && resource.Name == "*" {
+		return fmt.Errorf("%s may not get the usage of all users", principal.User.ID)
+	}
+	return nil
+}
@@ -9,6 +9,9 @@ import (
 	"github.com/kagent-dev/kagent/go/internal/a2a"
 	"github.com/kagent-dev/kagent/go/internal/database"
 	"github.com/kagent-dev/kagent/go/internal/httpserver/handlers"
+	"github.com/kagent-dev/kagent/go/internal/modelcatalog"
+	"github.com/kagent-dev/kagent/go/internal/pushnotification"
+	"github.com/kagent-dev/kagent/go/internal/usage"
 	common "github.com/kagent-dev/kagent/go/internal/utils"
 	"github.com/kagent-dev/kagent/go/internal/version"
 	"github.com/kagent-dev/kagent/go/pkg/auth"
@@ -38,6 +41,10 @@ const (
 	APIPathFeedback        = "/api/feedback"
 	APIPathLangGraph       = "/api/langgraph"
 	APIPathCrewAI          = "/api/crewai"
+	APIPathUsage           = "/api/usage"
+
+	// APIPathPushNotificationJWKS serves the keys verifying the signed push notifications
+	APIPathPushNotificationJWKS = "/api/push-notifications/jwks.json"
 )
 
 var defaultModelConfig = types.NamespacedName{
@@ -55,6 +62,11 @@ type ServerConfig struct {
 	DbClient          database.Client
 	Authenticator     auth.AuthProvider
 	Authorizer        auth.Authorizer
+	Prices            usage.PriceTable
+	// ModelCatalog lists the models of the providers, shared with the controller
+	ModelCatalog *modelcatalog.Catalog
+	// PushNotificationSigner signs the push notifications, nil if they are not signed
+	PushNotificationSigner *pushnotification.Signer
 }
 
 // HTTPServer is the structure that manages the HTTP server
@@ -74,7 +86,7 @@ func NewHTTPServer(config ServerConfig) (*HTTPServer, error) {
 	return &HTTPServer{
 		config:        config,
 		router:        config.Router,
-		handlers:      handlers.NewHandlers(config.KubeClient, defaultModelConfig, config.DbClient, config.WatchedNamespaces, config.Authorizer),
+		handlers:      handlers.NewHandlers(config.KubeClient, defaultModelConfig, config.DbClient, config.WatchedNamespaces, config.Authorizer, config.Prices, config.A2AHandler, config.ModelCatalog),
 		authenticator: config.Authenticator,
 	}, nil
 }
@@ -153,6 +165,8 @@ func (s *HTTPServer) setupRoutes() {
 	s.router.HandleFunc(APIPathModelConfig, adaptHandler(s.handlers.ModelConfig.HandleCreateModelConfig)).Methods(http.MethodPost)
 	s.router.HandleFunc(APIPathModelConfig+"/{namespace}/{name}", adaptHandler(s.handlers.ModelConfig.HandleDeleteModelConfig)).Methods(http.MethodDelete)
 	s.router.HandleFunc(APIPathModelConfig+"/{namespace}/{name}", adaptHandler(s.handlers.ModelConfig.HandleUpdateModelConfig)).Methods(http.MethodPut)
+	s.router.HandleFunc(APIPathModelConfig+"/{namespace}/{name}/models", adaptHandler(s.handlers.ModelConfig.HandleListModelConfigModels)).Methods(http.MethodGet)
+	s.router.HandleFunc(APIPathModelConfig+"/{namespace}/{name}/test", adaptHandler(s.handlers.ModelConfig.HandleTestModelConfig)).Methods(http.MethodPost)
 
 	// Sessions - using database handlers
 	s.router.HandleFunc(APIPathSessions, adaptHandler(s.handlers.Sessions.HandleListSessions)).Methods(http.MethodGet)
@@ -168,6 +182,8 @@ func (s *HTTPServer) setupRoutes() {
 	s.router.HandleFunc(APIPathTasks+"/{task_id}", adaptHandler(s.handlers.Tasks.HandleGetTask)).Methods(http.MethodGet)
 	s.router.HandleFunc(APIPathTasks, adaptHandler(s.handlers.Tasks.HandleCreateTask)).Methods(http.MethodPost)
 	s.router.HandleFunc(APIPathTasks+"/{task_id}", adaptHandler(s.handlers.Tasks.HandleDeleteTask)).Methods(http.MethodDelete)
+	s.router.HandleFunc(APIPathTasks+"/{task_id}/cancel", adaptHandler(s.handlers.Tasks.HandleCancelTask)).Methods(http.MethodPost)
+	s.router.HandleFunc(APIPathTasks+"/{task_id}/push-notifications", adaptHandler(s.handlers.Tasks.HandleListPushNotificationDeliveries)).Methods(http.MethodGet)
 
 	// Tools - using database handlers
 	s.router.HandleFunc(APIPathTools, adaptHandler(s.handlers.Tools.HandleListTools)).Methods(http.MethodGet)
@@ -176,6 +192,7 @@ func (s *HTTPServer) setupRoutes() {
 	s.router.HandleFunc(APIPathToolServers, adaptHandler(s.handlers.ToolServers.HandleListToolServers)).Methods(http.MethodGet)
 	s.router.HandleFunc(APIPathToolServers, adaptHandler(s.handlers.ToolServers.HandleCreateToolServer)).Methods(http.MethodPost)
 	s.router.HandleFunc(APIPathToolServers+"/{namespace}/{name}", adaptHandler(s.handlers.ToolServers.HandleDeleteToolServer)).Methods(http.MethodDelete)
+	s.router.HandleFunc(APIPathToolServers+"/{namespace}/{name}/refresh", adaptHandler(s.handlers.ToolServers.HandleRefreshToolServer)).Methods(http.MethodPost)
 
 	// Tool Server Types
 	s.router.HandleFunc(APIPathToolServerTypes, adaptHandler(s.handlers.ToolServerTypes.HandleListToolServerTypes)).Methods(http.MethodGet)
@@ -221,6 +238,14 @@ func (s *HTTPServer) setupRoutes() {
 	s.router.HandleFunc(APIPathCrewAI+"/flows/state", adaptHandler(s.handlers.CrewAI.HandleStoreFlowState)).Methods(http.MethodPost)
 	s.router.HandleFunc(APIPathCrewAI+"/flows/state", adaptHandler(s.handlers.CrewAI.HandleGetFlowState)).Methods(http.MethodGet)
 
+	// Usage
+	s.router.HandleFunc(APIPathUsage, adaptHandler(s.handlers.Usage.HandleGetUsage)).Methods(http.MethodGet)
+
+	// Push notifications
+	if s.config.PushNotificationSigner != nil {
+		s.router.HandleFunc(APIPathPushNotificationJWKS, s.config.PushNotificationSigner.HandleJWKS).Methods(http.MethodGet)
+	}
+
 	// A2A
 	s.router.PathPrefix(APIPathA2A + "/{namespace}/{name}").Handler(s.config.A2AHandler)
 
@@ -0,0 +1,61 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/kagent-dev/kagent/go/internal/database"
+)
+
+// The usage metrics are labelled by agent and model only, sessions and users would make
+// their cardinality unbounded. Usage by session and user is served by the usage API.
+var usageLabels = []string{"agent", "model_config", "model"}
+
+var (
+	modelRequests = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "kagent_model_requests_total",
+			Help: "Number of model calls of agents.",
+		},
+		usageLabels,
+	)
+	modelTokens = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "kagent_model_tokens_total",
+			Help: "Number of tokens used by model calls of agents, by type: input, output or cached. Cached tokens are part of the input tokens.",
+		},
+		append(usageLabels, "type"),
+	)
+	modelCost = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "kagent_model_cost_total",
+			Help: "Cost of the model calls of agents, according to the configured price table.",
+		},
+		usageLabels,
+	)
+	modelLatency = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "kagent_model_latency_seconds",
+			Help:    "Latency of the model calls of agents.",
+			Buckets: []float64{0.25, 0.5, 1, 2.5, 5, 10, 30, 60, 120},
+		},
+		usageLabels,
+	)
+)
+
+// UsageCollectors returns the collectors of the usage metrics.
+func UsageCollectors() []prometheus.Collector {
+	return []prometheus.Collector{modelRequests, modelTokens, modelCost, modelLatency}
+}
+
+// RecordUsage adds the usage of a model call to the usage metrics.
+func RecordUsage(usage *database.Usage) {
+	labels := prometheus.Labels{"agent": usage.AgentID, "model_config": usage.ModelConfig, "model": usage.Model}
+	modelRequests.With(labels).Inc()
+	modelTokens.MustCurryWith(labels).WithLabelValues("input").Add(float64(usage.InputTokens))
+	modelTokens.MustCurryWith(labels).WithLabelValues("output").Add(float64(usage.OutputTokens))
+	modelTokens.MustCurryWith(labels).WithLabelValues("cached").Add(float64(usage.CachedTokens))
+	modelCost.With(labels).Add(usage.Cost)
+	if usage.LatencyMs > 0 {
+		modelLatency.With(labels).Observe(float64(usage.LatencyMs) / 1000)
+	}
+}
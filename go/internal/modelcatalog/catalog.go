@@ -0,0 +1,249 @@
+package modelcatalog
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/kagent-dev/kagent/go/api/v1alpha2"
+	"github.com/kagent-dev/kagent/go/internal/egress"
+	kclient "github.com/kagent-dev/kagent/go/pkg/client"
+)
+
+const (
+	// DefaultTTL is how long the models listed by a provider are cached
+	DefaultTTL = 10 * time.Minute
+	// listTimeout bounds the requests listing the models of a provider
+	listTimeout = 10 * time.Second
+)
+
+// Catalog lists the models available to ModelConfigs by querying their providers,
+// caching the results for a TTL.
+type Catalog struct {
+	kube client.Client
+	ttl  time.Duration
+	now  func() time.Time
+
+	mu      sync.Mutex
+	entries map[types.NamespacedName]cacheEntry
+}
+
+type cacheEntry struct {
+	// version identifies the spec and secrets the models were listed with
+	version   string
+	models    []kclient.ModelInfo
+	expiresAt time.Time
+}
+
+// NewCatalog creates a catalog caching the models of each ModelConfig for the ttl.
+func NewCatalog(kube client.Client, ttl time.Duration) *Catalog {
+	return &Catalog{
+		kube:    kube,
+		ttl:     ttl,
+		now:     time.Now,
+		entries: map[types.NamespacedName]cacheEntry{},
+	}
+}
+
+// Models returns the models of the provider of the ModelConfig merged with the
+// default models of the provider. Listed models come first, annotated with the
+// capabilities of the matching default model. The cache is bypassed when refresh is set.
+//
+// Providers that cannot be queried return their default models.
+func (c *Catalog) Models(ctx context.Context, modelConfig *v1alpha2.ModelConfig, refresh bool) ([]kclient.ModelInfo, error) {
+	list, ok := lister(modelConfig)
+	if !ok {
+		return mergeModels(modelConfig, nil), nil
+	}
+
+	key := client.ObjectKeyFromObject(modelConfig)
+	version := fmt.Sprintf("%d/%s", modelConfig.Generation, modelConfig.Status.SecretHash)
+	if !refresh {
+		if models, ok := c.cached(key, version); ok {
+			return models, nil
+		}
+	}
+
+	apiKey, err := c.apiKey(ctx, modelConfig)
+	if err != nil {
+		return nil, err
+	}
+	httpClient, err := c.httpClient(ctx, modelConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	listCtx, cancel := context.WithTimeout(ctx, listTimeout)
+	defer cancel()
+	names, err := list(listCtx, httpClient, modelConfig, apiKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list models of %s: %w", modelConfig.Spec.Provider, err)
+	}
+
+	models := mergeModels(modelConfig, names)
+	c.store(key, version, models)
+	return models, nil
+}
+
+// ProviderModels returns the models of each provider: the models listed by the providers of the
+// ModelConfigs, from the cache when fresh, followed by the default models that were not listed.
+// ModelConfigs whose provider fails to list its models only contribute the defaults.
+func (c *Catalog) ProviderModels(ctx context.Context, modelConfigs []v1alpha2.ModelConfig) kclient.ProviderModels {
+	listed := make([][]kclient.ModelInfo, len(modelConfigs))
+	var wg sync.WaitGroup
+	for i := range modelConfigs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			models, err := c.Models(ctx, &modelConfigs[i], false)
+			if err != nil {
+				log.FromContext(ctx).V(1).Info("Failed to list models", "modelConfig", client.ObjectKeyFromObject(&modelConfigs[i]), "error", err.Error())
+				return
+			}
+			listed[i] = models
+		}()
+	}
+	wg.Wait()
+
+	providerModels := kclient.ProviderModels{}
+	for i, models := range listed {
+		provider := modelConfigs[i].Spec.Provider
+		for _, model := range models {
+			if model.Discovered {
+				providerModels[provider] = appendModel(providerModels[provider], model)
+			}
+		}
+	}
+	for provider, defaults := range DefaultModels() {
+		for _, model := range defaults {
+			providerModels[provider] = appendModel(providerModels[provider], model)
+		}
+	}
+	return providerModels
+}
+
+// appendModel appends a model unless a model of the same name is already present
+func appendModel(models []kclient.ModelInfo, model kclient.ModelInfo) []kclient.ModelInfo {
+	if slices.ContainsFunc(models, func(m kclient.ModelInfo) bool { return m.Name == model.Name }) {
+		return models
+	}
+	return append(models, model)
+}
+
+// Forget drops the cached models of the ModelConfig.
+func (c *Catalog) Forget(key types.NamespacedName) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.entries, key)
+}
+
+func (c *Catalog) cached(key types.NamespacedName, version string) ([]kclient.ModelInfo, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok || entry.version != version || !c.now().Before(entry.expiresAt) {
+		return nil, false
+	}
+	return entry.models, true
+}
+
+func (c *Catalog) store(key types.NamespacedName, version string, models []kclient.ModelInfo) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries[key] = cacheEntry{
+		version:   version,
+		models:    models,
+		expiresAt: c.now().Add(c.ttl),
+	}
+}
+
+func (c *Catalog) apiKey(ctx context.Context, modelConfig *v1alpha2.ModelConfig) (string, error) {
+	if modelConfig.Spec.APIKeySecret == "" {
+		return "", nil
+	}
+	value, err := c.secretValue(ctx, modelConfig.Namespace, modelConfig.Spec.APIKeySecret, modelConfig.Spec.APIKeySecretKey)
+	if err != nil {
+		return "", fmt.Errorf("failed to get API key: %w", err)
+	}
+	return string(value), nil
+}
+
+// httpClient returns a client honouring the TLS and proxy settings of the ModelConfig.
+func (c *Catalog) httpClient(ctx context.Context, modelConfig *v1alpha2.ModelConfig) (*http.Client, error) {
+	config, err := egress.Resolve(ctx, c.kube, modelConfig.Namespace, modelConfig.Spec.TLS, modelConfig.Spec.Proxy)
+	if err != nil {
+		return nil, err
+	}
+	return config.HTTPClient()
+}
+
+func (c *Catalog) secretValue(ctx context.Context, namespace, name, key string) ([]byte, error) {
+	secret := &corev1.Secret{}
+	if err := c.kube.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, secret); err != nil {
+		return nil, err
+	}
+	value, ok := secret.Data[key]
+	if !ok {
+		return nil, fmt.Errorf("key %s not found in secret %s", key, name)
+	}
+	return value, nil
+}
+
+// mergeModels annotates the listed models with the capabilities of the default
+// models of the provider and appends the defaults that were not listed.
+func mergeModels(modelConfig *v1alpha2.ModelConfig, names []string) []kclient.ModelInfo {
+	defaults := DefaultModels()[modelConfig.Spec.Provider]
+	unknown := unknownModel(modelConfig)
+
+	models := make([]kclient.ModelInfo, 0, len(names)+len(defaults))
+	for _, name := range names {
+		model := unknown
+		if i := slices.IndexFunc(defaults, func(m kclient.ModelInfo) bool { return m.Name == name }); i >= 0 {
+			model = defaults[i]
+		}
+		model.Name = name
+		model.Discovered = true
+		models = append(models, model)
+	}
+	for _, model := range defaults {
+		if !slices.Contains(names, model.Name) {
+			models = append(models, model)
+		}
+	}
+	return models
+}
+
+// unknownModel returns the capabilities assumed for a listed model that is not a default one.
+func unknownModel(modelConfig *v1alpha2.ModelConfig) kclient.ModelInfo {
+	switch modelConfig.Spec.Provider {
+	case v1alpha2.ModelProviderOpenAICompatible:
+		if modelConfig.Spec.OpenAICompatible == nil {
+			return kclient.ModelInfo{FunctionCalling: true}
+		}
+		capabilities := modelConfig.Spec.OpenAICompatible.GetCapabilities()
+		return kclient.ModelInfo{
+			FunctionCalling: ptr.Deref(capabilities.FunctionCalling, true),
+			Vision:          ptr.Deref(capabilities.Vision, false),
+			JSONMode:        ptr.Deref(capabilities.JSONMode, false),
+		}
+	case v1alpha2.ModelProviderOllama:
+		// tool support depends on the model, which the tags API does not report
+		return kclient.ModelInfo{}
+	case v1alpha2.ModelProviderAnthropic:
+		return kclient.ModelInfo{FunctionCalling: true, Vision: true}
+	default:
+		return kclient.ModelInfo{FunctionCalling: true, JSONMode: true}
+	}
+}
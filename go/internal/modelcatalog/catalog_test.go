@@ -0,0 +1,241 @@
+package modelcatalog
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/kagent-dev/kagent/go/api/v1alpha2"
+	kclient "github.com/kagent-dev/kagent/go/pkg/client"
+)
+
+func newTestCatalog(t *testing.T, objects ...runtime.Object) *Catalog {
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	require.NoError(t, v1alpha2.AddToScheme(scheme))
+	kube := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(objects...).Build()
+	return NewCatalog(kube, time.Minute)
+}
+
+func TestCatalogOpenAI(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		assert.Equal(t, "/v1/models", r.URL.Path)
+		assert.Equal(t, "Bearer sk-test", r.Header.Get("Authorization"))
+		_, _ = w.Write([]byte(`{"data":[{"id":"gpt-4o"},{"id":"ft:gpt-4o:acme"}]}`))
+	}))
+	defer server.Close()
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "openai", Namespace: "test"},
+		Data:       map[string][]byte{"key": []byte("sk-test")},
+	}
+	catalog := newTestCatalog(t, secret)
+	now := time.Now()
+	catalog.now = func() time.Time { return now }
+
+	modelConfig := &v1alpha2.ModelConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "openai", Namespace: "test", Generation: 1},
+		Spec: v1alpha2.ModelConfigSpec{
+			Provider:        v1alpha2.ModelProviderOpenAI,
+			Model:           "gpt-4o",
+			APIKeySecret:    "openai",
+			APIKeySecretKey: "key",
+			OpenAI:          &v1alpha2.OpenAIConfig{BaseURL: server.URL + "/v1"},
+		},
+	}
+
+	models, err := catalog.Models(context.Background(), modelConfig, false)
+	require.NoError(t, err)
+	require.Len(t, models, len(DefaultModels()[v1alpha2.ModelProviderOpenAI])+1)
+	assert.Equal(t, kclient.ModelInfo{Name: "ft:gpt-4o:acme", FunctionCalling: true, JSONMode: true, Discovered: true}, models[0])
+	assert.Equal(t, kclient.ModelInfo{Name: "gpt-4o", FunctionCalling: true, Vision: true, JSONMode: true, Discovered: true}, models[1])
+	assert.False(t, models[2].Discovered, "defaults that were not listed are appended")
+
+	_, err = catalog.Models(context.Background(), modelConfig, false)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, requests.Load(), "served from the cache")
+
+	_, err = catalog.Models(context.Background(), modelConfig, true)
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, requests.Load(), "refresh bypasses the cache")
+
+	modelConfig.Status.SecretHash = "changed"
+	_, err = catalog.Models(context.Background(), modelConfig, false)
+	require.NoError(t, err)
+	assert.EqualValues(t, 3, requests.Load(), "secret change invalidates the cache")
+
+	now = now.Add(2 * time.Minute)
+	_, err = catalog.Models(context.Background(), modelConfig, false)
+	require.NoError(t, err)
+	assert.EqualValues(t, 4, requests.Load(), "expired")
+}
+
+func TestCatalogOpenAICompatible(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/models", r.URL.Path)
+		_, _ = w.Write([]byte(`{"object":"list","data":[{"id":"qwen3-32b","object":"model"},{"id":"llama-3.3-70b","object":"model"}]}`))
+	}))
+	defer server.Close()
+
+	modelConfig := &v1alpha2.ModelConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "vllm", Namespace: "test", Generation: 1},
+		Spec: v1alpha2.ModelConfigSpec{
+			Provider: v1alpha2.ModelProviderOpenAICompatible,
+			Model:    "qwen3-32b",
+			OpenAICompatible: &v1alpha2.OpenAICompatibleConfig{
+				Profile: v1alpha2.OpenAICompatibleProfile("vLLM"),
+				BaseURL: server.URL + "/v1",
+			},
+		},
+	}
+
+	models, err := newTestCatalog(t).Models(context.Background(), modelConfig, false)
+	require.NoError(t, err)
+	assert.Equal(t, []kclient.ModelInfo{
+		{Name: "llama-3.3-70b", FunctionCalling: true, JSONMode: true, Discovered: true},
+		{Name: "qwen3-32b", FunctionCalling: true, JSONMode: true, Discovered: true},
+	}, models)
+}
+
+func TestCatalogOllama(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/tags", r.URL.Path)
+		_, _ = w.Write([]byte(`{"models":[{"name":"qwen3:8b"},{"name":"mistral"}]}`))
+	}))
+	defer server.Close()
+
+	catalog := newTestCatalog(t)
+	modelConfig := &v1alpha2.ModelConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "ollama", Namespace: "test"},
+		Spec: v1alpha2.ModelConfigSpec{
+			Provider: v1alpha2.ModelProviderOllama,
+			Model:    "mistral",
+			Ollama:   &v1alpha2.OllamaConfig{Host: server.URL},
+		},
+	}
+
+	models, err := catalog.Models(context.Background(), modelConfig, false)
+	require.NoError(t, err)
+	assert.Equal(t, kclient.ModelInfo{Name: "mistral", Discovered: true}, models[0])
+	assert.Equal(t, kclient.ModelInfo{Name: "qwen3:8b", Discovered: true}, models[1])
+}
+
+func TestCatalogAnthropicPagination(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "sk-ant", r.Header.Get("x-api-key"))
+		assert.Equal(t, anthropicVersion, r.Header.Get("anthropic-version"))
+		if r.URL.Query().Get("after_id") == "" {
+			_, _ = w.Write([]byte(`{"data":[{"id":"claude-sonnet-4-5"}],"has_more":true,"last_id":"claude-sonnet-4-5"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"data":[{"id":"claude-haiku-4-5"}],"has_more":false}`))
+	}))
+	defer server.Close()
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "anthropic", Namespace: "test"},
+		Data:       map[string][]byte{"key": []byte("sk-ant")},
+	}
+	catalog := newTestCatalog(t, secret)
+	modelConfig := &v1alpha2.ModelConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "anthropic", Namespace: "test"},
+		Spec: v1alpha2.ModelConfigSpec{
+			Provider:        v1alpha2.ModelProviderAnthropic,
+			Model:           "claude-sonnet-4-5",
+			APIKeySecret:    "anthropic",
+			APIKeySecretKey: "key",
+			Anthropic:       &v1alpha2.AnthropicConfig{BaseURL: server.URL},
+		},
+	}
+
+	models, err := catalog.Models(context.Background(), modelConfig, false)
+	require.NoError(t, err)
+	assert.Equal(t, "claude-haiku-4-5", models[0].Name)
+	assert.Equal(t, "claude-sonnet-4-5", models[1].Name)
+	assert.True(t, models[1].Discovered)
+}
+
+func TestCatalogErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	catalog := newTestCatalog(t)
+
+	_, err := catalog.Models(context.Background(), &v1alpha2.ModelConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "vllm", Namespace: "test"},
+		Spec: v1alpha2.ModelConfigSpec{
+			Provider:         v1alpha2.ModelProviderOpenAICompatible,
+			OpenAICompatible: &v1alpha2.OpenAICompatibleConfig{BaseURL: server.URL},
+		},
+	}, false)
+	assert.ErrorContains(t, err, "unexpected status 401")
+
+	_, err = catalog.Models(context.Background(), &v1alpha2.ModelConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "openai", Namespace: "test"},
+		Spec: v1alpha2.ModelConfigSpec{
+			Provider:        v1alpha2.ModelProviderOpenAI,
+			APIKeySecret:    "missing",
+			APIKeySecretKey: "key",
+		},
+	}, false)
+	assert.ErrorContains(t, err, "failed to get API key")
+
+	models, err := catalog.Models(context.Background(), &v1alpha2.ModelConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "bedrock", Namespace: "test"},
+		Spec: v1alpha2.ModelConfigSpec{
+			Provider: v1alpha2.ModelProviderBedrock,
+			Bedrock:  &v1alpha2.BedrockConfig{Region: "us-east-1"},
+		},
+	}, false)
+	require.NoError(t, err, "providers that cannot be listed return their defaults")
+	assert.Equal(t, DefaultModels()[v1alpha2.ModelProviderBedrock], models)
+}
+
+func TestCatalogProviderModels(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"models":[{"name":"qwen3:8b"}]}`))
+	}))
+	defer server.Close()
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer failing.Close()
+
+	ollama := func(name, host string) v1alpha2.ModelConfig {
+		return v1alpha2.ModelConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "test"},
+			Spec: v1alpha2.ModelConfigSpec{
+				Provider: v1alpha2.ModelProviderOllama,
+				Ollama:   &v1alpha2.OllamaConfig{Host: host},
+			},
+		}
+	}
+
+	providerModels := newTestCatalog(t).ProviderModels(context.Background(), []v1alpha2.ModelConfig{
+		ollama("ollama", server.URL),
+		ollama("ollama-copy", server.URL),
+		ollama("ollama-down", failing.URL),
+	})
+
+	defaults := DefaultModels()
+	require.Len(t, providerModels, len(defaults))
+	assert.Equal(t, defaults[v1alpha2.ModelProviderOpenAI], providerModels[v1alpha2.ModelProviderOpenAI])
+	ollamaModels := providerModels[v1alpha2.ModelProviderOllama]
+	require.Len(t, ollamaModels, len(defaults[v1alpha2.ModelProviderOllama])+1, "listed models are merged once")
+	assert.Equal(t, kclient.ModelInfo{Name: "qwen3:8b", Discovered: true}, ollamaModels[0])
+	assert.Equal(t, defaults[v1alpha2.ModelProviderOllama], ollamaModels[1:])
+}
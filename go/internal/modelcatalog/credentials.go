@@ -0,0 +1,115 @@
+package modelcatalog
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kagent-dev/kagent/go/api/v1alpha2"
+)
+
+// Reasons of the CredentialsValid condition of a ModelConfig
+const (
+	CredentialsReasonValid       = "CredentialsValid"
+	CredentialsReasonInvalid     = "CredentialsInvalid"
+	CredentialsReasonUnreachable = "ProviderUnreachable"
+	CredentialsReasonSecretError = "SecretError"
+	CredentialsReasonUnsupported = "CheckUnsupported"
+)
+
+// CredentialsCheck is the outcome of probing the provider of a ModelConfig with its credentials.
+type CredentialsCheck struct {
+	Status  metav1.ConditionStatus
+	Reason  string
+	Message string
+}
+
+// Transient returns whether the check failed to tell whether the credentials are valid, e.g.
+// because the provider was unreachable, and should be retried.
+func (c CredentialsCheck) Transient() bool {
+	return c.Reason == CredentialsReasonUnreachable
+}
+
+// CheckCredentials makes a cheap authenticated request, listing the models, to the
+// provider of the ModelConfig. The messages never include the response of the
+// provider and have the credentials redacted.
+//
+// Providers whose models cannot be listed, and providers that cannot be reached or respond with
+// an unexpected status, are reported with an Unknown status.
+func (c *Catalog) CheckCredentials(ctx context.Context, modelConfig *v1alpha2.ModelConfig) CredentialsCheck {
+	provider := modelConfig.Spec.Provider
+	list, ok := lister(modelConfig)
+	if !ok {
+		return CredentialsCheck{
+			Status:  metav1.ConditionUnknown,
+			Reason:  CredentialsReasonUnsupported,
+			Message: fmt.Sprintf("credentials of %s models cannot be checked", provider),
+		}
+	}
+
+	apiKey, err := c.apiKey(ctx, modelConfig)
+	if err != nil {
+		return CredentialsCheck{Status: metav1.ConditionFalse, Reason: CredentialsReasonSecretError, Message: err.Error()}
+	}
+	httpClient, err := c.httpClient(ctx, modelConfig)
+	if err != nil {
+		return CredentialsCheck{Status: metav1.ConditionFalse, Reason: CredentialsReasonSecretError, Message: err.Error()}
+	}
+
+	listCtx, cancel := context.WithTimeout(ctx, listTimeout)
+	defer cancel()
+	_, err = list(listCtx, httpClient, modelConfig, apiKey)
+
+	var statusErr *statusError
+	switch {
+	case err == nil:
+		return CredentialsCheck{Status: metav1.ConditionTrue, Reason: CredentialsReasonValid}
+	case errors.Is(err, errInvalidKey):
+		return CredentialsCheck{Status: metav1.ConditionFalse, Reason: CredentialsReasonSecretError, Message: err.Error()}
+	case errors.Is(err, errCredentialsRejected):
+		return CredentialsCheck{
+			Status:  metav1.ConditionFalse,
+			Reason:  CredentialsReasonInvalid,
+			Message: fmt.Sprintf("%s rejected the credentials", provider),
+		}
+	case errors.As(err, &statusErr) && (statusErr.statusCode == http.StatusUnauthorized || statusErr.statusCode == http.StatusForbidden):
+		return CredentialsCheck{
+			Status:  metav1.ConditionFalse,
+			Reason:  CredentialsReasonInvalid,
+			Message: fmt.Sprintf("%s rejected the credentials with status %d", provider, statusErr.statusCode),
+		}
+	case errors.As(err, &statusErr):
+		return CredentialsCheck{
+			Status:  metav1.ConditionUnknown,
+			Reason:  CredentialsReasonUnreachable,
+			Message: fmt.Sprintf("%s responded with status %d", provider, statusErr.statusCode),
+		}
+	default:
+		return CredentialsCheck{
+			Status:  metav1.ConditionUnknown,
+			Reason:  CredentialsReasonUnreachable,
+			Message: redact(fmt.Sprintf("failed to reach %s: %v", provider, err), apiKey, azureADToken(modelConfig)),
+		}
+	}
+}
+
+// redact replaces the secrets in the message.
+func redact(message string, secrets ...string) string {
+	for _, secret := range secrets {
+		if secret != "" {
+			message = strings.ReplaceAll(message, secret, "[REDACTED]")
+		}
+	}
+	return message
+}
+
+func azureADToken(modelConfig *v1alpha2.ModelConfig) string {
+	if modelConfig.Spec.AzureOpenAI == nil {
+		return ""
+	}
+	return modelConfig.Spec.AzureOpenAI.AzureADToken
+}
@@ -0,0 +1,315 @@
+package modelcatalog
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kagent-dev/kagent/go/api/v1alpha2"
+)
+
+func TestCheckCredentials(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Header.Get("Authorization") {
+		case "Bearer sk-valid":
+			_, _ = w.Write([]byte(`{"data":[{"id":"gpt-4o"}]}`))
+		case "Bearer sk-limited":
+			w.WriteHeader(http.StatusTooManyRequests)
+		default:
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"error":{"message":"Incorrect API key provided"}}`))
+		}
+	}))
+	defer server.Close()
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "openai", Namespace: "test"},
+		Data: map[string][]byte{
+			"valid":   []byte("sk-valid"),
+			"invalid": []byte("sk-invalid"),
+			"limited": []byte("sk-limited"),
+		},
+	}
+	catalog := newTestCatalog(t, secret)
+
+	openAI := func(secretKey, baseURL string) *v1alpha2.ModelConfig {
+		return &v1alpha2.ModelConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: "openai", Namespace: "test"},
+			Spec: v1alpha2.ModelConfigSpec{
+				Provider:        v1alpha2.ModelProviderOpenAI,
+				APIKeySecret:    "openai",
+				APIKeySecretKey: secretKey,
+				OpenAI:          &v1alpha2.OpenAIConfig{BaseURL: baseURL},
+			},
+		}
+	}
+
+	tests := []struct {
+		name        string
+		modelConfig *v1alpha2.ModelConfig
+		expected    CredentialsCheck
+	}{
+		{
+			name:        "valid",
+			modelConfig: openAI("valid", server.URL),
+			expected:    CredentialsCheck{Status: metav1.ConditionTrue, Reason: CredentialsReasonValid},
+		},
+		{
+			name:        "rejected",
+			modelConfig: openAI("invalid", server.URL),
+			expected: CredentialsCheck{
+				Status:  metav1.ConditionFalse,
+				Reason:  CredentialsReasonInvalid,
+				Message: "OpenAI rejected the credentials with status 401",
+			},
+		},
+		{
+			name:        "unexpected status",
+			modelConfig: openAI("limited", server.URL),
+			expected: CredentialsCheck{
+				Status:  metav1.ConditionUnknown,
+				Reason:  CredentialsReasonUnreachable,
+				Message: "OpenAI responded with status 429",
+			},
+		},
+		{
+			name:        "missing secret key",
+			modelConfig: openAI("missing", server.URL),
+			expected: CredentialsCheck{
+				Status:  metav1.ConditionFalse,
+				Reason:  CredentialsReasonSecretError,
+				Message: "failed to get API key: key missing not found in secret openai",
+			},
+		},
+		{
+			name: "unsupported provider",
+			modelConfig: &v1alpha2.ModelConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: "fallback", Namespace: "test"},
+				Spec:       v1alpha2.ModelConfigSpec{Provider: v1alpha2.ModelProviderFallback},
+			},
+			expected: CredentialsCheck{
+				Status:  metav1.ConditionUnknown,
+				Reason:  CredentialsReasonUnsupported,
+				Message: "credentials of Fallback models cannot be checked",
+			},
+		},
+		{
+			name: "workload identity",
+			modelConfig: &v1alpha2.ModelConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: "vertex", Namespace: "test"},
+				Spec: v1alpha2.ModelConfigSpec{
+					Provider: v1alpha2.ModelProviderGeminiVertexAI,
+					GeminiVertexAI: &v1alpha2.GeminiVertexAIConfig{BaseVertexAIConfig: v1alpha2.BaseVertexAIConfig{
+						ProjectID:        "project",
+						WorkloadIdentity: &v1alpha2.GKEWorkloadIdentityConfig{},
+					}},
+				},
+			},
+			expected: CredentialsCheck{
+				Status:  metav1.ConditionUnknown,
+				Reason:  CredentialsReasonUnsupported,
+				Message: "credentials of GeminiVertexAI models cannot be checked",
+			},
+		},
+		{
+			name: "AWS credential chain",
+			modelConfig: &v1alpha2.ModelConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: "bedrock", Namespace: "test"},
+				Spec: v1alpha2.ModelConfigSpec{
+					Provider: v1alpha2.ModelProviderBedrock,
+					Bedrock:  &v1alpha2.BedrockConfig{Region: "us-east-1", RoleARN: "arn:aws:iam::123456789012:role/agent"},
+				},
+			},
+			expected: CredentialsCheck{
+				Status:  metav1.ConditionUnknown,
+				Reason:  CredentialsReasonUnsupported,
+				Message: "credentials of Bedrock models cannot be checked",
+			},
+		},
+		{
+			name: "agent identity",
+			modelConfig: &v1alpha2.ModelConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: "azure", Namespace: "test"},
+				Spec: v1alpha2.ModelConfigSpec{
+					Provider: v1alpha2.ModelProviderAzureOpenAI,
+					AzureOpenAI: &v1alpha2.AzureOpenAIConfig{
+						Endpoint: server.URL,
+						Identity: &v1alpha2.AzureIdentityConfig{Mode: v1alpha2.AzureIdentityModeManagedIdentity},
+					},
+				},
+			},
+			expected: CredentialsCheck{
+				Status:  metav1.ConditionUnknown,
+				Reason:  CredentialsReasonUnsupported,
+				Message: "credentials of AzureOpenAI models cannot be checked",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, catalog.CheckCredentials(context.Background(), tt.modelConfig))
+		})
+	}
+
+	t.Run("unreachable", func(t *testing.T) {
+		check := catalog.CheckCredentials(context.Background(), openAI("valid", "http://127.0.0.1:0/sk-valid"))
+		assert.Equal(t, metav1.ConditionUnknown, check.Status)
+		assert.Equal(t, CredentialsReasonUnreachable, check.Reason)
+		assert.True(t, check.Transient())
+		assert.NotContains(t, check.Message, "sk-valid")
+		assert.Contains(t, check.Message, "[REDACTED]")
+	})
+}
+
+func TestCheckCredentialsGoogleAndBedrock(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/gemini/models" && r.Header.Get("x-goog-api-key") == "gemini-valid":
+			_, _ = w.Write([]byte(`{"models":[{"name":"models/gemini-2.5-pro"}]}`))
+		case r.URL.Path == "/gemini/models":
+			w.WriteHeader(http.StatusBadRequest)
+		case r.URL.Path == "/bedrock/us-east-1/foundation-models" && r.Header.Get("Authorization") == "Bearer bedrock-valid":
+			_, _ = w.Write([]byte(`{"modelSummaries":[{"modelId":"anthropic.claude-3-5-sonnet-20240620-v1:0"}]}`))
+		case r.URL.Path == "/bedrock/us-east-1/foundation-models":
+			w.WriteHeader(http.StatusForbidden)
+		case r.URL.Path == "/token/valid":
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"access_token":"token","token_type":"Bearer","expires_in":3600}`))
+		case r.URL.Path == "/token/invalid":
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"error":"invalid_grant"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	previousGemini, previousBedrock := geminiBaseURL, bedrockBaseURL
+	geminiBaseURL = server.URL + "/gemini"
+	bedrockBaseURL = func(region string) string { return server.URL + "/bedrock/" + region }
+	t.Cleanup(func() { geminiBaseURL, bedrockBaseURL = previousGemini, previousBedrock })
+
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	require.NoError(t, err)
+	serviceAccountKey := func(tokenPath string) []byte {
+		key, err := json.Marshal(map[string]string{
+			"type":         "service_account",
+			"client_email": "agent@project.iam.gserviceaccount.com",
+			"private_key":  string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})),
+			"token_uri":    server.URL + tokenPath,
+		})
+		require.NoError(t, err)
+		return key
+	}
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "keys", Namespace: "test"},
+		Data: map[string][]byte{
+			"gemini-valid":    []byte("gemini-valid"),
+			"gemini-invalid":  []byte("gemini-invalid"),
+			"bedrock-valid":   []byte("bedrock-valid"),
+			"bedrock-invalid": []byte("bedrock-invalid"),
+			"vertex-valid":    serviceAccountKey("/token/valid"),
+			"vertex-invalid":  serviceAccountKey("/token/invalid"),
+			"vertex-other":    []byte(`{"type":"external_account"}`),
+		},
+	}
+	catalog := newTestCatalog(t, secret)
+
+	modelConfig := func(provider v1alpha2.ModelProvider, secretKey string) *v1alpha2.ModelConfig {
+		modelConfig := &v1alpha2.ModelConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: "model", Namespace: "test"},
+			Spec: v1alpha2.ModelConfigSpec{
+				Provider:        provider,
+				APIKeySecret:    "keys",
+				APIKeySecretKey: secretKey,
+			},
+		}
+		switch provider {
+		case v1alpha2.ModelProviderBedrock:
+			modelConfig.Spec.Bedrock = &v1alpha2.BedrockConfig{Region: "us-east-1"}
+		case v1alpha2.ModelProviderAnthropicVertexAI:
+			modelConfig.Spec.AnthropicVertexAI = &v1alpha2.AnthropicVertexAIConfig{BaseVertexAIConfig: v1alpha2.BaseVertexAIConfig{ProjectID: "project"}}
+		}
+		return modelConfig
+	}
+
+	tests := []struct {
+		name        string
+		modelConfig *v1alpha2.ModelConfig
+		expected    CredentialsCheck
+	}{
+		{
+			name:        "Gemini",
+			modelConfig: modelConfig(v1alpha2.ModelProviderGemini, "gemini-valid"),
+			expected:    CredentialsCheck{Status: metav1.ConditionTrue, Reason: CredentialsReasonValid},
+		},
+		{
+			name:        "Gemini rejected",
+			modelConfig: modelConfig(v1alpha2.ModelProviderGemini, "gemini-invalid"),
+			expected: CredentialsCheck{
+				Status:  metav1.ConditionFalse,
+				Reason:  CredentialsReasonInvalid,
+				Message: "Gemini rejected the credentials",
+			},
+		},
+		{
+			name:        "Bedrock",
+			modelConfig: modelConfig(v1alpha2.ModelProviderBedrock, "bedrock-valid"),
+			expected:    CredentialsCheck{Status: metav1.ConditionTrue, Reason: CredentialsReasonValid},
+		},
+		{
+			name:        "Bedrock rejected",
+			modelConfig: modelConfig(v1alpha2.ModelProviderBedrock, "bedrock-invalid"),
+			expected: CredentialsCheck{
+				Status:  metav1.ConditionFalse,
+				Reason:  CredentialsReasonInvalid,
+				Message: "Bedrock rejected the credentials with status 403",
+			},
+		},
+		{
+			name:        "Vertex AI",
+			modelConfig: modelConfig(v1alpha2.ModelProviderAnthropicVertexAI, "vertex-valid"),
+			expected:    CredentialsCheck{Status: metav1.ConditionTrue, Reason: CredentialsReasonValid},
+		},
+		{
+			name:        "Vertex AI rejected",
+			modelConfig: modelConfig(v1alpha2.ModelProviderAnthropicVertexAI, "vertex-invalid"),
+			expected: CredentialsCheck{
+				Status:  metav1.ConditionFalse,
+				Reason:  CredentialsReasonInvalid,
+				Message: "AnthropicVertexAI rejected the credentials",
+			},
+		},
+		{
+			name:        "Vertex AI credentials other than a service account key",
+			modelConfig: modelConfig(v1alpha2.ModelProviderAnthropicVertexAI, "vertex-other"),
+			expected: CredentialsCheck{
+				Status:  metav1.ConditionFalse,
+				Reason:  CredentialsReasonSecretError,
+				Message: `invalid key: credentials of type "external_account" are not a service account key`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, catalog.CheckCredentials(context.Background(), tt.modelConfig))
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package modelcatalog
+
+import (
+	"github.com/kagent-dev/kagent/go/api/v1alpha2"
+	kclient "github.com/kagent-dev/kagent/go/pkg/client"
+)
+
+// DefaultModels returns the well known models of each provider. They are offered
+// when a provider cannot be queried and annotate the models it lists with their capabilities.
+func DefaultModels() kclient.ProviderModels {
+	return kclient.ProviderModels{
+		v1alpha2.ModelProviderOpenAI: {
+			{Name: "gpt-5", FunctionCalling: true, Vision: true, JSONMode: true},
+			{Name: "gpt-5-mini", FunctionCalling: true, Vision: true, JSONMode: true},
+			{Name: "gpt-5-nano", FunctionCalling: true, Vision: true, JSONMode: true},
+			{Name: "gpt-4o", FunctionCalling: true, Vision: true, JSONMode: true},
+			{Name: "o4-mini", FunctionCalling: true, Vision: true, JSONMode: true},
+			{Name: "gpt-4-turbo", FunctionCalling: true, Vision: true, JSONMode: true},
+			{Name: "gpt-4", FunctionCalling: true, JSONMode: true},
+			{Name: "gpt-3.5-turbo", FunctionCalling: true, JSONMode: true},
+		},
+		v1alpha2.ModelProviderAnthropic: {
+			{Name: "claude-opus-4-1-20250805", FunctionCalling: true, Vision: true},
+			{Name: "claude-opus-4-20250514", FunctionCalling: true, Vision: true},
+			{Name: "claude-sonnet-4-20250514", FunctionCalling: true, Vision: true},
+			{Name: "claude-3-7-sonnet-20250219", FunctionCalling: true, Vision: true},
+			{Name: "claude-3-5-sonnet-20240620", FunctionCalling: true, Vision: true},
+			{Name: "claude-sonnet-4-5", FunctionCalling: true, Vision: true},
+		},
+		v1alpha2.ModelProviderAzureOpenAI: {
+			{Name: "gpt-4", FunctionCalling: true, JSONMode: true},
+			{Name: "gpt-35-turbo", FunctionCalling: true, JSONMode: true},
+			{Name: "gpt-oss-120b", FunctionCalling: true, JSONMode: true},
+			{Name: "gpt-4.1", FunctionCalling: true, Vision: true, JSONMode: true},
+			{Name: "gpt-4.1-mini", FunctionCalling: true, Vision: true, JSONMode: true},
+			{Name: "gpt-4.1-nano", FunctionCalling: true, Vision: true, JSONMode: true},
+			{Name: "gpt-4o", FunctionCalling: true, Vision: true, JSONMode: true},
+			{Name: "gpt-4o-mini", FunctionCalling: true, Vision: true, JSONMode: true},
+			{Name: "o4-mini", FunctionCalling: true, Vision: true, JSONMode: true},
+			{Name: "o3", FunctionCalling: true, Vision: true, JSONMode: true},
+			{Name: "o3-mini", FunctionCalling: true, JSONMode: true},
+		},
+		v1alpha2.ModelProviderOllama: {
+			{Name: "llama2", FunctionCalling: false},
+			{Name: "llama2:13b", FunctionCalling: false},
+			{Name: "llama2:70b", FunctionCalling: false},
+			{Name: "mistral", FunctionCalling: false},
+			{Name: "mixtral", FunctionCalling: false},
+		},
+		v1alpha2.ModelProviderGemini: {
+			{Name: "gemini-2.5-pro", FunctionCalling: true, Vision: true, JSONMode: true},
+			{Name: "gemini-2.5-flash", FunctionCalling: true, Vision: true, JSONMode: true},
+			{Name: "gemini-2.5-flash-lite", FunctionCalling: true, Vision: true, JSONMode: true},
+			{Name: "gemini-2.0-flash", FunctionCalling: true, Vision: true, JSONMode: true},
+			{Name: "gemini-2.0-flash-lite", FunctionCalling: true, Vision: true, JSONMode: true},
+		},
+		v1alpha2.ModelProviderGeminiVertexAI: {
+			{Name: "gemini-2.5-pro", FunctionCalling: true, Vision: true, JSONMode: true},
+			{Name: "gemini-2.5-flash", FunctionCalling: true, Vision: true, JSONMode: true},
+			{Name: "gemini-2.5-flash-lite", FunctionCalling: true, Vision: true, JSONMode: true},
+			{Name: "gemini-2.0-flash", FunctionCalling: true, Vision: true, JSONMode: true},
+			{Name: "gemini-2.0-flash-lite", FunctionCalling: true, Vision: true, JSONMode: true},
+		},
+		v1alpha2.ModelProviderAnthropicVertexAI: {
+			{Name: "claude-opus-4-1@20250805", FunctionCalling: true, Vision: true},
+			{Name: "claude-sonnet-4@20250514", FunctionCalling: true, Vision: true},
+			{Name: "claude-3-5-haiku@20241022", FunctionCalling: true},
+		},
+		v1alpha2.ModelProviderBedrock: {
+			{Name: "anthropic.claude-sonnet-4-20250514-v1:0", FunctionCalling: true, Vision: true},
+			{Name: "anthropic.claude-3-7-sonnet-20250219-v1:0", FunctionCalling: true, Vision: true},
+			{Name: "anthropic.claude-3-5-haiku-20241022-v1:0", FunctionCalling: true},
+			{Name: "amazon.nova-pro-v1:0", FunctionCalling: true, Vision: true},
+			{Name: "amazon.nova-lite-v1:0", FunctionCalling: true, Vision: true},
+			{Name: "meta.llama3-3-70b-instruct-v1:0", FunctionCalling: true},
+		},
+	}
+}
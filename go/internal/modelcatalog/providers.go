@@ -0,0 +1,350 @@
+package modelcatalog
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/jwt"
+
+	"github.com/kagent-dev/kagent/go/api/v1alpha2"
+)
+
+const (
+	defaultOpenAIBaseURL    = "https://api.openai.com/v1"
+	defaultAnthropicBaseURL = "https://api.anthropic.com"
+	defaultOllamaHost       = "http://localhost:11434"
+	anthropicVersion        = "2023-06-01"
+	// azureDeploymentsAPIVersion is the last data plane API version that lists deployments
+	azureDeploymentsAPIVersion = "2022-12-01"
+	// googleCloudPlatformScope is the OAuth scope of the Vertex AI requests
+	googleCloudPlatformScope = "https://www.googleapis.com/auth/cloud-platform"
+	// googleTokenURL is the token endpoint of the service account keys that do not have one
+	googleTokenURL = "https://oauth2.googleapis.com/token"
+)
+
+var (
+	// geminiBaseURL is the base URL of the Gemini API, a variable for the tests
+	geminiBaseURL = "https://generativelanguage.googleapis.com/v1beta"
+	// bedrockBaseURL returns the base URL of the Bedrock control plane in a region, a variable for the tests
+	bedrockBaseURL = func(region string) string { return fmt.Sprintf("https://bedrock.%s.amazonaws.com", region) }
+)
+
+var (
+	// errCredentialsRejected is returned when a provider rejects the credentials without an
+	// authentication status
+	errCredentialsRejected = errors.New("credentials rejected")
+	// errInvalidKey is returned when the key in the secret of the ModelConfig cannot be used
+	errInvalidKey = errors.New("invalid key")
+)
+
+// listFunc returns the names of the models a provider serves
+type listFunc func(ctx context.Context, httpClient *http.Client, modelConfig *v1alpha2.ModelConfig, apiKey string) ([]string, error)
+
+// listers holds the providers whose models can be listed
+var listers = map[v1alpha2.ModelProvider]listFunc{
+	v1alpha2.ModelProviderOpenAI: func(ctx context.Context, httpClient *http.Client, modelConfig *v1alpha2.ModelConfig, apiKey string) ([]string, error) {
+		baseURL := defaultOpenAIBaseURL
+		if modelConfig.Spec.OpenAI != nil && modelConfig.Spec.OpenAI.BaseURL != "" {
+			baseURL = modelConfig.Spec.OpenAI.BaseURL
+		}
+		return listOpenAIModels(ctx, httpClient, baseURL, apiKey)
+	},
+	v1alpha2.ModelProviderOpenAICompatible: func(ctx context.Context, httpClient *http.Client, modelConfig *v1alpha2.ModelConfig, apiKey string) ([]string, error) {
+		if modelConfig.Spec.OpenAICompatible == nil {
+			return nil, fmt.Errorf("openAICompatible model config is required")
+		}
+		return listOpenAIModels(ctx, httpClient, modelConfig.Spec.OpenAICompatible.GetBaseURL(), apiKey)
+	},
+	v1alpha2.ModelProviderAnthropic:         listAnthropicModels,
+	v1alpha2.ModelProviderOllama:            listOllamaModels,
+	v1alpha2.ModelProviderAzureOpenAI:       listAzureOpenAIDeployments,
+	v1alpha2.ModelProviderGemini:            listGeminiModels,
+	v1alpha2.ModelProviderGeminiVertexAI:    checkVertexAIServiceAccount,
+	v1alpha2.ModelProviderAnthropicVertexAI: checkVertexAIServiceAccount,
+	v1alpha2.ModelProviderBedrock:           listBedrockModels,
+}
+
+// lister returns the function listing the models of the ModelConfig, if the controller can
+// authenticate to its provider. Agents using a token credential, GKE workload identity or the
+// AWS credential chain authenticate with their own identity, which the controller does not have.
+func lister(modelConfig *v1alpha2.ModelConfig) (listFunc, bool) {
+	switch modelConfig.Spec.Provider {
+	case v1alpha2.ModelProviderAzureOpenAI:
+		if modelConfig.Spec.AzureOpenAI != nil && modelConfig.Spec.AzureOpenAI.Identity != nil {
+			return nil, false
+		}
+	case v1alpha2.ModelProviderGeminiVertexAI, v1alpha2.ModelProviderAnthropicVertexAI, v1alpha2.ModelProviderBedrock:
+		if modelConfig.Spec.APIKeySecret == "" {
+			return nil, false
+		}
+	}
+	list, ok := listers[modelConfig.Spec.Provider]
+	return list, ok
+}
+
+func listOpenAIModels(ctx context.Context, httpClient *http.Client, baseURL, apiKey string) ([]string, error) {
+	headers := map[string]string{}
+	if apiKey != "" {
+		headers["Authorization"] = "Bearer " + apiKey
+	}
+
+	var body struct {
+		Data []struct {
+			ID string `json:"id"`
+		} `json:"data"`
+	}
+	if err := getJSON(ctx, httpClient, strings.TrimSuffix(baseURL, "/")+"/models", headers, &body); err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(body.Data))
+	for _, model := range body.Data {
+		names = append(names, model.ID)
+	}
+	slices.Sort(names)
+	return names, nil
+}
+
+func listAnthropicModels(ctx context.Context, httpClient *http.Client, modelConfig *v1alpha2.ModelConfig, apiKey string) ([]string, error) {
+	baseURL := defaultAnthropicBaseURL
+	if modelConfig.Spec.Anthropic != nil && modelConfig.Spec.Anthropic.BaseURL != "" {
+		baseURL = modelConfig.Spec.Anthropic.BaseURL
+	}
+	headers := map[string]string{
+		"x-api-key":         apiKey,
+		"anthropic-version": anthropicVersion,
+	}
+
+	var names []string
+	afterID := ""
+	for {
+		url := strings.TrimSuffix(baseURL, "/") + "/v1/models?limit=1000"
+		if afterID != "" {
+			url += "&after_id=" + afterID
+		}
+
+		var body struct {
+			Data []struct {
+				ID string `json:"id"`
+			} `json:"data"`
+			HasMore bool   `json:"has_more"`
+			LastID  string `json:"last_id"`
+		}
+		if err := getJSON(ctx, httpClient, url, headers, &body); err != nil {
+			return nil, err
+		}
+		for _, model := range body.Data {
+			names = append(names, model.ID)
+		}
+		if !body.HasMore || body.LastID == "" {
+			break
+		}
+		afterID = body.LastID
+	}
+	slices.Sort(names)
+	return names, nil
+}
+
+func listOllamaModels(ctx context.Context, httpClient *http.Client, modelConfig *v1alpha2.ModelConfig, _ string) ([]string, error) {
+	host := defaultOllamaHost
+	if modelConfig.Spec.Ollama != nil && modelConfig.Spec.Ollama.Host != "" {
+		host = modelConfig.Spec.Ollama.Host
+	}
+
+	var body struct {
+		Models []struct {
+			Name string `json:"name"`
+		} `json:"models"`
+	}
+	if err := getJSON(ctx, httpClient, strings.TrimSuffix(host, "/")+"/api/tags", nil, &body); err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(body.Models))
+	for _, model := range body.Models {
+		names = append(names, model.Name)
+	}
+	slices.Sort(names)
+	return names, nil
+}
+
+// listAzureOpenAIDeployments lists the deployments of the resource, which is what
+// Azure OpenAI requests name as the model.
+func listAzureOpenAIDeployments(ctx context.Context, httpClient *http.Client, modelConfig *v1alpha2.ModelConfig, apiKey string) ([]string, error) {
+	if modelConfig.Spec.AzureOpenAI == nil || modelConfig.Spec.AzureOpenAI.Endpoint == "" {
+		return nil, fmt.Errorf("azureOpenAI endpoint is required")
+	}
+	headers := map[string]string{}
+	if modelConfig.Spec.AzureOpenAI.AzureADToken != "" {
+		headers["Authorization"] = "Bearer " + modelConfig.Spec.AzureOpenAI.AzureADToken
+	} else {
+		headers["api-key"] = apiKey
+	}
+
+	var body struct {
+		Data []struct {
+			ID string `json:"id"`
+		} `json:"data"`
+	}
+	url := strings.TrimSuffix(modelConfig.Spec.AzureOpenAI.Endpoint, "/") + "/openai/deployments?api-version=" + azureDeploymentsAPIVersion
+	if err := getJSON(ctx, httpClient, url, headers, &body); err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(body.Data))
+	for _, deployment := range body.Data {
+		names = append(names, deployment.ID)
+	}
+	slices.Sort(names)
+	return names, nil
+}
+
+func listGeminiModels(ctx context.Context, httpClient *http.Client, _ *v1alpha2.ModelConfig, apiKey string) ([]string, error) {
+	headers := map[string]string{"x-goog-api-key": apiKey}
+
+	var names []string
+	pageToken := ""
+	for {
+		url := geminiBaseURL + "/models?pageSize=1000"
+		if pageToken != "" {
+			url += "&pageToken=" + pageToken
+		}
+
+		var body struct {
+			Models []struct {
+				Name string `json:"name"`
+			} `json:"models"`
+			NextPageToken string `json:"nextPageToken"`
+		}
+		err := getJSON(ctx, httpClient, url, headers, &body)
+		// the Gemini API rejects invalid API keys as bad requests
+		var statusErr *statusError
+		if errors.As(err, &statusErr) && statusErr.statusCode == http.StatusBadRequest {
+			return nil, errCredentialsRejected
+		}
+		if err != nil {
+			return nil, err
+		}
+		for _, model := range body.Models {
+			names = append(names, strings.TrimPrefix(model.Name, "models/"))
+		}
+		if body.NextPageToken == "" {
+			break
+		}
+		pageToken = body.NextPageToken
+	}
+	slices.Sort(names)
+	return names, nil
+}
+
+// checkVertexAIServiceAccount checks the service account key of the ModelConfig by getting an
+// access token for it. Vertex AI does not list the models of the publishers a project can use, so
+// no model is listed.
+func checkVertexAIServiceAccount(ctx context.Context, httpClient *http.Client, _ *v1alpha2.ModelConfig, key string) ([]string, error) {
+	var serviceAccount struct {
+		Type         string `json:"type"`
+		ClientEmail  string `json:"client_email"`
+		PrivateKey   string `json:"private_key"`
+		PrivateKeyID string `json:"private_key_id"`
+		TokenURI     string `json:"token_uri"`
+	}
+	if err := json.Unmarshal([]byte(key), &serviceAccount); err != nil {
+		return nil, fmt.Errorf("%w: the service account key is not JSON", errInvalidKey)
+	}
+	if serviceAccount.Type != "service_account" {
+		return nil, fmt.Errorf("%w: credentials of type %q are not a service account key", errInvalidKey, serviceAccount.Type)
+	}
+	if block, _ := pem.Decode([]byte(serviceAccount.PrivateKey)); block == nil {
+		return nil, fmt.Errorf("%w: the private key of the service account key is not PEM encoded", errInvalidKey)
+	}
+	config := &jwt.Config{
+		Email:        serviceAccount.ClientEmail,
+		PrivateKey:   []byte(serviceAccount.PrivateKey),
+		PrivateKeyID: serviceAccount.PrivateKeyID,
+		TokenURL:     serviceAccount.TokenURI,
+		Scopes:       []string{googleCloudPlatformScope},
+	}
+	if config.TokenURL == "" {
+		config.TokenURL = googleTokenURL
+	}
+
+	_, err := config.TokenSource(context.WithValue(ctx, oauth2.HTTPClient, httpClient)).Token()
+	var retrieveErr *oauth2.RetrieveError
+	if errors.As(err, &retrieveErr) && retrieveErr.Response != nil {
+		switch retrieveErr.Response.StatusCode {
+		case http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden:
+			return nil, errCredentialsRejected
+		}
+		return nil, &statusError{statusCode: retrieveErr.Response.StatusCode}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return nil, nil
+}
+
+// listBedrockModels lists the foundation models of the region with the Bedrock API key of the
+// ModelConfig.
+func listBedrockModels(ctx context.Context, httpClient *http.Client, modelConfig *v1alpha2.ModelConfig, apiKey string) ([]string, error) {
+	if modelConfig.Spec.Bedrock == nil || modelConfig.Spec.Bedrock.Region == "" {
+		return nil, fmt.Errorf("bedrock region is required")
+	}
+	headers := map[string]string{"Authorization": "Bearer " + apiKey}
+
+	var body struct {
+		ModelSummaries []struct {
+			ModelID string `json:"modelId"`
+		} `json:"modelSummaries"`
+	}
+	if err := getJSON(ctx, httpClient, bedrockBaseURL(modelConfig.Spec.Bedrock.Region)+"/foundation-models", headers, &body); err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(body.ModelSummaries))
+	for _, model := range body.ModelSummaries {
+		names = append(names, model.ModelID)
+	}
+	slices.Sort(names)
+	return names, nil
+}
+
+// statusError is returned when a provider responds with an unexpected status,
+// the body of the response is dropped as it may echo the credentials.
+type statusError struct {
+	statusCode int
+}
+
+func (e *statusError) Error() string {
+	return fmt.Sprintf("unexpected status %d", e.statusCode)
+}
+
+func getJSON(ctx context.Context, httpClient *http.Client, url string, headers map[string]string, out any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return &statusError{statusCode: resp.StatusCode}
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,120 @@
+package pushnotification
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+)
+
+// errBlockedAddress is returned for the notifications to addresses the controller must not reach
+var errBlockedAddress = errors.New("address not allowed")
+
+// internalNetworks are the ranges not reachable through the Internet, besides the loopback,
+// private, link-local and multicast ones known to the net package
+var internalNetworks = mustParseCIDRs("100.64.0.0/10", "192.0.0.0/24", "198.18.0.0/15")
+
+// addressPolicy keeps the push notifications from reaching the cluster or the cloud metadata
+// endpoints: they are sent to HTTP URLs whose host resolves to public addresses only, unless the
+// host or its addresses are allowed.
+type addressPolicy struct {
+	hosts    map[string]bool
+	networks []*net.IPNet
+}
+
+// newAddressPolicy creates a policy allowing the given hosts and CIDR ranges
+func newAddressPolicy(allowed []string) (*addressPolicy, error) {
+	policy := &addressPolicy{hosts: map[string]bool{}}
+	for _, entry := range allowed {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		if strings.Contains(entry, "/") {
+			_, network, err := net.ParseCIDR(entry)
+			if err != nil {
+				return nil, fmt.Errorf("invalid allowed push notification network %q: %w", entry, err)
+			}
+			policy.networks = append(policy.networks, network)
+			continue
+		}
+		policy.hosts[strings.ToLower(entry)] = true
+	}
+	return policy, nil
+}
+
+// checkURL checks that a notification URL is an absolute HTTP URL
+func (p *addressPolicy) checkURL(rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return fmt.Errorf("invalid push notification URL: %w", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("push notification URL scheme %q is not supported", u.Scheme)
+	}
+	if u.Hostname() == "" {
+		return fmt.Errorf("push notification URL %q has no host", rawURL)
+	}
+	return nil
+}
+
+// allowedIP returns whether notifications may be sent to an address
+func (p *addressPolicy) allowedIP(ip net.IP) bool {
+	for _, network := range p.networks {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	if ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() || ip.IsLinkLocalUnicast() ||
+		ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast() || ip.IsMulticast() {
+		return false
+	}
+	for _, network := range internalNetworks {
+		if network.Contains(ip) {
+			return false
+		}
+	}
+	return true
+}
+
+// dialContext resolves the host of a connection and dials the first allowed address, so that the
+// address checked is the address connected to
+func (p *addressPolicy) dialContext(dialer *net.Dialer) func(ctx context.Context, network, address string) (net.Conn, error) {
+	return func(ctx context.Context, network, address string) (net.Conn, error) {
+		host, port, err := net.SplitHostPort(address)
+		if err != nil {
+			return nil, err
+		}
+		if p.hosts[strings.ToLower(host)] {
+			return dialer.DialContext(ctx, network, address)
+		}
+
+		addrs, err := net.DefaultResolver.LookupIPAddr(ctx, host)
+		if err != nil {
+			return nil, err
+		}
+		for _, addr := range addrs {
+			if !p.allowedIP(addr.IP) {
+				return nil, fmt.Errorf("%w: %s resolves to %s", errBlockedAddress, host, addr.IP)
+			}
+		}
+		if len(addrs) == 0 {
+			return nil, fmt.Errorf("no address found for %s", host)
+		}
+		return dialer.DialContext(ctx, network, net.JoinHostPort(addrs[0].IP.String(), port))
+	}
+}
+
+func mustParseCIDRs(cidrs ...string) []*net.IPNet {
+	networks := make([]*net.IPNet, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			panic(err)
+		}
+		networks = append(networks, network)
+	}
+	return networks
+}
@@ -0,0 +1,248 @@
+// Package pushnotification delivers the A2A push notifications of the tasks of agents. The
+// notifications are enqueued in the database when a task changes state, and delivered by the
+// leader controller replica, so that they survive restarts of the controller.
+package pushnotification
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/kagent-dev/kagent/go/internal/database"
+	ctrllog "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"trpc.group/trpc-go/trpc-a2a-go/protocol"
+)
+
+const (
+	// NotificationTokenHeader carries the token of the push notification config, for the
+	// receiver to validate the notifications
+	NotificationTokenHeader = "X-A2A-Notification-Token"
+
+	pollInterval    = time.Second
+	batchSize       = 100
+	deliveryTimeout = 10 * time.Second
+	dialTimeout     = 5 * time.Second
+	baseBackoff     = time.Second
+	maxBackoff      = 10 * time.Minute
+	// deliveredRetention is the time delivered notifications are kept, and deadLetterRetention
+	// the time dead letters are kept for inspection. Both are deleted earlier with their task.
+	deliveredRetention  = 24 * time.Hour
+	deadLetterRetention = 7 * 24 * time.Hour
+)
+
+// Deliverer delivers the push notifications enqueued in the database, retrying failed deliveries
+// with an exponential backoff until they fail maxAttempts times, after which they are kept as
+// dead letters. Notifications are delivered at least once.
+type Deliverer struct {
+	dbClient    database.Client
+	httpClient  *http.Client
+	policy      *addressPolicy
+	signer      *Signer
+	maxAttempts int
+	now         func() time.Time
+}
+
+var _ manager.Runnable = (*Deliverer)(nil)
+var _ manager.LeaderElectionRunnable = (*Deliverer)(nil)
+
+// NewDeliverer creates a deliverer. Notifications are signed with the signer when it is not nil
+// and their config has no credentials of its own. They are not sent to loopback, private or
+// link-local addresses, unless their host or their address is in allowedHosts, which holds
+// host names and CIDR ranges. Redirects are not followed.
+func NewDeliverer(dbClient database.Client, signer *Signer, maxAttempts int, allowedHosts []string) (*Deliverer, error) {
+	policy, err := newAddressPolicy(allowedHosts)
+	if err != nil {
+		return nil, err
+	}
+	transport := &http.Transport{
+		DialContext:           policy.dialContext(&net.Dialer{Timeout: dialTimeout}),
+		TLSHandshakeTimeout:   dialTimeout,
+		ResponseHeaderTimeout: deliveryTimeout,
+		MaxIdleConnsPerHost:   2,
+		IdleConnTimeout:       90 * time.Second,
+	}
+	return &Deliverer{
+		dbClient: dbClient,
+		httpClient: &http.Client{
+			Timeout:   deliveryTimeout,
+			Transport: transport,
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		policy:      policy,
+		signer:      signer,
+		maxAttempts: maxAttempts,
+		now:         time.Now,
+	}, nil
+}
+
+// NeedLeaderElection makes only the leader replica deliver the notifications
+func (d *Deliverer) NeedLeaderElection() bool {
+	return true
+}
+
+func (d *Deliverer) Start(ctx context.Context) error {
+	log := ctrllog.FromContext(ctx).WithName("push-notification-deliverer")
+
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+	lastPrune := time.Time{}
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+
+		if err := d.deliverDue(ctx); err != nil {
+			log.Error(err, "Failed to deliver push notifications")
+		}
+		if now := d.now(); now.Sub(lastPrune) > time.Hour {
+			if err := d.dbClient.DeleteExpiredPushNotificationDeliveries(now.Add(-deliveredRetention), now.Add(-deadLetterRetention)); err != nil {
+				log.Error(err, "Failed to delete expired push notifications")
+			}
+			lastPrune = now
+		}
+	}
+}
+
+// deliverDue attempts to deliver the notifications due
+func (d *Deliverer) deliverDue(ctx context.Context) error {
+	log := ctrllog.FromContext(ctx).WithName("push-notification-deliverer")
+
+	deliveries, err := d.dbClient.ListDuePushNotificationDeliveries(d.now(), batchSize)
+	if err != nil {
+		return err
+	}
+	for i := range deliveries {
+		if ctx.Err() != nil {
+			return nil
+		}
+		delivery := &deliveries[i]
+		err := d.deliver(ctx, delivery)
+
+		now := d.now()
+		delivery.Attempts++
+		switch {
+		case err == nil:
+			delivery.DeliveredAt = &now
+			delivery.LastError = ""
+		case delivery.Attempts >= d.maxAttempts || isPermanent(err):
+			delivery.DeadLetteredAt = &now
+			delivery.LastError = err.Error()
+			log.Info("Push notification dead lettered", "taskID", delivery.TaskID, "attempts", delivery.Attempts, "error", err.Error())
+		default:
+			delivery.NextAttemptAt = now.Add(backoff(delivery.Attempts))
+			delivery.LastError = err.Error()
+			log.V(1).Info("Push notification delivery failed", "taskID", delivery.TaskID, "attempts", delivery.Attempts, "error", err.Error())
+		}
+		if err := d.dbClient.UpdatePushNotificationDelivery(delivery); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deliver posts the task of a delivery to the URL of its config
+func (d *Deliverer) deliver(ctx context.Context, delivery *database.PushNotificationDelivery) error {
+	var config protocol.TaskPushNotificationConfig
+	if err := json.Unmarshal([]byte(delivery.Config), &config); err != nil {
+		return permanentError{fmt.Errorf("failed to parse push notification config: %w", err)}
+	}
+	pushConfig := config.PushNotificationConfig
+	payload := []byte(delivery.Payload)
+	if err := d.policy.checkURL(pushConfig.URL); err != nil {
+		return permanentError{err}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, pushConfig.URL, bytes.NewReader(payload))
+	if err != nil {
+		return permanentError{fmt.Errorf("failed to create push notification request: %w", err)}
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if pushConfig.Token != "" {
+		req.Header.Set(NotificationTokenHeader, pushConfig.Token)
+	}
+	if authorization, err := d.authorization(pushConfig, payload); err != nil {
+		return err
+	} else if authorization != "" {
+		req.Header.Set("Authorization", authorization)
+	}
+
+	resp, err := d.httpClient.Do(req)
+	if err != nil {
+		err = fmt.Errorf("failed to send push notification: %w", err)
+		if errors.Is(err, errBlockedAddress) {
+			return permanentError{err}
+		}
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+
+	if resp.StatusCode >= 300 && resp.StatusCode < 400 {
+		return permanentError{fmt.Errorf("push notification redirected with status %s, redirects are not followed", resp.Status)}
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		err := fmt.Errorf("push notification failed with status %s", resp.Status)
+		// the receiver rejects the notification, retrying will not help
+		if resp.StatusCode >= 400 && resp.StatusCode < 500 &&
+			resp.StatusCode != http.StatusRequestTimeout && resp.StatusCode != http.StatusTooManyRequests {
+			return permanentError{err}
+		}
+		return err
+	}
+	return nil
+}
+
+// authorization returns the Authorization header of a notification: the credentials of the
+// bearer scheme of its config if any, else a JWT signing the notification.
+func (d *Deliverer) authorization(config protocol.PushNotificationConfig, payload []byte) (string, error) {
+	if authentication := config.Authentication; authentication != nil && authentication.Credentials != nil {
+		for _, scheme := range authentication.Schemes {
+			if strings.EqualFold(scheme, "bearer") {
+				return "Bearer " + *authentication.Credentials, nil
+			}
+		}
+	}
+	if d.signer == nil {
+		return "", nil
+	}
+	token, err := d.signer.Sign(payload)
+	if err != nil {
+		return "", err
+	}
+	return "Bearer " + token, nil
+}
+
+// backoff returns the delay before the next attempt after the given number of attempts
+func backoff(attempts int) time.Duration {
+	delay := baseBackoff
+	for i := 1; i < attempts && delay < maxBackoff; i++ {
+		delay *= 2
+	}
+	return min(delay, maxBackoff)
+}
+
+// permanentError is a delivery failure that retrying will not fix
+type permanentError struct {
+	error
+}
+
+func (e permanentError) Unwrap() error {
+	return e.error
+}
+
+func isPermanent(err error) bool {
+	_, ok := err.(permanentError)
+	return ok
+}
@@ -0,0 +1,220 @@
+package pushnotification
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/utils/ptr"
+	"trpc.group/trpc-go/trpc-a2a-go/protocol"
+
+	"github.com/kagent-dev/kagent/go/internal/database"
+	database_fake "github.com/kagent-dev/kagent/go/internal/database/fake"
+)
+
+type receivedNotification struct {
+	header http.Header
+	body   []byte
+}
+
+// newReceiver starts a server receiving push notifications, responding with the given statuses
+// in turn and with 200 once they are exhausted
+func newReceiver(t *testing.T, statuses ...int) (*httptest.Server, func() []receivedNotification) {
+	var lock sync.Mutex
+	var received []receivedNotification
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		lock.Lock()
+		defer lock.Unlock()
+		received = append(received, receivedNotification{header: r.Header.Clone(), body: body})
+		if len(statuses) > 0 {
+			w.WriteHeader(statuses[0])
+			statuses = statuses[1:]
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server, func() []receivedNotification {
+		lock.Lock()
+		defer lock.Unlock()
+		return received
+	}
+}
+
+func storeTaskWithConfig(t *testing.T, dbClient database.Client, config protocol.PushNotificationConfig, states ...protocol.TaskState) {
+	require.NoError(t, dbClient.StorePushNotification(&protocol.TaskPushNotificationConfig{TaskID: "task-1", PushNotificationConfig: config}))
+	for _, state := range states {
+		require.NoError(t, dbClient.StoreTask(&protocol.Task{ID: "task-1", ContextID: "context-1", Status: protocol.TaskStatus{State: state}}))
+	}
+}
+
+// newTestDeliverer creates a deliverer allowed to reach the receivers on the loopback address
+func newTestDeliverer(t *testing.T, dbClient database.Client, signer *Signer, maxAttempts int) *Deliverer {
+	deliverer, err := NewDeliverer(dbClient, signer, maxAttempts, []string{"127.0.0.0/8"})
+	require.NoError(t, err)
+	return deliverer
+}
+
+func TestDelivererDeliversTaskStates(t *testing.T) {
+	server, received := newReceiver(t)
+	dbClient := database_fake.NewClient()
+	storeTaskWithConfig(t, dbClient, protocol.PushNotificationConfig{
+		URL:            server.URL,
+		Token:          "token",
+		Authentication: &protocol.AuthenticationInfo{Schemes: []string{"Bearer"}, Credentials: ptr.To("secret")},
+	}, protocol.TaskStateWorking, protocol.TaskStateWorking, protocol.TaskStateCompleted)
+
+	deliverer := newTestDeliverer(t, dbClient, nil, 3)
+	require.NoError(t, deliverer.deliverDue(context.Background()))
+
+	// the task changed state twice
+	notifications := received()
+	require.Len(t, notifications, 2)
+	assert.Equal(t, "token", notifications[0].header.Get(NotificationTokenHeader))
+	assert.Equal(t, "Bearer secret", notifications[0].header.Get("Authorization"))
+	assert.Contains(t, string(notifications[1].body), `"state":"completed"`)
+
+	deliveries, err := dbClient.ListPushNotificationDeliveries("task-1")
+	require.NoError(t, err)
+	for _, delivery := range deliveries {
+		assert.NotNil(t, delivery.DeliveredAt)
+		assert.Equal(t, 1, delivery.Attempts)
+	}
+
+	// delivered notifications are not delivered again
+	require.NoError(t, deliverer.deliverDue(context.Background()))
+	assert.Len(t, received(), 2)
+}
+
+func TestDelivererRetriesAndDeadLetters(t *testing.T) {
+	server, received := newReceiver(t, http.StatusServiceUnavailable, http.StatusServiceUnavailable, http.StatusServiceUnavailable)
+	dbClient := database_fake.NewClient()
+	storeTaskWithConfig(t, dbClient, protocol.PushNotificationConfig{URL: server.URL}, protocol.TaskStateCompleted)
+
+	now := time.Now()
+	deliverer := newTestDeliverer(t, dbClient, nil, 2)
+	deliverer.now = func() time.Time { return now }
+
+	require.NoError(t, deliverer.deliverDue(context.Background()))
+	deliveries, err := dbClient.ListPushNotificationDeliveries("task-1")
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1)
+	assert.Equal(t, now.Add(baseBackoff), deliveries[0].NextAttemptAt)
+	assert.Contains(t, deliveries[0].LastError, "503")
+
+	// not due before the backoff
+	require.NoError(t, deliverer.deliverDue(context.Background()))
+	assert.Len(t, received(), 1)
+
+	now = now.Add(baseBackoff)
+	require.NoError(t, deliverer.deliverDue(context.Background()))
+	assert.Len(t, received(), 2)
+	deliveries, err = dbClient.ListPushNotificationDeliveries("task-1")
+	require.NoError(t, err)
+	assert.NotNil(t, deliveries[0].DeadLetteredAt)
+	assert.Nil(t, deliveries[0].DeliveredAt)
+
+	// dead letters are not delivered again
+	now = now.Add(time.Hour)
+	require.NoError(t, deliverer.deliverDue(context.Background()))
+	assert.Len(t, received(), 2)
+}
+
+func TestDelivererDeadLettersRejectedNotifications(t *testing.T) {
+	server, received := newReceiver(t, http.StatusUnauthorized)
+	dbClient := database_fake.NewClient()
+	storeTaskWithConfig(t, dbClient, protocol.PushNotificationConfig{URL: server.URL}, protocol.TaskStateCompleted)
+
+	deliverer := newTestDeliverer(t, dbClient, nil, 5)
+	require.NoError(t, deliverer.deliverDue(context.Background()))
+	assert.Len(t, received(), 1)
+
+	deliveries, err := dbClient.ListPushNotificationDeliveries("task-1")
+	require.NoError(t, err)
+	assert.NotNil(t, deliveries[0].DeadLetteredAt)
+	assert.Equal(t, 1, deliveries[0].Attempts)
+}
+
+func TestDelivererRefusesInternalAddresses(t *testing.T) {
+	server, received := newReceiver(t)
+	redirecting := httptest.NewServer(http.RedirectHandler(server.URL, http.StatusTemporaryRedirect))
+	defer redirecting.Close()
+
+	tests := []struct {
+		name         string
+		url          string
+		allowedHosts []string
+		wantErr      string
+	}{
+		{name: "loopback address", url: server.URL, wantErr: "address not allowed"},
+		{name: "metadata endpoint", url: "http://169.254.169.254/latest/meta-data", wantErr: "address not allowed"},
+		{name: "unsupported scheme", url: "file:///etc/passwd", allowedHosts: []string{"127.0.0.0/8"}, wantErr: `scheme "file" is not supported`},
+		{name: "redirect", url: redirecting.URL, allowedHosts: []string{"127.0.0.0/8"}, wantErr: "redirects are not followed"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dbClient := database_fake.NewClient()
+			storeTaskWithConfig(t, dbClient, protocol.PushNotificationConfig{URL: tt.url}, protocol.TaskStateCompleted)
+
+			deliverer, err := NewDeliverer(dbClient, nil, 5, tt.allowedHosts)
+			require.NoError(t, err)
+			require.NoError(t, deliverer.deliverDue(context.Background()))
+
+			// the notification is dead lettered without being retried
+			deliveries, err := dbClient.ListPushNotificationDeliveries("task-1")
+			require.NoError(t, err)
+			require.Len(t, deliveries, 1)
+			assert.NotNil(t, deliveries[0].DeadLetteredAt)
+			assert.Contains(t, deliveries[0].LastError, tt.wantErr)
+		})
+	}
+	assert.Empty(t, received())
+}
+
+func TestNewDelivererRejectsInvalidAllowedNetworks(t *testing.T) {
+	_, err := NewDeliverer(database_fake.NewClient(), nil, 3, []string{"10.0.0.0/33"})
+	assert.ErrorContains(t, err, "invalid allowed push notification network")
+}
+
+func TestDelivererSignsNotifications(t *testing.T) {
+	server, received := newReceiver(t)
+	dbClient := database_fake.NewClient()
+	storeTaskWithConfig(t, dbClient, protocol.PushNotificationConfig{URL: server.URL}, protocol.TaskStateCompleted)
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	deliverer := newTestDeliverer(t, dbClient, NewSigner(key), 3)
+	require.NoError(t, deliverer.deliverDue(context.Background()))
+
+	notifications := received()
+	require.Len(t, notifications, 1)
+	authorization := notifications[0].header.Get("Authorization")
+	require.NotEmpty(t, authorization)
+
+	claims := jwt.MapClaims{}
+	_, err = jwt.ParseWithClaims(authorization[len("Bearer "):], claims, func(token *jwt.Token) (any, error) {
+		return &key.PublicKey, nil
+	}, jwt.WithValidMethods([]string{"RS256"}))
+	require.NoError(t, err)
+	hash := sha256.Sum256(notifications[0].body)
+	assert.Equal(t, hex.EncodeToString(hash[:]), claims["request_body_sha256"])
+}
+
+func TestBackoff(t *testing.T) {
+	assert.Equal(t, time.Second, backoff(1))
+	assert.Equal(t, 2*time.Second, backoff(2))
+	assert.Equal(t, 8*time.Second, backoff(4))
+	assert.Equal(t, maxBackoff, backoff(30))
+}
@@ -0,0 +1,102 @@
+package pushnotification
+
+import (
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Signer signs the push notifications with a JWT carrying the SHA-256 hash of their body, as
+// verified by A2A clients with the keys published by the JWKS endpoint.
+type Signer struct {
+	key   *rsa.PrivateKey
+	keyID string
+	now   func() time.Time
+}
+
+// LoadSigner loads the PEM encoded RSA private key signing the push notifications. The key is
+// shared by all the controller replicas so that the notifications can be verified whichever
+// replica delivers them.
+func LoadSigner(path string) (*Signer, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read push notification signing key: %w", err)
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("failed to decode push notification signing key %s: no PEM data", path)
+	}
+
+	var key *rsa.PrivateKey
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "PRIVATE KEY":
+		var parsed any
+		parsed, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+		if rsaKey, ok := parsed.(*rsa.PrivateKey); ok {
+			key = rsaKey
+		} else if err == nil {
+			err = fmt.Errorf("unsupported key type %T", parsed)
+		}
+	default:
+		err = fmt.Errorf("unsupported PEM block %q", block.Type)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse push notification signing key %s: %w", path, err)
+	}
+	return NewSigner(key), nil
+}
+
+// NewSigner creates a signer signing with the key, identified by the hash of its public key
+func NewSigner(key *rsa.PrivateKey) *Signer {
+	hash := sha256.Sum256(x509.MarshalPKCS1PublicKey(&key.PublicKey))
+	return &Signer{
+		key:   key,
+		keyID: hex.EncodeToString(hash[:8]),
+		now:   time.Now,
+	}
+}
+
+// Sign returns the JWT authenticating a push notification with the given body
+func (s *Signer) Sign(payload []byte) (string, error) {
+	hash := sha256.Sum256(payload)
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+		"iat":                 s.now().Unix(),
+		"request_body_sha256": hex.EncodeToString(hash[:]),
+	})
+	token.Header["kid"] = s.keyID
+	signed, err := token.SignedString(s.key)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign push notification: %w", err)
+	}
+	return signed, nil
+}
+
+// HandleJWKS serves the public key of the signer as a JSON Web Key Set
+func (s *Signer) HandleJWKS(w http.ResponseWriter, r *http.Request) {
+	encode := func(b []byte) string { return base64.RawURLEncoding.EncodeToString(b) }
+	jwks := map[string]any{
+		"keys": []map[string]string{{
+			"kty": "RSA",
+			"use": "sig",
+			"alg": "RS256",
+			"kid": s.keyID,
+			"n":   encode(s.key.N.Bytes()),
+			"e":   encode(big.NewInt(int64(s.key.E)).Bytes()),
+		}},
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(jwks)
+}
@@ -0,0 +1,62 @@
+package pushnotification
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeKey(t *testing.T, block *pem.Block) string {
+	path := filepath.Join(t.TempDir(), "key.pem")
+	require.NoError(t, os.WriteFile(path, pem.EncodeToMemory(block), 0o600))
+	return path
+}
+
+func TestLoadSigner(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	pkcs8, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+
+	pkcs1Signer, err := LoadSigner(writeKey(t, &pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}))
+	require.NoError(t, err)
+	pkcs8Signer, err := LoadSigner(writeKey(t, &pem.Block{Type: "PRIVATE KEY", Bytes: pkcs8}))
+	require.NoError(t, err)
+	// replicas loading the same key publish the same key ID
+	assert.Equal(t, pkcs1Signer.keyID, pkcs8Signer.keyID)
+
+	_, err = LoadSigner(writeKey(t, &pem.Block{Type: "CERTIFICATE", Bytes: []byte("not a key")}))
+	assert.ErrorContains(t, err, `unsupported PEM block "CERTIFICATE"`)
+
+	_, err = LoadSigner(filepath.Join(t.TempDir(), "missing.pem"))
+	assert.ErrorContains(t, err, "failed to read push notification signing key")
+}
+
+func TestSignerHandleJWKS(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	signer := NewSigner(key)
+
+	recorder := httptest.NewRecorder()
+	signer.HandleJWKS(recorder, httptest.NewRequest(http.MethodGet, "/api/push-notifications/jwks.json", nil))
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	var jwks struct {
+		Keys []map[string]string `json:"keys"`
+	}
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &jwks))
+	require.Len(t, jwks.Keys, 1)
+	assert.Equal(t, signer.keyID, jwks.Keys[0]["kid"])
+	assert.Equal(t, "RS256", jwks.Keys[0]["alg"])
+	assert.Equal(t, "AQAB", jwks.Keys[0]["e"])
+}
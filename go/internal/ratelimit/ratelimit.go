@@ -0,0 +1,200 @@
+// Package ratelimit enforces the rate limits of agents for each of their users. Its state is
+// kept in the database so that the limits hold across the controller replicas.
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync/atomic"
+	"time"
+
+	"github.com/kagent-dev/kagent/go/api/v1alpha2"
+	"github.com/kagent-dev/kagent/go/internal/database"
+)
+
+const (
+	LimitRequestsPerMinute = "requestsPerMinute"
+	LimitConcurrentTasks   = "concurrentTasks"
+	LimitDailyTokenBudget  = "dailyTokenBudget"
+
+	// concurrencyRetryAfter is the time after which a request rejected by the concurrency limit
+	// is suggested to be retried, as when the tasks of the user complete is unknown
+	concurrencyRetryAfter = 5 * time.Second
+	// concurrencyLeaseTTL bounds the time a concurrent slot is held by a replica going away
+	// before releasing it, or by a task whose agent does not store its states
+	concurrencyLeaseTTL = 15 * time.Minute
+)
+
+// LimitExceededError is returned when a request exceeds one of the rate limits of an agent
+type LimitExceededError struct {
+	// Limit is the name of the limit exceeded, e.g. requestsPerMinute
+	Limit string
+	// RetryAfter is the time after which the request may be admitted
+	RetryAfter time.Duration
+}
+
+func (e *LimitExceededError) Error() string {
+	return fmt.Sprintf("rate limit %s exceeded, retry after %s", e.Limit, e.RetryAfter)
+}
+
+// RetryAfterSeconds is the value of the Retry-After header for the error, rounded up to the second
+func (e *LimitExceededError) RetryAfterSeconds() int64 {
+	return int64(math.Ceil(e.RetryAfter.Seconds()))
+}
+
+// Limiter enforces the rate limits of agents. Agents are identified by their ID in the
+// database, and users by the ID of their principal.
+type Limiter struct {
+	dbClient database.Client
+	now      func() time.Time
+}
+
+func NewLimiter(dbClient database.Client) *Limiter {
+	return &Limiter{
+		dbClient: dbClient,
+		now:      time.Now,
+	}
+}
+
+// Lease is the concurrent slot taken by a request. It is released when the request completes,
+// unless the request started a task the agent keeps working on, which then holds the slot.
+type Lease struct {
+	dbClient database.Client
+	id       uint
+	held     atomic.Bool
+}
+
+// HoldForTask makes the task started by the request hold the slot until the task is stored in a
+// state the agent stopped working on it in, until Complete is called, or until the lease expires.
+func (l *Lease) HoldForTask(taskID string) error {
+	if l == nil || taskID == "" {
+		return nil
+	}
+	if err := l.dbClient.BindConcurrencyLease(l.id, taskID, concurrencyLeaseTTL); err != nil {
+		return err
+	}
+	l.held.Store(true)
+	return nil
+}
+
+// Release releases the slot when the request completes, unless it is held by a task. It is a
+// no-op on a nil lease.
+func (l *Lease) Release() {
+	if l == nil || l.held.Load() {
+		return
+	}
+	// an unreleased lease expires
+	_ = l.dbClient.ReleaseConcurrencyLease(l.id)
+}
+
+// Complete releases the slot once the agent stopped working on the task holding it, for the
+// agents that do not store their tasks. It is a no-op on a nil lease.
+func (l *Lease) Complete() {
+	if l == nil {
+		return
+	}
+	_ = l.dbClient.ReleaseConcurrencyLease(l.id)
+}
+
+type leaseKey struct{}
+
+// WithLease returns a context carrying the lease of a request
+func WithLease(ctx context.Context, lease *Lease) context.Context {
+	return context.WithValue(ctx, leaseKey{}, lease)
+}
+
+// LeaseFrom returns the lease of a request, nil if it holds none
+func LeaseFrom(ctx context.Context) *Lease {
+	lease, _ := ctx.Value(leaseKey{}).(*Lease)
+	return lease
+}
+
+// Admit admits a request of a user to an agent if it is within all the limits. The returned
+// lease holds the concurrent slot taken by the request, nil if the agent has no concurrency
+// limit, and must be released when the request completes. A *LimitExceededError is returned
+// when the request is rejected.
+func (l *Limiter) Admit(agentID, userID string, limits *v1alpha2.RateLimits) (*Lease, error) {
+	if limits == nil {
+		return nil, nil
+	}
+
+	if err := l.CheckBudget(agentID, userID, limits); err != nil {
+		return nil, err
+	}
+
+	// the concurrent slot is taken first, so that the requests it rejects are not counted
+	// against the requests per minute
+	var lease *Lease
+	if limits.ConcurrentTasks != nil {
+		acquired, err := l.dbClient.AcquireConcurrencyLease(
+			key(LimitConcurrentTasks, agentID, userID),
+			int64(*limits.ConcurrentTasks),
+			concurrencyLeaseTTL,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if acquired == nil {
+			return nil, &LimitExceededError{
+				Limit:      LimitConcurrentTasks,
+				RetryAfter: concurrencyRetryAfter,
+			}
+		}
+		lease = &Lease{dbClient: l.dbClient, id: acquired.ID}
+	}
+
+	if limits.RequestsPerMinute != nil {
+		now := l.now().UTC()
+		windowStart := now.Truncate(time.Minute)
+		counted, err := l.dbClient.IncrementRateLimitCounter(key(LimitRequestsPerMinute, agentID, userID), windowStart, int64(*limits.RequestsPerMinute))
+		if err != nil {
+			lease.Release()
+			return nil, err
+		}
+		if !counted {
+			lease.Release()
+			return nil, &LimitExceededError{
+				Limit:      LimitRequestsPerMinute,
+				RetryAfter: windowStart.Add(time.Minute).Sub(now),
+			}
+		}
+	}
+
+	return lease, nil
+}
+
+// CheckBudget checks that a user has not used the daily token budget of an agent. It returns a
+// *LimitExceededError retrying after the next midnight UTC when the budget is used.
+func (l *Limiter) CheckBudget(agentID, userID string, limits *v1alpha2.RateLimits) error {
+	if limits == nil || limits.DailyTokenBudget == nil {
+		return nil
+	}
+
+	now := l.now().UTC()
+	dayStart := now.Truncate(24 * time.Hour)
+	summaries, err := l.dbClient.AggregateUsage(database.UsageQuery{
+		From:    dayStart,
+		AgentID: agentID,
+		UserID:  userID,
+	})
+	if err != nil {
+		return err
+	}
+
+	var used int64
+	for _, summary := range summaries {
+		used += summary.InputTokens + summary.OutputTokens
+	}
+	if used >= *limits.DailyTokenBudget {
+		return &LimitExceededError{
+			Limit:      LimitDailyTokenBudget,
+			RetryAfter: dayStart.Add(24 * time.Hour).Sub(now),
+		}
+	}
+	return nil
+}
+
+func key(limit, agentID, userID string) string {
+	return fmt.Sprintf("%s:%s:%s", limit, agentID, userID)
+}
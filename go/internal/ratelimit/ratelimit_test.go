@@ -0,0 +1,163 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/utils/ptr"
+	"trpc.group/trpc-go/trpc-a2a-go/protocol"
+
+	"github.com/kagent-dev/kagent/go/api/v1alpha2"
+	"github.com/kagent-dev/kagent/go/internal/database"
+	database_fake "github.com/kagent-dev/kagent/go/internal/database/fake"
+)
+
+const agentID = "default__NS__test_agent"
+
+func newTestLimiter(now time.Time) (*Limiter, database.Client) {
+	dbClient := database_fake.NewClient()
+	limiter := NewLimiter(dbClient)
+	limiter.now = func() time.Time { return now }
+	return limiter, dbClient
+}
+
+func requireLimitExceeded(t *testing.T, err error, limit string, retryAfter time.Duration) {
+	t.Helper()
+	var limitErr *LimitExceededError
+	require.ErrorAs(t, err, &limitErr)
+	assert.Equal(t, limit, limitErr.Limit)
+	assert.Equal(t, retryAfter, limitErr.RetryAfter)
+}
+
+func TestAdmitRequestsPerMinute(t *testing.T) {
+	now := time.Date(2025, time.March, 1, 12, 30, 45, 0, time.UTC)
+	limiter, _ := newTestLimiter(now)
+	limits := &v1alpha2.RateLimits{RequestsPerMinute: ptr.To[int32](2)}
+
+	for range 2 {
+		lease, err := limiter.Admit(agentID, "alice", limits)
+		require.NoError(t, err)
+		lease.Release()
+	}
+	_, err := limiter.Admit(agentID, "alice", limits)
+	requireLimitExceeded(t, err, LimitRequestsPerMinute, 15*time.Second)
+
+	// users are limited separately
+	_, err = limiter.Admit(agentID, "bob", limits)
+	require.NoError(t, err)
+
+	// the next minute starts a new window
+	limiter.now = func() time.Time { return now.Add(15 * time.Second) }
+	_, err = limiter.Admit(agentID, "alice", limits)
+	require.NoError(t, err)
+}
+
+func TestAdmitConcurrentTasks(t *testing.T) {
+	limiter, _ := newTestLimiter(time.Now())
+	limits := &v1alpha2.RateLimits{ConcurrentTasks: ptr.To[int32](1)}
+
+	lease, err := limiter.Admit(agentID, "alice", limits)
+	require.NoError(t, err)
+	_, err = limiter.Admit(agentID, "alice", limits)
+	requireLimitExceeded(t, err, LimitConcurrentTasks, concurrencyRetryAfter)
+
+	lease.Release()
+	_, err = limiter.Admit(agentID, "alice", limits)
+	require.NoError(t, err)
+}
+
+func TestAdmitCountsAdmittedRequestsOnly(t *testing.T) {
+	limiter, _ := newTestLimiter(time.Date(2025, time.March, 1, 12, 30, 45, 0, time.UTC))
+	limits := &v1alpha2.RateLimits{RequestsPerMinute: ptr.To[int32](2), ConcurrentTasks: ptr.To[int32](1)}
+
+	lease, err := limiter.Admit(agentID, "alice", limits)
+	require.NoError(t, err)
+
+	// the retries rejected by the concurrency limit do not use up the requests per minute
+	for range 3 {
+		_, err = limiter.Admit(agentID, "alice", limits)
+		requireLimitExceeded(t, err, LimitConcurrentTasks, concurrencyRetryAfter)
+	}
+	lease.Release()
+
+	lease, err = limiter.Admit(agentID, "alice", limits)
+	require.NoError(t, err)
+	lease.Release()
+
+	// the request rejected by the requests per minute releases its concurrent slot
+	_, err = limiter.Admit(agentID, "alice", limits)
+	requireLimitExceeded(t, err, LimitRequestsPerMinute, 15*time.Second)
+	limits.RequestsPerMinute = nil
+	_, err = limiter.Admit(agentID, "alice", limits)
+	require.NoError(t, err)
+}
+
+func TestLeaseHeldForTask(t *testing.T) {
+	limiter, dbClient := newTestLimiter(time.Now())
+	limits := &v1alpha2.RateLimits{ConcurrentTasks: ptr.To[int32](1)}
+
+	lease, err := limiter.Admit(agentID, "alice", limits)
+	require.NoError(t, err)
+	require.NoError(t, lease.HoldForTask("task-1"))
+
+	// the task still in progress holds the slot once the request completed
+	lease.Release()
+	_, err = limiter.Admit(agentID, "alice", limits)
+	requireLimitExceeded(t, err, LimitConcurrentTasks, concurrencyRetryAfter)
+
+	require.NoError(t, dbClient.StoreTask(&protocol.Task{ID: "task-1", Status: protocol.TaskStatus{State: protocol.TaskStateWorking}}))
+	_, err = limiter.Admit(agentID, "alice", limits)
+	requireLimitExceeded(t, err, LimitConcurrentTasks, concurrencyRetryAfter)
+
+	// until the agent stops working on it
+	require.NoError(t, dbClient.StoreTask(&protocol.Task{ID: "task-1", Status: protocol.TaskStatus{State: protocol.TaskStateCompleted}}))
+	lease, err = limiter.Admit(agentID, "alice", limits)
+	require.NoError(t, err)
+
+	// or the task is observed to complete for the agents not storing their tasks
+	require.NoError(t, lease.HoldForTask("task-2"))
+	lease.Complete()
+	_, err = limiter.Admit(agentID, "alice", limits)
+	require.NoError(t, err)
+
+	// requests without a concurrency limit hold no lease
+	lease, err = limiter.Admit(agentID, "alice", &v1alpha2.RateLimits{})
+	require.NoError(t, err)
+	assert.Nil(t, lease)
+	require.NoError(t, lease.HoldForTask("task-3"))
+	lease.Release()
+}
+
+func TestCheckBudget(t *testing.T) {
+	now := time.Date(2025, time.March, 1, 18, 0, 0, 0, time.UTC)
+	limiter, dbClient := newTestLimiter(now)
+	limits := &v1alpha2.RateLimits{DailyTokenBudget: ptr.To[int64](1000)}
+
+	for _, usage := range []*database.Usage{
+		// yesterday
+		{EventID: "event-1", AgentID: agentID, UserID: "alice", InputTokens: 5000, CreatedAt: now.Add(-20 * time.Hour)},
+		{EventID: "event-2", AgentID: agentID, UserID: "alice", InputTokens: 600, OutputTokens: 300, CreatedAt: now.Add(-time.Hour)},
+		{EventID: "event-3", AgentID: agentID, UserID: "bob", InputTokens: 5000, CreatedAt: now.Add(-time.Hour)},
+	} {
+		_, err := dbClient.StoreUsage(usage)
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, limiter.CheckBudget(agentID, "alice", limits))
+	require.NoError(t, limiter.CheckBudget(agentID, "alice", nil))
+
+	_, err := dbClient.StoreUsage(&database.Usage{EventID: "event-4", AgentID: agentID, UserID: "alice", OutputTokens: 100, CreatedAt: now})
+	require.NoError(t, err)
+	err = limiter.CheckBudget(agentID, "alice", limits)
+	requireLimitExceeded(t, err, LimitDailyTokenBudget, 6*time.Hour)
+
+	_, err = limiter.Admit(agentID, "alice", limits)
+	requireLimitExceeded(t, err, LimitDailyTokenBudget, 6*time.Hour)
+}
+
+func TestLimitExceededErrorRetryAfterSeconds(t *testing.T) {
+	err := &LimitExceededError{Limit: LimitRequestsPerMinute, RetryAfter: 1500 * time.Millisecond}
+	assert.Equal(t, int64(2), err.RetryAfterSeconds())
+}
@@ -0,0 +1,239 @@
+// Package remoteagent connects the controller to the A2A agents imported with RemoteAgent
+// resources, authenticating the requests with the headers and OAuth client credentials of the
+// resource.
+package remoteagent
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+	"sync"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"trpc.group/trpc-go/trpc-a2a-go/protocol"
+	"trpc.group/trpc-go/trpc-a2a-go/server"
+
+	"github.com/kagent-dev/kagent/go/api/v1alpha2"
+	"github.com/kagent-dev/kagent/go/internal/egress"
+)
+
+// maxAgentCardBytes bounds the size of the agent cards read from remote agents
+const maxAgentCardBytes = 1 << 20
+
+// HTTPClient returns the client for the requests to a remote agent, honouring its TLS and proxy
+// configuration and adding its headers and OAuth tokens to the requests. The requests are only
+// sent to the origin of the URL of the agent, so that an agent card cannot redirect the
+// credentials elsewhere. The referenced Secrets and ConfigMaps are read again for every request,
+// through the cache of the client, so that rotated credentials are used without a new client.
+// The tokens are requested through the same TLS and proxy configuration, and renewed when they
+// expire.
+func HTTPClient(ctx context.Context, kube client.Client, agent *v1alpha2.RemoteAgent) (*http.Client, error) {
+	origin, err := url.Parse(agent.Spec.URL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid url %q: %w", agent.Spec.URL, err)
+	}
+	transport := &credentialsTransport{
+		kube:      kube,
+		namespace: agent.Namespace,
+		spec:      *agent.Spec.DeepCopy(),
+		origin:    origin,
+	}
+	// the credentials are checked upfront, to report them as not resolvable
+	if _, err := transport.resolve(ctx); err != nil {
+		return nil, err
+	}
+	return &http.Client{Transport: transport}, nil
+}
+
+// CheckEndpoint checks that the A2A endpoint advertised by the agent card of a remote agent has
+// the origin of the URL of the agent, the credentials of the agent are not sent anywhere else
+func CheckEndpoint(spec *v1alpha2.RemoteAgentSpec, endpoint string) error {
+	origin, err := url.Parse(spec.URL)
+	if err != nil {
+		return fmt.Errorf("invalid url %q: %w", spec.URL, err)
+	}
+	target, err := url.Parse(endpoint)
+	if err != nil {
+		return fmt.Errorf("invalid url %q: %w", endpoint, err)
+	}
+	if !sameOrigin(origin, target) {
+		return fmt.Errorf("url %q does not have the origin of the agent url %q", endpoint, spec.URL)
+	}
+	return nil
+}
+
+// FetchAgentCard fetches and validates the agent card of a remote agent
+func FetchAgentCard(ctx context.Context, httpClient *http.Client, spec *v1alpha2.RemoteAgentSpec) (*server.AgentCard, error) {
+	cardPath := spec.AgentCardPath
+	if cardPath == "" {
+		cardPath = protocol.AgentCardPath
+	}
+	cardURL := strings.TrimSuffix(spec.URL, "/") + "/" + strings.TrimPrefix(cardPath, "/")
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, cardURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("invalid agent card URL %s: %w", cardURL, err)
+	}
+	req.Header.Set("Accept", "application/json")
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch agent card from %s: %w", cardURL, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch agent card from %s: status %d", cardURL, resp.StatusCode)
+	}
+
+	var card server.AgentCard
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxAgentCardBytes)).Decode(&card); err != nil {
+		return nil, fmt.Errorf("invalid agent card at %s: %w", cardURL, err)
+	}
+	if err := ValidateAgentCard(&card); err != nil {
+		return nil, fmt.Errorf("invalid agent card at %s: %w", cardURL, err)
+	}
+	if err := CheckEndpoint(spec, card.URL); err != nil {
+		return nil, fmt.Errorf("invalid agent card at %s: %w", cardURL, err)
+	}
+	return &card, nil
+}
+
+// ValidateAgentCard checks that an agent card has what the controller needs to serve the agent
+func ValidateAgentCard(card *server.AgentCard) error {
+	if card.Name == "" {
+		return fmt.Errorf("name is required")
+	}
+	if card.URL == "" {
+		return fmt.Errorf("url is required")
+	}
+	endpoint, err := url.Parse(card.URL)
+	if err != nil {
+		return fmt.Errorf("invalid url %q: %w", card.URL, err)
+	}
+	if endpoint.Scheme != "http" && endpoint.Scheme != "https" || endpoint.Host == "" {
+		return fmt.Errorf("url %q is not an absolute HTTP URL", card.URL)
+	}
+	if card.PreferredTransport != nil && *card.PreferredTransport != "" && !strings.EqualFold(*card.PreferredTransport, "JSONRPC") {
+		return fmt.Errorf("preferred transport %s is not supported, only JSONRPC is", *card.PreferredTransport)
+	}
+	for i, skill := range card.Skills {
+		if skill.ID == "" || skill.Name == "" {
+			return fmt.Errorf("skill %d must have an id and a name", i)
+		}
+	}
+	return nil
+}
+
+// credentialsTransport resolves the TLS and proxy configuration, the headers and the OAuth
+// client secret of a remote agent for every request. The underlying transport and the token
+// source are only created again when the values they are created from change.
+type credentialsTransport struct {
+	kube      client.Client
+	namespace string
+	spec      v1alpha2.RemoteAgentSpec
+	origin    *url.URL
+
+	mu           sync.Mutex
+	egress       *egress.Config
+	base         http.RoundTripper
+	clientSecret string
+	tokenSource  oauth2.TokenSource
+}
+
+// credentials are what a request to the agent is sent with
+type credentials struct {
+	base        http.RoundTripper
+	headers     map[string]string
+	tokenSource oauth2.TokenSource
+}
+
+func (t *credentialsTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !sameOrigin(t.origin, req.URL) {
+		return nil, fmt.Errorf("request to %s refused, the credentials of the agent are only sent to %s://%s", req.URL.Redacted(), t.origin.Scheme, t.origin.Host)
+	}
+	creds, err := t.resolve(req.Context())
+	if err != nil {
+		return nil, err
+	}
+
+	req = req.Clone(req.Context())
+	for name, value := range creds.headers {
+		req.Header.Set(name, value)
+	}
+	if creds.tokenSource != nil {
+		token, err := creds.tokenSource.Token()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get OAuth token: %w", err)
+		}
+		token.SetAuthHeader(req)
+	}
+	return creds.base.RoundTrip(req)
+}
+
+// resolve reads the current credentials of the agent
+func (t *credentialsTransport) resolve(ctx context.Context) (*credentials, error) {
+	config, err := egress.Resolve(ctx, t.kube, t.namespace, t.spec.TLS, t.spec.Proxy)
+	if err != nil {
+		return nil, err
+	}
+	headers, err := t.spec.ResolveHeaders(ctx, t.kube, t.namespace)
+	if err != nil {
+		return nil, err
+	}
+	var clientSecret string
+	if oauth := t.spec.OAuth; oauth != nil {
+		if clientSecret, err = oauth.ClientSecretFrom.Resolve(ctx, t.kube, t.namespace); err != nil {
+			return nil, fmt.Errorf("failed to resolve OAuth client secret: %w", err)
+		}
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.base == nil || !reflect.DeepEqual(config, t.egress) {
+		base, err := config.HTTPClient()
+		if err != nil {
+			return nil, err
+		}
+		t.egress, t.base, t.tokenSource = config, base.Transport, nil
+		if t.base == nil {
+			t.base = http.DefaultTransport
+		}
+	}
+	if t.spec.OAuth != nil && (t.tokenSource == nil || clientSecret != t.clientSecret) {
+		t.clientSecret = clientSecret
+		t.tokenSource = t.newTokenSource(clientSecret)
+	}
+	return &credentials{base: t.base, headers: headers, tokenSource: t.tokenSource}, nil
+}
+
+// newTokenSource returns the source of the tokens of the client credentials flow, requested
+// through the base transport
+func (t *credentialsTransport) newTokenSource(clientSecret string) oauth2.TokenSource {
+	oauth := t.spec.OAuth
+	config := &clientcredentials.Config{
+		ClientID:     oauth.ClientID,
+		ClientSecret: clientSecret,
+		TokenURL:     oauth.TokenURL,
+		Scopes:       oauth.Scopes,
+	}
+	if len(oauth.EndpointParams) > 0 {
+		config.EndpointParams = url.Values{}
+		for name, value := range oauth.EndpointParams {
+			config.EndpointParams.Set(name, value)
+		}
+	}
+	// the token source outlives the context of the request that created it
+	tokenCtx := context.WithValue(context.Background(), oauth2.HTTPClient, &http.Client{Transport: t.base})
+	return config.TokenSource(tokenCtx)
+}
+
+func sameOrigin(a, b *url.URL) bool {
+	return strings.EqualFold(a.Scheme, b.Scheme) && strings.EqualFold(a.Host, b.Host)
+}
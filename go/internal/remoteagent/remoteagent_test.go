@@ -0,0 +1,163 @@
+package remoteagent
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"trpc.group/trpc-go/trpc-a2a-go/server"
+
+	"github.com/kagent-dev/kagent/go/api/v1alpha2"
+)
+
+func TestHTTPClient(t *testing.T) {
+	tokenRequests := 0
+	// the credentials the agent currently accepts
+	wantClientSecret, wantAPIKey := "secret", "key"
+	mux := http.NewServeMux()
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		tokenRequests++
+		require.NoError(t, r.ParseForm())
+		clientID, clientSecret, _ := r.BasicAuth()
+		if clientID != "client" || clientSecret != wantClientSecret || r.Form.Get("audience") != "agents" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{"access_token": "token-" + clientSecret, "token_type": "Bearer", "expires_in": 3600})
+	})
+	mux.HandleFunc("/agent/.well-known/agent-card.json", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token-"+wantClientSecret || r.Header.Get("X-Api-Key") != wantAPIKey {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(server.AgentCard{
+			Name:   "remote",
+			URL:    "http://" + r.Host + "/agent",
+			Skills: []server.AgentSkill{{ID: "search", Name: "Search"}},
+		})
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "credentials", Namespace: "test"},
+		Data:       map[string][]byte{"clientSecret": []byte("secret"), "apiKey": []byte("key")},
+	}
+	kube := fake.NewClientBuilder().WithScheme(scheme).WithObjects(secret).Build()
+
+	agent := &v1alpha2.RemoteAgent{
+		ObjectMeta: metav1.ObjectMeta{Name: "remote", Namespace: "test"},
+		Spec: v1alpha2.RemoteAgentSpec{
+			URL: srv.URL + "/agent/",
+			HeadersFrom: []v1alpha2.ValueRef{{
+				Name:      "X-API-Key",
+				ValueFrom: &v1alpha2.ValueSource{Type: v1alpha2.SecretValueSource, Name: "credentials", Key: "apiKey"},
+			}},
+			OAuth: &v1alpha2.OAuthClientCredentials{
+				TokenURL:         srv.URL + "/token",
+				ClientID:         "client",
+				ClientSecretFrom: v1alpha2.ValueSource{Type: v1alpha2.SecretValueSource, Name: "credentials", Key: "clientSecret"},
+				EndpointParams:   map[string]string{"audience": "agents"},
+			},
+		},
+	}
+
+	httpClient, err := HTTPClient(context.Background(), kube, agent)
+	require.NoError(t, err)
+
+	card, err := FetchAgentCard(context.Background(), httpClient, &agent.Spec)
+	require.NoError(t, err)
+	assert.Equal(t, "remote", card.Name)
+	assert.Equal(t, srv.URL+"/agent", card.URL)
+
+	// the token is reused until it expires
+	_, err = FetchAgentCard(context.Background(), httpClient, &agent.Spec)
+	require.NoError(t, err)
+	assert.Equal(t, 1, tokenRequests)
+
+	// the requests are rejected without the headers
+	_, err = FetchAgentCard(context.Background(), http.DefaultClient, &agent.Spec)
+	assert.ErrorContains(t, err, "status 401")
+
+	// rotated credentials are used by the same client
+	wantClientSecret, wantAPIKey = "secret-2", "key-2"
+	secret.Data = map[string][]byte{"clientSecret": []byte("secret-2"), "apiKey": []byte("key-2")}
+	require.NoError(t, kube.Update(context.Background(), secret))
+	_, err = FetchAgentCard(context.Background(), httpClient, &agent.Spec)
+	require.NoError(t, err)
+	assert.Equal(t, 2, tokenRequests)
+
+	// the credentials are not sent to other origins
+	resp, err := httpClient.Get("http://agents.example.com/agent")
+	if err == nil {
+		resp.Body.Close()
+	}
+	assert.ErrorContains(t, err, "refused")
+}
+
+func TestFetchAgentCardRejectsOtherOrigins(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(server.AgentCard{Name: "remote", URL: "https://attacker.example.com/a2a"})
+	}))
+	defer srv.Close()
+
+	_, err := FetchAgentCard(context.Background(), srv.Client(), &v1alpha2.RemoteAgentSpec{URL: srv.URL})
+	assert.ErrorContains(t, err, "does not have the origin of the agent url")
+}
+
+func TestValidateAgentCard(t *testing.T) {
+	tests := []struct {
+		name    string
+		card    server.AgentCard
+		wantErr string
+	}{
+		{
+			name: "valid",
+			card: server.AgentCard{Name: "agent", URL: "https://agents.example.com/a2a", PreferredTransport: ptr.To("JSONRPC")},
+		},
+		{
+			name:    "no name",
+			card:    server.AgentCard{URL: "https://agents.example.com/a2a"},
+			wantErr: "name is required",
+		},
+		{
+			name:    "relative url",
+			card:    server.AgentCard{Name: "agent", URL: "/a2a"},
+			wantErr: "not an absolute HTTP URL",
+		},
+		{
+			name:    "unsupported transport",
+			card:    server.AgentCard{Name: "agent", URL: "https://agents.example.com/a2a", PreferredTransport: ptr.To("GRPC")},
+			wantErr: "preferred transport GRPC is not supported",
+		},
+		{
+			name:    "skill without id",
+			card:    server.AgentCard{Name: "agent", URL: "https://agents.example.com/a2a", Skills: []server.AgentSkill{{Name: "Search"}}},
+			wantErr: "skill 0 must have an id and a name",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateAgentCard(&tt.card)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package usage
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+)
+
+// Price is the price of a model per million tokens.
+type Price struct {
+	Input  float64 `json:"input"`
+	Output float64 `json:"output"`
+	// CachedInput is the price of input tokens read from the prompt cache, defaults to the input price
+	CachedInput *float64 `json:"cachedInput,omitempty"`
+}
+
+// PriceTable maps model names to their price. Models prefixed with their provider, as in
+// "anthropic/claude-sonnet-4", are priced by their name when the table has no entry for the
+// prefixed name.
+type PriceTable map[string]Price
+
+// LoadPriceTable reads a price table from a YAML or JSON file.
+func LoadPriceTable(path string) (PriceTable, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read price table: %w", err)
+	}
+	var table PriceTable
+	if err := yaml.UnmarshalStrict(data, &table); err != nil {
+		return nil, fmt.Errorf("failed to parse price table %s: %w", path, err)
+	}
+	for model, price := range table {
+		if price.Input < 0 || price.Output < 0 || (price.CachedInput != nil && *price.CachedInput < 0) {
+			return nil, fmt.Errorf("price of model %s must not be negative", model)
+		}
+	}
+	return table, nil
+}
+
+// Cost returns the cost of the tokens of a model call, zero when the model has no price.
+// The cached tokens are part of the input tokens.
+func (t PriceTable) Cost(model string, inputTokens, outputTokens, cachedTokens int64) float64 {
+	price, ok := t.lookup(model)
+	if !ok {
+		return 0
+	}
+
+	cachedPrice := price.Input
+	if price.CachedInput != nil {
+		cachedPrice = *price.CachedInput
+	}
+	cachedTokens = min(cachedTokens, inputTokens)
+
+	return (float64(inputTokens-cachedTokens)*price.Input +
+		float64(cachedTokens)*cachedPrice +
+		float64(outputTokens)*price.Output) / 1e6
+}
+
+func (t PriceTable) lookup(model string) (Price, bool) {
+	if price, ok := t[model]; ok {
+		return price, true
+	}
+	if i := strings.LastIndex(model, "/"); i >= 0 {
+		price, ok := t[model[i+1:]]
+		return price, ok
+	}
+	return Price{}, false
+}
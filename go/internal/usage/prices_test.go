@@ -0,0 +1,58 @@
+package usage
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writePriceTable(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "prices.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestLoadPriceTable(t *testing.T) {
+	table, err := LoadPriceTable(writePriceTable(t, `
+gpt-4o:
+  input: 2.5
+  output: 10
+  cachedInput: 1.25
+claude-sonnet-4:
+  input: 3
+  output: 15
+`))
+	require.NoError(t, err)
+	assert.Len(t, table, 2)
+	assert.Equal(t, 1.25, *table["gpt-4o"].CachedInput)
+
+	_, err = LoadPriceTable(writePriceTable(t, "gpt-4o:\n  input: 2.5\n  outptu: 10\n"))
+	assert.ErrorContains(t, err, "failed to parse price table")
+
+	_, err = LoadPriceTable(writePriceTable(t, "gpt-4o:\n  input: -1\n"))
+	assert.ErrorContains(t, err, "price of model gpt-4o must not be negative")
+
+	_, err = LoadPriceTable(filepath.Join(t.TempDir(), "missing.yaml"))
+	assert.ErrorContains(t, err, "failed to read price table")
+}
+
+func TestCost(t *testing.T) {
+	cachedInput := 1.25
+	table := PriceTable{
+		"gpt-4o":          {Input: 2.5, Output: 10, CachedInput: &cachedInput},
+		"claude-sonnet-4": {Input: 3, Output: 15},
+	}
+
+	// 600k uncached and 400k cached input tokens, 100k output tokens
+	assert.InDelta(t, 0.6*2.5+0.4*1.25+0.1*10, table.Cost("gpt-4o", 1_000_000, 100_000, 400_000), 1e-9)
+	// cached tokens default to the input price
+	assert.InDelta(t, 3+15, table.Cost("claude-sonnet-4", 1_000_000, 1_000_000, 500_000), 1e-9)
+	// models prefixed with their provider
+	assert.InDelta(t, 3.0, table.Cost("anthropic/claude-sonnet-4", 1_000_000, 0, 0), 1e-9)
+	// models without a price
+	assert.Zero(t, table.Cost("llama3", 1_000_000, 1_000_000, 0))
+	assert.Zero(t, PriceTable(nil).Cost("gpt-4o", 1_000_000, 1_000_000, 0))
+}
@@ -0,0 +1,80 @@
+// Package usage accounts for the tokens agents use on their model calls and what they cost.
+package usage
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/kagent-dev/kagent/go/internal/database"
+)
+
+const (
+	// ModelMetadataKey, ModelConfigMetadataKey and LatencyMetadataKey are the custom metadata
+	// keys the agent runtime records the model, the ModelConfig and the latency of a model call in
+	ModelMetadataKey       = "kagent_model"
+	ModelConfigMetadataKey = "kagent_model_config"
+	LatencyMetadataKey     = "kagent_latency_ms"
+)
+
+// FromEvent parses the usage metadata of a serialized ADK event. It returns nil when the
+// event does not carry any usage. Fields are read in both their snake case and camel case
+// form, depending on whether the event was serialized by alias.
+func FromEvent(data string) (*database.Usage, error) {
+	var event map[string]any
+	if err := json.Unmarshal([]byte(data), &event); err != nil {
+		return nil, fmt.Errorf("failed to parse event: %w", err)
+	}
+
+	metadata, _ := field(event, "usage_metadata").(map[string]any)
+	if metadata == nil {
+		return nil, nil
+	}
+
+	usage := &database.Usage{
+		InputTokens: count(metadata, "prompt_token_count"),
+		// thinking tokens are billed as output
+		OutputTokens: count(metadata, "candidates_token_count") + count(metadata, "thoughts_token_count"),
+		CachedTokens: count(metadata, "cached_content_token_count"),
+	}
+	if usage.InputTokens == 0 && usage.OutputTokens == 0 {
+		return nil, nil
+	}
+
+	if custom, _ := field(event, "custom_metadata").(map[string]any); custom != nil {
+		usage.Model, _ = custom[ModelMetadataKey].(string)
+		usage.ModelConfig, _ = custom[ModelConfigMetadataKey].(string)
+		usage.LatencyMs = count(custom, LatencyMetadataKey)
+	}
+	if usage.Model == "" {
+		usage.Model, _ = field(event, "model_version").(string)
+	}
+
+	return usage, nil
+}
+
+// field returns the value of a snake case key, or of its camel case form.
+func field(object map[string]any, key string) any {
+	if value, ok := object[key]; ok {
+		return value
+	}
+	return object[camelCase(key)]
+}
+
+func count(object map[string]any, key string) int64 {
+	value, _ := field(object, key).(float64)
+	return int64(value)
+}
+
+func camelCase(key string) string {
+	parts := strings.Split(key, "_")
+	for i := 1; i < len(parts); i++ {
+		if parts[i] != "" {
+			runes := []rune(parts[i])
+			runes[0] = unicode.ToUpper(runes[0])
+			parts[i] = string(runes)
+		}
+	}
+	return strings.Join(parts, "")
+}
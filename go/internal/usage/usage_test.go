@@ -0,0 +1,49 @@
+package usage
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kagent-dev/kagent/go/internal/database"
+)
+
+func TestFromEvent(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     string
+		expected *database.Usage
+	}{
+		{
+			name: "snake case with kagent metadata",
+			data: `{"id":"e1","author":"agent","usage_metadata":{"prompt_token_count":120,"candidates_token_count":30,"thoughts_token_count":10,"cached_content_token_count":100,"total_token_count":160},` +
+				`"custom_metadata":{"kagent_model":"gpt-4o","kagent_model_config":"default/openai","kagent_latency_ms":850}}`,
+			expected: &database.Usage{Model: "gpt-4o", ModelConfig: "default/openai", InputTokens: 120, OutputTokens: 40, CachedTokens: 100, LatencyMs: 850},
+		},
+		{
+			name:     "camel case with model version",
+			data:     `{"id":"e1","usageMetadata":{"promptTokenCount":12,"candidatesTokenCount":3},"modelVersion":"gemini-2.5-pro"}`,
+			expected: &database.Usage{Model: "gemini-2.5-pro", InputTokens: 12, OutputTokens: 3},
+		},
+		{
+			name: "no usage metadata",
+			data: `{"id":"e1","author":"user","content":{"parts":[{"text":"hi"}]}}`,
+		},
+		{
+			name: "empty usage metadata",
+			data: `{"id":"e1","usage_metadata":{"total_token_count":0}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			usage, err := FromEvent(tt.data)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, usage)
+		})
+	}
+
+	_, err := FromEvent("not json")
+	assert.ErrorContains(t, err, "failed to parse event")
+}
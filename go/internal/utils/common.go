@@ -147,6 +147,32 @@ func ParseRefString(ref string, parentNamespace string) (types.NamespacedName, e
 	}, nil
 }
 
+// AllowedNamespacesAnnotation lists, comma separated, the namespaces whose agents may
+// reference the annotated object from another namespace. "*" allows all namespaces.
+const AllowedNamespacesAnnotation = "kagent.dev/allowed-namespaces"
+
+// IsReferenceAllowed reports whether obj may be referenced from fromNamespace.
+// References within the same namespace are always allowed, cross-namespace references
+// must be opted into by the target through the AllowedNamespacesAnnotation.
+func IsReferenceAllowed(obj client.Object, fromNamespace string) bool {
+	if obj.GetNamespace() == fromNamespace {
+		return true
+	}
+
+	allowed, ok := obj.GetAnnotations()[AllowedNamespacesAnnotation]
+	if !ok {
+		return false
+	}
+
+	for _, namespace := range strings.Split(allowed, ",") {
+		namespace = strings.TrimSpace(namespace)
+		if namespace == "*" || namespace == fromNamespace {
+			return true
+		}
+	}
+	return false
+}
+
 // ConvertToPythonIdentifier converts Kubernetes identifiers to Python-compatible format
 // by replacing hyphens with underscores and slashes with "__NS__".
 func ConvertToPythonIdentifier(name string) string {
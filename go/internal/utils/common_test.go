@@ -5,6 +5,8 @@ import (
 	"strings"
 	"testing"
 
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 )
 
@@ -283,3 +285,63 @@ func TestValidateDNS1123Subdomain(t *testing.T) {
 		})
 	}
 }
+
+func TestIsReferenceAllowed(t *testing.T) {
+	tests := []struct {
+		name          string
+		namespace     string
+		annotations   map[string]string
+		fromNamespace string
+		want          bool
+	}{
+		{
+			name:          "Same namespace",
+			namespace:     "tools",
+			fromNamespace: "tools",
+			want:          true,
+		},
+		{
+			name:          "Cross namespace without annotation",
+			namespace:     "tools",
+			fromNamespace: "team-a",
+			want:          false,
+		},
+		{
+			name:          "Cross namespace in allow-list",
+			namespace:     "tools",
+			annotations:   map[string]string{AllowedNamespacesAnnotation: "team-b, team-a"},
+			fromNamespace: "team-a",
+			want:          true,
+		},
+		{
+			name:          "Cross namespace not in allow-list",
+			namespace:     "tools",
+			annotations:   map[string]string{AllowedNamespacesAnnotation: "team-b"},
+			fromNamespace: "team-a",
+			want:          false,
+		},
+		{
+			name:          "Wildcard allow-list",
+			namespace:     "tools",
+			annotations:   map[string]string{AllowedNamespacesAnnotation: "*"},
+			fromNamespace: "team-a",
+			want:          true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			obj := &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "mcp",
+					Namespace:   tt.namespace,
+					Annotations: tt.annotations,
+				},
+			}
+
+			if got := IsReferenceAllowed(obj, tt.fromNamespace); got != tt.want {
+				t.Errorf("IsReferenceAllowed() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package webhook
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/kagent-dev/kagent/go/api/v1alpha2"
+	agent_translator "github.com/kagent-dev/kagent/go/internal/controller/translator/agent"
+)
+
+var agentWebhookLog = ctrl.Log.WithName("agent-webhook")
+
+// SetupAgentWebhookWithManager registers the validating webhook for Agents.
+func SetupAgentWebhookWithManager(mgr ctrl.Manager, translator agent_translator.AdkApiTranslator, tools agent_translator.ToolLister) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&v1alpha2.Agent{}).
+		WithValidator(&AgentValidator{Translator: translator, Tools: tools}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/validate-kagent-dev-v1alpha2-agent,mutating=false,failurePolicy=fail,sideEffects=None,groups=kagent.dev,resources=agents,verbs=create;update,versions=v1alpha2,name=vagent-v1alpha2.kagent.dev,admissionReviewVersions=v1
+
+// AgentValidator rejects agents whose references would only fail at reconcile time:
+// self-referencing or cyclic agent tools, missing ModelConfigs and unknown tool names.
+type AgentValidator struct {
+	Translator agent_translator.AdkApiTranslator
+	// Tools lists the tools discovered on the RemoteMCPServers, the status of the servers is used when nil
+	Tools agent_translator.ToolLister
+}
+
+var _ admission.CustomValidator = &AgentValidator{}
+
+func (v *AgentValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, v.validate(ctx, obj, nil)
+}
+
+// ValidateUpdate validates the agents whose spec changed. The references of an agent being
+// deleted, or of an agent whose metadata or status only is written, e.g. to add or remove its
+// finalizer, may be gone without the write being at fault.
+func (v *AgentValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	oldAgent, ok := oldObj.(*v1alpha2.Agent)
+	if !ok {
+		return nil, fmt.Errorf("expected an Agent but got %T", oldObj)
+	}
+	newAgent, ok := newObj.(*v1alpha2.Agent)
+	if !ok {
+		return nil, fmt.Errorf("expected an Agent but got %T", newObj)
+	}
+	if newAgent.DeletionTimestamp != nil || equality.Semantic.DeepEqual(oldAgent.Spec, newAgent.Spec) {
+		return nil, nil
+	}
+	return nil, v.validate(ctx, newObj, oldAgent)
+}
+
+func (v *AgentValidator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// validate validates an agent, previous being the agent it updates if any
+func (v *AgentValidator) validate(ctx context.Context, obj runtime.Object, previous *v1alpha2.Agent) error {
+	agent, ok := obj.(*v1alpha2.Agent)
+	if !ok {
+		return fmt.Errorf("expected an Agent but got %T", obj)
+	}
+
+	if err := v.Translator.ValidateAgent(ctx, agent, agent_translator.ValidationOptions{Tools: v.Tools, Previous: previous}); err != nil {
+		agentWebhookLog.V(1).Info("rejecting agent", "agent", agent.Namespace+"/"+agent.Name, "reason", err.Error())
+		return fmt.Errorf("invalid agent %s/%s: %w", agent.Namespace, agent.Name, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,60 @@
+package webhook
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/kagent-dev/kagent/go/api/v1alpha2"
+	agent_translator "github.com/kagent-dev/kagent/go/internal/controller/translator/agent"
+)
+
+func TestAgentValidatorUpdate(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, v1alpha2.AddToScheme(scheme))
+	// the ModelConfig of the agent was deleted
+	kube := fake.NewClientBuilder().WithScheme(scheme).Build()
+	validator := &AgentValidator{
+		Translator: agent_translator.NewAdkApiTranslator(kube, types.NamespacedName{Namespace: "test", Name: "model"}, nil),
+	}
+
+	agent := &v1alpha2.Agent{
+		ObjectMeta: metav1.ObjectMeta{Name: "agent", Namespace: "test", Finalizers: []string{v1alpha2.AgentCleanupFinalizer}},
+		Spec: v1alpha2.AgentSpec{
+			Type: v1alpha2.AgentType_Declarative,
+			Declarative: &v1alpha2.DeclarativeAgentSpec{
+				SystemMessage: "You are a helpful agent.",
+				ModelConfig:   "model",
+			},
+		},
+	}
+
+	t.Run("finalizer removal", func(t *testing.T) {
+		updated := agent.DeepCopy()
+		updated.Finalizers = nil
+		_, err := validator.ValidateUpdate(context.Background(), agent, updated)
+		assert.NoError(t, err)
+	})
+
+	t.Run("agent being deleted", func(t *testing.T) {
+		deleting := agent.DeepCopy()
+		deleting.DeletionTimestamp = &metav1.Time{}
+		updated := deleting.DeepCopy()
+		updated.Spec.Description = "changed"
+		_, err := validator.ValidateUpdate(context.Background(), deleting, updated)
+		assert.NoError(t, err)
+	})
+
+	t.Run("spec change", func(t *testing.T) {
+		updated := agent.DeepCopy()
+		updated.Spec.Description = "changed"
+		_, err := validator.ValidateUpdate(context.Background(), agent, updated)
+		assert.ErrorContains(t, err, "ModelConfig test/model not found")
+	})
+}
@@ -0,0 +1,130 @@
+package webhook
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// conversionPath is the path controller-runtime serves the conversion webhook at
+	conversionPath = "/convert"
+	// conversionCheckInterval is the interval the CA bundle is checked for rotations at
+	conversionCheckInterval = time.Minute
+)
+
+var (
+	conversionLog = ctrl.Log.WithName("conversion-webhook")
+	crdGVK        = schema.GroupVersionKind{Group: "apiextensions.k8s.io", Version: "v1", Kind: "CustomResourceDefinition"}
+)
+
+// ConversionConfigurer points the conversion of CRDs at the webhook server of the controller,
+// setting their `spec.conversion` to the `Webhook` strategy with the controller service and the
+// CA bundle of the serving certificate. The CA bundle is patched again when it is rotated.
+// Without a CA bundle file, the CA bundle of the CRDs is left as is, e.g. for cert-manager to inject it.
+type ConversionConfigurer struct {
+	Client           client.Client
+	CRDNames         []string
+	ServiceName      string
+	ServiceNamespace string
+	ServicePort      int32
+	// CABundlePath is the path of the PEM encoded CA bundle of the serving certificate
+	CABundlePath string
+
+	caBundle []byte
+}
+
+// Start configures the CRDs, then keeps their CA bundle up to date until the context is done.
+// Failures are retried at the next check.
+func (c *ConversionConfigurer) Start(ctx context.Context) error {
+	ticker := time.NewTicker(conversionCheckInterval)
+	defer ticker.Stop()
+	for {
+		if err := c.configure(ctx); err != nil {
+			conversionLog.Error(err, "Failed to configure the conversion webhook")
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// configure patches the CRDs when the CA bundle changed since the last patch
+func (c *ConversionConfigurer) configure(ctx context.Context) error {
+	caBundle, err := c.readCABundle()
+	if err != nil {
+		return err
+	}
+	if c.caBundle != nil && string(caBundle) == string(c.caBundle) {
+		return nil
+	}
+
+	patch, err := c.conversionPatch(caBundle)
+	if err != nil {
+		return err
+	}
+	for _, name := range c.CRDNames {
+		crd := &unstructured.Unstructured{}
+		crd.SetGroupVersionKind(crdGVK)
+		crd.SetName(name)
+		if err := c.Client.Patch(ctx, crd, client.RawPatch(types.MergePatchType, patch)); err != nil {
+			return fmt.Errorf("failed to configure the conversion webhook of CRD %s: %w", name, err)
+		}
+		conversionLog.Info("Configured the conversion webhook", "crd", name)
+	}
+	c.caBundle = caBundle
+	return nil
+}
+
+func (c *ConversionConfigurer) readCABundle() ([]byte, error) {
+	if c.CABundlePath == "" {
+		return []byte{}, nil
+	}
+	caBundle, err := os.ReadFile(c.CABundlePath)
+	if errors.Is(err, os.ErrNotExist) {
+		conversionLog.Info("No CA bundle found, leaving the CA bundle of the CRDs to be injected", "path", c.CABundlePath)
+		return []byte{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the CA bundle: %w", err)
+	}
+	return caBundle, nil
+}
+
+// conversionPatch returns the merge patch of the conversion of the CRDs
+func (c *ConversionConfigurer) conversionPatch(caBundle []byte) ([]byte, error) {
+	clientConfig := map[string]any{
+		"service": map[string]any{
+			"name":      c.ServiceName,
+			"namespace": c.ServiceNamespace,
+			"path":      conversionPath,
+			"port":      c.ServicePort,
+		},
+	}
+	if len(caBundle) > 0 {
+		clientConfig["caBundle"] = base64.StdEncoding.EncodeToString(caBundle)
+	}
+	return json.Marshal(map[string]any{
+		"spec": map[string]any{
+			"conversion": map[string]any{
+				"strategy": "Webhook",
+				"webhook": map[string]any{
+					"conversionReviewVersions": []string{"v1"},
+					"clientConfig":             clientConfig,
+				},
+			},
+		},
+	})
+}
@@ -0,0 +1,61 @@
+package webhook
+
+import (
+	"context"
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestConversionConfigurer(t *testing.T) {
+	crd := &unstructured.Unstructured{}
+	crd.SetGroupVersionKind(crdGVK)
+	crd.SetName("agents.kagent.dev")
+	require.NoError(t, unstructured.SetNestedField(crd.Object, "kagent.dev", "spec", "group"))
+	kube := fake.NewClientBuilder().WithScheme(runtime.NewScheme()).WithObjects(crd).Build()
+
+	caBundlePath := filepath.Join(t.TempDir(), "ca.crt")
+	configurer := &ConversionConfigurer{
+		Client:           kube,
+		CRDNames:         []string{"agents.kagent.dev"},
+		ServiceName:      "kagent-controller",
+		ServiceNamespace: "kagent",
+		ServicePort:      443,
+		CABundlePath:     caBundlePath,
+	}
+
+	getConversion := func() map[string]any {
+		current := &unstructured.Unstructured{}
+		current.SetGroupVersionKind(crdGVK)
+		require.NoError(t, kube.Get(context.Background(), client.ObjectKey{Name: "agents.kagent.dev"}, current))
+		conversion, found, err := unstructured.NestedMap(current.Object, "spec", "conversion")
+		require.NoError(t, err)
+		require.True(t, found)
+		return conversion
+	}
+
+	// without a CA bundle, the CA bundle is left to be injected
+	require.NoError(t, configurer.configure(context.Background()))
+	conversion := getConversion()
+	assert.Equal(t, "Webhook", conversion["strategy"])
+	service, _, _ := unstructured.NestedMap(conversion, "webhook", "clientConfig", "service")
+	assert.Equal(t, map[string]any{"name": "kagent-controller", "namespace": "kagent", "path": "/convert", "port": int64(443)}, service)
+	_, found, _ := unstructured.NestedString(conversion, "webhook", "clientConfig", "caBundle")
+	assert.False(t, found)
+
+	// the CA bundle is set once available, and again once rotated
+	for _, ca := range []string{"ca-1", "ca-2"} {
+		require.NoError(t, os.WriteFile(caBundlePath, []byte(ca), 0o600))
+		require.NoError(t, configurer.configure(context.Background()))
+		caBundle, _, _ := unstructured.NestedString(getConversion(), "webhook", "clientConfig", "caBundle")
+		assert.Equal(t, base64.StdEncoding.EncodeToString([]byte(ca)), caBundle)
+	}
+}
@@ -0,0 +1,55 @@
+package api
+
+import "trpc.group/trpc-go/trpc-a2a-go/protocol"
+
+// A2A streams
+
+const (
+	// StreamEventIDMetadataKey is the key of the metadata of the events streamed by the controller
+	// holding their ID
+	StreamEventIDMetadataKey = "kagent_event_id"
+	// LastStreamEventIDMetadataKey is the key of the metadata of a resubscription to a task
+	// holding the ID of the last event received, to replay the events following it
+	LastStreamEventIDMetadataKey = "kagent_last_event_id"
+)
+
+// StreamingEventTaskID returns the ID of the task of a streamed event, empty if it has none
+func StreamingEventTaskID(result protocol.StreamingMessageResult) string {
+	switch result := result.(type) {
+	case *protocol.Task:
+		return result.ID
+	case *protocol.TaskStatusUpdateEvent:
+		return result.TaskID
+	case *protocol.TaskArtifactUpdateEvent:
+		return result.TaskID
+	case *protocol.Message:
+		if result.TaskID != nil {
+			return *result.TaskID
+		}
+	}
+	return ""
+}
+
+// IsFinalStreamingEvent returns whether an event ends the stream of a task
+func IsFinalStreamingEvent(event protocol.StreamingMessageEvent) bool {
+	switch result := event.Result.(type) {
+	case *protocol.Message:
+		return true
+	case *protocol.Task:
+		return IsFinalTaskState(result.Status.State)
+	case *protocol.TaskStatusUpdateEvent:
+		return result.Final
+	}
+	return false
+}
+
+// IsFinalTaskState returns whether the agent stopped working on a task in the given state, until
+// it gets a new message if the task is interrupted
+func IsFinalTaskState(state protocol.TaskState) bool {
+	switch state {
+	case protocol.TaskStateCompleted, protocol.TaskStateCanceled, protocol.TaskStateFailed, protocol.TaskStateRejected,
+		protocol.TaskStateInputRequired, protocol.TaskStateAuthRequired:
+		return true
+	}
+	return false
+}
@@ -4,6 +4,7 @@ import (
 	"github.com/kagent-dev/kagent/go/api/v1alpha1"
 	"github.com/kagent-dev/kagent/go/api/v1alpha2"
 	"github.com/kagent-dev/kagent/go/internal/database"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 // Common types
@@ -65,6 +66,8 @@ type CreateModelConfigRequest struct {
 	GeminiParams            *v1alpha2.GeminiConfig            `json:"gemini,omitempty"`
 	GeminiVertexAIParams    *v1alpha2.GeminiVertexAIConfig    `json:"geminiVertexAI,omitempty"`
 	AnthropicVertexAIParams *v1alpha2.AnthropicVertexAIConfig `json:"anthropicVertexAI,omitempty"`
+	BedrockParams           *v1alpha2.BedrockConfig           `json:"bedrock,omitempty"`
+	OpenAICompatibleParams  *v1alpha2.OpenAICompatibleConfig  `json:"openAICompatible,omitempty"`
 }
 
 // UpdateModelConfigRequest represents a request to update a model configuration
@@ -79,6 +82,16 @@ type UpdateModelConfigRequest struct {
 	GeminiParams            *v1alpha2.GeminiConfig            `json:"gemini,omitempty"`
 	GeminiVertexAIParams    *v1alpha2.GeminiVertexAIConfig    `json:"geminiVertexAI,omitempty"`
 	AnthropicVertexAIParams *v1alpha2.AnthropicVertexAIConfig `json:"anthropicVertexAI,omitempty"`
+	BedrockParams           *v1alpha2.BedrockConfig           `json:"bedrock,omitempty"`
+	OpenAICompatibleParams  *v1alpha2.OpenAICompatibleConfig  `json:"openAICompatible,omitempty"`
+}
+
+// TestModelConfigResponse is the outcome of checking the credentials of a model config against its provider
+type TestModelConfigResponse struct {
+	// Status is True, False or Unknown when the provider cannot be checked
+	Status  string `json:"status"`
+	Reason  string `json:"reason"`
+	Message string `json:"message,omitempty"`
 }
 
 // Agent types
@@ -86,6 +99,8 @@ type UpdateModelConfigRequest struct {
 type AgentResponse struct {
 	ID    string          `json:"id"`
 	Agent *v1alpha2.Agent `json:"agent"`
+	// RemoteAgent is set instead of Agent for the agents imported with a RemoteAgent
+	RemoteAgent *v1alpha2.RemoteAgent `json:"remoteAgent,omitempty"`
 	// Config         *adk.AgentConfig       `json:"config"`
 	ModelProvider   v1alpha2.ModelProvider `json:"modelProvider"`
 	Model           string                 `json:"model"`
@@ -94,6 +109,31 @@ type AgentResponse struct {
 	Tools           []*v1alpha2.Tool       `json:"tools"`
 	DeploymentReady bool                   `json:"deploymentReady"`
 	Accepted        bool                   `json:"accepted"`
+	// Why the agent is not ready, from its Ready condition
+	ReadyReason  string `json:"readyReason,omitempty"`
+	ReadyMessage string `json:"readyMessage,omitempty"`
+}
+
+// Object returns the resource of the agent, an Agent or a RemoteAgent
+func (a *AgentResponse) Object() client.Object {
+	if a.RemoteAgent != nil {
+		return a.RemoteAgent
+	}
+	return a.Agent
+}
+
+// Description returns the description of the agent
+func (a *AgentResponse) Description() string {
+	if a.RemoteAgent != nil {
+		if a.RemoteAgent.Spec.Description == "" && a.RemoteAgent.Status.AgentCard != nil {
+			return a.RemoteAgent.Status.AgentCard.Description
+		}
+		return a.RemoteAgent.Spec.Description
+	}
+	if a.Agent != nil {
+		return a.Agent.Spec.Description
+	}
+	return ""
 }
 
 // Session types
@@ -132,13 +172,23 @@ type Tool = database.Tool
 // Feedback represents a feedback from the database
 type Feedback = database.Feedback
 
+// Usage types
+
+// UsageQuery selects the token usage to aggregate
+type UsageQuery = database.UsageQuery
+
+// UsageSummary represents token usage aggregated over a group
+type UsageSummary = database.UsageSummary
+
 // ToolServer types
 
 // ToolServerResponse represents a tool server response
 type ToolServerResponse struct {
-	Ref             string              `json:"ref"`
-	GroupKind       string              `json:"groupKind"`
-	DiscoveredTools []*v1alpha2.MCPTool `json:"discoveredTools"`
+	Ref                 string                  `json:"ref"`
+	GroupKind           string                  `json:"groupKind"`
+	DiscoveredTools     []*v1alpha2.MCPTool     `json:"discoveredTools"`
+	DiscoveredResources []*v1alpha2.MCPResource `json:"discoveredResources,omitempty"`
+	DiscoveredPrompts   []*v1alpha2.MCPPrompt   `json:"discoveredPrompts,omitempty"`
 }
 
 // Memory types
@@ -16,6 +16,8 @@ type ClientSet struct {
 	Model       Model
 	Namespace   Namespace
 	Feedback    Feedback
+	Usage       Usage
+	Task        Task
 }
 
 // New creates a new KAgent client set
@@ -36,5 +38,7 @@ func New(baseURL string, options ...ClientOption) *ClientSet {
 		Model:       NewModelClient(baseClient),
 		Namespace:   NewNamespaceClient(baseClient),
 		Feedback:    NewFeedbackClient(baseClient),
+		Usage:       NewUsageClient(baseClient),
+		Task:        NewTaskClient(baseClient),
 	}
 }
@@ -11,6 +11,10 @@ import (
 type ModelInfo struct {
 	Name            string `json:"name"`
 	FunctionCalling bool   `json:"function_calling"`
+	Vision          bool   `json:"vision,omitempty"`
+	JSONMode        bool   `json:"json_mode,omitempty"`
+	// Discovered is set for models listed by the provider, as opposed to the well known defaults
+	Discovered bool `json:"discovered,omitempty"`
 }
 
 // ProviderModels represents a map of provider names to their supported models
@@ -15,6 +15,8 @@ type ModelConfigInterface interface {
 	CreateModelConfig(ctx context.Context, request *api.CreateModelConfigRequest) (*api.StandardResponse[*v1alpha2.ModelConfig], error)
 	UpdateModelConfig(ctx context.Context, namespace, name string, request *api.UpdateModelConfigRequest) (*api.StandardResponse[*api.ModelConfigResponse], error)
 	DeleteModelConfig(ctx context.Context, namespace, name string) error
+	ListModelConfigModels(ctx context.Context, namespace, name string) (*api.StandardResponse[[]ModelInfo], error)
+	TestModelConfig(ctx context.Context, namespace, name string) (*api.StandardResponse[api.TestModelConfigResponse], error)
 }
 
 // ModelConfigClient handles model configuration requests
@@ -98,3 +100,35 @@ func (c *ModelConfigClient) DeleteModelConfig(ctx context.Context, namespace, co
 	}
 	return nil
 }
+
+// ListModelConfigModels lists the models available to a model configuration
+func (c *ModelConfigClient) ListModelConfigModels(ctx context.Context, namespace, name string) (*api.StandardResponse[[]ModelInfo], error) {
+	path := fmt.Sprintf("/api/modelconfigs/%s/%s/models", namespace, name)
+	resp, err := c.client.Get(ctx, path, "")
+	if err != nil {
+		return nil, err
+	}
+
+	var models api.StandardResponse[[]ModelInfo]
+	if err := DecodeResponse(resp, &models); err != nil {
+		return nil, err
+	}
+
+	return &models, nil
+}
+
+// TestModelConfig checks the credentials of a model configuration against its provider
+func (c *ModelConfigClient) TestModelConfig(ctx context.Context, namespace, name string) (*api.StandardResponse[api.TestModelConfigResponse], error) {
+	path := fmt.Sprintf("/api/modelconfigs/%s/%s/test", namespace, name)
+	resp, err := c.client.Post(ctx, path, nil, "")
+	if err != nil {
+		return nil, err
+	}
+
+	var result api.StandardResponse[api.TestModelConfigResponse]
+	if err := DecodeResponse(resp, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
@@ -0,0 +1,145 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/kagent-dev/kagent/go/pkg/client/api"
+	a2aclient "trpc.group/trpc-go/trpc-a2a-go/client"
+	"trpc.group/trpc-go/trpc-a2a-go/protocol"
+)
+
+// maxStreamReconnects is the number of attempts to resume a dropped stream
+const maxStreamReconnects = 5
+
+// streamReconnectBackoff is the wait before the first attempt, doubled for each attempt
+var streamReconnectBackoff = time.Second
+
+// A2AStreamer defines the A2A streaming operations, implemented by the A2A client
+type A2AStreamer interface {
+	StreamMessage(ctx context.Context, params protocol.SendMessageParams, opts ...a2aclient.RequestOption) (<-chan protocol.StreamingMessageEvent, error)
+	ResubscribeTask(ctx context.Context, params protocol.TaskIDParams, opts ...a2aclient.RequestOption) (<-chan protocol.StreamingMessageEvent, error)
+}
+
+// StreamUpdate is an event of a resumable stream, or a change of the state of its connection
+type StreamUpdate struct {
+	Event protocol.StreamingMessageEvent
+	// Reconnecting is set, without an event, when the stream dropped and is being resumed
+	Reconnecting bool
+	// Err is set, without an event, when the stream dropped and could not be resumed
+	Err error
+}
+
+// StreamMessageResumable sends a message to an agent, streaming the events of its task. When the
+// stream drops before the task is over, it resubscribes to the task with the ID of the last event
+// received, so the events missed in the meantime are replayed. The channel is closed once the
+// task is over, the stream cannot be resumed or the context is done.
+func StreamMessageResumable(ctx context.Context, streamer A2AStreamer, params protocol.SendMessageParams) (<-chan StreamUpdate, error) {
+	events, err := streamer.StreamMessage(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	updates := make(chan StreamUpdate)
+	go func() {
+		defer close(updates)
+		send := func(update StreamUpdate) bool {
+			select {
+			case updates <- update:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+
+		var taskID, lastEventID string
+		// resumes counts the streams resumed without events, which end as they start
+		resumes := 0
+		for {
+			over := false
+			for event := range events {
+				resumes = 0
+				if id := api.StreamingEventTaskID(event.Result); id != "" {
+					taskID = id
+				}
+				if id := streamEventID(event); id != "" {
+					lastEventID = id
+				}
+				over = over || api.IsFinalStreamingEvent(event)
+				if !send(StreamUpdate{Event: event}) {
+					return
+				}
+			}
+			if over || ctx.Err() != nil {
+				return
+			}
+			if taskID == "" {
+				send(StreamUpdate{Err: fmt.Errorf("stream dropped before the task was created")})
+				return
+			}
+			if resumes++; resumes > maxStreamReconnects {
+				send(StreamUpdate{Err: fmt.Errorf("stream of task %s keeps dropping", taskID)})
+				return
+			}
+
+			events, err = resubscribe(ctx, streamer, taskID, lastEventID, send)
+			if err != nil {
+				if ctx.Err() == nil {
+					send(StreamUpdate{Err: err})
+				}
+				return
+			}
+		}
+	}()
+	return updates, nil
+}
+
+// resubscribe resubscribes to a task after the last event received, with backoff
+func resubscribe(
+	ctx context.Context,
+	streamer A2AStreamer,
+	taskID, lastEventID string,
+	send func(StreamUpdate) bool,
+) (<-chan protocol.StreamingMessageEvent, error) {
+	if !send(StreamUpdate{Reconnecting: true}) {
+		return nil, ctx.Err()
+	}
+
+	params := protocol.TaskIDParams{ID: taskID}
+	if lastEventID != "" {
+		params.Metadata = map[string]any{api.LastStreamEventIDMetadataKey: lastEventID}
+	}
+	backoff := streamReconnectBackoff
+	var err error
+	for range maxStreamReconnects {
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		var events <-chan protocol.StreamingMessageEvent
+		if events, err = streamer.ResubscribeTask(ctx, params); err == nil {
+			return events, nil
+		}
+		backoff *= 2
+	}
+	return nil, fmt.Errorf("failed to resume the stream of task %s: %w", taskID, err)
+}
+
+// streamEventID returns the ID the controller gave to an event, if any
+func streamEventID(event protocol.StreamingMessageEvent) string {
+	var metadata map[string]any
+	switch result := event.Result.(type) {
+	case *protocol.Message:
+		metadata = result.Metadata
+	case *protocol.Task:
+		metadata = result.Metadata
+	case *protocol.TaskStatusUpdateEvent:
+		metadata = result.Metadata
+	case *protocol.TaskArtifactUpdateEvent:
+		metadata = result.Metadata
+	}
+	id, _ := metadata[api.StreamEventIDMetadataKey].(string)
+	return id
+}
@@ -0,0 +1,165 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	a2aclient "trpc.group/trpc-go/trpc-a2a-go/client"
+	"trpc.group/trpc-go/trpc-a2a-go/protocol"
+
+	"github.com/kagent-dev/kagent/go/pkg/client/api"
+)
+
+// fakeStreamer streams the given events, then answers the resubscriptions with the next
+// resubscription, or with the last one once there are no more
+type fakeStreamer struct {
+	events          []protocol.StreamingMessageEvent
+	resubscriptions []resubscription
+
+	lock           sync.Mutex
+	params         []protocol.TaskIDParams
+	resubscribedAt []time.Time
+}
+
+type resubscription struct {
+	events []protocol.StreamingMessageEvent
+	err    error
+}
+
+func streamOf(events []protocol.StreamingMessageEvent) <-chan protocol.StreamingMessageEvent {
+	stream := make(chan protocol.StreamingMessageEvent, len(events))
+	for _, event := range events {
+		stream <- event
+	}
+	close(stream)
+	return stream
+}
+
+func (s *fakeStreamer) StreamMessage(ctx context.Context, params protocol.SendMessageParams, opts ...a2aclient.RequestOption) (<-chan protocol.StreamingMessageEvent, error) {
+	return streamOf(s.events), nil
+}
+
+func (s *fakeStreamer) ResubscribeTask(ctx context.Context, params protocol.TaskIDParams, opts ...a2aclient.RequestOption) (<-chan protocol.StreamingMessageEvent, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.params = append(s.params, params)
+	s.resubscribedAt = append(s.resubscribedAt, time.Now())
+
+	next := s.resubscriptions[min(len(s.params), len(s.resubscriptions))-1]
+	if next.err != nil {
+		return nil, next.err
+	}
+	return streamOf(next.events), nil
+}
+
+func statusEvent(eventID string, state protocol.TaskState, final bool) protocol.StreamingMessageEvent {
+	return protocol.StreamingMessageEvent{Result: &protocol.TaskStatusUpdateEvent{
+		Kind:     protocol.KindTaskStatusUpdate,
+		TaskID:   "task-1",
+		Status:   protocol.TaskStatus{State: state},
+		Final:    final,
+		Metadata: map[string]any{api.StreamEventIDMetadataKey: eventID},
+	}}
+}
+
+func collectUpdates(t *testing.T, streamer A2AStreamer) []StreamUpdate {
+	updates, err := StreamMessageResumable(context.Background(), streamer, protocol.SendMessageParams{})
+	require.NoError(t, err)
+	var collected []StreamUpdate
+	for update := range updates {
+		collected = append(collected, update)
+	}
+	return collected
+}
+
+func withReconnectBackoff(t *testing.T, backoff time.Duration) {
+	previous := streamReconnectBackoff
+	streamReconnectBackoff = backoff
+	t.Cleanup(func() { streamReconnectBackoff = previous })
+}
+
+func TestStreamMessageResumableReconnects(t *testing.T) {
+	withReconnectBackoff(t, time.Millisecond)
+	streamer := &fakeStreamer{
+		events: []protocol.StreamingMessageEvent{statusEvent("1", protocol.TaskStateWorking, false)},
+		resubscriptions: []resubscription{
+			{err: errors.New("connection refused")},
+			{events: []protocol.StreamingMessageEvent{statusEvent("2", protocol.TaskStateCompleted, true)}},
+		},
+	}
+
+	updates := collectUpdates(t, streamer)
+	require.Len(t, updates, 3)
+	assert.Equal(t, streamer.events[0], updates[0].Event)
+	assert.True(t, updates[1].Reconnecting)
+	assert.Equal(t, protocol.TaskStateCompleted, updates[2].Event.Result.(*protocol.TaskStatusUpdateEvent).Status.State)
+	assert.NoError(t, updates[2].Err)
+
+	// the events following the last event received are asked for
+	require.Len(t, streamer.params, 2)
+	for _, params := range streamer.params {
+		assert.Equal(t, "task-1", params.ID)
+		assert.Equal(t, "1", params.Metadata[api.LastStreamEventIDMetadataKey])
+	}
+}
+
+func TestStreamMessageResumableBacksOff(t *testing.T) {
+	backoff := 5 * time.Millisecond
+	withReconnectBackoff(t, backoff)
+	streamer := &fakeStreamer{
+		events:          []protocol.StreamingMessageEvent{statusEvent("1", protocol.TaskStateWorking, false)},
+		resubscriptions: []resubscription{{err: errors.New("connection refused")}},
+	}
+
+	start := time.Now()
+	updates := collectUpdates(t, streamer)
+	require.Len(t, updates, 3)
+	assert.True(t, updates[1].Reconnecting)
+	assert.ErrorContains(t, updates[2].Err, "failed to resume the stream of task task-1")
+
+	// the attempts are given up after the maximum, waiting twice as long between each
+	require.Len(t, streamer.resubscribedAt, maxStreamReconnects)
+	previous := start
+	for i, resumedAt := range streamer.resubscribedAt {
+		assert.GreaterOrEqual(t, resumedAt.Sub(previous), backoff<<i, "attempt %d", i)
+		previous = resumedAt
+	}
+}
+
+func TestStreamMessageResumableGivesUp(t *testing.T) {
+	withReconnectBackoff(t, time.Millisecond)
+
+	t.Run("stream keeps dropping", func(t *testing.T) {
+		streamer := &fakeStreamer{
+			events:          []protocol.StreamingMessageEvent{statusEvent("1", protocol.TaskStateWorking, false)},
+			resubscriptions: []resubscription{{}},
+		}
+		updates := collectUpdates(t, streamer)
+		require.NotEmpty(t, updates)
+		assert.ErrorContains(t, updates[len(updates)-1].Err, "stream of task task-1 keeps dropping")
+		assert.Len(t, streamer.params, maxStreamReconnects)
+	})
+
+	t.Run("stream dropped before the task", func(t *testing.T) {
+		streamer := &fakeStreamer{}
+		updates := collectUpdates(t, streamer)
+		require.Len(t, updates, 1)
+		assert.ErrorContains(t, updates[0].Err, "stream dropped before the task was created")
+		assert.Empty(t, streamer.params)
+	})
+
+	t.Run("task over", func(t *testing.T) {
+		streamer := &fakeStreamer{
+			events: []protocol.StreamingMessageEvent{statusEvent("1", protocol.TaskStateInputRequired, true)},
+		}
+		updates := collectUpdates(t, streamer)
+		require.Len(t, updates, 1)
+		assert.NoError(t, updates[0].Err)
+		assert.Empty(t, streamer.params)
+	})
+}
@@ -0,0 +1,62 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kagent-dev/kagent/go/pkg/client/api"
+	"trpc.group/trpc-go/trpc-a2a-go/protocol"
+)
+
+// Task defines the task operations
+type Task interface {
+	GetTask(ctx context.Context, taskID string) (*api.StandardResponse[*protocol.Task], error)
+	CancelTask(ctx context.Context, taskID string) (*api.StandardResponse[*protocol.Task], error)
+}
+
+// taskClient handles task-related requests
+type taskClient struct {
+	client *BaseClient
+}
+
+// NewTaskClient creates a new task client
+func NewTaskClient(client *BaseClient) Task {
+	return &taskClient{client: client}
+}
+
+// GetTask retrieves a specific task
+func (c *taskClient) GetTask(ctx context.Context, taskID string) (*api.StandardResponse[*protocol.Task], error) {
+	path := fmt.Sprintf("/api/tasks/%s", taskID)
+	resp, err := c.client.Get(ctx, path, c.client.GetUserIDOrDefault(""))
+	if err != nil {
+		return nil, err
+	}
+
+	var response api.StandardResponse[*protocol.Task]
+	if err := DecodeResponse(resp, &response); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+// CancelTask cancels a task of a session of the user
+func (c *taskClient) CancelTask(ctx context.Context, taskID string) (*api.StandardResponse[*protocol.Task], error) {
+	userID := c.client.GetUserIDOrDefault("")
+	if userID == "" {
+		return nil, fmt.Errorf("userID is required")
+	}
+
+	path := fmt.Sprintf("/api/tasks/%s/cancel", taskID)
+	resp, err := c.client.Post(ctx, path, nil, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var response api.StandardResponse[*protocol.Task]
+	if err := DecodeResponse(resp, &response); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
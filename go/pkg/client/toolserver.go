@@ -13,6 +13,7 @@ type ToolServer interface {
 	ListToolServers(ctx context.Context) ([]api.ToolServerResponse, error)
 	CreateToolServer(ctx context.Context, toolServer *v1alpha1.ToolServer) (*v1alpha1.ToolServer, error)
 	DeleteToolServer(ctx context.Context, namespace, toolServerName string) error
+	RefreshToolServer(ctx context.Context, namespace, toolServerName string) error
 }
 
 // ToolServerClient handles tool server-related requests
@@ -64,3 +65,13 @@ func (c *ToolServerClient) DeleteToolServer(ctx context.Context, namespace, tool
 	}
 	return nil
 }
+
+// RefreshToolServer requests a rediscovery of the tools of a tool server
+func (c *ToolServerClient) RefreshToolServer(ctx context.Context, namespace, toolServerName string) error {
+	path := fmt.Sprintf("/api/toolservers/%s/%s/refresh", namespace, toolServerName)
+	_, err := c.client.Post(ctx, path, nil, "")
+	if err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package client
+
+import (
+	"context"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/kagent-dev/kagent/go/pkg/client/api"
+)
+
+// Usage defines the token usage operations
+type Usage interface {
+	GetUsage(ctx context.Context, query api.UsageQuery) (*api.StandardResponse[[]api.UsageSummary], error)
+}
+
+// usageClient handles token usage requests
+type usageClient struct {
+	client *BaseClient
+}
+
+// NewUsageClient creates a new usage client
+func NewUsageClient(client *BaseClient) Usage {
+	return &usageClient{client: client}
+}
+
+// GetUsage aggregates the token usage selected by the query
+func (c *usageClient) GetUsage(ctx context.Context, query api.UsageQuery) (*api.StandardResponse[[]api.UsageSummary], error) {
+	params := url.Values{}
+	if !query.From.IsZero() {
+		params.Set("from", query.From.Format(time.RFC3339))
+	}
+	if !query.To.IsZero() {
+		params.Set("to", query.To.Format(time.RFC3339))
+	}
+	if len(query.GroupBy) > 0 {
+		groupBy := make([]string, len(query.GroupBy))
+		for i, dimension := range query.GroupBy {
+			groupBy[i] = string(dimension)
+		}
+		params.Set("group_by", strings.Join(groupBy, ","))
+	}
+	for key, value := range map[string]string{
+		"agent":        query.AgentID,
+		"session":      query.SessionID,
+		"user":         query.UserID,
+		"model_config": query.ModelConfig,
+	} {
+		if value != "" {
+			params.Set(key, value)
+		}
+	}
+
+	path := "/api/usage"
+	if len(params) > 0 {
+		path += "?" + params.Encode()
+	}
+	resp, err := c.client.Get(ctx, path, c.client.GetUserIDOrDefault(""))
+	if err != nil {
+		return nil, err
+	}
+
+	var usage api.StandardResponse[[]api.UsageSummary]
+	if err := DecodeResponse(resp, &usage); err != nil {
+		return nil, err
+	}
+
+	return &usage, nil
+}
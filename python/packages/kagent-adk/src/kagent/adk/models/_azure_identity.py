@@ -0,0 +1,93 @@
+"""Microsoft Entra token providers for Azure OpenAI.
+
+The controller configures the agent with the same environment variables the Azure
+Workload Identity webhook injects, so these providers follow the conventions of the
+Azure SDKs without depending on them.
+"""
+
+import asyncio
+import os
+import time
+from typing import Literal
+
+import httpx
+
+COGNITIVE_SERVICES_SCOPE = "https://cognitiveservices.azure.com/.default"
+COGNITIVE_SERVICES_RESOURCE = "https://cognitiveservices.azure.com"
+DEFAULT_AUTHORITY_HOST = "https://login.microsoftonline.com/"
+IMDS_TOKEN_ENDPOINT = "http://169.254.169.254/metadata/identity/oauth2/token"
+
+# Tokens are refreshed this many seconds before they expire
+_REFRESH_MARGIN = 300
+
+TokenCredential = Literal["workload_identity", "managed_identity"]
+
+
+class AzureTokenProvider:
+    """Returns a cached Microsoft Entra access token for Azure OpenAI, refreshing it before it expires.
+
+    Instances are async callables, as expected by ``azure_ad_token_provider`` of ``AsyncAzureOpenAI``.
+    """
+
+    def __init__(self, token_credential: TokenCredential, transport: httpx.AsyncBaseTransport | None = None):
+        self.token_credential = token_credential
+        self._transport = transport
+        self._lock = asyncio.Lock()
+        self._token: str | None = None
+        self._expires_at = 0.0
+
+    async def __call__(self) -> str:
+        async with self._lock:
+            if self._token is None or time.time() >= self._expires_at - _REFRESH_MARGIN:
+                self._token, expires_in = await self._fetch_token()
+                self._expires_at = time.time() + expires_in
+            return self._token
+
+    async def _fetch_token(self) -> tuple[str, float]:
+        async with httpx.AsyncClient(transport=self._transport, timeout=30.0) as client:
+            if self.token_credential == "workload_identity":
+                response = await self._workload_identity_token(client)
+            elif self.token_credential == "managed_identity":
+                response = await self._managed_identity_token(client)
+            else:
+                raise ValueError(f"Unsupported Azure token credential: {self.token_credential}")
+
+        if response.status_code != 200:
+            # the body is not included as it may describe the assertion
+            raise RuntimeError(
+                f"Failed to get Azure AD token with {self.token_credential}: status {response.status_code}"
+            )
+        body = response.json()
+        return body["access_token"], float(body["expires_in"])
+
+    async def _workload_identity_token(self, client: httpx.AsyncClient) -> httpx.Response:
+        client_id = os.environ.get("AZURE_CLIENT_ID")
+        tenant_id = os.environ.get("AZURE_TENANT_ID")
+        token_file = os.environ.get("AZURE_FEDERATED_TOKEN_FILE")
+        if not client_id or not tenant_id or not token_file:
+            raise ValueError(
+                "AZURE_CLIENT_ID, AZURE_TENANT_ID and AZURE_FEDERATED_TOKEN_FILE environment variables "
+                "must be set to use workload identity"
+            )
+
+        # the projected token is rotated by the kubelet, so it is read for every exchange
+        with open(token_file) as f:
+            assertion = f.read().strip()
+
+        authority_host = os.environ.get("AZURE_AUTHORITY_HOST", DEFAULT_AUTHORITY_HOST)
+        return await client.post(
+            f"{authority_host.rstrip('/')}/{tenant_id}/oauth2/v2.0/token",
+            data={
+                "client_id": client_id,
+                "scope": COGNITIVE_SERVICES_SCOPE,
+                "grant_type": "client_credentials",
+                "client_assertion_type": "urn:ietf:params:oauth:client-assertion-type:jwt-bearer",
+                "client_assertion": assertion,
+            },
+        )
+
+    async def _managed_identity_token(self, client: httpx.AsyncClient) -> httpx.Response:
+        params = {"api-version": "2018-02-01", "resource": COGNITIVE_SERVICES_RESOURCE}
+        if client_id := os.environ.get("AZURE_CLIENT_ID"):
+            params["client_id"] = client_id
+        return await client.get(IMDS_TOKEN_ENDPOINT, params=params, headers={"Metadata": "true"})
@@ -0,0 +1,74 @@
+from __future__ import annotations
+
+import asyncio
+import logging
+from typing import TYPE_CHECKING, AsyncGenerator
+
+import httpx
+from google.adk.models import BaseLlm
+from google.adk.models.llm_response import LlmResponse
+
+from ._usage import MODEL_METADATA_KEY
+
+if TYPE_CHECKING:
+    from google.adk.models.llm_request import LlmRequest
+
+logger = logging.getLogger(__name__)
+
+
+def _is_retryable(error: Exception) -> bool:
+    """Whether the error is a rate limit, a server error or a timeout."""
+    if isinstance(error, (TimeoutError, httpx.TimeoutException)):
+        return True
+
+    # openai, anthropic and litellm errors carry status_code, google.genai errors carry code
+    for attr in ("status_code", "code"):
+        status = getattr(error, attr, None)
+        if isinstance(status, int):
+            return status in (408, 429) or status >= 500
+
+    name = type(error).__name__.lower()
+    return "timeout" in name or "ratelimit" in name
+
+
+class FallbackLlm(BaseLlm):
+    """Tries its models in order, moving on to the next one when a model is rate limited,
+    fails with a server error or times out. Each model is retried with exponential backoff
+    before failing over. A model that already streamed part of a response is not retried."""
+
+    models: list[BaseLlm]
+    max_attempts: int = 1
+    initial_backoff: float = 1.0
+    max_backoff: float = 30.0
+
+    async def generate_content_async(
+        self, llm_request: LlmRequest, stream: bool = False
+    ) -> AsyncGenerator[LlmResponse, None]:
+        last_error: Exception | None = None
+        for llm in self.models:
+            request = llm_request.model_copy(update={"model": llm.model})
+            for attempt in range(self.max_attempts):
+                if attempt > 0:
+                    await asyncio.sleep(min(self.initial_backoff * 2 ** (attempt - 1), self.max_backoff))
+
+                started = False
+                try:
+                    async for response in llm.generate_content_async(request, stream=stream):
+                        started = True
+                        if response.usage_metadata is not None:
+                            # account the usage to the model that served the request
+                            metadata = response.custom_metadata or {}
+                            response.custom_metadata = {**metadata, MODEL_METADATA_KEY: llm.model}
+                        yield response
+                    return
+                except Exception as e:
+                    if started or not _is_retryable(e):
+                        raise
+                    last_error = e
+                    logger.warning(
+                        "Model %s failed, attempt %d of %d: %s", llm.model, attempt + 1, self.max_attempts, e
+                    )
+            logger.warning("Failing over from model %s", llm.model)
+
+        if last_error is not None:
+            raise last_error
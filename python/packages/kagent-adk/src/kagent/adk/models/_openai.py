@@ -3,6 +3,7 @@ from __future__ import annotations
 import base64
 import json
 import os
+import ssl
 from functools import cached_property
 from typing import TYPE_CHECKING, Any, AsyncGenerator, Iterable, Literal, Optional
 
@@ -32,6 +33,8 @@ from openai.types.chat.chat_completion_message_tool_call_param import (
 from openai.types.shared_params import FunctionDefinition, FunctionParameters
 from pydantic import Field
 
+from ._azure_identity import AzureTokenProvider, TokenCredential
+from ._proxy import create_proxy_mounts, proxy_url_from_env
 from ._ssl import create_ssl_context
 
 if TYPE_CHECKING:
@@ -49,9 +52,12 @@ def _convert_role_to_openai(role: Optional[str]) -> str:
 
 
 def _convert_content_to_openai_messages(
-    contents: list[types.Content], system_instruction: Optional[str] = None
+    contents: list[types.Content], system_instruction: Optional[str] = None, include_images: bool = True
 ) -> list[ChatCompletionMessageParam]:
-    """Convert google.genai Content list to OpenAI messages format."""
+    """Convert google.genai Content list to OpenAI messages format.
+
+    Images are dropped when include_images is False, for models without vision support.
+    """
     messages: list[ChatCompletionMessageParam] = []
 
     # Add system message if provided
@@ -84,7 +90,7 @@ def _convert_content_to_openai_messages(
             elif part.function_response:
                 function_responses.append(part.function_response)
             elif part.inline_data and part.inline_data.mime_type and part.inline_data.mime_type.startswith("image"):
-                if part.inline_data.data:
+                if part.inline_data.data and include_images:
                     image_data = base64.b64encode(part.inline_data.data).decode()
                     image_part: ChatCompletionContentPartImageParam = {
                         "type": "image_url",
@@ -296,6 +302,18 @@ class BaseOpenAI(BaseLlm):
     tls_disable_verify: Optional[bool] = None
     tls_ca_cert_path: Optional[str] = None
     tls_disable_system_cas: Optional[bool] = None
+    tls_client_cert_path: Optional[str] = None
+    tls_client_key_path: Optional[str] = None
+
+    # Proxy configuration, the credentials are read from the environment
+    proxy_url: Optional[str] = None
+    no_proxy: Optional[list[str]] = None
+
+    # Capabilities of the model, all features are used when not set
+    supports_function_calling: bool = True
+    supports_streaming: bool = True
+    supports_vision: bool = True
+    supports_json_mode: bool = False
 
     @classmethod
     def supported_models(cls) -> list[str]:
@@ -317,31 +335,40 @@ class BaseOpenAI(BaseLlm):
         return disable_verify, ca_cert_path, disable_system_cas
 
     def _create_http_client(self) -> Optional[httpx.AsyncClient]:
-        """Create HTTP client with custom SSL context using OpenAI SDK defaults.
+        """Create HTTP client with custom SSL context and proxy using OpenAI SDK defaults.
 
         Uses DefaultAsyncHttpxClient to preserve OpenAI's default settings for
         timeout, connection pooling, and redirect behavior while applying custom
-        SSL configuration.
+        SSL and proxy configuration.
 
         Returns:
-            DefaultAsyncHttpxClient with SSL configuration, or None if no TLS config
+            DefaultAsyncHttpxClient with SSL and proxy configuration, or None if neither is configured
         """
         disable_verify, ca_cert_path, disable_system_cas = self._get_tls_config()
+        has_tls_config = disable_verify or ca_cert_path or disable_system_cas or self.tls_client_cert_path
+
+        # Only create custom http client if TLS or proxy configuration is present
+        if not has_tls_config and not self.proxy_url:
+            # No TLS configuration, return None to use OpenAI SDK default
+            return None
 
-        # Only create custom http client if TLS configuration is present
-        if disable_verify or ca_cert_path or disable_system_cas:
+        ssl_context: ssl.SSLContext | bool = True
+        if has_tls_config:
             ssl_context = create_ssl_context(
                 disable_verify=disable_verify,
                 ca_cert_path=ca_cert_path,
                 disable_system_cas=disable_system_cas,
+                client_cert_path=self.tls_client_cert_path,
+                client_key_path=self.tls_client_key_path,
             )
 
-            # ssl_context is either False (verification disabled) or SSLContext
-            # Use DefaultAsyncHttpxClient to preserve OpenAI's defaults
-            return DefaultAsyncHttpxClient(verify=ssl_context)
+        if self.proxy_url:
+            mounts = create_proxy_mounts(proxy_url_from_env(self.proxy_url), self.no_proxy, ssl_context)
+            return DefaultAsyncHttpxClient(mounts=mounts)
 
-        # No TLS configuration, return None to use OpenAI SDK default
-        return None
+        # ssl_context is either False (verification disabled) or SSLContext
+        # Use DefaultAsyncHttpxClient to preserve OpenAI's defaults
+        return DefaultAsyncHttpxClient(verify=ssl_context)
 
     @cached_property
     def _client(self) -> AsyncOpenAI:
@@ -376,7 +403,9 @@ class BaseOpenAI(BaseLlm):
                             text_parts.append(part.text)
                     system_instruction = "\n".join(text_parts)
 
-        messages = _convert_content_to_openai_messages(llm_request.contents, system_instruction)
+        messages = _convert_content_to_openai_messages(
+            llm_request.contents, system_instruction, include_images=self.supports_vision
+        )
 
         # Prepare request parameters
         kwargs = {
@@ -401,8 +430,15 @@ class BaseOpenAI(BaseLlm):
         if self.top_p is not None:
             kwargs["top_p"] = self.top_p
 
+        if (
+            self.supports_json_mode
+            and llm_request.config
+            and llm_request.config.response_mime_type == "application/json"
+        ):
+            kwargs["response_format"] = {"type": "json_object"}
+
         # Handle tools
-        if llm_request.config and llm_request.config.tools:
+        if self.supports_function_calling and llm_request.config and llm_request.config.tools:
             # Filter to only google.genai.types.Tool objects
             genai_tools = []
             for tool in llm_request.config.tools:
@@ -416,7 +452,7 @@ class BaseOpenAI(BaseLlm):
                     kwargs["tool_choice"] = "auto"
 
         try:
-            if stream:
+            if stream and self.supports_streaming:
                 # Handle streaming
                 async for chunk in await self._client.chat.completions.create(stream=True, **kwargs):
                     if chunk.choices and chunk.choices[0].delta:
@@ -441,6 +477,26 @@ class OpenAI(BaseOpenAI):
     type: Literal["openai"]
 
 
+class OpenAICompatible(BaseOpenAI):
+    """Model served by any server implementing the OpenAI API, such as vLLM or a LiteLLM proxy."""
+
+    type: Literal["openai_compatible"]
+
+    @cached_property
+    def _client(self) -> AsyncOpenAI:
+        """Get the OpenAI client, the API key is optional for self-hosted servers."""
+        http_client = self._create_http_client()
+
+        return AsyncOpenAI(
+            # the client requires a key, servers without authentication ignore it
+            api_key=self.api_key or os.environ.get("OPENAI_API_KEY") or "EMPTY",
+            base_url=self.base_url,
+            default_headers=self.default_headers,
+            timeout=self.timeout,
+            http_client=http_client,
+        )
+
+
 class AzureOpenAI(BaseOpenAI):
     """Azure OpenAI model implementation."""
 
@@ -448,6 +504,8 @@ class AzureOpenAI(BaseOpenAI):
     api_version: Optional[str] = None
     azure_endpoint: Optional[str] = None
     azure_deployment: Optional[str] = None
+    # Authenticate with Microsoft Entra tokens instead of an API key
+    token_credential: Optional[TokenCredential] = None
 
     @cached_property
     def _client(self) -> AsyncAzureOpenAI:
@@ -461,13 +519,22 @@ class AzureOpenAI(BaseOpenAI):
                 "Azure endpoint must be provided either via azure_endpoint parameter or AZURE_OPENAI_ENDPOINT environment variable"
             )
 
+        http_client = self._create_http_client()
+
+        if self.token_credential:
+            return AsyncAzureOpenAI(
+                azure_ad_token_provider=AzureTokenProvider(self.token_credential),
+                api_version=api_version,
+                azure_endpoint=azure_endpoint,
+                default_headers=self.default_headers,
+                http_client=http_client,
+            )
+
         if not api_key:
             raise ValueError(
                 "API key must be provided either via api_key parameter or AZURE_OPENAI_API_KEY environment variable"
             )
 
-        http_client = self._create_http_client()
-
         return AsyncAzureOpenAI(
             api_key=api_key,
             api_version=api_version,
@@ -0,0 +1,60 @@
+"""Proxy utilities for configuring httpx clients with an explicit egress proxy."""
+
+import os
+import ssl
+from urllib.parse import quote, urlsplit, urlunsplit
+
+import httpx
+
+# The controller sets the credentials of the proxy of the model in these environment variables
+PROXY_USERNAME_ENV = "MODEL_PROXY_USERNAME"
+PROXY_PASSWORD_ENV = "MODEL_PROXY_PASSWORD"
+
+
+def proxy_url_from_env(proxy_url: str) -> str:
+    """Add the proxy credentials from the environment, if any, to the proxy URL."""
+    username = os.environ.get(PROXY_USERNAME_ENV)
+    if not username:
+        return proxy_url
+    return proxy_url_with_credentials(proxy_url, username, os.environ.get(PROXY_PASSWORD_ENV, ""))
+
+
+def proxy_url_with_credentials(proxy_url: str, username: str, password: str) -> str:
+    """Return the proxy URL authenticating with the username and password."""
+    parts = urlsplit(proxy_url)
+    host = parts.hostname or ""
+    if ":" in host:
+        host = f"[{host}]"
+    if parts.port:
+        host = f"{host}:{parts.port}"
+    netloc = f"{quote(username, safe='')}:{quote(password, safe='')}@{host}"
+    return urlunsplit(parts._replace(netloc=netloc))
+
+
+def create_proxy_mounts(
+    proxy_url: str,
+    no_proxy: list[str] | None,
+    verify: ssl.SSLContext | bool = True,
+) -> dict[str, httpx.AsyncBaseTransport]:
+    """Create httpx mounts sending requests through the proxy, except those to the no-proxy hosts.
+
+    No-proxy entries follow the conventions httpx applies to the NO_PROXY environment variable:
+    "*" disables the proxy and other entries match the host and its subdomains.
+
+    Args:
+        proxy_url: URL of the proxy, including its credentials.
+        no_proxy: Hosts that are connected to directly.
+        verify: SSL verification of the connections, as accepted by httpx.
+
+    Returns:
+        Mounts for httpx.AsyncClient.
+    """
+    direct = httpx.AsyncHTTPTransport(verify=verify)
+    mounts: dict[str, httpx.AsyncBaseTransport] = {"all://": httpx.AsyncHTTPTransport(proxy=proxy_url, verify=verify)}
+    for host in no_proxy or []:
+        host = host.strip().lstrip(".")
+        if host == "*":
+            return {"all://": direct}
+        if host:
+            mounts[f"all://*{host}"] = direct
+    return mounts
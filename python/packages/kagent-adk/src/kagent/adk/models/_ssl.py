@@ -140,6 +140,8 @@ def create_ssl_context(
     disable_verify: bool,
     ca_cert_path: str | None,
     disable_system_cas: bool,
+    client_cert_path: str | None = None,
+    client_key_path: str | None = None,
 ) -> ssl.SSLContext | bool:
     """Create SSL context for httpx client based on TLS configuration.
 
@@ -156,9 +158,12 @@ def create_ssl_context(
         disable_system_cas: If True, system CA certificates are NOT included in the trust store.
             When False (default), system CAs are used (safe behavior).
             When True with ca_cert_path, only the custom CA is trusted.
+        client_cert_path: Optional path to the client certificate presented for mutual TLS, in PEM format.
+        client_key_path: Optional path to the private key of the client certificate, in PEM format.
 
     Returns:
-        - False if disable_verify=True (httpx special value to disable verification)
+        - False if disable_verify=True and no client certificate is set
+          (httpx special value to disable verification)
         - ssl.SSLContext configured with appropriate CA certificates otherwise
 
     Raises:
@@ -195,7 +200,15 @@ def create_ssl_context(
             "=" * 60
         )
         logger.info("TLS Mode: Disabled (disable_verify=True)")
-        return False  # httpx accepts False to disable verification
+        if not client_cert_path:
+            return False  # httpx accepts False to disable verification
+
+        # a context is still needed to present the client certificate
+        ctx = ssl.create_default_context()
+        ctx.check_hostname = False
+        ctx.verify_mode = ssl.CERT_NONE
+        _load_client_certificate(ctx, client_cert_path, client_key_path)
+        return ctx
 
     # Determine TLS mode
     if ca_cert_path and not disable_system_cas:
@@ -242,4 +255,19 @@ def create_ssl_context(
                 f"You can inspect it with: openssl x509 -in {ca_cert_path} -text -noout"
             ) from e
 
+    if client_cert_path:
+        _load_client_certificate(ctx, client_cert_path, client_key_path)
+
     return ctx
+
+
+def _load_client_certificate(ctx: ssl.SSLContext, cert_path: str, key_path: str | None) -> None:
+    """Load the client certificate presented for mutual TLS into the SSL context."""
+    try:
+        ctx.load_cert_chain(certfile=cert_path, keyfile=key_path)
+        logger.info("Client certificate loaded from: %s", cert_path)
+    except (OSError, ssl.SSLError) as e:
+        raise ssl.SSLError(
+            f"Failed to load client certificate from {cert_path}: {e}\n"
+            f"Please ensure the kubernetes.io/tls Secret is mounted and contains tls.crt and tls.key."
+        ) from e
@@ -0,0 +1,49 @@
+"""Model callbacks recording the model, the ModelConfig and the latency of model calls in the custom metadata of their events.
+
+The controller reads them, together with the usage metadata of the events, to account the token usage of agents.
+"""
+
+import time
+from contextvars import ContextVar
+from typing import Optional
+
+from google.adk.agents.callback_context import CallbackContext
+from google.adk.models.llm_request import LlmRequest
+from google.adk.models.llm_response import LlmResponse
+
+# Keep in sync with go/internal/usage
+MODEL_METADATA_KEY = "kagent_model"
+MODEL_CONFIG_METADATA_KEY = "kagent_model_config"
+LATENCY_METADATA_KEY = "kagent_latency_ms"
+
+# The model and the start time of the model call in progress
+_model_call: ContextVar[Optional[tuple[str, float]]] = ContextVar("kagent_model_call", default=None)
+
+
+def record_model_call_start(callback_context: CallbackContext, llm_request: LlmRequest) -> Optional[LlmResponse]:
+    """Before model callback recording the model and the start time of the call."""
+    _model_call.set((llm_request.model or "", time.monotonic()))
+    return None
+
+
+def record_model_call_usage(
+    callback_context: CallbackContext, llm_response: LlmResponse, *, model_config: Optional[str] = None
+) -> Optional[LlmResponse]:
+    """After model callback adding the model, the ModelConfig and the latency of the call to responses carrying
+    usage metadata. The ModelConfig is the namespace/name of the ModelConfig of the agent, bound with functools.partial.
+
+    A model already set on the response, e.g. the model a fallback model failed over to, is kept.
+    """
+    model_call = _model_call.get()
+    if llm_response.usage_metadata is None or model_call is None:
+        return None
+
+    model, started_at = model_call
+    metadata = dict(llm_response.custom_metadata or {})
+    if model:
+        metadata.setdefault(MODEL_METADATA_KEY, model)
+    if model_config:
+        metadata[MODEL_CONFIG_METADATA_KEY] = model_config
+    metadata[LATENCY_METADATA_KEY] = int((time.monotonic() - started_at) * 1000)
+    llm_response.custom_metadata = metadata
+    return None
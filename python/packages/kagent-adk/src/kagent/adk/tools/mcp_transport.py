@@ -0,0 +1,95 @@
+"""TLS and proxy configuration of the connections to MCP servers.
+
+The controller resolves the referenced secrets and inlines the certificates and proxy
+credentials in the agent config, which is itself stored in a Secret.
+"""
+
+import os
+import ssl
+import tempfile
+from typing import Any
+
+import httpx
+from pydantic import BaseModel
+
+from ..models._proxy import create_proxy_mounts
+from ..models._ssl import create_ssl_context
+
+# the defaults of the MCP client
+_DEFAULT_TIMEOUT = httpx.Timeout(30.0, read=300.0)
+
+
+class McpTransportConfig(BaseModel):
+    tls_disable_verify: bool = False
+    tls_disable_system_cas: bool = False
+    # PEM encoded certificates and key
+    tls_ca_cert: str | None = None
+    tls_client_cert: str | None = None
+    tls_client_key: str | None = None
+    # URL of the proxy, including its credentials
+    proxy_url: str | None = None
+    no_proxy: list[str] | None = None
+
+
+def _write_pem(directory: str, name: str, pem: str | None) -> str | None:
+    if not pem:
+        return None
+    path = os.path.join(directory, name)
+    with open(os.open(path, os.O_WRONLY | os.O_CREAT | os.O_TRUNC, 0o600), "w") as f:
+        f.write(pem)
+    return path
+
+
+def create_httpx_client_factory(config: McpTransportConfig):
+    """Create a factory of httpx clients honouring the TLS and proxy configuration of a MCP server.
+
+    The factory has the signature of ``httpx_client_factory`` of the MCP client transports.
+    """
+    verify: ssl.SSLContext | bool = True
+    if config.tls_disable_verify or config.tls_disable_system_cas or config.tls_ca_cert or config.tls_client_cert:
+        # ssl only loads certificates from files
+        directory = tempfile.mkdtemp(prefix="kagent-mcp-tls-")
+        verify = create_ssl_context(
+            disable_verify=config.tls_disable_verify,
+            ca_cert_path=_write_pem(directory, "ca.crt", config.tls_ca_cert),
+            disable_system_cas=config.tls_disable_system_cas,
+            client_cert_path=_write_pem(directory, "tls.crt", config.tls_client_cert),
+            client_key_path=_write_pem(directory, "tls.key", config.tls_client_key),
+        )
+
+    def factory(
+        headers: dict[str, str] | None = None,
+        timeout: httpx.Timeout | None = None,
+        auth: httpx.Auth | None = None,
+    ) -> httpx.AsyncClient:
+        kwargs: dict[str, Any] = {
+            "follow_redirects": True,
+            "headers": headers,
+            "timeout": timeout or _DEFAULT_TIMEOUT,
+            "auth": auth,
+        }
+        if config.proxy_url:
+            # transports are closed with their client, so every client gets its own
+            kwargs["mounts"] = create_proxy_mounts(config.proxy_url, config.no_proxy, verify)
+        else:
+            kwargs["verify"] = verify
+        return httpx.AsyncClient(**kwargs)
+
+    return factory
+
+
+def with_transport(params, transport: McpTransportConfig | None):
+    """Return the connection params of a MCP server using the TLS and proxy configuration, if any.
+
+    Raises:
+        ValueError: If the installed google-adk cannot use a custom HTTP client, the MCP server
+            would otherwise be connected to without its TLS and proxy configuration.
+    """
+    if transport is None:
+        return params
+    if "httpx_client_factory" not in type(params).model_fields:
+        raise ValueError(
+            f"MCP server {params.url} has a TLS or proxy configuration, "
+            "but the installed google-adk does not support custom HTTP clients"
+        )
+    return params.model_copy(update={"httpx_client_factory": create_httpx_client_factory(transport)})
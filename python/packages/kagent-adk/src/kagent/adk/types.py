@@ -1,5 +1,7 @@
+import functools
 import logging
-from typing import Any, Literal, Optional, Union
+import os
+from typing import Annotated, Any, Literal, Optional, Union
 
 import httpx
 from google.adk.agents import Agent
@@ -7,6 +9,7 @@ from google.adk.agents.base_agent import BaseAgent
 from google.adk.agents.llm_agent import ToolUnion
 from google.adk.agents.remote_a2a_agent import AGENT_CARD_WELL_KNOWN_PATH, DEFAULT_TIMEOUT, RemoteA2aAgent
 from google.adk.code_executors.base_code_executor import BaseCodeExecutor
+from google.adk.models import BaseLlm
 from google.adk.models.anthropic_llm import Claude as ClaudeLLM
 from google.adk.models.google_llm import Gemini as GeminiLLM
 from google.adk.models.lite_llm import LiteLlm
@@ -15,9 +18,13 @@ from google.adk.tools.mcp_tool import MCPToolset, SseConnectionParams, Streamabl
 from pydantic import BaseModel, Field
 
 from kagent.adk.sandbox_code_executer import SandboxedLocalCodeExecutor
+from kagent.adk.tools.mcp_transport import McpTransportConfig, with_transport
 
 from .models import AzureOpenAI as OpenAIAzure
+from .models import FallbackLlm
 from .models import OpenAI as OpenAINative
+from .models import OpenAICompatible as OpenAICompatibleNative
+from .models._usage import record_model_call_start, record_model_call_usage
 
 logger = logging.getLogger(__name__)
 
@@ -25,11 +32,13 @@ logger = logging.getLogger(__name__)
 class HttpMcpServerConfig(BaseModel):
     params: StreamableHTTPConnectionParams
     tools: list[str] = Field(default_factory=list)
+    transport: McpTransportConfig | None = None
 
 
 class SseMcpServerConfig(BaseModel):
     params: SseConnectionParams
     tools: list[str] = Field(default_factory=list)
+    transport: McpTransportConfig | None = None
 
 
 class RemoteAgentConfig(BaseModel):
@@ -40,14 +49,54 @@ class RemoteAgentConfig(BaseModel):
     description: str = ""
 
 
+class PineconeMemoryConfig(BaseModel):
+    index_host: str
+    top_k: int | None = None
+    namespace: str | None = None
+    record_fields: list[str] | None = None
+    score_threshold: float | None = None
+
+
+class MemoryConfig(BaseModel):
+    name: str
+    type: Literal["pinecone"]
+    api_key_env: str | None = None  # env var holding the memory provider API key
+    pinecone: PineconeMemoryConfig | None = None
+
+
+class ModelCapabilities(BaseModel):
+    function_calling: bool = True
+    streaming: bool = True
+    vision: bool = True
+    json_mode: bool = False
+
+
 class BaseLLM(BaseModel):
     model: str
     headers: dict[str, str] | None = None
+    # unset when the model supports everything the agent may use
+    capabilities: ModelCapabilities | None = None
 
     # TLS/SSL configuration (applies to all model types)
     tls_disable_verify: bool | None = None
     tls_ca_cert_path: str | None = None
     tls_disable_system_cas: bool | None = None
+    tls_client_cert_path: str | None = None
+    tls_client_key_path: str | None = None
+
+    # Proxy configuration
+    proxy_url: str | None = None
+    no_proxy: list[str] | None = None
+
+    # variables of the provider read from other variables, e.g. for the models of a fallback chain
+    env: dict[str, str] | None = None
+
+
+def _env_override(config: BaseLLM, name: str) -> str | None:
+    """Returns the value of a variable of the provider that the config points at another variable."""
+    if config.env and name in config.env:
+        return os.environ.get(config.env[name])
+    return None
 
 
 class OpenAI(BaseLLM):
@@ -66,11 +115,20 @@ class OpenAI(BaseLLM):
 
 
 class AzureOpenAI(BaseLLM):
+    max_tokens: int | None = None
+    temperature: float | None = None
+    top_p: float | None = None
+
     type: Literal["azure_openai"]
+    token_credential: Literal["workload_identity", "managed_identity"] | None = None
 
 
 class Anthropic(BaseLLM):
     base_url: str | None = None
+    max_tokens: int | None = None
+    temperature: float | None = None
+    top_k: int | None = None
+    top_p: float | None = None
 
     type: Literal["anthropic"]
 
@@ -91,16 +149,175 @@ class Gemini(BaseLLM):
     type: Literal["gemini"]
 
 
+class Bedrock(BaseLLM):
+    region: str
+    inference_profile: str | None = None
+    guardrail_id: str | None = None
+    guardrail_version: str | None = None
+    max_tokens: int | None = None
+    temperature: float | None = None
+    top_p: float | None = None
+
+    type: Literal["bedrock"]
+
+
+class OpenAICompatible(BaseLLM):
+    base_url: str
+    max_tokens: int | None = None
+    temperature: float | None = None
+    timeout: int | None = None
+    top_p: float | None = None
+
+    type: Literal["openai_compatible"]
+
+
+LlmConfig = Union[
+    OpenAI, Anthropic, GeminiVertexAI, GeminiAnthropic, Ollama, AzureOpenAI, Gemini, Bedrock, OpenAICompatible
+]
+
+
+class ModelRetryPolicy(BaseModel):
+    max_attempts: int = 1
+    initial_backoff: float = 1.0  # seconds
+    max_backoff: float = 30.0  # seconds
+
+
+def _create_llm(config: LlmConfig) -> BaseLlm | str:
+    extra_headers = config.headers or {}
+
+    if config.type == "openai":
+        return OpenAINative(
+            type="openai",
+            api_key=_env_override(config, "OPENAI_API_KEY"),
+            base_url=config.base_url,
+            default_headers=extra_headers,
+            frequency_penalty=config.frequency_penalty,
+            max_tokens=config.max_tokens,
+            model=config.model,
+            n=config.n,
+            presence_penalty=config.presence_penalty,
+            reasoning_effort=config.reasoning_effort,
+            seed=config.seed,
+            temperature=config.temperature,
+            timeout=config.timeout,
+            top_p=config.top_p,
+            # TLS configuration
+            tls_disable_verify=config.tls_disable_verify,
+            tls_ca_cert_path=config.tls_ca_cert_path,
+            tls_disable_system_cas=config.tls_disable_system_cas,
+            tls_client_cert_path=config.tls_client_cert_path,
+            tls_client_key_path=config.tls_client_key_path,
+            # Proxy configuration
+            proxy_url=config.proxy_url,
+            no_proxy=config.no_proxy,
+        )
+    elif config.type == "anthropic":
+        kwargs = {}
+        if api_key := _env_override(config, "ANTHROPIC_API_KEY"):
+            kwargs["api_key"] = api_key
+        return LiteLlm(
+            model=f"anthropic/{config.model}",
+            base_url=config.base_url,
+            extra_headers=extra_headers,
+            max_tokens=config.max_tokens,
+            temperature=config.temperature,
+            top_k=config.top_k,
+            top_p=config.top_p,
+            **kwargs,
+        )
+    elif config.type == "gemini_vertex_ai":
+        return GeminiLLM(model=config.model)
+    elif config.type == "gemini_anthropic":
+        return ClaudeLLM(model=config.model)
+    elif config.type == "ollama":
+        return LiteLlm(model=f"ollama_chat/{config.model}", extra_headers=extra_headers)
+    elif config.type == "azure_openai":
+        return OpenAIAzure(
+            model=config.model,
+            type="azure_openai",
+            api_key=_env_override(config, "AZURE_OPENAI_API_KEY"),
+            api_version=_env_override(config, "OPENAI_API_VERSION"),
+            azure_endpoint=_env_override(config, "AZURE_OPENAI_ENDPOINT"),
+            default_headers=extra_headers,
+            max_tokens=config.max_tokens,
+            temperature=config.temperature,
+            token_credential=config.token_credential,
+            top_p=config.top_p,
+            # TLS configuration
+            tls_disable_verify=config.tls_disable_verify,
+            tls_ca_cert_path=config.tls_ca_cert_path,
+            tls_disable_system_cas=config.tls_disable_system_cas,
+            tls_client_cert_path=config.tls_client_cert_path,
+            tls_client_key_path=config.tls_client_key_path,
+            # Proxy configuration
+            proxy_url=config.proxy_url,
+            no_proxy=config.no_proxy,
+        )
+    elif config.type == "gemini":
+        return config.model
+    elif config.type == "openai_compatible":
+        capabilities = config.capabilities or ModelCapabilities()
+        return OpenAICompatibleNative(
+            type="openai_compatible",
+            api_key=_env_override(config, "OPENAI_API_KEY"),
+            base_url=config.base_url,
+            default_headers=extra_headers,
+            max_tokens=config.max_tokens,
+            model=config.model,
+            temperature=config.temperature,
+            timeout=config.timeout,
+            top_p=config.top_p,
+            supports_function_calling=capabilities.function_calling,
+            supports_streaming=capabilities.streaming,
+            supports_vision=capabilities.vision,
+            supports_json_mode=capabilities.json_mode,
+            # TLS configuration
+            tls_disable_verify=config.tls_disable_verify,
+            tls_ca_cert_path=config.tls_ca_cert_path,
+            tls_disable_system_cas=config.tls_disable_system_cas,
+            tls_client_cert_path=config.tls_client_cert_path,
+            tls_client_key_path=config.tls_client_key_path,
+            # Proxy configuration
+            proxy_url=config.proxy_url,
+            no_proxy=config.no_proxy,
+        )
+    elif config.type == "bedrock":
+        # credentials are resolved by boto3 from the environment (bearer token, web identity or instance role)
+        kwargs = {}
+        if config.inference_profile:
+            kwargs["model_id"] = config.inference_profile
+        if config.guardrail_id:
+            kwargs["guardrailConfig"] = {
+                "guardrailIdentifier": config.guardrail_id,
+                "guardrailVersion": config.guardrail_version,
+            }
+        return LiteLlm(
+            model=f"bedrock/{config.model}",
+            aws_region_name=config.region,
+            extra_headers=extra_headers,
+            max_tokens=config.max_tokens,
+            temperature=config.temperature,
+            top_p=config.top_p,
+            **kwargs,
+        )
+    else:
+        raise ValueError(f"Invalid model type: {config.type}")
+
+
 class AgentConfig(BaseModel):
-    model: Union[OpenAI, Anthropic, GeminiVertexAI, GeminiAnthropic, Ollama, AzureOpenAI, Gemini] = Field(
-        discriminator="type"
-    )
+    model: LlmConfig = Field(discriminator="type")
+    # models tried in order when the model is rate limited, fails or times out
+    fallback_models: list[Annotated[LlmConfig, Field(discriminator="type")]] | None = None
+    model_retry: ModelRetryPolicy | None = None
     description: str
     instruction: str
     http_tools: list[HttpMcpServerConfig] | None = None  # Streamable HTTP MCP tools
     sse_tools: list[SseMcpServerConfig] | None = None  # SSE MCP tools
     remote_agents: list[RemoteAgentConfig] | None = None  # remote agents
+    memory: list[MemoryConfig] | None = None  # vector memories, resolved by the controller
     execute_code: bool | None = None
+    # namespace/name of the ModelConfig of the agent, recorded with the token usage of its model calls
+    model_config_ref: str | None = None
 
     def to_agent(self, name: str) -> Agent:
         if name is None or not str(name).strip():
@@ -108,10 +325,12 @@ class AgentConfig(BaseModel):
         tools: list[ToolUnion] = []
         if self.http_tools:
             for http_tool in self.http_tools:  # add http tools
-                tools.append(MCPToolset(connection_params=http_tool.params, tool_filter=http_tool.tools))
+                params = with_transport(http_tool.params, http_tool.transport)
+                tools.append(MCPToolset(connection_params=params, tool_filter=http_tool.tools))
         if self.sse_tools:
             for sse_tool in self.sse_tools:  # add sse tools
-                tools.append(MCPToolset(connection_params=sse_tool.params, tool_filter=sse_tool.tools))
+                params = with_transport(sse_tool.params, sse_tool.transport)
+                tools.append(MCPToolset(connection_params=params, tool_filter=sse_tool.tools))
         if self.remote_agents:
             for remote_agent in self.remote_agents:  # Add remote agents as tools
                 client = None
@@ -130,54 +349,24 @@ class AgentConfig(BaseModel):
 
                 tools.append(AgentTool(agent=remote_a2a_agent))
 
-        extra_headers = self.model.headers or {}
-
         code_executor = SandboxedLocalCodeExecutor() if self.execute_code else None
 
-        if self.model.type == "openai":
-            model = OpenAINative(
-                type="openai",
-                base_url=self.model.base_url,
-                default_headers=extra_headers,
-                frequency_penalty=self.model.frequency_penalty,
-                max_tokens=self.model.max_tokens,
-                model=self.model.model,
-                n=self.model.n,
-                presence_penalty=self.model.presence_penalty,
-                reasoning_effort=self.model.reasoning_effort,
-                seed=self.model.seed,
-                temperature=self.model.temperature,
-                timeout=self.model.timeout,
-                top_p=self.model.top_p,
-                # TLS configuration
-                tls_disable_verify=self.model.tls_disable_verify,
-                tls_ca_cert_path=self.model.tls_ca_cert_path,
-                tls_disable_system_cas=self.model.tls_disable_system_cas,
-            )
-        elif self.model.type == "anthropic":
-            model = LiteLlm(
-                model=f"anthropic/{self.model.model}", base_url=self.model.base_url, extra_headers=extra_headers
+        model = _create_llm(self.model)
+        if self.fallback_models:
+            retry = self.model_retry or ModelRetryPolicy()
+            # the fallback needs model instances, gemini models are otherwise resolved by name
+            llms = [
+                GeminiLLM(model=llm) if isinstance(llm, str) else llm
+                for llm in [model, *(_create_llm(m) for m in self.fallback_models)]
+            ]
+            model = FallbackLlm(
+                model=llms[0].model,
+                models=llms,
+                max_attempts=retry.max_attempts,
+                initial_backoff=retry.initial_backoff,
+                max_backoff=retry.max_backoff,
             )
-        elif self.model.type == "gemini_vertex_ai":
-            model = GeminiLLM(model=self.model.model)
-        elif self.model.type == "gemini_anthropic":
-            model = ClaudeLLM(model=self.model.model)
-        elif self.model.type == "ollama":
-            model = LiteLlm(model=f"ollama_chat/{self.model.model}", extra_headers=extra_headers)
-        elif self.model.type == "azure_openai":
-            model = OpenAIAzure(
-                model=self.model.model,
-                type="azure_openai",
-                default_headers=extra_headers,
-                # TLS configuration
-                tls_disable_verify=self.model.tls_disable_verify,
-                tls_ca_cert_path=self.model.tls_ca_cert_path,
-                tls_disable_system_cas=self.model.tls_disable_system_cas,
-            )
-        elif self.model.type == "gemini":
-            model = self.model.model
-        else:
-            raise ValueError(f"Invalid model type: {self.model.type}")
+
         return Agent(
             name=name,
             model=model,
@@ -185,4 +374,6 @@ class AgentConfig(BaseModel):
             instruction=self.instruction,
             tools=tools,
             code_executor=code_executor,
+            before_model_callback=record_model_call_start,
+            after_model_callback=functools.partial(record_model_call_usage, model_config=self.model_config_ref),
         )
@@ -0,0 +1,65 @@
+"""Unit tests for the Microsoft Entra token providers of Azure OpenAI."""
+
+from urllib.parse import parse_qs
+
+import httpx
+import pytest
+
+from kagent.adk.models._azure_identity import COGNITIVE_SERVICES_SCOPE, AzureTokenProvider
+
+
+@pytest.mark.asyncio
+async def test_workload_identity_exchanges_federated_token(tmp_path, monkeypatch):
+    token_file = tmp_path / "azure-identity-token"
+    token_file.write_text("service-account-token\n")
+    monkeypatch.setenv("AZURE_CLIENT_ID", "client-id")
+    monkeypatch.setenv("AZURE_TENANT_ID", "tenant-id")
+    monkeypatch.setenv("AZURE_FEDERATED_TOKEN_FILE", str(token_file))
+    monkeypatch.setenv("AZURE_AUTHORITY_HOST", "https://login.example.com/")
+
+    requests = []
+
+    def handler(request: httpx.Request) -> httpx.Response:
+        requests.append(request)
+        return httpx.Response(200, json={"access_token": "entra-token", "expires_in": 3600})
+
+    provider = AzureTokenProvider("workload_identity", transport=httpx.MockTransport(handler))
+
+    assert await provider() == "entra-token"
+    assert await provider() == "entra-token"
+    assert len(requests) == 1, "the token is cached until it expires"
+
+    request = requests[0]
+    assert str(request.url) == "https://login.example.com/tenant-id/oauth2/v2.0/token"
+    form = parse_qs(request.content.decode())
+    assert form["client_id"] == ["client-id"]
+    assert form["client_assertion"] == ["service-account-token"]
+    assert form["scope"] == [COGNITIVE_SERVICES_SCOPE]
+
+
+@pytest.mark.asyncio
+async def test_managed_identity_uses_instance_metadata(monkeypatch):
+    monkeypatch.setenv("AZURE_CLIENT_ID", "user-assigned")
+
+    def handler(request: httpx.Request) -> httpx.Response:
+        assert request.headers["Metadata"] == "true"
+        assert request.url.params["client_id"] == "user-assigned"
+        return httpx.Response(200, json={"access_token": "imds-token", "expires_in": "3599"})
+
+    provider = AzureTokenProvider("managed_identity", transport=httpx.MockTransport(handler))
+
+    assert await provider() == "imds-token"
+
+
+@pytest.mark.asyncio
+async def test_token_error_does_not_leak_response(monkeypatch):
+    monkeypatch.delenv("AZURE_CLIENT_ID", raising=False)
+
+    def handler(request: httpx.Request) -> httpx.Response:
+        return httpx.Response(400, json={"error_description": "secret details"})
+
+    provider = AzureTokenProvider("managed_identity", transport=httpx.MockTransport(handler))
+
+    with pytest.raises(RuntimeError) as exc_info:
+        await provider()
+    assert "secret details" not in str(exc_info.value)
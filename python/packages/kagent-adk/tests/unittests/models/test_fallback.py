@@ -0,0 +1,97 @@
+"""Unit tests for failing over between models."""
+
+from typing import AsyncGenerator
+
+import httpx
+import pytest
+from google.adk.models import BaseLlm
+from google.adk.models.llm_request import LlmRequest
+from google.adk.models.llm_response import LlmResponse
+from google.genai import types
+from pydantic import ConfigDict
+
+from kagent.adk.models import FallbackLlm
+from kagent.adk.models._usage import MODEL_METADATA_KEY
+
+
+class StatusError(Exception):
+    def __init__(self, status_code: int):
+        super().__init__(f"status {status_code}")
+        self.status_code = status_code
+
+
+class FakeLlm(BaseLlm):
+    model_config = ConfigDict(arbitrary_types_allowed=True)
+
+    errors: list[Exception] = []
+    requested_models: list[str] = []
+
+    async def generate_content_async(
+        self, llm_request: LlmRequest, stream: bool = False
+    ) -> AsyncGenerator[LlmResponse, None]:
+        self.requested_models.append(llm_request.model)
+        if self.errors:
+            raise self.errors.pop(0)
+        yield LlmResponse(content=types.Content(role="model", parts=[types.Part(text=self.model)]))
+
+
+async def collect(llm: BaseLlm) -> list[str]:
+    request = LlmRequest(model=llm.model, contents=[])
+    return [response.content.parts[0].text async for response in llm.generate_content_async(request)]
+
+
+async def test_fails_over_on_rate_limit():
+    primary = FakeLlm(model="primary", errors=[StatusError(429)])
+    secondary = FakeLlm(model="secondary")
+    llm = FallbackLlm(model="primary", models=[primary, secondary])
+
+    assert await collect(llm) == ["secondary"]
+    assert secondary.requested_models == ["secondary"]
+
+
+async def test_retries_before_failing_over():
+    primary = FakeLlm(model="primary", errors=[StatusError(503), httpx.ReadTimeout("timed out")])
+    secondary = FakeLlm(model="secondary")
+    llm = FallbackLlm(model="primary", models=[primary, secondary], max_attempts=3, initial_backoff=0)
+
+    assert await collect(llm) == ["primary"]
+    assert primary.requested_models == ["primary"] * 3
+    assert secondary.requested_models == []
+
+
+async def test_does_not_fail_over_on_client_error():
+    primary = FakeLlm(model="primary", errors=[StatusError(400)])
+    secondary = FakeLlm(model="secondary")
+    llm = FallbackLlm(model="primary", models=[primary, secondary])
+
+    with pytest.raises(StatusError):
+        await collect(llm)
+    assert secondary.requested_models == []
+
+
+async def test_raises_last_error_when_all_models_fail():
+    primary = FakeLlm(model="primary", errors=[StatusError(500)])
+    secondary = FakeLlm(model="secondary", errors=[StatusError(502)])
+    llm = FallbackLlm(model="primary", models=[primary, secondary])
+
+    with pytest.raises(StatusError, match="status 502"):
+        await collect(llm)
+
+
+async def test_records_model_serving_the_request():
+    class UsageLlm(FakeLlm):
+        async def generate_content_async(
+            self, llm_request: LlmRequest, stream: bool = False
+        ) -> AsyncGenerator[LlmResponse, None]:
+            if self.errors:
+                raise self.errors.pop(0)
+            usage = types.GenerateContentResponseUsageMetadata(prompt_token_count=10, candidates_token_count=5)
+            yield LlmResponse(usage_metadata=usage)
+
+    primary = UsageLlm(model="primary", errors=[StatusError(429)])
+    secondary = UsageLlm(model="secondary")
+    llm = FallbackLlm(model="primary", models=[primary, secondary])
+
+    request = LlmRequest(model=llm.model, contents=[])
+    responses = [response async for response in llm.generate_content_async(request)]
+    assert responses[0].custom_metadata == {MODEL_METADATA_KEY: "secondary"}
@@ -21,7 +21,7 @@ from google.genai import types
 from google.genai.types import Content, Part
 from openai.types.chat.chat_completion_tool_param import ChatCompletionToolParam
 
-from kagent.adk.models import OpenAI
+from kagent.adk.models import OpenAI, OpenAICompatible
 from kagent.adk.models._openai import _convert_tools_to_openai
 
 
@@ -338,6 +338,46 @@ async def test_generate_content_async_with_max_tokens(llm_request, generate_cont
         assert kwargs["max_tokens"] == 4096
 
 
+@pytest.mark.asyncio
+async def test_generate_content_async_without_function_calling(generate_content_response):
+    llm = OpenAICompatible(
+        model="qwen3-32b",
+        type="openai_compatible",
+        base_url="http://vllm:8000/v1",
+        supports_function_calling=False,
+        supports_streaming=False,
+        supports_json_mode=True,
+    )
+    llm_request = LlmRequest(
+        model="qwen3-32b",
+        contents=[Content(role="user", parts=[Part.from_text(text="Hello")])],
+        config=types.GenerateContentConfig(
+            response_mime_type="application/json",
+            tools=[types.Tool(function_declarations=[types.FunctionDeclaration(name="get_weather")])],
+        ),
+    )
+    with mock.patch.object(llm, "_client") as mock_client:
+
+        async def mock_coro(*args, **kwargs):
+            return generate_content_response
+
+        mock_client.chat.completions.create.return_value = mock_coro()
+
+        _ = [resp async for resp in llm.generate_content_async(llm_request, stream=True)]
+        _, kwargs = mock_client.chat.completions.create.call_args
+        assert kwargs["stream"] is False
+        assert "tools" not in kwargs
+        assert kwargs["response_format"] == {"type": "json_object"}
+
+
+def test_openai_compatible_client_without_api_key(monkeypatch):
+    monkeypatch.delenv("OPENAI_API_KEY", raising=False)
+    llm = OpenAICompatible(model="qwen3-32b", type="openai_compatible", base_url="http://vllm:8000/v1")
+
+    assert llm._client.api_key == "EMPTY"
+    assert str(llm._client.base_url) == "http://vllm:8000/v1/"
+
+
 # ============================================================================
 # SSL/TLS Configuration Tests
 # ============================================================================
@@ -0,0 +1,62 @@
+"""Unit tests for recording the model and the latency of model calls."""
+
+from unittest.mock import MagicMock
+
+from google.adk.models.llm_request import LlmRequest
+from google.adk.models.llm_response import LlmResponse
+from google.genai import types
+
+from kagent.adk.models._usage import (
+    LATENCY_METADATA_KEY,
+    MODEL_CONFIG_METADATA_KEY,
+    MODEL_METADATA_KEY,
+    record_model_call_start,
+    record_model_call_usage,
+)
+
+
+def usage_response(**kwargs) -> LlmResponse:
+    usage = types.GenerateContentResponseUsageMetadata(prompt_token_count=10, candidates_token_count=5)
+    return LlmResponse(usage_metadata=usage, **kwargs)
+
+
+def test_records_model_and_latency():
+    context = MagicMock()
+    record_model_call_start(context, LlmRequest(model="gpt-4o"))
+
+    response = usage_response()
+    assert record_model_call_usage(context, response) is None
+    assert response.custom_metadata[MODEL_METADATA_KEY] == "gpt-4o"
+    assert response.custom_metadata[LATENCY_METADATA_KEY] >= 0
+
+
+def test_records_model_config():
+    context = MagicMock()
+    record_model_call_start(context, LlmRequest(model="gpt-4o"))
+
+    response = usage_response()
+    record_model_call_usage(context, response, model_config="default/openai")
+    assert response.custom_metadata[MODEL_CONFIG_METADATA_KEY] == "default/openai"
+
+    response = usage_response()
+    record_model_call_usage(context, response)
+    assert MODEL_CONFIG_METADATA_KEY not in response.custom_metadata
+
+
+def test_keeps_model_set_by_fallback():
+    context = MagicMock()
+    record_model_call_start(context, LlmRequest(model="primary"))
+
+    response = usage_response(custom_metadata={MODEL_METADATA_KEY: "secondary", "other": "value"})
+    record_model_call_usage(context, response)
+    assert response.custom_metadata[MODEL_METADATA_KEY] == "secondary"
+    assert response.custom_metadata["other"] == "value"
+
+
+def test_ignores_responses_without_usage():
+    context = MagicMock()
+    record_model_call_start(context, LlmRequest(model="gpt-4o"))
+
+    response = LlmResponse(partial=True)
+    record_model_call_usage(context, response)
+    assert response.custom_metadata is None
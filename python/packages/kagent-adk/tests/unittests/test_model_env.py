@@ -0,0 +1,35 @@
+"""Unit tests for the models reading the variables of their provider from other variables."""
+
+from kagent.adk.types import AzureOpenAI, OpenAI, _create_llm
+
+
+def test_model_reads_variables_its_config_points_at(monkeypatch):
+    monkeypatch.setenv("OPENAI_API_KEY", "default-key")
+    monkeypatch.setenv("OPENAI_API_KEY_GPT_4O_MINI", "mini-key")
+
+    llm = _create_llm(
+        OpenAI(type="openai", model="gpt-4o-mini", env={"OPENAI_API_KEY": "OPENAI_API_KEY_GPT_4O_MINI"})
+    )
+    assert llm.api_key == "mini-key"
+
+    # without env, the client reads the default variable
+    assert _create_llm(OpenAI(type="openai", model="gpt-4o")).api_key is None
+
+
+def test_azure_model_reads_variables_its_config_points_at(monkeypatch):
+    monkeypatch.setenv("AZURE_OPENAI_API_KEY_AZURE", "azure-key")
+    monkeypatch.setenv("AZURE_OPENAI_ENDPOINT_AZURE", "https://azure.example.com")
+
+    llm = _create_llm(
+        AzureOpenAI(
+            type="azure_openai",
+            model="gpt-4o",
+            env={
+                "AZURE_OPENAI_API_KEY": "AZURE_OPENAI_API_KEY_AZURE",
+                "AZURE_OPENAI_ENDPOINT": "AZURE_OPENAI_ENDPOINT_AZURE",
+            },
+        )
+    )
+    assert llm.api_key == "azure-key"
+    assert llm.azure_endpoint == "https://azure.example.com"
+    assert llm.api_version is None
@@ -0,0 +1,68 @@
+"""Unit tests for the TLS and proxy configuration of the connections to MCP servers."""
+
+import os
+import stat
+from unittest import mock
+
+import httpx
+import pytest
+from google.adk.tools.mcp_tool import StreamableHTTPConnectionParams
+from pydantic import BaseModel
+
+from kagent.adk.tools import mcp_transport
+from kagent.adk.tools.mcp_transport import McpTransportConfig, create_httpx_client_factory, with_transport
+
+
+def test_factory_without_configuration():
+    factory = create_httpx_client_factory(McpTransportConfig())
+    auth = httpx.BasicAuth("user", "password")
+
+    client = factory(headers={"X-Api-Key": "key"}, auth=auth)
+    assert client.headers["X-Api-Key"] == "key"
+    assert client.timeout == mcp_transport._DEFAULT_TIMEOUT
+    assert client.auth is auth
+    assert client.follow_redirects
+
+    client = factory(timeout=httpx.Timeout(5.0))
+    assert client.timeout == httpx.Timeout(5.0)
+
+
+def test_factory_writes_certificates_for_ssl():
+    config = McpTransportConfig(tls_ca_cert="CA", tls_client_cert="CERT", tls_client_key="KEY")
+    with mock.patch.object(mcp_transport, "create_ssl_context", return_value=False) as create_ssl_context:
+        create_httpx_client_factory(config)
+
+    kwargs = create_ssl_context.call_args.kwargs
+    for key, content in [("ca_cert_path", "CA"), ("client_cert_path", "CERT"), ("client_key_path", "KEY")]:
+        path = kwargs[key]
+        with open(path) as f:
+            assert f.read() == content
+        # the private key and certificates are only readable by the agent
+        assert stat.S_IMODE(os.stat(path).st_mode) == 0o600
+
+
+def test_factory_routes_through_proxy():
+    config = McpTransportConfig(proxy_url="http://proxy.example.com:3128", no_proxy=[".svc.cluster.local"])
+    client = create_httpx_client_factory(config)()
+
+    patterns = {pattern.pattern for pattern in client._mounts}
+    assert patterns == {"all://", "all://*svc.cluster.local"}
+
+
+def test_with_transport():
+    params = StreamableHTTPConnectionParams(url="http://mcp.example.com/mcp")
+    assert with_transport(params, None) is params
+
+    if "httpx_client_factory" not in StreamableHTTPConnectionParams.model_fields:
+        pytest.skip("the installed google-adk does not support custom HTTP clients")
+    configured = with_transport(params, McpTransportConfig(tls_disable_verify=True))
+    assert configured.url == params.url
+    assert isinstance(configured.httpx_client_factory(), httpx.AsyncClient)
+
+
+def test_with_transport_fails_without_custom_client_support():
+    class LegacyParams(BaseModel):
+        url: str
+
+    with pytest.raises(ValueError, match="does not support custom HTTP clients"):
+        with_transport(LegacyParams(url="http://mcp.example.com/mcp"), McpTransportConfig(tls_disable_verify=True))
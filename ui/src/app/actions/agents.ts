@@ -193,7 +193,8 @@ export async function getAgents(): Promise<BaseResponse<AgentResponse[]>> {
   try {
     const { data } = await fetchApi<BaseResponse<AgentResponse[]>>(`/agents`);
 
-    const sortedData = data?.sort((a, b) => {
+    // The agents imported with a RemoteAgent are not supported by the UI yet
+    const sortedData = data?.filter((a) => a.agent).sort((a, b) => {
       const aRef = k8sRefUtils.toRef(a.agent.metadata.namespace || "", a.agent.metadata.name);
       const bRef = k8sRefUtils.toRef(b.agent.metadata.namespace || "", b.agent.metadata.name);
       return aRef.localeCompare(bRef);
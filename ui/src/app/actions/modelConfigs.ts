@@ -1,7 +1,7 @@
 "use server";
 import { revalidatePath } from "next/cache";
 import { fetchApi, createErrorResponse } from "./utils";
-import { BaseResponse, ModelConfig, CreateModelConfigRequest, UpdateModelConfigPayload } from "@/types";
+import { BaseResponse, ModelConfig, CreateModelConfigRequest, UpdateModelConfigPayload, ProviderModel, TestModelConfigResponse } from "@/types";
 import { k8sRefUtils } from "@/lib/k8sUtils";
 
 /**
@@ -50,6 +50,38 @@ export async function getModelConfig(configRef: string): Promise<BaseResponse<Mo
   }
 }
 
+/**
+ * Lists the models served by the provider of a model configuration
+ * @param configRef The model configuration ref string
+ * @param refresh Whether to bypass the cached models
+ * @returns A promise with the models of the provider
+ */
+export async function getModelConfigModels(configRef: string, refresh = false): Promise<BaseResponse<ProviderModel[]>> {
+  try {
+    const query = refresh ? "?refresh=true" : "";
+    const response = await fetchApi<BaseResponse<ProviderModel[]>>(`/modelconfigs/${configRef}/models${query}`);
+    return response;
+  } catch (error) {
+    return createErrorResponse<ProviderModel[]>(error, "Error listing models");
+  }
+}
+
+/**
+ * Checks the credentials of a model configuration against its provider
+ * @param configRef The model configuration ref string
+ * @returns A promise with the outcome of the check
+ */
+export async function testModelConfig(configRef: string): Promise<BaseResponse<TestModelConfigResponse>> {
+  try {
+    const response = await fetchApi<BaseResponse<TestModelConfigResponse>>(`/modelconfigs/${configRef}/test`, {
+      method: "POST",
+    });
+    return response;
+  } catch (error) {
+    return createErrorResponse<TestModelConfigResponse>(error, "Error testing model config");
+  }
+}
+
 /**
  * Creates a new model configuration
  * @param config The model configuration to create
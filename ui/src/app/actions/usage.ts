@@ -0,0 +1,36 @@
+'use server'
+
+import { fetchApi, createErrorResponse } from './utils';
+import { BaseResponse, UsageQuery, UsageSummary } from '@/types';
+
+/**
+ * Aggregates the token usage of agents
+ * @param query The time range, dimensions to group by and filters of the usage
+ * @returns A promise with the usage aggregated by group
+ */
+export async function getUsage(query: UsageQuery = {}): Promise<BaseResponse<UsageSummary[]>> {
+  try {
+    const params = new URLSearchParams();
+    if (query.from) params.set("from", query.from);
+    if (query.to) params.set("to", query.to);
+    if (query.groupBy?.length) params.set("group_by", query.groupBy.join(","));
+    if (query.agent) params.set("agent", query.agent);
+    if (query.session) params.set("session", query.session);
+    if (query.user) params.set("user", query.user);
+    if (query.modelConfig) params.set("model_config", query.modelConfig);
+
+    const search = params.toString();
+    const response = await fetchApi<BaseResponse<UsageSummary[]>>(search ? `/usage?${search}` : '/usage');
+
+    if (!response) {
+      throw new Error("Failed to get usage");
+    }
+
+    return {
+      message: "Usage fetched successfully",
+      data: response.data,
+    };
+  } catch (error) {
+    return createErrorResponse<UsageSummary[]>(error, "Error getting usage");
+  }
+}
@@ -1,11 +1,11 @@
 "use client";
-import React, { useState, useEffect } from "react";
+import React, { useState, useEffect, useMemo } from "react";
 import { Button } from "@/components/ui/button";
 import { Loader2 } from "lucide-react";
 import { useRouter, useSearchParams } from "next/navigation";
 import { LoadingState } from "@/components/LoadingState";
 import { ErrorState } from "@/components/ErrorState";
-import { getModelConfig, createModelConfig, updateModelConfig } from "@/app/actions/modelConfigs";
+import { getModelConfig, getModelConfigModels, createModelConfig, updateModelConfig } from "@/app/actions/modelConfigs";
 import { useAgents } from "@/components/AgentsProvider";
 import type {
     CreateModelConfigRequest,
@@ -16,9 +16,12 @@ import type {
     AnthropicConfigPayload,
     OllamaConfigPayload,
     ProviderModelsResponse,
+    ProviderModel,
     GeminiConfigPayload,
     GeminiVertexAIConfigPayload,
-    AnthropicVertexAIConfigPayload
+    AnthropicVertexAIConfigPayload,
+    BedrockConfigPayload,
+    OpenAICompatibleConfigPayload
 } from "@/types";
 import { toast } from "sonner";
 import { isResourceNameValid, createRFC1123ValidName } from "@/lib/utils";
@@ -118,6 +121,7 @@ function ModelPageContent() {
   const [optionalParams, setOptionalParams] = useState<ModelParam[]>([]);
   const [providers, setProviders] = useState<Provider[]>([]);
   const [providerModelsData, setProviderModelsData] = useState<ProviderModelsResponse | null>(null);
+  const [configModels, setConfigModels] = useState<{ providerKey: string; models: ProviderModel[] } | null>(null);
   const [selectedCombinedModel, setSelectedCombinedModel] = useState<string | undefined>(undefined);
   const [selectedModelSupportsFunctionCalling, setSelectedModelSupportsFunctionCalling] = useState<boolean | null>(null);
   const [modelTag, setModelTag] = useState("");
@@ -130,6 +134,12 @@ function ModelPageContent() {
   const [isParamsSectionExpanded, setIsParamsSectionExpanded] = useState(false);
   const isOllamaSelected = selectedProvider?.type === "Ollama";
 
+  // when editing, the models served by the provider of the model config replace those of the catalog
+  const availableModelsData = useMemo(() => {
+    if (!providerModelsData || !configModels) return providerModelsData;
+    return { ...providerModelsData, [configModels.providerKey]: configModels.models };
+  }, [providerModelsData, configModels]);
+
   useEffect(() => {
     let isMounted = true;
     const fetchData = async () => {
@@ -210,6 +220,18 @@ function ModelPageContent() {
             setSelectedCombinedModel(`${providerFormKey}::${modelName}`);
           }
 
+          if (providerFormKey) {
+            // the models served by the provider of the model config, listed without blocking the form
+            getModelConfigModels(modelData.ref).then(modelsResponse => {
+              if (!isMounted) return;
+              if (modelsResponse.error || !modelsResponse.data) {
+                console.warn("Failed to list the models of the provider:", modelsResponse.error);
+                return;
+              }
+              setConfigModels({ providerKey: providerFormKey, models: modelsResponse.data });
+            });
+          }
+
           if (!modelData.apiKeySecretRef) {
             setIsApiKeyNeeded(false);
           } else {
@@ -453,6 +475,12 @@ function ModelPageContent() {
       case 'AnthropicVertexAI':
         payload.anthropicVertexAI = providerParams as AnthropicVertexAIConfigPayload;
         break;
+      case 'Bedrock':
+        payload.bedrock = providerParams as BedrockConfigPayload;
+        break;
+      case 'OpenAICompatible':
+        payload.openAICompatible = providerParams as OpenAICompatibleConfigPayload;
+        break;
       default:
         console.error("Unsupported provider type during payload construction:", providerType);
         toast.error("Internal error: Unsupported provider type.");
@@ -471,6 +499,8 @@ function ModelPageContent() {
           anthropic: payload.anthropic,
           azureOpenAI: payload.azureOpenAI,
           ollama: payload.ollama,
+          bedrock: payload.bedrock,
+          openAICompatible: payload.openAICompatible,
         };
         const modelConfigRef = k8sRefUtils.toRef(modelConfigNamespace || '', modelConfigName);
         response = await updateModelConfig(modelConfigRef, updatePayload);
@@ -528,7 +558,7 @@ function ModelPageContent() {
             onToggleEditName={() => setIsEditingName(!isEditingName)}
             onNamespaceChange={setNamespace}
             providers={providers}
-            providerModelsData={providerModelsData}
+            providerModelsData={availableModelsData}
             selectedCombinedModel={selectedCombinedModel}
             onModelChange={(comboboxValue, providerKey, modelName, functionCalling) => {
               setSelectedCombinedModel(comboboxValue);
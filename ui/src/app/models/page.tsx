@@ -1,10 +1,10 @@
 "use client";
 import React, { useState, useEffect } from "react";
 import { Button } from "@/components/ui/button";
-import { Plus, ChevronDown, ChevronRight, Pencil, Trash2 } from "lucide-react";
+import { Plus, ChevronDown, ChevronRight, Pencil, Trash2, ShieldCheck } from "lucide-react";
 import { useRouter } from "next/navigation";
 import { ModelConfig } from "@/types";
-import { getModelConfigs, deleteModelConfig } from "@/app/actions/modelConfigs";
+import { getModelConfigs, deleteModelConfig, testModelConfig } from "@/app/actions/modelConfigs";
 import { LoadingState } from "@/components/LoadingState";
 import { ErrorState } from "@/components/ErrorState";
 import { toast } from "sonner";
@@ -25,6 +25,7 @@ export default function ModelsPage() {
     const [error, setError] = useState<string | null>(null);
     const [expandedRows, setExpandedRows] = useState<Set<string>>(new Set());
     const [modelToDelete, setModelToDelete] = useState<ModelConfig | null>(null);
+    const [testingModel, setTestingModel] = useState<string | null>(null);
 
     useEffect(() => {
         fetchModels();
@@ -62,6 +63,29 @@ export default function ModelsPage() {
         router.push(`/models/new?edit=true&name=${modelRef.name}&namespace=${modelRef.namespace}`);
     };
 
+    const handleTest = async (model: ModelConfig) => {
+        setTestingModel(model.ref);
+        try {
+            const response = await testModelConfig(model.ref);
+            if (response.error || !response.data) {
+                throw new Error(response.error || "Failed to test model credentials");
+            }
+            const { status, reason, message } = response.data;
+            if (status === "True") {
+                toast.success(`Credentials of "${model.ref}" are valid`);
+            } else if (status === "False") {
+                toast.error(`Credentials of "${model.ref}" are not valid: ${message || reason}`);
+            } else {
+                toast.warning(`Credentials of "${model.ref}" could not be checked: ${message || reason}`);
+            }
+        } catch (err) {
+            const errorMessage = err instanceof Error ? err.message : "Failed to test model credentials";
+            toast.error(errorMessage);
+        } finally {
+            setTestingModel(null);
+        }
+    };
+
     const handleDelete = async (model: ModelConfig) => {
         setModelToDelete(model);
     };
@@ -121,6 +145,19 @@ export default function ModelsPage() {
                                         <span className="font-medium">{model.ref}</span>
                                     </div>
                                     <div className="flex space-x-2">
+                                        <Button
+                                            data-test={`test-model-${model.ref}`}
+                                            variant="ghost"
+                                            size="sm"
+                                            title="Test credentials"
+                                            disabled={testingModel === model.ref}
+                                            onClick={(e) => {
+                                                e.stopPropagation();
+                                                handleTest(model);
+                                            }}
+                                        >
+                                            <ShieldCheck className="h-4 w-4" />
+                                        </Button>
                                         <Button
                                             data-test={`edit-model-${model.ref}`}
                                             variant="ghost"
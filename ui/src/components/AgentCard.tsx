@@ -13,7 +13,7 @@ interface AgentCardProps {
   agentResponse: AgentResponse;
 }
 
-export function AgentCard({ agentResponse: { agent, model, modelProvider, deploymentReady, accepted } }: AgentCardProps) {
+export function AgentCard({ agentResponse: { agent, model, modelProvider, deploymentReady, accepted, readyReason, readyMessage } }: AgentCardProps) {
   const router = useRouter();
   const agentRef = k8sRefUtils.toRef(
     agent.metadata.namespace || '',
@@ -39,7 +39,8 @@ export function AgentCard({ agentResponse: { agent, model, modelProvider, deploy
     }
     if (!deploymentReady) {
       return {
-        message: "Agent not Ready",
+        message: readyReason ? `Agent not Ready: ${readyReason}` : "Agent not Ready",
+        title: readyMessage,
         className:"bg-yellow-400/30 text-yellow-800 dark:bg-yellow-500/40 dark:text-yellow-200"
       };
     }
@@ -92,7 +93,7 @@ export function AgentCard({ agentResponse: { agent, model, modelProvider, deploy
         <div className={cn(
           "absolute bottom-0 left-0 right-0 z-20 py-1.5 px-4 text-right text-xs font-medium rounded-b-xl",
           statusInfo.className
-        )}>
+        )} title={statusInfo.title}>
           {statusInfo.message}
         </div>
       )}
@@ -11,6 +11,7 @@ import { Anthropic } from './icons/Anthropic';
 import { Ollama } from './icons/Ollama';
 import { Azure } from './icons/Azure';
 import { Gemini } from './icons/Gemini';
+import { Bedrock } from './icons/Bedrock';
 
 interface ComboboxOption {
     label: string; // e.g., "OpenAI - gpt-4o"
@@ -64,6 +65,8 @@ export function ModelProviderCombobox({
             'Gemini': Gemini,
             'GeminiVertexAI': Gemini,
             'AnthropicVertexAI': Anthropic,
+            'Bedrock': Bedrock,
+            'OpenAICompatible': OpenAI,
         };
         if (!providerKey || !PROVIDER_ICONS[providerKey]) {
             return null;
@@ -0,0 +1,10 @@
+export function Bedrock() {
+    return <svg width="800px" height="800px" viewBox="0 0 256 256" version="1.1" xmlns="http://www.w3.org/2000/svg">
+        <title>Amazon Bedrock</title>
+        <g stroke="none" strokeWidth="1" fill="none" fillRule="evenodd">
+            <rect width="256" height="256" rx="32" fill="#01A88D" />
+            <path d="M128,40 L204,84 L204,172 L128,216 L52,172 L52,84 Z M128,68 L76,98 L76,158 L128,188 L180,158 L180,98 Z" fill="#FFFFFF" fillRule="nonzero" />
+            <circle cx="128" cy="128" r="22" fill="#FFFFFF" />
+        </g>
+    </svg>
+}
@@ -1,6 +1,6 @@
 
-export type BackendModelProviderType = "OpenAI" | "AzureOpenAI" | "Anthropic" | "Ollama" | "Gemini" | "GeminiVertexAI" | "AnthropicVertexAI";
-export const modelProviders = ["OpenAI", "AzureOpenAI", "Anthropic", "Ollama", "Gemini", "GeminiVertexAI", "AnthropicVertexAI"] as const;
+export type BackendModelProviderType = "OpenAI" | "AzureOpenAI" | "Anthropic" | "Ollama" | "Gemini" | "GeminiVertexAI" | "AnthropicVertexAI" | "Bedrock" | "OpenAICompatible";
+export const modelProviders = ["OpenAI", "AzureOpenAI", "Anthropic", "Ollama", "Gemini", "GeminiVertexAI", "AnthropicVertexAI", "Bedrock", "OpenAICompatible"] as const;
 export type ModelProviderKey = typeof modelProviders[number];
 
 
@@ -62,6 +62,20 @@ export const PROVIDERS_INFO: {
         modelDocsLink: "https://cloud.google.com/vertex-ai/docs",
         help: "Configure your Google Cloud project and credentials for Vertex AI."
     },
+    Bedrock: {
+        name: "Amazon Bedrock",
+        type: "Bedrock",
+        apiKeyLink: "https://console.aws.amazon.com/bedrock/home#/api-keys",
+        modelDocsLink: "https://docs.aws.amazon.com/bedrock/latest/userguide/models-supported.html",
+        help: "Set the AWS region. Use a Bedrock API key, or leave it empty and set roleArn to use IRSA or STS."
+    },
+    OpenAICompatible: {
+        name: "OpenAI-compatible",
+        type: "OpenAICompatible",
+        apiKeyLink: null,
+        modelDocsLink: "https://platform.openai.com/docs/api-reference/models/list",
+        help: "Set the base URL of a vLLM, LiteLLM, Together or Groq endpoint. The API key is optional."
+    },
 };
 
 export const isValidProviderInfoKey = (key: string): key is ModelProviderKey => {
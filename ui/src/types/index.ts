@@ -39,11 +39,20 @@ export interface Provider {
 export type ProviderModel = {
   name: string;
   function_calling: boolean;
+  vision?: boolean;
+  json_mode?: boolean;
+  discovered?: boolean;
 }
 
 // Define the type for the expected API response structure
 export type ProviderModelsResponse = Record<string, ProviderModel[]>;
 
+export interface TestModelConfigResponse {
+  status: "True" | "False" | "Unknown";
+  reason: string;
+  message?: string;
+}
+
 // Export OpenAIConfigPayload
 export interface OpenAIConfigPayload {
   baseUrl?: string;
@@ -72,11 +81,23 @@ export interface AzureOpenAIConfigPayload {
   apiVersion: string;
   azureDeployment?: string;
   azureAdToken?: string;
+  identity?: AzureIdentityConfig;
   temperature?: string;
   maxTokens?: number;
   topP?: string;
 }
 
+export interface AzureIdentityConfig {
+  mode?: "WorkloadIdentity" | "ManagedIdentity";
+  clientId?: string;
+  tenantId?: string;
+  authorityHost?: string;
+}
+
+export interface GKEWorkloadIdentityConfig {
+  serviceAccount?: string;
+}
+
 export interface OllamaConfigPayload {
   host?: string;
   options?: Record<string, string>;
@@ -93,6 +114,7 @@ export interface GeminiConfigPayload {
 export interface GeminiVertexAIConfigPayload {
   project?: string;
   location?: string;
+  workloadIdentity?: GKEWorkloadIdentityConfig;
   temperature?: string;
   maxTokens?: number;
   topP?: string;
@@ -102,12 +124,41 @@ export interface GeminiVertexAIConfigPayload {
 export interface AnthropicVertexAIConfigPayload {
   project?: string;
   location?: string;
+  workloadIdentity?: GKEWorkloadIdentityConfig;
   temperature?: string;
   maxTokens?: number;
   topP?: string;
   topK?: number;
 }
 
+export interface BedrockConfigPayload {
+  region: string;
+  inferenceProfile?: string;
+  guardrailId?: string;
+  guardrailVersion?: string;
+  roleArn?: string;
+  maxTokens?: number;
+  temperature?: string;
+  topP?: string;
+}
+
+export interface ModelCapabilitiesPayload {
+  functionCalling?: boolean;
+  streaming?: boolean;
+  vision?: boolean;
+  jsonMode?: boolean;
+}
+
+export interface OpenAICompatibleConfigPayload {
+  profile?: "Custom" | "vLLM" | "LiteLLM" | "Together" | "Groq";
+  baseUrl?: string;
+  capabilities?: ModelCapabilitiesPayload;
+  temperature?: string;
+  maxTokens?: number;
+  topP?: string;
+  timeout?: number;
+}
+
 export interface CreateModelConfigRequest {
   ref: string;
   provider: Pick<Provider, "name" | "type">;
@@ -120,6 +171,8 @@ export interface CreateModelConfigRequest {
   gemini?: GeminiConfigPayload;
   geminiVertexAI?: GeminiVertexAIConfigPayload;
   anthropicVertexAI?: AnthropicVertexAIConfigPayload;
+  bedrock?: BedrockConfigPayload;
+  openAICompatible?: OpenAICompatibleConfigPayload;
 }
 
 export interface UpdateModelConfigPayload {
@@ -133,6 +186,8 @@ export interface UpdateModelConfigPayload {
   gemini?: GeminiConfigPayload;
   geminiVertexAI?: GeminiVertexAIConfigPayload;
   anthropicVertexAI?: AnthropicVertexAIConfigPayload;
+  bedrock?: BedrockConfigPayload;
+  openAICompatible?: OpenAICompatibleConfigPayload;
 }
 
 /**
@@ -179,6 +234,36 @@ export interface Session {
   deleted_at: string;
 }
 
+export type UsageGroupBy = "agent" | "session" | "user" | "model_config" | "model";
+
+export interface UsageQuery {
+  // RFC 3339 time range, from inclusive and to exclusive
+  from?: string;
+  to?: string;
+  groupBy?: UsageGroupBy[];
+  // Agent as namespace/name
+  agent?: string;
+  session?: string;
+  user?: string;
+  // ModelConfig as namespace/name
+  modelConfig?: string;
+}
+
+export interface UsageSummary {
+  // Only the dimensions the usage is grouped by are set
+  agent_id?: string;
+  session_id?: string;
+  user_id?: string;
+  model_config?: string;
+  model?: string;
+  requests: number;
+  input_tokens: number;
+  output_tokens: number;
+  cached_tokens: number;
+  cost: number;
+  avg_latency_ms: number;
+}
+
 export interface ToolsResponse {
   id: string;
   server_name: string;
@@ -187,6 +272,17 @@ export interface ToolsResponse {
   deleted_at: string;
   description: string;
   group_kind: string;
+  input_schema?: Record<string, unknown>;
+  output_schema?: Record<string, unknown>;
+  annotations?: ToolAnnotations;
+}
+
+export interface ToolAnnotations {
+  title?: string;
+  readOnlyHint?: boolean;
+  destructiveHint?: boolean;
+  idempotentHint?: boolean;
+  openWorldHint?: boolean;
 }
 
 
@@ -226,6 +322,15 @@ export interface AgentSpec {
   byo?: BYOAgentSpec;
   description: string;
   skills?: SkillForAgent;
+  // Limits applied to each user of the agent
+  rateLimits?: RateLimits;
+}
+
+export interface RateLimits {
+  requestsPerMinute?: number;
+  concurrentTasks?: number;
+  // Input and output tokens per day, reset at midnight UTC
+  dailyTokenBudget?: number;
 }
 
 export interface DeclarativeAgentSpec {
@@ -233,10 +338,20 @@ export interface DeclarativeAgentSpec {
   tools: Tool[];
   // Name of the model config resource
   modelConfig: string;
+  // Sampling parameters overriding the ones of the model config for this agent
+  modelOverrides?: ModelOverrides;
   stream?: boolean;
   a2aConfig?: A2AConfig;
 }
 
+export interface ModelOverrides {
+  temperature?: string;
+  maxTokens?: number;
+  topP?: string;
+  topK?: number;
+  reasoningEffort?: string;
+}
+
 export interface BYOAgentSpec {
   deployment: BYODeploymentSpec;
 }
@@ -277,15 +392,27 @@ export interface Agent {
   spec: AgentSpec;
 }
 
+export interface RemoteAgent {
+  metadata: ResourceMetadata;
+  spec: {
+    description?: string;
+    url: string;
+  };
+}
+
 export interface AgentResponse {
   id: number;
   agent: Agent;
+  // Set instead of agent for the agents imported with a RemoteAgent
+  remoteAgent?: RemoteAgent;
   model: string;
   modelProvider: string;
   modelConfigRef: string;
   tools: Tool[];
   deploymentReady: boolean;
   accepted: boolean;
+  readyReason?: string;
+  readyMessage?: string;
 }
 
 export interface RemoteMCPServer {
@@ -331,12 +458,32 @@ export interface RemoteMCPServerSpec {
   timeout?: string;
   sseReadTimeout?: string;
   terminateOnClose?: boolean;
+  tls?: TLSConfig;
+  proxy?: ProxyConfig;
+}
+
+export interface TLSConfig {
+  disableVerify?: boolean;
+  caCertSecretRef?: string;
+  caCertSecretKey?: string;
+  disableSystemCAs?: boolean;
+  // kubernetes.io/tls Secret presented for mutual TLS
+  clientCertSecretRef?: string;
+}
+
+export interface ProxyConfig {
+  url: string;
+  noProxy?: string[];
+  // kubernetes.io/basic-auth Secret
+  credentialsSecretRef?: string;
 }
 
 export interface RemoteMCPServerResponse {
   ref: string; // namespace/name
   groupKind: string;
   discoveredTools: DiscoveredTool[];
+  discoveredResources?: DiscoveredResource[];
+  discoveredPrompts?: DiscoveredPrompt[];
 }
 
 // MCPServer types for stdio-based servers
@@ -373,6 +520,8 @@ export interface MCPServerResponse {
   ref: string; // namespace/name
   groupKind: string;
   discoveredTools: DiscoveredTool[];
+  discoveredResources?: DiscoveredResource[];
+  discoveredPrompts?: DiscoveredPrompt[];
 }
 
 // Union type for tool server responses
@@ -392,4 +541,25 @@ export interface ToolServerCreateRequest {
 export interface DiscoveredTool {
   name: string;
   description: string;
+  inputSchema?: Record<string, unknown>;
+  outputSchema?: Record<string, unknown>;
+  annotations?: ToolAnnotations;
+  schemaOmitted?: boolean;
+}
+
+export interface DiscoveredResource {
+  uri: string;
+  name: string;
+  description?: string;
+  mimeType?: string;
+}
+
+export interface DiscoveredPrompt {
+  name: string;
+  description?: string;
+  arguments?: {
+    name: string;
+    description?: string;
+    required?: boolean;
+  }[];
 }